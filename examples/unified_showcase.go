@@ -35,31 +35,6 @@ var (
 )
 
 // --- Simple Custom Middleware ---
-func simpleRequestLoggerMiddleware() xylium.Middleware {
-	return func(next xylium.HandlerFunc) xylium.HandlerFunc {
-		return func(c *xylium.Context) error {
-			startTime := time.Now()
-			// Logger from context, potentially enriched by RequestID middleware
-			logger := c.Logger().WithFields(xylium.M{"custom_middleware": "SimpleRequestLogger"})
-			logger.Infof("REQ START: %s %s from %s", c.Method(), c.Path(), c.RealIP())
-
-			err := next(c) // Call the next handler
-
-			latency := time.Since(startTime)
-			// Ensure c.Ctx is not nil before accessing response details,
-			// especially if an early panic could have occurred before c.Ctx was fully handled.
-			statusCode := 0
-			if c.Ctx != nil {
-				statusCode = c.Ctx.Response.StatusCode()
-			}
-
-			logger.Infof("REQ END: %s %s completed in %v, Status: %d",
-				c.Method(), c.Path(), latency, statusCode)
-			return err
-		}
-	}
-}
-
 func apiKeyAuthMiddleware(validKey string) xylium.Middleware {
 	return func(next xylium.HandlerFunc) xylium.HandlerFunc {
 		return func(c *xylium.Context) error {
@@ -82,20 +57,6 @@ func apiKeyAuthMiddleware(validKey string) xylium.Middleware {
 	}
 }
 
-func securityHeadersMiddleware() xylium.Middleware {
-	return func(next xylium.HandlerFunc) xylium.HandlerFunc {
-		return func(c *xylium.Context) error {
-			c.SetHeader("X-Content-Type-Options", "nosniff")
-			c.SetHeader("X-Frame-Options", "DENY")
-			c.SetHeader("X-XSS-Protection", "1; mode=block")
-			// Consider adding other headers like:
-			// c.SetHeader("Content-Security-Policy", "default-src 'self'")
-			// c.SetHeader("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
-			return next(c)
-		}
-	}
-}
-
 func main() {
 	startupTime = time.Now()
 
@@ -114,10 +75,10 @@ func main() {
 	app.RegisterCloser(sharedRateLimitStore) // Xylium will call store.Close() on shutdown
 
 	// --- Global Middleware Setup ---
-	app.Use(xylium.RequestID())                            // Adds a unique request ID
-	app.Use(simpleRequestLoggerMiddleware())               // Custom request logger
-	app.Use(securityHeadersMiddleware())                   // Adds common security headers
-	app.Use(xylium.TimeoutWithConfig(xylium.TimeoutConfig{ // Request timeout
+	app.Use(xylium.RequestID())                                                     // Adds a unique request ID
+	app.Use(xylium.AccessLog(xylium.AccessLogConfig{}))                             // Structured access log (level by status class)
+	app.Use(xylium.SecureHeaders(xylium.SecureHeadersConfig{FrameOptions: "DENY"})) // Adds common security headers
+	app.Use(xylium.TimeoutWithConfig(xylium.TimeoutConfig{                          // Request timeout
 		Timeout: 15 * time.Second,
 		Message: "Sorry, the request took too long to process.",
 	}))
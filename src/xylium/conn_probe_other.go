@@ -0,0 +1,12 @@
+// src/xylium/conn_probe_other.go
+//go:build !unix
+
+package xylium
+
+import "net" // For net.Conn.
+
+// connPeerClosed always reports the connection as open on platforms without MSG_PEEK
+// support, so disconnect detection is disabled there.
+func connPeerClosed(conn net.Conn) bool {
+	return false
+}
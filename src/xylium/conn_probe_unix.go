@@ -0,0 +1,46 @@
+// src/xylium/conn_probe_unix.go
+//go:build unix
+
+package xylium
+
+import (
+	"errors"  // For errors.Is on syscall errors.
+	"net"     // For net.Conn and syscall.Conn access.
+	"syscall" // For the non-consuming MSG_PEEK read.
+)
+
+// connPeerClosed reports whether the peer of `conn` has closed the connection, without
+// consuming any data from it. It peeks at the socket with MSG_PEEK|MSG_DONTWAIT: a
+// zero-byte read (EOF) or a reset means the peer is gone, while pending data or
+// EAGAIN means it is still connected. Connections that do not expose a file
+// descriptor (e.g., in-memory test listeners) are reported as connected.
+func connPeerClosed(conn net.Conn) bool {
+	// Unwrap TLS connections to probe the underlying TCP socket.
+	if tlsConn, ok := conn.(interface{ NetConn() net.Conn }); ok {
+		conn = tlsConn.NetConn()
+	}
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return false
+	}
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		return false
+	}
+
+	closed := false
+	var buf [1]byte
+	_ = rawConn.Read(func(fd uintptr) bool {
+		n, _, recvErr := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		switch {
+		case recvErr == nil:
+			closed = n == 0 // EOF: the peer closed its side.
+		case errors.Is(recvErr, syscall.EAGAIN), errors.Is(recvErr, syscall.EINTR):
+			closed = false // No data pending; still connected.
+		default:
+			closed = true // E.g., ECONNRESET.
+		}
+		return true // Never wait for readiness.
+	})
+	return closed
+}
@@ -3,10 +3,15 @@ package xylium
 import (
 	"context" // For Go's context.Context
 	"fmt"     // For fmt.Sprintf in MustGet panic message.
+	"net"     // For the connection handed over by c.Hijack.
+	"sort"    // For a deterministic order of validation translator locales.
+	"strings" // For normalizing locale names to language tags.
 	"sync"    // For sync.RWMutex, sync.Once for thread-safety and one-time operations.
+	"time"    // For c.Deadline().
 
-	"github.com/go-playground/validator/v10" // For default struct validation.
-	"github.com/valyala/fasthttp"            // For fasthttp.RequestCtx, the underlying request context.
+	ut "github.com/go-playground/universal-translator" // For localized validation messages.
+	"github.com/go-playground/validator/v10"           // For default struct validation.
+	"github.com/valyala/fasthttp"                      // For fasthttp.RequestCtx, the underlying request context.
 )
 
 // --- Validator Management ---
@@ -17,7 +22,14 @@ var (
 	defaultValidator *validator.Validate
 	// defaultValidatorLock protects concurrent access to `defaultValidator`.
 	// This ensures that `SetCustomValidator` and `GetValidator` operations are thread-safe.
+	// It also protects the validation translator settings below.
 	defaultValidatorLock sync.RWMutex
+	// validationTranslators holds the translators registered with `SetValidationTranslators`,
+	// keyed by locale. Nil if validation messages are not localized.
+	validationTranslators map[string]ut.Translator
+	// validationLocaleOffers lists the registered locales as language tags (e.g., "pt-BR" for
+	// "pt_BR"), default locale first, for matching against `Accept-Language`.
+	validationLocaleOffers []string
 )
 
 // init initializes the defaultValidator instance with a new `validator.Validate`
@@ -53,6 +65,84 @@ func GetValidator() *validator.Validate {
 	return defaultValidator
 }
 
+// SetValidationTranslators localizes the validation error messages returned by
+// `c.BindAndValidate()` (the "details" of the 400 error). For each request, the translator
+// whose locale best matches the `Accept-Language` header (see `c.AcceptsLanguages`) is used,
+// or the one for `defaultLocale` if there is no header or no match.
+//
+// `translators` is keyed by locale as used by `go-playground/locales` (e.g., "en", "id",
+// "pt_BR"); underscores match hyphens in `Accept-Language`. The translations themselves
+// must be registered with the validator, e.g., with the packages in
+// `github.com/go-playground/validator/v10/translations`. A nil or empty map disables
+// localization again. This function is thread-safe.
+//
+// Panics if `translators` is non-empty and has no translator for `defaultLocale`.
+//
+// Example:
+//
+//	uni := ut.New(en.New(), en.New(), id.New())
+//	enTrans, _ := uni.GetTranslator("en")
+//	idTrans, _ := uni.GetTranslator("id")
+//	v := validator.New()
+//	en_translations.RegisterDefaultTranslations(v, enTrans)
+//	id_translations.RegisterDefaultTranslations(v, idTrans)
+//	xylium.SetCustomValidator(v)
+//	xylium.SetValidationTranslators(map[string]ut.Translator{"en": enTrans, "id": idTrans}, "en")
+func SetValidationTranslators(translators map[string]ut.Translator, defaultLocale string) {
+	defaultValidatorLock.Lock()
+	defer defaultValidatorLock.Unlock()
+	if len(translators) == 0 {
+		validationTranslators, validationLocaleOffers = nil, nil
+		return
+	}
+	if translators[defaultLocale] == nil {
+		panic(fmt.Sprintf("xylium: SetValidationTranslators has no translator for default locale '%s'", defaultLocale))
+	}
+	copied := make(map[string]ut.Translator, len(translators))
+	locales := make([]string, 0, len(translators))
+	for locale, trans := range translators {
+		if trans == nil {
+			continue
+		}
+		copied[locale] = trans
+		if locale != defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	offers := []string{localeLanguageTag(defaultLocale)}
+	for _, locale := range locales {
+		offers = append(offers, localeLanguageTag(locale))
+	}
+	validationTranslators, validationLocaleOffers = copied, offers
+}
+
+// localeLanguageTag converts a locale name such as "pt_BR" to a language tag ("pt-BR").
+func localeLanguageTag(locale string) string {
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// validationTranslator returns the translator for the request's preferred language
+// among those registered with `SetValidationTranslators`, or nil if none are registered.
+func (c *Context) validationTranslator() ut.Translator {
+	defaultValidatorLock.RLock()
+	translators, offers := validationTranslators, validationLocaleOffers
+	defaultValidatorLock.RUnlock()
+	if len(translators) == 0 {
+		return nil
+	}
+	tag := c.AcceptsLanguages(offers...)
+	if tag == "" {
+		tag = offers[0] // No acceptable locale: fall back to the default.
+	}
+	for locale, trans := range translators {
+		if localeLanguageTag(locale) == tag {
+			return trans
+		}
+	}
+	return nil
+}
+
 // --- Context Struct ---
 
 // Context represents the context of a single HTTP request within the Xylium framework.
@@ -74,6 +164,10 @@ type Context struct {
 	// would contain `{"id": "123"}`.
 	Params map[string]string
 
+	// paramNames lists the keys of `Params` in path order, as captured by the router
+	// (nil if no parameters were captured). See `ParamNames`.
+	paramNames []string
+
 	// handlers is the chain of `HandlerFunc` (middleware and the final route handler)
 	// to be executed for the current request. This slice is populated by the router.
 	handlers []HandlerFunc
@@ -114,12 +208,48 @@ type Context struct {
 	// or middleware like `Timeout`), and propagation of request-scoped values to downstream
 	// services or goroutines that are `context.Context`-aware.
 	goCtx context.Context
+
+	// respGuard, when non-nil, serializes response writes made through this Context
+	// against a middleware that may abandon it (e.g., `Timeout`). Once the guard is
+	// detached, all further response writes via this Context are silently discarded.
+	// It is nil for the Context created by the router; middleware attach it to
+	// derived Contexts whose handlers may outlive the middleware itself.
+	respGuard *responseGuard
+
+	// group is the `RouteGroup` of the matched route (nil for routes registered directly
+	// on the router). It is used to resolve group-level error handlers.
+	group *RouteGroup
+
+	// route is the handle of the matched route (nil if no route matched). It is used to
+	// read route metadata (see `RouteMeta`).
+	route *Route
+
+	// routePattern is the path pattern of the matched route (empty if no route matched).
+	// See `RoutePattern`.
+	routePattern string
+
+	// strippedPrefix is the group prefix removed from `Path` and `RoutePattern` while
+	// the group and route middleware and handler of a group with `RouteGroup.SetStripPrefix`
+	// run (empty otherwise). See `StrippedPrefix`.
+	strippedPrefix string
 }
 
 // reset is called when a Context instance is released back to the `sync.Pool`.
 // It meticulously clears all request-specific data to prepare the Context for safe reuse
-// in a subsequent request, preventing data leakage between requests.
+// in a subsequent request, preventing data leakage between requests:
+//   - `Ctx`, `router`, `group`, `route`, `goCtx` and `respGuard` are set to nil; `routePattern` and `strippedPrefix` are cleared.
+//   - `Params` and the request-scoped store (`c.Set`/`c.Get`) are emptied; the maps are reused.
+//   - The handler chain is emptied and `index` reset; cached query and form arguments are dropped.
+//   - `responseOnce` is reset.
+//   - Temporary files of a multipart form parsed with `ServerConfig.MultipartMaxMemory` are removed.
+//   - A write deadline set with `SetWriteDeadline` is dropped.
+//
+// Hooks registered with `OnContextRelease` run just before reset.
 func (c *Context) reset() {
+	if c.Ctx != nil {
+		c.removeMultipartForm() // Delete temporary files of an uploaded multipart form.
+		c.Ctx.RemoveUserValue(writeDeadlineUserValueKey)
+	}
 	c.Ctx = nil // Clear reference to fasthttp.RequestCtx.
 
 	// Clear path parameters map. The map itself is reused if it exists.
@@ -129,6 +259,8 @@ func (c *Context) reset() {
 		}
 	}
 
+	c.paramNames = nil // Owned by the router's lookup; not reused.
+
 	// Reset handlers slice and current handler index.
 	c.handlers = c.handlers[:0] // Clears the slice while retaining underlying array capacity.
 	c.index = -1                // Reset index to indicate no handlers have been run.
@@ -152,6 +284,11 @@ func (c *Context) reset() {
 	c.formArgs = nil             // Clear cached form arguments.
 	c.responseOnce = sync.Once{} // Reset sync.Once for the next request.
 	c.goCtx = nil                // Clear Go context.Context reference.
+	c.respGuard = nil            // Clear response write guard.
+	c.group = nil                // Clear matched route group.
+	c.route = nil                // Clear matched route handle.
+	c.routePattern = ""          // Clear matched route pattern.
+	c.strippedPrefix = ""        // Clear stripped group prefix.
 }
 
 // Next executes the next handler in the middleware chain for the current request.
@@ -194,6 +331,13 @@ func (c *Context) setRouter(r *Router) {
 //   - The response body is being streamed (`c.Ctx.Response.IsBodyStream()` is true).
 //   - Any part of the response body has actually been written (`len(c.Ctx.Response.Body()) > 0`).
 func (c *Context) ResponseCommitted() bool {
+	// A Context whose response has been taken over by a middleware (e.g., after a
+	// `Timeout` fired) must not attempt to write anymore. Reporting it as committed
+	// lets handlers that check this method stop early.
+	if c.respGuard != nil && c.respGuard.isDetached() {
+		return true
+	}
+
 	if c.Ctx == nil {
 		// Should not happen in a normal request lifecycle.
 		// If Ctx is nil, assume not committed for safety, though this indicates a deeper issue.
@@ -232,6 +376,98 @@ func (c *Context) ResponseCommitted() bool {
 	return false
 }
 
+// Hijack takes over the client connection once the current response (e.g., a
+// "101 Switching Protocols" handshake) has been sent, and calls `handler` with it. It is
+// the building block for protocol upgrades, such as the `websocket` sub-package.
+//
+// `handler` runs after the route handler (and all middleware) have returned, on the
+// connection's goroutine, so it must not use the `*Context`. Deadlines applied while
+// serving the request are cleared before it is called. `keepOpen` reports
+// `ServerConfig.KeepHijackedConns`: if false (default), the connection is closed as soon
+// as `handler` returns; if true, `handler` owns the connection and must close it. Once
+// hijacked, `c.ResponseCommitted()` reports true.
+func (c *Context) Hijack(handler func(conn net.Conn, keepOpen bool)) {
+	if handler == nil {
+		panic("xylium: Context.Hijack requires a non-nil handler")
+	}
+	keepOpen := c.router != nil && c.router.serverConfig.KeepHijackedConns
+	c.Ctx.Hijack(func(conn net.Conn) {
+		_ = conn.SetDeadline(time.Time{})
+		handler(conn, keepOpen)
+	})
+}
+
+// responseGuard coordinates response writes between a request's original Context and
+// a derived Context whose handler runs in a separate goroutine (see `TimeoutWithConfig`).
+// Each response-writing method on a guarded Context holds the guard's lock for the
+// duration of the write. When the owning middleware calls `detach`, it waits for any
+// in-flight write to finish and then marks the guard so subsequent writes are dropped.
+// Guards may be chained via `parent` when guarded middleware are nested.
+type responseGuard struct {
+	mu       sync.Mutex
+	detached bool
+	parent   *responseGuard
+}
+
+// acquire locks the guard (and its ancestors) for a response write.
+// Returns false, holding no locks, if the guard or any ancestor is detached.
+func (g *responseGuard) acquire() bool {
+	g.mu.Lock()
+	if g.detached {
+		g.mu.Unlock()
+		return false
+	}
+	if g.parent != nil && !g.parent.acquire() {
+		g.mu.Unlock()
+		return false
+	}
+	return true
+}
+
+// release unlocks the guard and its ancestors after a successful `acquire`.
+func (g *responseGuard) release() {
+	if g.parent != nil {
+		g.parent.release()
+	}
+	g.mu.Unlock()
+}
+
+// detach waits for any in-flight write to complete and then discards all future writes.
+func (g *responseGuard) detach() {
+	g.mu.Lock()
+	g.detached = true
+	g.mu.Unlock()
+}
+
+// isDetached reports whether the guard or any of its ancestors has been detached.
+func (g *responseGuard) isDetached() bool {
+	g.mu.Lock()
+	detached := g.detached
+	g.mu.Unlock()
+	if !detached && g.parent != nil {
+		return g.parent.isDetached()
+	}
+	return detached
+}
+
+// beginResponseWrite must be called by every method that modifies the response.
+// It returns false if the write must be discarded because the response has been
+// taken over by a middleware. When it returns true, the caller must call
+// `endResponseWrite` once the write is complete.
+func (c *Context) beginResponseWrite() bool {
+	if c.respGuard == nil {
+		return true
+	}
+	return c.respGuard.acquire()
+}
+
+// endResponseWrite releases the guard acquired by a successful `beginResponseWrite`.
+func (c *Context) endResponseWrite() {
+	if c.respGuard != nil {
+		c.respGuard.release()
+	}
+}
+
 // RouterMode returns the operating mode (e.g., "debug", "release", "test") of the
 // `xylium.Router` instance that is handling the current request.
 // This can be used by handlers or middleware to alter their behavior based on the
@@ -255,6 +491,9 @@ func (c *Context) RouterMode() string {
 //   - `trace_id` (from `ContextKeyOtelTraceID`, typically set by OpenTelemetry middleware).
 //   - `span_id` (from `ContextKeyOtelSpanID`, typically set by OpenTelemetry middleware).
 //
+// Fields added with `c.AddLogFields` (e.g., `user_id` after authentication) are included
+// too; the standard fields above take precedence over them.
+//
 // Using `c.Logger()` ensures that log messages are consistently formatted and
 // can be easily correlated to specific requests or traces.
 func (c *Context) Logger() Logger {
@@ -277,6 +516,14 @@ func (c *Context) Logger() Logger {
 	baseLogger := c.router.Logger() // Get the router's configured base logger.
 	logFields := M{}                // Initialize a map for contextual log fields.
 
+	// Start with the fields accumulated via AddLogFields. The stored map is never modified
+	// after being stored (see AddLogFields), so it can be read without holding the lock.
+	if accumulated, ok := Get[M](c, ContextKeyLogFields); ok {
+		for k, v := range accumulated {
+			logFields[k] = v
+		}
+	}
+
 	// Attempt to retrieve and add standard contextual fields from the context store.
 	// These keys are defined as constants in types.go (e.g., ContextKeyRequestID).
 	if requestIDValue, exists := c.Get(ContextKeyRequestID); exists {
@@ -309,6 +556,34 @@ func (c *Context) Logger() Logger {
 	return baseLogger
 }
 
+// AddLogFields adds `fields` to the log fields of the current request, so that every
+// logger later returned by `c.Logger()` includes them, in this and any subsequent
+// middleware or handler. Fields with the same key as earlier ones replace them.
+// Loggers obtained from `c.Logger()` before the call are not affected.
+// This operation is thread-safe.
+//
+// Example (in an authentication middleware):
+//
+//	c.AddLogFields(xylium.M{"user_id": user.ID, "tenant": user.Tenant})
+//	return next(c) // Handlers' c.Logger() now logs user_id and tenant.
+func (c *Context) AddLogFields(fields M) {
+	if len(fields) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, _ := c.store[ContextKeyLogFields].(M)
+	// Copy-on-write: maps already read by c.Logger() are never modified.
+	merged := make(M, len(existing)+len(fields))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	c.store[ContextKeyLogFields] = merged
+}
+
 // GoContext returns the standard Go `context.Context` associated with this `xylium.Context`.
 // This `context.Context` is used throughout Xylium for managing request lifecycle events
 // such as cancellation (e.g., due to client disconnect or timeout middleware) and deadlines.
@@ -328,6 +603,20 @@ func (c *Context) GoContext() context.Context {
 	return c.goCtx
 }
 
+// Context returns the request-scoped Go `context.Context`. It is an alias for
+// `c.GoContext()`, provided so handlers can pass it directly to `context.Context`-aware
+// APIs, e.g., `db.QueryContext(c.Context(), ...)`. When the `Timeout` middleware is
+// active, the returned context carries its deadline and is canceled when it fires.
+func (c *Context) Context() context.Context {
+	return c.GoContext()
+}
+
+// Deadline returns the deadline of the request-scoped Go context (see `c.GoContext()`),
+// e.g., set by the `Timeout` middleware. `ok` is false if no deadline is set.
+func (c *Context) Deadline() (deadline time.Time, ok bool) {
+	return c.GoContext().Deadline()
+}
+
 // WithGoContext returns a new `xylium.Context` instance derived from the receiver `c`,
 // but with its internal Go `context.Context` (accessible via `newC.GoContext()`)
 // replaced by the provided `goCtx`.
@@ -361,19 +650,26 @@ func (c *Context) WithGoContext(goCtx context.Context) *Context {
 	// different handling (e.g., some shared, some new).
 	newC := &Context{
 		// Fields shallow copied or shared:
-		Ctx:       c.Ctx,       // Share the fasthttp context.
-		Params:    c.Params,    // Share route parameters map.
-		handlers:  c.handlers,  // Share the handler chain (index will diverge if Next is called).
-		index:     c.index,     // Copy current index (Next on newC will advance its own).
-		store:     c.store,     // Share the underlying key-value store.
-		mu:        c.mu,        // Share the mutex for the store.
-		router:    c.router,    // Share the router reference.
-		queryArgs: c.queryArgs, // Share cached query args (read-only after parse).
-		formArgs:  c.formArgs,  // Share cached form args (read-only after parse).
+		Ctx:        c.Ctx,        // Share the fasthttp context.
+		Params:     c.Params,     // Share route parameters map.
+		paramNames: c.paramNames, // Share the ordered parameter names.
+		handlers:   c.handlers,   // Share the handler chain (index will diverge if Next is called).
+		index:      c.index,      // Copy current index (Next on newC will advance its own).
+		store:      c.store,      // Share the underlying key-value store.
+		mu:         c.mu,         // Share the mutex for the store.
+		router:     c.router,     // Share the router reference.
+		queryArgs:  c.queryArgs,  // Share cached query args (read-only after parse).
+		formArgs:   c.formArgs,   // Share cached form args (read-only after parse).
 
 		// Fields re-initialized or set specific to newC:
-		responseOnce: sync.Once{}, // newC gets its own responseOnce.
-		goCtx:        goCtx,       // The new Go context.Context.
+		responseOnce: sync.Once{},    // newC gets its own responseOnce.
+		goCtx:        goCtx,          // The new Go context.Context.
+		respGuard:    c.respGuard,    // Inherit any response write guard from c.
+		group:        c.group,        // Share the matched route group.
+		route:        c.route,        // Share the matched route handle.
+		routePattern: c.routePattern, // Share the matched route pattern.
+
+		strippedPrefix: c.strippedPrefix, // Share the stripped group prefix.
 	}
 	return newC
 }
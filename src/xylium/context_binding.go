@@ -1,16 +1,20 @@
 package xylium
 
 import (
+	"bytes"         // For buffering streamed request bodies before decoding.
 	"encoding/json" // For unmarshalling JSON request bodies.
 	"encoding/xml"  // For unmarshalling XML request bodies.
+	"errors"        // For joining per-field binding errors.
 	"fmt"           // For string formatting in error messages.
+	"io"            // For io.LimitReader when reading streamed bodies.
 	"reflect"       // For reflection-based data binding.
 	"strconv"       // For parsing strings to numeric types and booleans.
 	"strings"       // For string manipulation (e.g., splitting tags).
 	"time"          // For parsing string values into time.Time.
 
-	"github.com/go-playground/validator/v10" // For struct field validation.
-	"github.com/valyala/fasthttp"            // For fasthttp.Args (query/form parameters).
+	ut "github.com/go-playground/universal-translator" // For localized validation messages.
+	"github.com/go-playground/validator/v10"           // For struct field validation.
+	"github.com/valyala/fasthttp"                      // For fasthttp.Args (query/form parameters).
 )
 
 // XBind is an interface that can be implemented by custom Go types to provide
@@ -34,6 +38,36 @@ type XBind interface {
 	Bind(c *Context) error
 }
 
+// PreBinder can be implemented by a binding target to run code before it is populated by
+// `c.Bind()` (and thus `c.BindAndValidate()`) or by the source-specific `c.BindJSON()`,
+// `c.BindXML()`, `c.BindQuery()` and `c.BindBody()`, e.g., to set defaults that the
+// request may override. `BeforeBind` runs before `XBind.Bind` for targets implementing both.
+// An error it returns aborts binding and is returned as-is (preferably an `*HTTPError`).
+type PreBinder interface {
+	BeforeBind(c *Context) error
+}
+
+// PostBinder can be implemented by a binding target to normalize it once it has been
+// populated, e.g., to trim whitespace or lowercase an email address, without repeating
+// that in every handler. `AfterBind` runs after binding succeeds (after `XBind.Bind` and
+// the route parameters), and in `c.BindAndValidate()` before validation, so validation
+// sees the normalized values. It is called by the same methods as `PreBinder.BeforeBind`.
+// An error it returns is returned as-is (preferably an `*HTTPError`).
+//
+// Call order for a target implementing all three interfaces:
+// `BeforeBind`, `Bind` (`XBind`, instead of the reflection-based binding), `AfterBind`,
+// then validation.
+//
+// Example:
+//
+//	func (in *SignupInput) AfterBind(c *xylium.Context) error {
+//		in.Email = strings.ToLower(strings.TrimSpace(in.Email))
+//		return nil
+//	}
+type PostBinder interface {
+	AfterBind(c *Context) error
+}
+
 // BindAndValidate performs two primary operations:
 //  1. **Binding**: It attempts to populate the fields of the `out` struct (which must
 //     be a non-nil pointer to a struct) with data from the HTTP request.
@@ -41,6 +75,7 @@ type XBind interface {
 //     - Otherwise, `c.Bind(out)` is invoked, which uses Xylium's default reflection-based
 //     binding logic (see `c.Bind()` and `c.bindWithReflection()` for details on how
 //     it determines the data source based on Content-Type and HTTP method).
+//     The `PreBinder` and `PostBinder` hooks of `out` run around this step.
 //  2. **Validation**: If the binding operation is successful (returns no error),
 //     `BindAndValidate` then validates the populated `out` struct using Xylium's
 //     currently configured `go-playground/validator/v10` instance (retrieved via
@@ -62,7 +97,8 @@ type XBind interface {
 //   - `"message": "Validation failed."`
 //   - `"details": map[string]string` where keys are field names (or field paths
 //     for nested structs, e.g., "Address.Street") and values are specific
-//     validation error messages (e.g., "validation failed on tag 'required'").
+//     validation error messages (e.g., "validation failed on tag 'required'", or a
+//     message in the request's language, see `SetValidationTranslators`).
 //     Xylium attempts to make these field paths client-friendly by removing the
 //     top-level struct name prefix.
 //   - `nil`: If both binding and validation are successful.
@@ -88,6 +124,7 @@ func (c *Context) BindAndValidate(out interface{}) error {
 				baseTypeName = outType.Elem().Name()
 			}
 
+			trans := c.validationTranslator() // Nil unless messages are localized.
 			for _, fe := range vErrs {
 				// `fe.Namespace()` gives the full path to the field, e.g., "ValidationStruct.Nested.InnerField".
 				fieldName := fe.Namespace()
@@ -106,12 +143,7 @@ func (c *Context) BindAndValidate(out interface{}) error {
 					// this stripping logic won't apply, which is fine.
 				}
 
-				// Construct a user-friendly error message for this specific field validation failure.
-				errMsg := fmt.Sprintf("validation failed on tag '%s'", fe.Tag())
-				if fe.Param() != "" { // Include validation parameter if present (e.g., for 'min', 'max', 'oneof').
-					errMsg += fmt.Sprintf(" (param: %s)", fe.Param())
-				}
-				errFields[fieldName] = errMsg
+				errFields[fieldName] = validationErrorMessage(fe, trans)
 			}
 			// Return a new HTTPError with status 400 and the structured validation details.
 			// The original `validator.ValidationErrors` is included as the internal error.
@@ -125,6 +157,75 @@ func (c *Context) BindAndValidate(out interface{}) error {
 	return nil
 }
 
+// validationErrorMessage returns the message reported in the "details" of a validation
+// error for `fe`, localized with `trans` if it is non-nil (see `SetValidationTranslators`).
+func validationErrorMessage(fe validator.FieldError, trans ut.Translator) string {
+	if trans != nil {
+		return fe.Translate(trans)
+	}
+	errMsg := fmt.Sprintf("validation failed on tag '%s'", fe.Tag())
+	if fe.Param() != "" { // Include validation parameter if present (e.g., for 'min', 'max', 'oneof').
+		errMsg += fmt.Sprintf(" (param: %s)", fe.Param())
+	}
+	return errMsg
+}
+
+// ErrResponseWritten is returned (wrapped around the original error) by `Must*` helpers
+// such as `c.MustBindAndValidate` after they have already written the error response.
+// Handlers should return it as-is; the router recognizes it and does not invoke the
+// error handler again. Use `errors.As` on the returned error to inspect the original
+// cause (e.g., the `*HTTPError` with validation details).
+var ErrResponseWritten = errors.New("xylium: error response already written")
+
+// ShouldBindAndValidate binds and validates the request into `out`, exactly like
+// `BindAndValidate`, without writing any response. The handler decides how to react to
+// the returned error (typically by returning it to the `GlobalErrorHandler`).
+func (c *Context) ShouldBindAndValidate(out interface{}) error {
+	return c.BindAndValidate(out)
+}
+
+// MustBindAndValidate binds and validates the request into `out`. On failure, it writes
+// the error response itself, using the same error handler the router would use for this
+// route (a group-level error handler, or the `GlobalErrorHandler`), and returns an error
+// wrapping both `ErrResponseWritten` and the original error, so the handler can return
+// immediately:
+//
+//	func CreateUser(c *xylium.Context) error {
+//		var input CreateUserInput
+//		if err := c.MustBindAndValidate(&input); err != nil {
+//			return err // The 400 response has already been sent.
+//		}
+//		// ...
+//	}
+//
+// After a failure the response is committed (`c.ResponseCommitted()` reports true), so
+// the handler must not write to the response again.
+func (c *Context) MustBindAndValidate(out interface{}) error {
+	err := c.BindAndValidate(out)
+	if err == nil {
+		return nil
+	}
+	return c.respondWithError(err)
+}
+
+// respondWithError writes the error response for `err` through the route's error
+// handler. It returns `err` wrapped with `ErrResponseWritten` on success, or `err`
+// unchanged if no response could be written, so the router handles it as usual.
+func (c *Context) respondWithError(err error) error {
+	errorHandler := defaultGlobalErrorHandler
+	if c.router != nil {
+		if h := c.router.errorHandlerFor(c.group); h != nil {
+			errorHandler = h
+		}
+	}
+	c.Set(ContextKeyErrorCause, err)
+	if handlerErr := errorHandler(c); handlerErr != nil {
+		c.Logger().Errorf("Failed to write error response for %s %s: %v (original error: %v)", c.Method(), c.Path(), handlerErr, err)
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrResponseWritten, err)
+}
+
 // Bind attempts to bind incoming request data to the `out` interface.
 // The `out` argument must be a non-nil pointer to the target data structure
 // (typically a struct, but can also be `*map[string]string` for reflection-based
@@ -140,6 +241,10 @@ func (c *Context) BindAndValidate(out interface{}) error {
 //     This mechanism intelligently determines the data source based on the request's
 //     HTTP method and `Content-Type` header:
 //     - For `GET`, `DELETE`, `HEAD` requests: Binds from URL query parameters (using `query` struct tags).
+//     Bracket notation is supported for query and form data: "tags[]=a&tags[]=b" binds a
+//     slice field like repeated "tags" keys, and "filter[status]=open" binds the field
+//     tagged "status" of a nested struct field tagged "filter" (or the key "status" of a
+//     `map[string]T` field).
 //     - For `POST`, `PUT`, `PATCH` requests:
 //     - `application/json`: Binds from JSON request body (using `json` struct tags).
 //     - `application/xml` or `text/xml`: Binds from XML request body (using `xml` struct tags).
@@ -148,6 +253,13 @@ func (c *Context) BindAndValidate(out interface{}) error {
 //     - If a `POST`/`PUT`/`PATCH` request has no body (`Content-Length: 0`), binding
 //     succeeds with `out` remaining in its zero-value state (or as initialized).
 //     Subsequent validation (if using `BindAndValidate`) will determine if this is acceptable.
+//  3. **Route Parameters**: After reflection-based binding, struct fields with a `param`
+//     struct tag (e.g., `param:"id"`) are populated from the route parameters (`c.Params`),
+//     using the same type conversion as query parameters. This runs for every method and
+//     after body binding, so a route parameter always wins over a body field bound into
+//     the same struct field. Fields without a `param` tag are never bound from route parameters.
+//  4. **Hooks**: If `out` implements `PreBinder`, its `BeforeBind` runs before step 1; if it
+//     implements `PostBinder`, its `AfterBind` runs once the steps above have succeeded.
 //
 // Returns:
 //   - `*xylium.HTTPError`: If binding fails (e.g., malformed JSON/XML, unsupported Content-Type,
@@ -156,21 +268,105 @@ func (c *Context) BindAndValidate(out interface{}) error {
 //   - `nil`: If binding is successful.
 func (c *Context) Bind(out interface{}) error {
 	// Validate that 'out' is a non-nil pointer.
-	rv := reflect.ValueOf(out)
-	if rv.Kind() != reflect.Ptr || rv.IsNil() {
-		// Create an internal error for logging/debugging context.
-		internalErr := fmt.Errorf("binding target 'out' must be a non-nil pointer, but got type %T (value: %v)", out, out)
-		// Return an HTTPError for the client.
-		return NewHTTPError(StatusInternalServerError, "Internal server error: Invalid binding target provided.").WithInternal(internalErr)
+	if err := checkBindTarget(out); err != nil {
+		return err
 	}
 
-	// Check if 'out' implements the XBind interface for custom binding.
-	if binder, ok := out.(XBind); ok {
-		return binder.Bind(c) // Delegate binding to the type's custom Bind method.
+	return c.bindWithHooks(out, func() error {
+		// Check if 'out' implements the XBind interface for custom binding.
+		if binder, ok := out.(XBind); ok {
+			return binder.Bind(c) // Delegate binding to the type's custom Bind method.
+		}
+
+		// Fallback to reflection-based binding, then bind route parameters.
+		if err := c.bindWithReflection(out); err != nil {
+			return err
+		}
+		return c.bindPathParams(out)
+	})
+}
+
+// bindWithHooks runs `bind` to populate `out`, preceded by `PreBinder.BeforeBind` and
+// followed by `PostBinder.AfterBind` if `out` implements them.
+func (c *Context) bindWithHooks(out interface{}, bind func() error) error {
+	if preBinder, ok := out.(PreBinder); ok {
+		if err := preBinder.BeforeBind(c); err != nil {
+			return err
+		}
+	}
+	if err := bind(); err != nil {
+		return err
+	}
+	if postBinder, ok := out.(PostBinder); ok {
+		return postBinder.AfterBind(c)
+	}
+	return nil
+}
+
+// bindPathParams populates the fields of the struct pointed to by `out` that have a
+// `param` struct tag with the matching route parameters from `c.Params`.
+// Targets that are not pointers to structs are left untouched.
+func (c *Context) bindPathParams(out interface{}) error {
+	if len(c.Params) == 0 {
+		return nil
+	}
+	elem := reflect.ValueOf(out).Elem()
+	if elem.Kind() != reflect.Struct {
+		return nil
 	}
 
-	// Fallback to reflection-based binding.
-	return c.bindWithReflection(out)
+	var fieldErrs bindingFieldErrors
+	typ := elem.Type()
+	for i := 0; i < elem.NumField(); i++ {
+		fieldStructType := typ.Field(i)
+		fieldReflectVal := elem.Field(i)
+		if !fieldReflectVal.CanSet() {
+			continue
+		}
+
+		// Only explicitly tagged fields are bound; there is no fallback to the field name.
+		paramName := strings.Split(fieldStructType.Tag.Get("param"), ",")[0]
+		if paramName == "" || paramName == "-" {
+			continue
+		}
+		paramValue, ok := c.Params[paramName]
+		if !ok {
+			continue
+		}
+
+		if err := c.setStructField(fieldReflectVal, fieldStructType.Type, []string{paramValue}, timeLayoutsForField(fieldStructType)); err != nil {
+			fieldErrs.add(paramName, err.Error(), fmt.Errorf("error binding route parameter '%s' to field '%s' (type %s): %w",
+				paramName, fieldStructType.Name, fieldStructType.Type.String(), err))
+		}
+	}
+	return fieldErrs.httpError("route parameters")
+}
+
+// bindingFieldErrors accumulates per-field conversion errors during reflection-based
+// binding, so a client is told about every malformed field at once.
+type bindingFieldErrors struct {
+	details map[string]string // Field name (as sent by the client) -> error message.
+	errs    []error           // Detailed errors, joined into the internal error.
+}
+
+// add records the conversion error `err` for `field`, with `message` shown to the client.
+func (b *bindingFieldErrors) add(field, message string, err error) {
+	if b.details == nil {
+		b.details = make(map[string]string)
+	}
+	b.details[field] = message
+	b.errs = append(b.errs, err)
+}
+
+// httpError returns nil if no errors were recorded. Otherwise, it returns an `*HTTPError`
+// with status 400 whose message has the same shape as validation errors:
+// `{"message": "Invalid values in <source>.", "details": {<field>: <error>}}`.
+func (b *bindingFieldErrors) httpError(source string) error {
+	if len(b.details) == 0 {
+		return nil
+	}
+	return NewHTTPError(StatusBadRequest, M{"message": "Invalid values in " + source + ".", "details": b.details}).
+		WithInternal(errors.Join(b.errs...))
 }
 
 // bindWithReflection is an internal method that handles the default, reflection-based
@@ -189,49 +385,98 @@ func (c *Context) bindWithReflection(out interface{}) error {
 		return nil // No body to bind from for POST/PUT/PATCH with empty body.
 	}
 
-	contentType := c.ContentType() // Get the request's Content-Type header.
-
 	// Determine binding strategy based on HTTP method.
 	if c.Method() == MethodGet || c.Method() == MethodDelete || c.Method() == MethodHead {
 		// For GET, DELETE, HEAD, always attempt to bind from URL query parameters.
-		if c.queryArgs == nil {
-			// Lazily parse and cache query arguments from fasthttp.RequestCtx.
-			c.queryArgs = c.Ctx.QueryArgs()
-		}
-		return c.bindDataFromArgs(out, c.queryArgs, "URL query parameters", "query")
+		return c.bindQuery(out)
 	}
 
 	// For other methods (POST, PUT, PATCH, etc.), determine binding by Content-Type.
+	return c.bindBody(out)
+}
+
+// BindJSON binds the request body as JSON into `out` (a non-nil pointer), regardless of
+// the request's `Content-Type` header and HTTP method (e.g., a JSON body on a GET request).
+// An empty body leaves `out` unchanged. Route parameters (`param` tags) are not bound.
+//
+// Returns an `*HTTPError` with status 400 if the body is not valid JSON for `out`,
+// exactly as `c.Bind()` does for JSON requests.
+func (c *Context) BindJSON(out interface{}) error {
+	if err := checkBindTarget(out); err != nil {
+		return err
+	}
+	return c.bindWithHooks(out, func() error { return c.bindJSON(out) })
+}
+
+// BindXML binds the request body as XML into `out` (a non-nil pointer), regardless of
+// the request's `Content-Type` header and HTTP method. An empty body leaves `out` unchanged.
+// Route parameters (`param` tags) are not bound.
+//
+// Returns an `*HTTPError` with status 400 if the body is not valid XML for `out`.
+func (c *Context) BindXML(out interface{}) error {
+	if err := checkBindTarget(out); err != nil {
+		return err
+	}
+	return c.bindWithHooks(out, func() error { return c.bindXML(out) })
+}
+
+// BindQuery binds only the URL query parameters into `out` (a pointer to a struct with
+// `query` tags, or `*map[string]string`), for any HTTP method. The request body is ignored.
+// Nested structs, maps and slices can be bound with bracket notation (see `Bind`).
+//
+// Returns an `*HTTPError` with status 400 if a query parameter cannot be converted to
+// the type of its field.
+func (c *Context) BindQuery(out interface{}) error {
+	if err := checkBindTarget(out); err != nil {
+		return err
+	}
+	return c.bindWithHooks(out, func() error { return c.bindQuery(out) })
+}
+
+// BindBody binds only the request body into `out` (a non-nil pointer), choosing the decoder
+// from the `Content-Type` header (JSON, XML, or form data) for any HTTP method, including
+// GET. Query and route parameters are ignored. An empty body leaves `out` unchanged.
+//
+// Returns the same `*HTTPError`s as `c.Bind()`: status 400 for malformed data, and status
+// 415 (Unsupported Media Type) for a non-empty body with an unsupported `Content-Type`.
+func (c *Context) BindBody(out interface{}) error {
+	if err := checkBindTarget(out); err != nil {
+		return err
+	}
+	return c.bindWithHooks(out, func() error { return c.bindBody(out) })
+}
+
+// checkBindTarget returns an `*HTTPError` with status 500 if `out` is not a non-nil pointer.
+func checkBindTarget(out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		// Create an internal error for logging/debugging context.
+		internalErr := fmt.Errorf("binding target 'out' must be a non-nil pointer, but got type %T (value: %v)", out, out)
+		// Return an HTTPError for the client.
+		return NewHTTPError(StatusInternalServerError, "Internal server error: Invalid binding target provided.").WithInternal(internalErr)
+	}
+	return nil
+}
+
+// bindBody binds the request body into `out` using the decoder selected by Content-Type.
+func (c *Context) bindBody(out interface{}) error {
+	contentType := c.ContentType() // Get the request's Content-Type header.
+
 	switch {
 	case strings.HasPrefix(contentType, "application/json"):
-		body := c.Body() // Get the raw request body.
-		if len(body) == 0 {
-			// Empty JSON body is considered valid for binding (results in zero-value struct).
-			return nil
-		}
-		if err := json.Unmarshal(body, out); err != nil {
-			return NewHTTPError(StatusBadRequest, "Invalid JSON data provided in request body.").WithInternal(err)
-		}
+		return c.bindJSON(out)
 	case strings.HasPrefix(contentType, "application/xml"), strings.HasPrefix(contentType, "text/xml"):
-		body := c.Body()
-		if len(body) == 0 {
-			return nil // Empty XML body is valid for binding.
-		}
-		if err := xml.Unmarshal(body, out); err != nil {
-			return NewHTTPError(StatusBadRequest, "Invalid XML data provided in request body.").WithInternal(err)
-		}
+		return c.bindXML(out)
 	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"),
 		strings.HasPrefix(contentType, "multipart/form-data"):
 		// For form data (URL-encoded or multipart), bind from POST arguments.
 		// Note: multipart/form-data file uploads are handled separately by c.FormFile() / c.MultipartForm().
 		// This binding focuses on non-file form fields.
-		if c.formArgs == nil {
-			// Lazily parse and cache POST form arguments from fasthttp.RequestCtx.
-			// Calling PostArgs() parses the body if it hasn't been already.
-			_ = c.Ctx.PostArgs() // Ensure parsing.
-			c.formArgs = c.Ctx.PostArgs()
+		formArgs, err := c.formBodyArgs() // Parsed lazily and cached, for both form encodings.
+		if err != nil {
+			return NewHTTPError(StatusBadRequest, "Invalid multipart form data provided in request body.").WithInternal(err)
 		}
-		return c.bindDataFromArgs(out, c.formArgs, "form data from request body", "form")
+		return c.bindDataFromArgs(out, formArgs, "form data from request body", "form")
 	default:
 		// If Content-Type is not recognized for binding and there is a request body,
 		// return an "Unsupported Media Type" error.
@@ -242,7 +487,204 @@ func (c *Context) bindWithReflection(out interface{}) error {
 		// No body and unrecognized Content-Type: effectively no data to bind, so succeed.
 		return nil
 	}
-	return nil // Should be covered by switch cases.
+}
+
+// bindingBody returns the request body for decoding by the binder.
+//
+// A streamed body (see `ServerConfig.StreamRequestBody`) is not limited by fasthttp, so it
+// is read here up to `ServerConfig.MaxRequestBodySize`: a larger body is rejected with a
+// 413 `*HTTPError` instead of being buffered whole, and a body that cannot be read
+// completely (e.g., the client disconnected mid-upload) with a 400 `*HTTPError`, rather
+// than being decoded as truncated, malformed data. The body read is buffered, so
+// `c.Body()` returns it afterwards.
+func (c *Context) bindingBody() ([]byte, error) {
+	if !c.Ctx.Request.IsBodyStream() {
+		return c.Body(), nil
+	}
+	limit := 0
+	if c.router != nil {
+		limit = c.router.serverConfig.MaxRequestBodySize
+	}
+	tooLarge := func(reason string) error {
+		return NewHTTPError(StatusRequestEntityTooLarge,
+			fmt.Sprintf("Request body exceeds the maximum allowed size of %d bytes.", limit)).
+			WithInternal(fmt.Errorf("request body too large: %s", reason))
+	}
+	if cl := c.Ctx.Request.Header.ContentLength(); limit > 0 && cl > limit {
+		return nil, tooLarge(fmt.Sprintf("Content-Length %d", cl))
+	}
+
+	var reader io.Reader = c.Ctx.RequestBodyStream()
+	if limit > 0 {
+		reader = io.LimitReader(reader, int64(limit)+1)
+	}
+	var buf bytes.Buffer
+	n, err := buf.ReadFrom(reader)
+	if err != nil {
+		return nil, NewHTTPError(StatusBadRequest, "Request body is incomplete or could not be read.").WithInternal(err)
+	}
+	if limit > 0 && n > int64(limit) {
+		return nil, tooLarge("streamed body exceeded limit")
+	}
+	// Replace the stream with the buffered body so c.Body() works as usual.
+	c.Ctx.Request.SetBody(buf.Bytes())
+	return c.Body(), nil
+}
+
+// bindJSON decodes the request body as JSON into `out`.
+func (c *Context) bindJSON(out interface{}) error {
+	body, err := c.bindingBody() // Get the raw request body.
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		// Empty JSON body is considered valid for binding (results in zero-value struct).
+		return nil
+	}
+	if c.router != nil && c.router.serverConfig.DisallowUnknownFields {
+		return c.bindJSONStrict(body, out)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		// A value of the wrong type (e.g., a string for an int field) is a field error:
+		// report every such field instead of only the first one json.Unmarshal returns.
+		// Syntax errors (malformed JSON) keep their own, plain error message.
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			if fieldErr := jsonFieldErrors(body, out).httpError("JSON request body"); fieldErr != nil {
+				return fieldErr
+			}
+		}
+		return NewHTTPError(StatusBadRequest, "Invalid JSON data provided in request body.").WithInternal(err)
+	}
+	return nil
+}
+
+// bindJSONStrict decodes the JSON `body` into `out` like `bindJSON`, but rejects object
+// keys that match no field of the target (`ServerConfig.DisallowUnknownFields`).
+func (c *Context) bindJSONStrict(body []byte, out interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(out)
+	if err == nil && decoder.InputOffset() < int64(len(bytes.TrimRight(body, " \t\r\n"))) {
+		// Like json.Unmarshal, reject data after the first JSON value.
+		err = errors.New("invalid character after top-level value")
+	}
+	if err == nil {
+		return nil
+	}
+	// encoding/json reports an unknown field as `json: unknown field "<name>"`.
+	if quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		name, unquoteErr := strconv.Unquote(quoted)
+		if unquoteErr != nil {
+			name = quoted
+		}
+		var fieldErrs bindingFieldErrors
+		fieldErrs.add(name, "unknown field", fmt.Errorf("error binding JSON field '%s': %w", name, err))
+		return fieldErrs.httpError("JSON request body")
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if fieldErr := jsonFieldErrors(body, out).httpError("JSON request body"); fieldErr != nil {
+			return fieldErr
+		}
+	}
+	return NewHTTPError(StatusBadRequest, "Invalid JSON data provided in request body.").WithInternal(err)
+}
+
+// jsonFieldErrors decodes each top-level field of the JSON object `body` separately into
+// the type of the matching field of the struct pointed to by `out`, and collects the
+// errors. An error inside a nested value is reported under its path (e.g., "address.zip").
+func jsonFieldErrors(body []byte, out interface{}) *bindingFieldErrors {
+	fieldErrs := &bindingFieldErrors{}
+	elem := reflect.ValueOf(out).Elem()
+	if elem.Kind() != reflect.Struct {
+		return fieldErrs
+	}
+	var rawFields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &rawFields); err != nil {
+		return fieldErrs
+	}
+	collectJSONFieldErrors(elem.Type(), rawFields, fieldErrs)
+	return fieldErrs
+}
+
+// collectJSONFieldErrors records decode errors for the fields of `typ` found in `rawFields`,
+// matching JSON keys to fields the way encoding/json does (tag name or field name, exact
+// match first, then case-insensitive). Fields of embedded structs are promoted.
+func collectJSONFieldErrors(typ reflect.Type, rawFields map[string]json.RawMessage, fieldErrs *bindingFieldErrors) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				collectJSONFieldErrors(embeddedType, rawFields, fieldErrs)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		rawValue, ok := rawFields[name]
+		if !ok {
+			for key, value := range rawFields {
+				if strings.EqualFold(key, name) {
+					rawValue, ok = value, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		if err := json.Unmarshal(rawValue, reflect.New(field.Type).Interface()); err != nil {
+			path, message := name, err.Error()
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				if typeErr.Field != "" {
+					path = name + "." + typeErr.Field
+				}
+				message = fmt.Sprintf("cannot unmarshal JSON %s into type %s", typeErr.Value, typeErr.Type)
+			}
+			fieldErrs.add(path, message, fmt.Errorf("error binding JSON field '%s': %w", path, err))
+		}
+	}
+}
+
+// bindXML decodes the request body as XML into `out`.
+func (c *Context) bindXML(out interface{}) error {
+	body, err := c.bindingBody()
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil // Empty XML body is valid for binding.
+	}
+	if err := xml.Unmarshal(body, out); err != nil {
+		return NewHTTPError(StatusBadRequest, "Invalid XML data provided in request body.").WithInternal(err)
+	}
+	return nil
+}
+
+// bindQuery binds the URL query parameters into `out`.
+func (c *Context) bindQuery(out interface{}) error {
+	if c.queryArgs == nil {
+		// Lazily parse and cache query arguments from fasthttp.RequestCtx.
+		c.queryArgs = c.Ctx.QueryArgs()
+	}
+	return c.bindDataFromArgs(out, c.queryArgs, "URL query parameters", "query")
 }
 
 // bindDataFromArgs is an internal helper function to bind data from `fasthttp.Args`
@@ -287,6 +729,19 @@ func (c *Context) bindDataFromArgs(out interface{}, args *fasthttp.Args, source
 		return NewHTTPError(StatusInternalServerError, "Internal server error: Invalid target type for argument binding.").WithInternal(unsupportedTypeErr)
 	}
 
+	var fieldErrs bindingFieldErrors // Conversion errors are collected, not returned one by one.
+	c.bindArgsToStruct(elem, "", args, source, tagKey, &fieldErrs)
+	return fieldErrs.httpError(source)
+}
+
+// bindArgsToStruct populates the fields of the struct `elem` from `args`, recording
+// conversion errors in `fieldErrs`. `prefix` is the argument name of `elem` itself when
+// it is a nested struct ("" at the top level), so the fields of a nested struct are
+// looked up with bracket notation, e.g., "filter[status]" for the field "status" of the
+// field "filter". Slice fields collect the repeated values of both "tags" and "tags[]";
+// map fields with string keys collect "filter[<key>]" arguments. Embedded structs
+// without a tag are flattened, as with `encoding/json`.
+func (c *Context) bindArgsToStruct(elem reflect.Value, prefix string, args *fasthttp.Args, source, tagKey string, fieldErrs *bindingFieldErrors) {
 	typ := elem.Type() // Get the type information of the struct.
 	numFields := elem.NumField()
 
@@ -307,19 +762,57 @@ func (c *Context) bindDataFromArgs(out interface{}, args *fasthttp.Args, source
 			// Tag might have options like ",omitempty". Take only the name part.
 			lookupName = strings.Split(tagValue, ",")[0]
 		}
+		if lookupName == "-" { // If tag is "-", explicitly skip this field for binding.
+			continue
+		}
+
+		// Nested structs (other than time.Time) are bound from bracketed arguments.
+		structType := fieldStructType.Type
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+		if structType.Kind() == reflect.Struct && structType != reflect.TypeOf(time.Time{}) {
+			nestedPrefix := prefix
+			if !fieldStructType.Anonymous || lookupName != "" {
+				if lookupName == "" {
+					lookupName = fieldStructType.Name
+				}
+				nestedPrefix = bracketArgName(prefix, lookupName)
+				if !argsHaveNamePrefix(args, nestedPrefix+"[") {
+					continue // Leave the struct (or nil pointer) untouched if nothing targets it.
+				}
+			}
+			if fieldReflectVal.Kind() == reflect.Ptr {
+				if fieldReflectVal.IsNil() {
+					fieldReflectVal.Set(reflect.New(structType))
+				}
+				fieldReflectVal = fieldReflectVal.Elem()
+			}
+			c.bindArgsToStruct(fieldReflectVal, nestedPrefix, args, source, tagKey, fieldErrs)
+			continue
+		}
+
 		if lookupName == "" { // If tag is missing or was just ",", use the field's actual name.
 			lookupName = fieldStructType.Name
 		}
-		if lookupName == "-" { // If tag is "-", explicitly skip this field for binding.
+		argName := bracketArgName(prefix, lookupName)
+
+		// Map fields collect the arguments named "<argName>[<key>]".
+		if fieldReflectVal.Kind() == reflect.Map {
+			if err := c.setMapField(fieldReflectVal, argName, args, timeLayoutsForField(fieldStructType)); err != nil {
+				fieldErrs.add(argName, err.Error(), fmt.Errorf("error binding %s parameter '%s' to field '%s' (type %s): %w",
+					source, argName, fieldStructType.Name, fieldStructType.Type.String(), err))
+			}
 			continue
 		}
 
-		// Get argument values from `args` based on `lookupName`.
+		// Get argument values from `args` based on `argName`.
 		var argStrValues []string
 		if fieldReflectVal.Kind() == reflect.Slice {
-			// If the struct field is a slice, attempt to get multiple values for the key.
+			// If the struct field is a slice, collect the values of all occurrences of the
+			// key, both repeated ("tags=a&tags=b") and bracketed ("tags[]=a&tags[]=b").
 			// `fasthttp.Args.PeekMulti` returns a slice of byte slices.
-			byteValues := args.PeekMulti(lookupName)
+			byteValues := append(args.PeekMulti(argName), args.PeekMulti(argName+"[]")...)
 			if len(byteValues) == 0 {
 				continue // No values found for this key.
 			}
@@ -330,7 +823,7 @@ func (c *Context) bindDataFromArgs(out interface{}, args *fasthttp.Args, source
 		} else {
 			// If the struct field is not a slice, get a single value.
 			// `fasthttp.Args.Peek` returns the first value for the key.
-			argValueBytes := args.Peek(lookupName)
+			argValueBytes := args.Peek(argName)
 			if argValueBytes == nil {
 				continue // No value found for this key.
 			}
@@ -338,20 +831,92 @@ func (c *Context) bindDataFromArgs(out interface{}, args *fasthttp.Args, source
 		}
 
 		// Set the struct field's value using the retrieved string(s).
-		if err := c.setStructField(fieldReflectVal, fieldStructType.Type, argStrValues); err != nil {
-			// If setting the field fails (e.g., parsing error), return an HTTPError.
-			bindingErr := fmt.Errorf("error binding %s parameter '%s' to field '%s' (type %s): %w",
-				source, lookupName, fieldStructType.Name, fieldStructType.Type.String(), err)
-			return NewHTTPError(StatusBadRequest, bindingErr.Error()).WithInternal(err)
+		if err := c.setStructField(fieldReflectVal, fieldStructType.Type, argStrValues, timeLayoutsForField(fieldStructType)); err != nil {
+			// If setting the field fails (e.g., parsing error), record it and continue with
+			// the remaining fields.
+			fieldErrs.add(argName, err.Error(), fmt.Errorf("error binding %s parameter '%s' to field '%s' (type %s): %w",
+				source, argName, fieldStructType.Name, fieldStructType.Type.String(), err))
 		}
 	}
-	return nil
+}
+
+// bracketArgName returns the argument name of the field `name` nested under `prefix`
+// in bracket notation, e.g., "filter[status]", or `name` itself at the top level.
+func bracketArgName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+// argsHaveNamePrefix reports whether any argument name in `args` starts with `prefix`.
+func argsHaveNamePrefix(args *fasthttp.Args, prefix string) bool {
+	found := false
+	args.VisitAll(func(key, _ []byte) {
+		if !found && strings.HasPrefix(string(key), prefix) {
+			found = true
+		}
+	})
+	return found
+}
+
+// setMapField populates the map field `fieldVal`, which must have string keys and
+// scalar values, from the arguments named "<argName>[<key>]", e.g., "filter[status]=open"
+// sets the key "status". Deeper brackets ("filter[a][b]") are not matched. The map is
+// only allocated if such an argument exists.
+func (c *Context) setMapField(fieldVal reflect.Value, argName string, args *fasthttp.Args, timeLayouts []string) error {
+	mapType := fieldVal.Type()
+	var bindErr error
+	args.VisitAll(func(key, value []byte) {
+		if bindErr != nil {
+			return
+		}
+		mapKey, ok := strings.CutPrefix(string(key), argName+"[")
+		if !ok || !strings.HasSuffix(mapKey, "]") {
+			return
+		}
+		mapKey = strings.TrimSuffix(mapKey, "]")
+		if mapKey == "" || strings.ContainsAny(mapKey, "[]") {
+			return
+		}
+		if mapType.Key().Kind() != reflect.String {
+			bindErr = fmt.Errorf("unsupported map key type '%s' for form/query string binding", mapType.Key())
+			return
+		}
+		elemVal := reflect.New(mapType.Elem()).Elem()
+		if err := c.setScalarField(elemVal, mapType.Elem(), string(value), timeLayouts); err != nil {
+			bindErr = fmt.Errorf("error setting map key '%s' from value '%s': %w", mapKey, value, err)
+			return
+		}
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.MakeMap(mapType))
+		}
+		fieldVal.SetMapIndex(reflect.ValueOf(mapKey).Convert(mapType.Key()), elemVal)
+	})
+	return bindErr
+}
+
+// DefaultTimeFormats are the layouts tried, in order, when binding a `time.Time` or
+// `*time.Time` field from query, form, or route parameter values, unless the field
+// has a `time_format` tag. Applications may replace it at startup, before serving
+// requests, to accept other formats everywhere.
+var DefaultTimeFormats = []string{time.RFC3339, "2006-01-02"}
+
+// timeLayoutsForField returns the layouts for binding `field` as a time: the layouts of
+// its `time_format` tag (separated by "|"), or nil to use `DefaultTimeFormats`.
+func timeLayoutsForField(field reflect.StructField) []string {
+	tagValue := field.Tag.Get("time_format")
+	if tagValue == "" {
+		return nil
+	}
+	return strings.Split(tagValue, "|")
 }
 
 // setStructField is an internal helper that populates a single struct field (`fieldVal`
 // of type `fieldType`) with one or more string values (`strValues`) obtained from
 // the request data (query/form). It handles both scalar and slice fields, as well as pointers.
-func (c *Context) setStructField(fieldVal reflect.Value, fieldType reflect.Type, strValues []string) error {
+// `timeLayouts` are the layouts for `time.Time` values; if empty, `DefaultTimeFormats` is used.
+func (c *Context) setStructField(fieldVal reflect.Value, fieldType reflect.Type, strValues []string, timeLayouts []string) error {
 	if len(strValues) == 0 {
 		return nil // No values to set.
 	}
@@ -382,7 +947,7 @@ func (c *Context) setStructField(fieldVal reflect.Value, fieldType reflect.Type,
 		newSlice := reflect.MakeSlice(fieldType, len(strValues), len(strValues))
 		for i, strVal := range strValues {
 			// Set each element of the new slice by parsing its string value.
-			if err := c.setScalarField(newSlice.Index(i), sliceElemType, strVal); err != nil {
+			if err := c.setScalarField(newSlice.Index(i), sliceElemType, strVal, timeLayouts); err != nil {
 				return fmt.Errorf("error setting slice element %d from value '%s': %w", i, strVal, err)
 			}
 		}
@@ -392,7 +957,7 @@ func (c *Context) setStructField(fieldVal reflect.Value, fieldType reflect.Type,
 
 	// Handle scalar (non-slice, non-pointer at this stage) fields.
 	// Only one string value is expected for scalar fields.
-	return c.setScalarField(fieldVal, fieldType, strValues[0])
+	return c.setScalarField(fieldVal, fieldType, strValues[0], timeLayouts)
 }
 
 // setScalarField is an internal helper that sets a scalar (non-slice) field (`fieldVal`
@@ -400,7 +965,7 @@ func (c *Context) setStructField(fieldVal reflect.Value, fieldType reflect.Type,
 // basic types like string, int, uint, bool, float, and `time.Time`.
 // It also correctly handles setting pointer-to-scalar types if `fieldVal` and `fieldType`
 // have already been dereferenced by `setStructField`.
-func (c *Context) setScalarField(fieldVal reflect.Value, fieldType reflect.Type, strValue string) error {
+func (c *Context) setScalarField(fieldVal reflect.Value, fieldType reflect.Type, strValue string, timeLayouts []string) error {
 	// If fieldType is still a pointer (e.g., for slice of pointers like []*int),
 	// dereference it for setting the underlying scalar value.
 	if fieldType.Kind() == reflect.Ptr {
@@ -430,20 +995,17 @@ func (c *Context) setScalarField(fieldVal reflect.Value, fieldType reflect.Type,
 		if strValue == "" { // Cannot parse an empty string into a time.
 			return fmt.Errorf("cannot parse empty string as time.Time for field")
 		}
-		// Try parsing in RFC3339 format first.
-		parsedTimeRFC3339, errRFC3339 := time.Parse(time.RFC3339, strValue)
-		if errRFC3339 == nil {
-			fieldVal.Set(reflect.ValueOf(parsedTimeRFC3339))
-			return nil
+		if len(timeLayouts) == 0 {
+			timeLayouts = DefaultTimeFormats
 		}
-		// If RFC3339 fails, try parsing in "YYYY-MM-DD" date format.
-		parsedTimeDate, errDate := time.Parse("2006-01-02", strValue)
-		if errDate == nil {
-			fieldVal.Set(reflect.ValueOf(parsedTimeDate))
-			return nil
+		// Try each layout in order; the first one that parses wins.
+		for _, layout := range timeLayouts {
+			if parsedTime, err := time.Parse(layout, strValue); err == nil {
+				fieldVal.Set(reflect.ValueOf(parsedTime))
+				return nil
+			}
 		}
-		// If both parsing attempts fail.
-		return fmt.Errorf("cannot parse '%s' as time.Time (tried RFC3339: %v; and YYYY-MM-DD: %v)", strValue, errRFC3339, errDate)
+		return fmt.Errorf("cannot parse '%s' as time.Time (expected layout: '%s')", strValue, strings.Join(timeLayouts, "' or '"))
 	}
 
 	// Handle other scalar types.
@@ -472,18 +1034,9 @@ func (c *Context) setScalarField(fieldVal reflect.Value, fieldType reflect.Type,
 		if strValue == "" { // Cannot parse empty string to boolean.
 			return fmt.Errorf("cannot parse empty string as boolean")
 		}
-		b, err := strconv.ParseBool(strValue)
+		b, err := parseBoolValue(strValue)
 		if err != nil {
-			// Allow common alternatives for boolean like "on"/"off", "yes"/"no".
-			lowerVal := strings.ToLower(strValue)
-			if lowerVal == "on" || lowerVal == "yes" {
-				b, err = true, nil
-			} else if lowerVal == "off" || lowerVal == "no" {
-				b, err = false, nil
-			}
-			if err != nil { // If still not parsable after checking alternatives.
-				return fmt.Errorf("cannot parse '%s' as boolean: %w", strValue, err)
-			}
+			return fmt.Errorf("cannot parse '%s' as boolean: %w", strValue, err)
 		}
 		fieldVal.SetBool(b)
 	case reflect.Float32, reflect.Float64:
@@ -501,3 +1054,20 @@ func (c *Context) setScalarField(fieldVal reflect.Value, fieldType reflect.Type,
 	}
 	return nil
 }
+
+// parseBoolValue parses a boolean from request data. Besides the values accepted by
+// `strconv.ParseBool`, it accepts the common alternatives "on"/"off" and "yes"/"no"
+// (case-insensitive), as sent by HTML checkboxes and hand-written query strings.
+func parseBoolValue(s string) (bool, error) {
+	b, err := strconv.ParseBool(s)
+	if err == nil {
+		return b, nil
+	}
+	switch strings.ToLower(s) {
+	case "on", "yes":
+		return true, nil
+	case "off", "no":
+		return false, nil
+	}
+	return false, err
+}
@@ -1,6 +1,8 @@
 package xylium
 
 import (
+	"strings" // For detecting cookie name prefixes.
+
 	"github.com/valyala/fasthttp" // For fasthttp.Cookie and related constants.
 )
 
@@ -44,32 +46,71 @@ func (c *Context) SetCookie(cookie *fasthttp.Cookie) *Context {
 	if cookie == nil {
 		return c // Do nothing if cookie is nil.
 	}
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	c.Ctx.Response.Header.SetCookie(cookie)
 	return c
 }
 
+// CookieOptions holds the attributes identifying a cookie to be deleted with
+// `c.ClearCookie`. A browser only deletes a cookie if the deleting "Set-Cookie" header
+// has the same name, Path and Domain as the cookie was set with.
+type CookieOptions struct {
+	// Path is the cookie's Path attribute. Default: "/".
+	Path string
+	// Domain is the cookie's Domain attribute. Leave empty for host-only cookies.
+	Domain string
+	// Secure sets the Secure attribute. Browsers ignore a "Set-Cookie" header for a
+	// cookie with SameSite=None or a "__Secure-"/"__Host-" name prefix without it; it is
+	// always set for such names.
+	Secure bool
+	// SameSite sets the SameSite attribute (e.g., `fasthttp.CookieSameSiteLaxMode`).
+	// Default: `fasthttp.CookieSameSiteDisabled` (attribute omitted).
+	SameSite fasthttp.CookieSameSite
+}
+
 // ClearCookie adds a "Set-Cookie" header to the response that instructs the browser
 // to delete the cookie with the specified `name`.
-// It achieves this by setting the cookie's value to empty, its path to "/" (common default),
-// HTTPOnly to true (common default), and its expiration time to a point in the past
-// (using `fasthttp.CookieExpireDelete`).
-// For effective deletion, ensure `Path` and `Domain` (if set originally) match the cookie
-// being cleared. This method uses common defaults; for precise control, construct and
-// set an expiring `fasthttp.Cookie` manually using `c.SetCookie`.
+// It achieves this by setting the cookie's value to empty, HTTPOnly to true (common
+// default), and its expiration time to a point in the past (using
+// `fasthttp.CookieExpireDelete`, sent as "expires=Tue, 10 Nov 2009 23:00:00 GMT").
+// For effective deletion, `Path` and `Domain` must match the cookie being cleared: pass
+// them in `opts` (only the first is used). Without `opts`, the path is "/" and no domain
+// is set, which matches cookies set with `NewxyliumCookie` defaults.
 // Returns the Context pointer for method chaining.
-func (c *Context) ClearCookie(name string) *Context {
+//
+// Example:
+//
+//	c.ClearCookie("session", xylium.CookieOptions{Path: "/app", Domain: "example.com"})
+func (c *Context) ClearCookie(name string, opts ...CookieOptions) *Context {
+	var opt CookieOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Path == "" {
+		opt.Path = "/" // Path should match the original cookie's path.
+	}
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	cookie := fasthttp.AcquireCookie()   // Get a cookie object from fasthttp's pool.
 	defer fasthttp.ReleaseCookie(cookie) // Return to pool when done.
 
 	cookie.SetKey(name)
-	cookie.SetValue("")      // Value can be empty for deletion.
-	cookie.SetPath("/")      // Path should ideally match the original cookie's path.
-	cookie.SetHTTPOnly(true) // Match common default.
+	cookie.SetValue("") // Value can be empty for deletion.
+	cookie.SetPath(opt.Path)
+	cookie.SetDomain(opt.Domain) // Must match the original cookie's domain, if it had one.
+	cookie.SetHTTPOnly(true)     // Match common default.
+	cookie.SetSecure(opt.Secure || strings.HasPrefix(name, "__Secure-") || strings.HasPrefix(name, "__Host-"))
+	if opt.SameSite != fasthttp.CookieSameSiteDisabled {
+		cookie.SetSameSite(opt.SameSite)
+	}
 	// `fasthttp.CookieExpireDelete` sets the expiration to a time in the past,
 	// signaling the browser to delete the cookie immediately.
 	cookie.SetExpire(fasthttp.CookieExpireDelete)
-	// Note: If the original cookie had a specific Domain attribute, it should also be set here
-	// for the browser to correctly identify and delete the cookie. e.g., cookie.SetDomain("example.com")
 
 	c.Ctx.Response.Header.SetCookie(cookie)
 	return c
@@ -84,6 +125,10 @@ func (c *Context) SetCustomCookie(customCookie *xyliumCookie) *Context {
 	if customCookie == nil {
 		return c // Do nothing if customCookie is nil.
 	}
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	// Directly use the embedded `fasthttp.Cookie` from `xyliumCookie`.
 	c.Ctx.Response.Header.SetCookie(&customCookie.Cookie)
 	return c
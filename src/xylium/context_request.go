@@ -1,10 +1,17 @@
 package xylium
 
 import (
+	"bytes"          // For BodyReader over an already buffered body.
 	"fmt"            // For error formatting in ParamInt, QueryParamInt.
+	"io"             // For the io.Reader returned by BodyReader.
 	"mime/multipart" // For FormFile, MultipartForm types.
-	"strconv"        // For parsing string parameters to integers.
+	"sort"           // For ordering Accept-Language ranges by quality.
+	"strconv"        // For parsing string parameters to integers and floats.
 	"strings"        // For string manipulation in RealIP, Scheme.
+	"time"           // For parsing parameters with ParamTime, QueryParamTime.
+
+	"github.com/valyala/fasthttp" // For fasthttp.Args holding parsed form values.
+	"golang.org/x/text/language"  // For BCP 47 language matching in PreferredLanguage.
 )
 
 // --- Request Information ---
@@ -15,12 +22,37 @@ func (c *Context) Method() string { return string(c.Ctx.Method()) }
 
 // Path returns the request path string (e.g., "/users/123").
 // This is the path part of the URI, without query parameters.
-func (c *Context) Path() string { return string(c.Ctx.Path()) }
+// Inside a group with `RouteGroup.SetStripPrefix`, the group prefix is removed (see
+// `StrippedPrefix`); `c.Ctx.Path()` always holds the full path.
+func (c *Context) Path() string { return stripPathPrefix(string(c.Ctx.Path()), c.strippedPrefix) }
+
+// StrippedPrefix returns the group prefix removed from `c.Path()` and `c.RoutePattern()`
+// for the current request, e.g., "/billing" for a request to "/billing/invoices" served
+// by a group "/billing" with `RouteGroup.SetStripPrefix`. It is "" if nothing is
+// stripped, including in global middleware and error handlers, which see full paths.
+// Use it to build links that point back into the mounted application.
+func (c *Context) StrippedPrefix() string { return c.strippedPrefix }
 
 // URI returns the full request URI string, including the path and query parameters
 // (e.g., "/search?query=xylium&limit=10").
 func (c *Context) URI() string { return string(c.Ctx.RequestURI()) }
 
+// RewriteMethod changes the HTTP method of the current request.
+// It is intended for use in a `PreRoutingHook` (see `Router.UsePreRouting`), where the
+// rewritten method is used for route lookup. Calling it after routing only changes what
+// `c.Method()` reports; it does not re-route the request.
+func (c *Context) RewriteMethod(method string) {
+	c.Ctx.Request.Header.SetMethod(method)
+}
+
+// RewritePath changes the path of the current request, keeping the query string intact.
+// Like `RewriteMethod`, it is intended for use in a `PreRoutingHook`, e.g., to strip a
+// tenant prefix or normalize trailing slashes before the route is looked up.
+// The path should start with '/'.
+func (c *Context) RewritePath(path string) {
+	c.Ctx.Request.URI().SetPath(path)
+}
+
 // IP returns the remote IP address of the client making the request, as seen by the server.
 // This might be the IP of a proxy if the server is behind one. For a more accurate
 // client IP, consider using `RealIP()`.
@@ -83,6 +115,27 @@ func (c *Context) IsTLS() bool { return c.Ctx.IsTLS() }
 // which is a common convention, though not a formal standard.
 func (c *Context) IsAJAX() bool { return c.Header("X-Requested-With") == "XMLHttpRequest" }
 
+// IsClientDisconnected reports whether the client has closed the connection while the
+// request is being handled, so long-running handlers can stop work nobody will receive.
+// It probes the socket without consuming data (on Unix-like systems; elsewhere it always
+// returns false). For automatic cancellation of `c.Context()`, see `CancelOnDisconnect`.
+//
+// Caveats: a client that half-closes its side of the connection after sending the request
+// is reported as disconnected. Unread request data (e.g., a body read via
+// `ServerConfig.StreamRequestBody`, or a pipelined request) hides a disconnect until it is
+// consumed. It always returns false for hijacked connections and for contexts without a
+// network connection (e.g., in tests).
+func (c *Context) IsClientDisconnected() bool {
+	if c.Ctx == nil || c.Ctx.Hijacked() {
+		return false
+	}
+	conn := c.Ctx.Conn()
+	if conn == nil {
+		return false
+	}
+	return connPeerClosed(conn)
+}
+
 // Header returns the value of a specific request header by its key.
 // Header keys are typically case-insensitive. `fasthttp` normalizes them.
 func (c *Context) Header(key string) string { return string(c.Ctx.Request.Header.Peek(key)) }
@@ -97,6 +150,140 @@ func (c *Context) Headers() map[string]string {
 	return h
 }
 
+// AcceptsLanguages returns the best of the `offers` (language tags such as "en", "en-GB",
+// "id") for the request's `Accept-Language` header, honoring quality values ("q").
+// Each language range, from most to least preferred, is matched against the offers
+// case-insensitively: an identical tag first, then an offer within the range (e.g.,
+// "en" accepts "en-GB"), then the range with trailing subtags removed (e.g., "en-US"
+// accepts "en"). "*" accepts any offer not excluded with q=0.
+//
+// It returns the first offer if the request has no `Accept-Language` header, and ""
+// if no offer is acceptable (or `offers` is empty).
+//
+// Example:
+//
+//	lang := c.AcceptsLanguages("en", "id", "fr") // "id" for "Accept-Language: id-ID,id;q=0.9,en;q=0.8"
+func (c *Context) AcceptsLanguages(offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	header := strings.TrimSpace(c.Header("Accept-Language"))
+	if header == "" {
+		return offers[0]
+	}
+	ranges := parseAcceptLanguage(header)
+
+	// Offers matched by a range with q=0 are never returned, not even for "*".
+	excluded := make(map[string]bool)
+	for _, r := range ranges {
+		if r.q > 0 || r.value == "*" {
+			continue
+		}
+		for _, offer := range offers {
+			if languageRangeMatches(r.value, offer) {
+				excluded[offer] = true
+			}
+		}
+	}
+
+	for _, r := range ranges {
+		if r.q <= 0 {
+			continue
+		}
+		if r.value == "*" {
+			for _, offer := range offers {
+				if !excluded[offer] {
+					return offer
+				}
+			}
+			continue
+		}
+		// Exact and within-range matches first, then lookup with truncated ranges.
+		for _, offer := range offers {
+			if !excluded[offer] && languageRangeMatches(r.value, offer) {
+				return offer
+			}
+		}
+		for rng := r.value; strings.Contains(rng, "-"); {
+			rng = rng[:strings.LastIndex(rng, "-")]
+			for _, offer := range offers {
+				if !excluded[offer] && strings.EqualFold(rng, offer) {
+					return offer
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// PreferredLanguage returns the tag from `supported` that best matches the request's
+// `Accept-Language` header, using the BCP 47 matching of `golang.org/x/text/language`
+// (which also knows related languages and scripts, e.g., that "nb" users understand "no").
+// The first supported tag is the default: it is returned if the header is missing or
+// invalid, or nothing matches. Returns `language.Und` if `supported` is empty.
+//
+// Example:
+//
+//	var supported = []language.Tag{language.English, language.Indonesian}
+//	tag := c.PreferredLanguage(supported)
+func (c *Context) PreferredLanguage(supported []language.Tag) language.Tag {
+	if len(supported) == 0 {
+		return language.Und
+	}
+	tags, _, err := language.ParseAcceptLanguage(c.Header("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return supported[0]
+	}
+	_, index, _ := language.NewMatcher(supported).Match(tags...)
+	return supported[index]
+}
+
+// qualityItem is one element of a header with quality values, such as
+// `Accept-Language` or `Accept-Encoding` (RFC 9110, section 12.4.2).
+type qualityItem struct {
+	value string  // Element as sent, without its parameters (e.g., "en-US", "gzip" or "*").
+	q     float64 // Quality value (1 if not given or invalid).
+}
+
+// parseQualityList parses a comma-separated header with quality values into its
+// elements, in header order. Empty elements are skipped.
+func parseQualityList(header string) []qualityItem {
+	var items []qualityItem
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, qValue, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(strings.TrimSpace(name), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(qValue), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		items = append(items, qualityItem{value: value, q: q})
+	}
+	return items
+}
+
+// parseAcceptLanguage parses an `Accept-Language` header into its language ranges,
+// ordered from highest to lowest quality (keeping header order for equal qualities).
+func parseAcceptLanguage(header string) []qualityItem {
+	ranges := parseQualityList(header)
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
+
+// languageRangeMatches reports whether the language tag `offer` is identical to or
+// within the language range `rng` (e.g., "en" matches "en" and "en-GB"), ignoring case.
+func languageRangeMatches(rng, offer string) bool {
+	return strings.EqualFold(rng, offer) ||
+		(len(offer) > len(rng) && offer[len(rng)] == '-' && strings.EqualFold(rng, offer[:len(rng)]))
+}
+
 // --- Request Data: Route Parameters, Query Parameters, Form Data, Cookies ---
 
 // Param returns the value of a route parameter extracted from the URL path.
@@ -107,6 +294,42 @@ func (c *Context) Param(name string) string {
 	return c.Params[name]
 }
 
+// ParamNames returns the names of the route parameters in the order they appear in the
+// route pattern, e.g., ["org", "repo"] for "/orgs/:org/repos/:repo", including a
+// catch-all parameter. Unlike iterating over `c.Params`, the order is stable, which lets
+// generic handlers bind parameters positionally. It returns nil if the route has no
+// parameters. For a context whose `Params` were not set by the router (e.g., in tests),
+// the names are returned in alphabetical order. The returned slice is a copy.
+func (c *Context) ParamNames() []string {
+	if len(c.Params) == 0 {
+		return nil
+	}
+	if len(c.paramNames) == len(c.Params) {
+		return append([]string(nil), c.paramNames...)
+	}
+	names := make([]string, 0, len(c.Params))
+	for name := range c.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParamValues returns the values of the route parameters in the order of `ParamNames`,
+// e.g., ["acme", "api"] for a request to "/orgs/acme/repos/api" matching
+// "/orgs/:org/repos/:repo". It returns nil if the route has no parameters.
+func (c *Context) ParamValues() []string {
+	names := c.ParamNames()
+	if names == nil {
+		return nil
+	}
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = c.Params[name]
+	}
+	return values
+}
+
 // ParamInt attempts to parse a route parameter as an integer.
 // Returns the integer value and an error if the parameter is not found or
 // if its value cannot be parsed into an integer.
@@ -133,6 +356,119 @@ func (c *Context) ParamIntDefault(name string, def int) int {
 	return v
 }
 
+// ParamBool attempts to parse a route parameter as a boolean. Besides the values accepted
+// by `strconv.ParseBool` ("1", "t", "true", "0", "f", "false", ...), "on"/"off" and
+// "yes"/"no" are accepted, as in struct binding.
+// Returns an error if the parameter is not found or cannot be parsed.
+func (c *Context) ParamBool(name string) (bool, error) {
+	s, ok := c.Params[name]
+	if !ok {
+		return false, fmt.Errorf("route parameter '%s' not found", name)
+	}
+	b, err := parseBoolValue(s)
+	if err != nil {
+		return false, fmt.Errorf("route parameter '%s' (value: '%s') is not a valid boolean: %w", name, s, err)
+	}
+	return b, nil
+}
+
+// ParamBoolDefault is like `ParamBool`, but returns `def` if the parameter is not found
+// or parsing fails.
+func (c *Context) ParamBoolDefault(name string, def bool) bool {
+	v, err := c.ParamBool(name)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParamFloat attempts to parse a route parameter as a float64.
+// Returns an error if the parameter is not found or cannot be parsed.
+func (c *Context) ParamFloat(name string) (float64, error) {
+	s, ok := c.Params[name]
+	if !ok {
+		return 0, fmt.Errorf("route parameter '%s' not found", name)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("route parameter '%s' (value: '%s') is not a valid float: %w", name, s, err)
+	}
+	return f, nil
+}
+
+// ParamFloatDefault is like `ParamFloat`, but returns `def` if the parameter is not found
+// or parsing fails.
+func (c *Context) ParamFloatDefault(name string, def float64) float64 {
+	v, err := c.ParamFloat(name)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParamTime attempts to parse a route parameter as a time with the given `layout`
+// (e.g., `time.RFC3339`, "2006-01-02"), as `time.Parse` does.
+// Returns an error if the parameter is not found or cannot be parsed.
+func (c *Context) ParamTime(name, layout string) (time.Time, error) {
+	s, ok := c.Params[name]
+	if !ok {
+		return time.Time{}, fmt.Errorf("route parameter '%s' not found", name)
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("route parameter '%s' (value: '%s') is not a valid time in layout '%s': %w", name, s, layout, err)
+	}
+	return t, nil
+}
+
+// ParamTimeDefault is like `ParamTime`, but returns `def` if the parameter is not found
+// or parsing fails.
+func (c *Context) ParamTimeDefault(name, layout string, def time.Time) time.Time {
+	v, err := c.ParamTime(name, layout)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// RoutePattern returns the path pattern of the route that matched the current request,
+// e.g., "/users/:id" for a request to "/users/123", including any group prefix. Unlike
+// `c.Path()`, its values are bounded by the number of routes, which makes it suitable as
+// a metrics label or log field. It returns "" before routing (e.g., in pre-routing hooks)
+// and for requests that matched no route (e.g., in 404/405 handlers and fallbacks).
+// Inside a group with `RouteGroup.SetStripPrefix`, the group prefix is removed, like
+// for `c.Path()`, e.g., "/:id" for the route "/billing/:id" of the group "/billing".
+func (c *Context) RoutePattern() string {
+	if c.routePattern == "" {
+		return ""
+	}
+	return stripPathPrefix(c.routePattern, c.strippedPrefix)
+}
+
+// RouteMeta returns the metadata value set under `key` with `Route.Meta` on the route
+// that matched the current request, and whether it was set. It returns nil and false
+// before routing (e.g., in pre-routing hooks) and for requests that matched no route
+// (e.g., in 404/405 handlers and fallbacks). This lets middleware enforce policies
+// declared on the routes themselves instead of matching on paths:
+//
+//	app.DELETE("/users/:id", DeleteUser).Meta("scope", "admin")
+//
+//	func RequireScope(next xylium.HandlerFunc) xylium.HandlerFunc {
+//		return func(c *xylium.Context) error {
+//			if scope, ok := c.RouteMeta("scope"); ok && !hasScope(c, scope.(string)) {
+//				return xylium.NewHTTPError(xylium.StatusForbidden, "Insufficient scope.")
+//			}
+//			return next(c)
+//		}
+//	}
+func (c *Context) RouteMeta(key string) (value interface{}, exists bool) {
+	if c.route == nil {
+		return nil, false
+	}
+	value, exists = c.route.meta[key]
+	return
+}
+
 // QueryParam returns the value of a URL query parameter by its key.
 // For a URL like "/search?query=xylium&limit=10", `c.QueryParam("query")` returns "xylium".
 // Returns an empty string if the key is not found.
@@ -186,8 +522,101 @@ func (c *Context) QueryParamIntDefault(key string, def int) int {
 	return v
 }
 
-// FormValue returns the value of a form field from a POST or PUT request body.
-// It supports "application/x-www-form-urlencoded" and "multipart/form-data" content types.
+// QueryParamBool attempts to parse a URL query parameter as a boolean, accepting the same
+// values as `ParamBool` (e.g., "true", "1", "on", "yes").
+// Returns an error if the key is not found, the value is empty, or it cannot be parsed.
+func (c *Context) QueryParamBool(key string) (bool, error) {
+	s := c.QueryParam(key)
+	if s == "" {
+		return false, fmt.Errorf("query parameter '%s' not found or is empty", key)
+	}
+	b, err := parseBoolValue(s)
+	if err != nil {
+		return false, fmt.Errorf("query parameter '%s' (value: '%s') is not a valid boolean: %w", key, s, err)
+	}
+	return b, nil
+}
+
+// QueryParamBoolDefault is like `QueryParamBool`, but returns `def` if the key is not
+// found, the value is empty, or parsing fails.
+func (c *Context) QueryParamBoolDefault(key string, def bool) bool {
+	v, err := c.QueryParamBool(key)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// QueryParamFloat attempts to parse a URL query parameter as a float64.
+// Returns an error if the key is not found, the value is empty, or it cannot be parsed.
+func (c *Context) QueryParamFloat(key string) (float64, error) {
+	s := c.QueryParam(key)
+	if s == "" {
+		return 0, fmt.Errorf("query parameter '%s' not found or is empty", key)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query parameter '%s' (value: '%s') is not a valid float: %w", key, s, err)
+	}
+	return f, nil
+}
+
+// QueryParamFloatDefault is like `QueryParamFloat`, but returns `def` if the key is not
+// found, the value is empty, or parsing fails.
+func (c *Context) QueryParamFloatDefault(key string, def float64) float64 {
+	v, err := c.QueryParamFloat(key)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// QueryParamTime attempts to parse a URL query parameter as a time with the given
+// `layout` (e.g., `time.RFC3339`, "2006-01-02"), as `time.Parse` does.
+// Returns an error if the key is not found, the value is empty, or it cannot be parsed.
+func (c *Context) QueryParamTime(key, layout string) (time.Time, error) {
+	s := c.QueryParam(key)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("query parameter '%s' not found or is empty", key)
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query parameter '%s' (value: '%s') is not a valid time in layout '%s': %w", key, s, layout, err)
+	}
+	return t, nil
+}
+
+// QueryParamTimeDefault is like `QueryParamTime`, but returns `def` if the key is not
+// found, the value is empty, or parsing fails.
+func (c *Context) QueryParamTimeDefault(key, layout string, def time.Time) time.Time {
+	v, err := c.QueryParamTime(key, layout)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// QueryParamArray returns all values of a URL query parameter that appears more than
+// once (e.g., "?tag=go&tag=web" gives ["go", "web"]), in the order they appear.
+// Returns nil if the key is not found.
+func (c *Context) QueryParamArray(key string) []string {
+	if c.queryArgs == nil {
+		c.queryArgs = c.Ctx.QueryArgs() // Parse and cache.
+	}
+	rawValues := c.queryArgs.PeekMulti(key)
+	if len(rawValues) == 0 {
+		return nil
+	}
+	values := make([]string, len(rawValues))
+	for i, v := range rawValues {
+		values[i] = string(v)
+	}
+	return values
+}
+
+// FormValue returns the value of a form field, looking in the URL query string first and
+// then in the request body ("application/x-www-form-urlencoded" or "multipart/form-data").
+// Use `PostForm` to read only from the request body.
 // Returns an empty string if the key is not found.
 // Form arguments are parsed from the request body by `fasthttp` and cached.
 func (c *Context) FormValue(key string) string {
@@ -195,45 +624,137 @@ func (c *Context) FormValue(key string) string {
 	return string(c.Ctx.FormValue(key))
 }
 
-// PostFormValue returns the value of a form field specifically from POST arguments
-// (from the request body, not URL query parameters).
-// Similar to `FormValue` but more specific to `c.Ctx.PostArgs()`.
+// PostFormValue returns the value of a form field specifically from the request body
+// ("application/x-www-form-urlencoded", or the non-file values of "multipart/form-data"),
+// never from URL query parameters.
 // Form arguments are parsed and cached on first access.
 func (c *Context) PostFormValue(key string) string {
-	if c.formArgs == nil {
-		_ = c.Ctx.PostArgs() // Ensure PostArgs are parsed and cached.
-		c.formArgs = c.Ctx.PostArgs()
-	}
-	return string(c.formArgs.Peek(key))
+	args, _ := c.formBodyArgs()
+	return string(args.Peek(key))
 }
 
-// PostFormParams returns all POST form parameters (from the request body) as a map[string]string.
+// PostForm is a shorthand for `PostFormValue`: it returns the value of the form field
+// `key` from the request body only. Returns an empty string if the key is not found.
+func (c *Context) PostForm(key string) string {
+	return c.PostFormValue(key)
+}
+
+// PostFormParams returns all form parameters from the request body as a map[string]string.
 // Form arguments are parsed and cached on first access.
 func (c *Context) PostFormParams() map[string]string {
-	if c.formArgs == nil {
-		_ = c.Ctx.PostArgs() // Ensure PostArgs are parsed and cached.
-		c.formArgs = c.Ctx.PostArgs()
-	}
+	args, _ := c.formBodyArgs()
 	p := make(map[string]string)
-	c.formArgs.VisitAll(func(k, v []byte) { p[string(k)] = string(v) })
+	args.VisitAll(func(k, v []byte) { p[string(k)] = string(v) })
 	return p
 }
 
+// formBodyArgs returns the form fields of the request body, parsed on first access and
+// cached in `c.formArgs`: the URL-encoded arguments, or the non-file values of a
+// multipart form (which `c.Ctx.PostArgs()` does not include). The returned Args is never
+// nil; it is empty if the body is not a form. An error is returned only for a multipart
+// body that cannot be parsed.
+func (c *Context) formBodyArgs() (*fasthttp.Args, error) {
+	if c.formArgs != nil {
+		return c.formArgs, nil
+	}
+	if !strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		c.formArgs = c.Ctx.PostArgs() // Parses URL-encoded bodies on first access.
+		return c.formArgs, nil
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return &fasthttp.Args{}, err
+	}
+	args := &fasthttp.Args{}
+	for key, values := range form.Value {
+		for _, value := range values {
+			args.Add(key, value)
+		}
+	}
+	c.formArgs = args
+	return c.formArgs, nil
+}
+
 // FormFile returns the first file uploaded for the provided form key in a "multipart/form-data" request.
 // It returns a `*multipart.FileHeader` (containing file metadata and an interface to read the file)
 // and an error if the key is not found or if there's an issue retrieving the file.
+// The form is parsed as described in `MultipartForm`.
 func (c *Context) FormFile(key string) (*multipart.FileHeader, error) {
-	// c.Ctx.FormFile() handles parsing the multipart form if necessary.
-	return c.Ctx.FormFile(key)
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	files := form.File[key]
+	if len(files) == 0 {
+		return nil, fasthttp.ErrMissingFile
+	}
+	return files[0], nil
 }
 
 // MultipartForm parses a "multipart/form-data" request body.
 // It returns a `*multipart.Form` containing both form field values and uploaded files.
 // Returns an error if the request body is not multipart or if parsing fails.
-// The form is parsed by `fasthttp` and cached.
+// The form is parsed on first access and cached for the rest of the request.
+//
+// If `ServerConfig.MultipartMaxMemory` is positive, the form is parsed by Xylium: up to
+// that many bytes of file contents are kept in memory and larger files are written to
+// temporary files, which are removed when the Context is released. Otherwise the form
+// is parsed by `fasthttp`, which keeps a buffered body's files entirely in memory.
+// Either way, uploaded files are only valid until the handler returns; use
+// `SaveUploadedFile` to keep them.
 func (c *Context) MultipartForm() (*multipart.Form, error) {
-	// c.Ctx.MultipartForm() handles parsing and caching.
-	return c.Ctx.MultipartForm()
+	maxMemory := int64(0)
+	if c.router != nil {
+		maxMemory = c.router.serverConfig.MultipartMaxMemory
+	}
+	if maxMemory <= 0 {
+		// c.Ctx.MultipartForm() handles parsing and caching.
+		return c.Ctx.MultipartForm()
+	}
+	// Cached on the fasthttp context, so derived Contexts (see WithGoContext) share the form.
+	if form, ok := c.Ctx.UserValue(multipartFormUserValueKey).(*multipart.Form); ok {
+		return form, nil
+	}
+	boundary := string(c.Ctx.Request.Header.MultipartFormBoundary())
+	if boundary == "" {
+		return nil, fasthttp.ErrNoMultipartForm
+	}
+	if len(c.Ctx.Request.Header.ContentEncoding()) > 0 {
+		// Compressed forms are rare; let fasthttp decode them (in memory).
+		return c.Ctx.MultipartForm()
+	}
+	var body io.Reader = c.BodyReader()
+	if c.Ctx.Request.IsBodyStream() && c.router.serverConfig.MaxRequestBodySize > 0 {
+		// A streamed body is not limited by fasthttp.
+		body = io.LimitReader(body, int64(c.router.serverConfig.MaxRequestBodySize))
+	}
+	form, err := multipart.NewReader(body, boundary).ReadForm(maxMemory)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read multipart/form-data body: %w", err)
+	}
+	c.Ctx.SetUserValue(multipartFormUserValueKey, form)
+	return form, nil
+}
+
+// multipartFormUserValueKey is the `fasthttp` user value key under which a form parsed
+// by `MultipartForm` (with `ServerConfig.MultipartMaxMemory` set) is cached.
+const multipartFormUserValueKey = "xylium_multipart_form"
+
+// removeMultipartForm deletes the temporary files of a form parsed by `MultipartForm`.
+// It is called when the Context is released.
+func (c *Context) removeMultipartForm() {
+	if form, ok := c.Ctx.UserValue(multipartFormUserValueKey).(*multipart.Form); ok {
+		// Errors are ignored: the files may have been moved by SaveUploadedFile.
+		_ = form.RemoveAll()
+		c.Ctx.RemoveUserValue(multipartFormUserValueKey)
+	}
+}
+
+// SaveUploadedFile saves an uploaded file (e.g., from `FormFile`) to `dst`.
+// A file spilled to a temporary file (see `ServerConfig.MultipartMaxMemory`) is moved
+// rather than copied when possible.
+func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dst string) error {
+	return fasthttp.SaveMultipartFile(file, dst)
 }
 
 // Body returns the raw request body as a byte slice.
@@ -243,6 +764,25 @@ func (c *Context) Body() []byte {
 	return c.Ctx.PostBody() // `fasthttp` caches the PostBody.
 }
 
+// BodyReader returns a reader over the request body, allowing large uploads to be
+// processed without holding the whole body in memory.
+//
+// When `ServerConfig.StreamRequestBody` is enabled and the body has not been buffered
+// yet, the reader streams directly from the connection. Otherwise (streaming disabled,
+// or the body was already buffered by `c.Body()`, the binder, or a middleware such as
+// `BodyLimit`), it reads from the buffered body.
+//
+// The stream can only be consumed once. Bytes read through BodyReader are no longer
+// visible to `c.Body()` or the binder (`c.Bind`, etc.), which buffer whatever remains of
+// the stream. Use one or the other: call `c.Body()`/`c.Bind()` first if the handler
+// needs both, in which case BodyReader reads from the buffered copy.
+func (c *Context) BodyReader() io.Reader {
+	if c.Ctx.Request.IsBodyStream() {
+		return c.Ctx.RequestBodyStream()
+	}
+	return bytes.NewReader(c.Body())
+}
+
 // Cookie returns the value of a request cookie by its name.
 // Returns an empty string if the cookie is not found.
 func (c *Context) Cookie(name string) string {
@@ -250,8 +790,14 @@ func (c *Context) Cookie(name string) string {
 }
 
 // Cookies returns all request cookies as a map[string]string.
+// If the request carries several cookies with the same name (e.g., set for different
+// paths), the first one is kept, matching `c.Cookie`. To delete a cookie, see `c.ClearCookie`.
 func (c *Context) Cookies() map[string]string {
 	ck := make(map[string]string)
-	c.Ctx.Request.Header.VisitAllCookie(func(k, v []byte) { ck[string(k)] = string(v) })
+	c.Ctx.Request.Header.VisitAllCookie(func(k, v []byte) {
+		if _, exists := ck[string(k)]; !exists {
+			ck[string(k)] = string(v)
+		}
+	})
 	return ck
 }
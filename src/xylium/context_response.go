@@ -2,18 +2,29 @@
 package xylium
 
 import (
+	"bytes"         // For detecting multi-range requests and matching trailer names.
 	"encoding/json" // For c.JSON() marshalling.
 	"encoding/xml"  // For c.XML() marshalling.
+	"errors"        // For the trailer support and stream deadline errors.
 	"fmt"           // For c.String() formatting and error messages.
+	"io"            // For c.Stream() readers.
+	"mime"          // For c.AttachmentReader() content type detection.
+	"net"           // For the connection whose write deadline SetWriteDeadline adjusts.
+	"net/http"      // For formatting the Last-Modified date of c.File().
 	"net/url"       // For c.Attachment() filename escaping.
 	"os"            // For c.File() to stat files.
 	"path/filepath" // For c.File() path cleaning.
+	"sync"          // For the pool of JSON encoding buffers.
+	"time"          // For stream deadlines and the modification time in c.File().
 
 	"github.com/valyala/fasthttp" // For fasthttp.ServeFile and status codes.
 )
 
 // --- Response Writing ---
 // This section provides methods for constructing and sending HTTP responses.
+// Every method that modifies the response goes through `beginResponseWrite`, so writes
+// made by a handler after its response was taken over by a middleware (e.g., `Timeout`)
+// are discarded instead of racing with the middleware's own response.
 
 // SetDefaultContentType sets the Content-Type header to "text/plain; charset=utf-8"
 // if it has not already been set by another response method (e.g., c.JSON, c.SetContentType).
@@ -32,17 +43,53 @@ func (c *Context) SetDefaultContentType() {
 }
 
 // Status sets the HTTP response status code.
+// Like every response-writing method, it is dropped once the response has been taken
+// over by a middleware (e.g., after a `Timeout` fired). Methods that send a complete
+// response (`c.JSON`, `c.String`, etc.) set the status and body in one step, so a late
+// write never changes the status without its body.
 // Returns the Context pointer for method chaining.
 // Example: `c.Status(http.StatusNotFound).JSON(...)`
 func (c *Context) Status(code int) *Context {
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	c.Ctx.SetStatusCode(code)
 	return c
 }
 
+// writeResponse sets the status code and, if `contentType` is not empty, the Content-Type
+// of the response, and then writes its body with `write`, all while holding the response
+// guard once. A write racing with a `Timeout` thus changes the status, headers and body
+// together or not at all. `write` must not call guarded Context methods.
+func (c *Context) writeResponse(code int, contentType string, write func() error) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
+	c.Ctx.SetStatusCode(code)
+	if contentType != "" {
+		c.Ctx.Response.Header.SetContentType(contentType)
+	}
+	return write()
+}
+
+// writeBytes returns a `writeResponse` body writer that appends `p` to the response body.
+func (c *Context) writeBytes(p []byte) func() error {
+	return func() error {
+		_, err := c.Ctx.Write(p)
+		return err
+	}
+}
+
 // SetHeader sets a response header with the given key and value.
 // If the header key already exists, its value is replaced.
 // Returns the Context pointer for method chaining.
 func (c *Context) SetHeader(key, value string) *Context {
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	c.Ctx.Response.Header.Set(key, value)
 	return c
 }
@@ -51,6 +98,10 @@ func (c *Context) SetHeader(key, value string) *Context {
 // Returns the Context pointer for method chaining.
 // Example: `c.SetContentType("application/octet-stream")`
 func (c *Context) SetContentType(contentType string) *Context {
+	if !c.beginResponseWrite() {
+		return c
+	}
+	defer c.endResponseWrite()
 	c.Ctx.Response.Header.SetContentType(contentType)
 	return c
 }
@@ -59,6 +110,10 @@ func (c *Context) SetContentType(contentType string) *Context {
 // It automatically calls `SetDefaultContentType` if no Content-Type has been set yet.
 // Returns an error if the write operation fails.
 func (c *Context) Write(p []byte) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
 	c.SetDefaultContentType() // Ensure a default Content-Type if none is set.
 	_, err := c.Ctx.Write(p)
 	return err
@@ -68,6 +123,10 @@ func (c *Context) Write(p []byte) error {
 // It automatically calls `SetDefaultContentType` if no Content-Type has been set yet.
 // Returns an error if the write operation fails.
 func (c *Context) WriteString(s string) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
 	c.SetDefaultContentType() // Ensure a default Content-Type if none is set.
 	_, err := c.Ctx.WriteString(s)
 	return err
@@ -76,20 +135,62 @@ func (c *Context) WriteString(s string) error {
 // JSON sends a JSON response with the given status code and data.
 // - Sets the Content-Type to "application/json; charset=utf-8".
 // - If `data` is `[]byte`, it's written directly to the response body.
-// - Otherwise, `data` is marshalled to JSON using `json.Marshal`.
+// - Otherwise, `data` is encoded to JSON like with `json.Marshal`, into a pooled buffer.
 // Returns an `*HTTPError` if marshalling fails, otherwise nil on success or write error.
+//
+// Encoded bodies of at least `ServerConfig.JSONStreamThreshold` bytes are streamed
+// instead of buffered (see that field).
 func (c *Context) JSON(code int, data interface{}) error {
-	c.Status(code).SetContentType("application/json; charset=utf-8")
 	if b, ok := data.([]byte); ok { // If data is already []byte, write directly.
-		return c.Write(b)
+		return c.writeResponse(code, "application/json; charset=utf-8", c.writeBytes(b))
 	}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
+	buf := jsonBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		putJSONBuffer(buf)
+		c.Status(code).SetContentType("application/json; charset=utf-8")
 		// Return an HTTPError that the GlobalErrorHandler can process.
 		// This ensures consistent error logging and response formatting.
 		return NewHTTPError(StatusInternalServerError, "JSON marshal error").WithInternal(err)
 	}
-	return c.Write(jsonData)
+	jsonData := bytes.TrimSuffix(buf.Bytes(), []byte("\n")) // Encode appends a newline; Marshal does not.
+	if c.streamsEncodedBody(len(jsonData)) {
+		// The stream is read after the handler returns, so it keeps the buffer.
+		return c.writeEncodedBody(code, "application/json; charset=utf-8", jsonData)
+	}
+	// Copies the data into the response body.
+	err := c.writeResponse(code, "application/json; charset=utf-8", c.writeBytes(jsonData))
+	putJSONBuffer(buf)
+	return err
+}
+
+// JSONBytes sends `raw`, which must already be valid JSON, as a JSON response with the
+// given status code, e.g., for fixed responses such as `{"status":"ok"}` on hot paths:
+//
+//	var statusOK = []byte(`{"status":"ok"}`)
+//
+//	app.GET("/health", func(c *xylium.Context) error { return c.JSONBytes(xylium.StatusOK, statusOK) })
+//
+// Unlike `c.JSON(code, raw)`, which gives the same response, it does not convert `raw`
+// to an `interface{}`, and thus does not allocate. `raw` is copied into the response, so
+// it may be reused after the call. `raw` is not validated.
+func (c *Context) JSONBytes(code int, raw []byte) error {
+	return c.writeResponse(code, "application/json; charset=utf-8", c.writeBytes(raw))
+}
+
+// jsonBufferPool holds the buffers `c.JSON` encodes into, to avoid allocating one for
+// every response.
+var jsonBufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledJSONBuffer is the capacity above which a buffer is not returned to
+// `jsonBufferPool`, so one large response does not keep its memory pooled.
+const maxPooledJSONBuffer = 64 * 1024
+
+// putJSONBuffer returns `buf` to `jsonBufferPool`, unless it has grown too large.
+func putJSONBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledJSONBuffer {
+		jsonBufferPool.Put(buf)
+	}
 }
 
 // XML sends an XML response with the given status code and data.
@@ -97,16 +198,94 @@ func (c *Context) JSON(code int, data interface{}) error {
 // - If `data` is `[]byte`, it's written directly to the response body.
 // - Otherwise, `data` is marshalled to XML using `xml.Marshal`.
 // Returns an `*HTTPError` if marshalling fails, otherwise nil on success or write error.
+//
+// Encoded bodies of at least `ServerConfig.JSONStreamThreshold` bytes are streamed
+// instead of buffered (see that field).
 func (c *Context) XML(code int, data interface{}) error {
-	c.Status(code).SetContentType("application/xml; charset=utf-8")
 	if b, ok := data.([]byte); ok { // If data is already []byte, write directly.
-		return c.Write(b)
+		return c.writeResponse(code, "application/xml; charset=utf-8", c.writeBytes(b))
 	}
 	xmlData, err := xml.Marshal(data)
 	if err != nil {
+		c.Status(code).SetContentType("application/xml; charset=utf-8")
 		return NewHTTPError(StatusInternalServerError, "XML marshal error").WithInternal(err)
 	}
-	return c.Write(xmlData)
+	return c.writeEncodedBody(code, "application/xml; charset=utf-8", xmlData)
+}
+
+// writeEncodedBody writes a body encoded by `c.JSON` or `c.XML`. Bodies of at least
+// `ServerConfig.JSONStreamThreshold` bytes are streamed through a `deadlineBodyReader`;
+// smaller ones (or all, if the threshold is not set) are written to the response buffer.
+// The data is fully encoded in memory before streaming, while the handler still owns it,
+// since the stream is read only after the handler has returned; only the write to the
+// client is streamed.
+func (c *Context) writeEncodedBody(code int, contentType string, body []byte) error {
+	if !c.streamsEncodedBody(len(body)) {
+		return c.writeResponse(code, contentType, c.writeBytes(body))
+	}
+	deadline, _ := c.Deadline()
+	return c.writeResponse(code, contentType, func() error {
+		c.setBodyStream(&deadlineBodyReader{data: body, deadline: deadline}, len(body))
+		return nil
+	})
+}
+
+// streamsEncodedBody reports whether `writeEncodedBody` streams a body of `size` bytes.
+func (c *Context) streamsEncodedBody(size int) bool {
+	return c.router != nil && c.router.serverConfig.JSONStreamThreshold > 0 &&
+		size >= c.router.serverConfig.JSONStreamThreshold
+}
+
+// errStreamDeadlineExceeded aborts a streamed body whose request deadline has passed.
+var errStreamDeadlineExceeded = errors.New("xylium: request deadline exceeded while streaming response body")
+
+// deadlineBodyReader streams an encoded body and fails once `deadline` (if set) has
+// passed, which makes fasthttp abort the response and close the connection.
+// It deliberately does not implement `io.WriterTo`, so every chunk goes through Read.
+type deadlineBodyReader struct {
+	data     []byte
+	deadline time.Time
+}
+
+// Read implements `io.Reader`.
+func (r *deadlineBodyReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	if !r.deadline.IsZero() && time.Now().After(r.deadline) {
+		return 0, errStreamDeadlineExceeded
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// abortBodyStream makes a response whose body is streamed fail instead of completing:
+// the stream is closed and replaced by one whose first read returns `err`, which makes
+// fasthttp abort the response and close the connection. It must be called before the
+// handler chain returns, as fasthttp reads the stream afterwards. It reports whether the
+// response had a body stream to abort.
+func (c *Context) abortBodyStream(err error) bool {
+	if !c.beginResponseWrite() {
+		return false
+	}
+	defer c.endResponseWrite()
+	if !c.Ctx.Response.IsBodyStream() {
+		return false
+	}
+	c.Ctx.Response.SetBodyStream(abortedBodyReader{err: err}, -1)
+	c.Ctx.SetConnectionClose()
+	return true
+}
+
+// abortedBodyReader is a streamed body that fails on the first read.
+type abortedBodyReader struct {
+	err error
+}
+
+// Read implements `io.Reader`.
+func (r abortedBodyReader) Read([]byte) (int, error) {
+	return 0, r.err
 }
 
 // String sends a plain text response with the given status code and formatted string.
@@ -114,11 +293,13 @@ func (c *Context) XML(code int, data interface{}) error {
 // - If `values` are provided, `s` is used as a format string for `fmt.Sprintf`.
 // Returns nil on success or an error if writing fails.
 func (c *Context) String(code int, s string, values ...interface{}) error {
-	c.Status(code).SetContentType("text/plain; charset=utf-8")
 	if len(values) > 0 {
-		return c.WriteString(fmt.Sprintf(s, values...))
+		s = fmt.Sprintf(s, values...)
 	}
-	return c.WriteString(s)
+	return c.writeResponse(code, "text/plain; charset=utf-8", func() error {
+		_, err := c.Ctx.WriteString(s)
+		return err
+	})
 }
 
 // HTML renders an HTML template using the configured `HTMLRenderer` on the Router
@@ -127,20 +308,58 @@ func (c *Context) String(code int, s string, values ...interface{}) error {
 // - `name` is the name of the template to render.
 // - `data` is the data to pass to the template.
 // Returns an `*HTTPError` if no `HTMLRenderer` is configured or if rendering fails.
+// It is equivalent to `c.Render(code, RendererHTML, name, data)`, so a renderer registered
+// as "html" with `Router.AddRenderer` takes precedence over `HTMLRenderer`.
 func (c *Context) HTML(code int, name string, data interface{}) error {
-	if c.router == nil || c.router.HTMLRenderer == nil {
-		return NewHTTPError(StatusInternalServerError, "HTML renderer not configured on router")
+	return c.Render(code, RendererHTML, name, data)
+}
+
+// Render renders the template `name` with `data` using the renderer registered under
+// `renderer` with `Router.AddRenderer`, and sends the output as a response with the given
+// status code. The "html" renderer (`RendererHTML`) falls back to `Router.HTMLRenderer`.
+// See `Renderer` for how the Content-Type is set.
+// Returns an `*HTTPError` if the renderer is not configured, or the renderer's error if
+// rendering fails.
+//
+// Example:
+//
+//	return c.Render(xylium.StatusOK, "pdf", "invoice", invoice)
+func (c *Context) Render(code int, renderer, name string, data interface{}) error {
+	var r Renderer
+	if c.router != nil {
+		r = c.router.renderer(renderer)
+	}
+	if r == nil {
+		if renderer == RendererHTML {
+			return NewHTTPError(StatusInternalServerError, "HTML renderer not configured on router")
+		}
+		return NewHTTPError(StatusInternalServerError, fmt.Sprintf("Renderer '%s' not configured on router", renderer))
 	}
-	c.Status(code).SetContentType("text/html; charset=utf-8")
-	// The HTMLRenderer's Render method writes directly to the response body writer.
-	return c.router.HTMLRenderer.Render(c.Ctx.Response.BodyWriter(), name, data, c)
+	contentType := ""
+	if typed, ok := r.(interface{ ContentType() string }); ok {
+		contentType = typed.ContentType()
+	} else if renderer == RendererHTML {
+		contentType = "text/html; charset=utf-8"
+	}
+	return c.writeResponse(code, contentType, func() error {
+		// The renderer writes directly to the response body writer.
+		return r.Render(c.Ctx.Response.BodyWriter(), name, data, c)
+	})
 }
 
 // File sends a local file as the response body.
 //   - `filepathToServe` is the path to the file on the server's filesystem.
 //   - It performs security checks: ensures the path is valid, the file exists, and is not a directory.
 //   - It uses `fasthttp.ServeFile` for efficient file serving, which also sets appropriate
-//     Content-Type based on file extension and handles `If-Modified-Since` requests.
+//     Content-Type based on file extension and the `Last-Modified` header.
+//   - Conditional GET: a GET or HEAD request whose `If-Modified-Since` is not older than
+//     the file's modification time (as read now, not from fasthttp's file cache) gets a
+//     `304 Not Modified` with `Last-Modified` and no body. As required by RFC 9110,
+//     `If-Modified-Since` is ignored when the request also carries `If-None-Match`.
+//   - Byte range requests are supported, as with `ServeFiles`: a `Range` header with a
+//     single range (e.g., "bytes=100-", "bytes=-500") gets a `206 Partial Content` response
+//     with `Content-Range`, and `Accept-Ranges: bytes` is always sent. Multi-range requests
+//     are served the whole file with `200 OK`, which HTTP allows.
 //
 // Returns an `*HTTPError` if the file is not found, is a directory, or if there's an access error.
 // Otherwise, returns nil as `fasthttp.ServeFile` handles the response.
@@ -165,10 +384,46 @@ func (c *Context) File(filepathToServe string) error {
 
 	// Penting: Jangan panggil SetDefaultContentType() di sini.
 	// Biarkan fasthttp.ServeFile yang menentukan Content-Type berdasarkan ekstensi file.
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
+	if lastModified := info.ModTime().UTC().Truncate(time.Second); lastModified.Unix() > 0 && notModifiedSince(c, lastModified) {
+		c.Ctx.NotModified()
+		c.Ctx.Response.Header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		return nil
+	}
+	defer ignoreMultiRangeRequest(c.Ctx)()
+	// The condition was evaluated above; hide it from fasthttp.ServeFile, which checks it
+	// against cached file metadata and regardless of the method and If-None-Match.
+	if ifModifiedSince := c.Ctx.Request.Header.Peek("If-Modified-Since"); len(ifModifiedSince) > 0 {
+		saved := append([]byte(nil), ifModifiedSince...)
+		c.Ctx.Request.Header.Del("If-Modified-Since")
+		defer c.Ctx.Request.Header.SetBytesV("If-Modified-Since", saved)
+	}
 	fasthttp.ServeFile(c.Ctx, absPath)
 	return nil
 }
 
+// notModifiedSince reports whether a GET or HEAD request's `If-Modified-Since` header
+// shows that the client's copy, last modified at `lastModified`, is still current. It
+// is false without the header, with an unparsable date, or when `If-None-Match` is
+// present, which takes precedence (RFC 9110, section 13.1.3).
+func notModifiedSince(c *Context, lastModified time.Time) bool {
+	if method := c.Method(); method != MethodGet && method != MethodHead {
+		return false
+	}
+	if len(c.Ctx.Request.Header.Peek("If-None-Match")) > 0 {
+		return false
+	}
+	ifModifiedSince := c.Ctx.Request.Header.Peek("If-Modified-Since")
+	if len(ifModifiedSince) == 0 {
+		return false
+	}
+	since, err := fasthttp.ParseHTTPDate(ifModifiedSince)
+	return err == nil && !lastModified.After(since)
+}
+
 // Attachment sends a local file as an attachment, prompting the user to download it
 // with the specified `downloadFilename`.
 // - It sets the "Content-Disposition" header to "attachment".
@@ -176,12 +431,289 @@ func (c *Context) File(filepathToServe string) error {
 // Returns an error if `c.File` returns an error.
 func (c *Context) Attachment(filepathToServe string, downloadFilename string) error {
 	// Set Content-Disposition dulu.
-	// url.PathEscape digunakan untuk memastikan nama file aman untuk header.
-	c.SetHeader("Content-Disposition", `attachment; filename="`+url.PathEscape(downloadFilename)+`"`)
+	c.SetHeader("Content-Disposition", attachmentDisposition(downloadFilename))
 	// Kemudian panggil c.File. c.File akan menangani Content-Type.
 	return c.File(filepathToServe)
 }
 
+// Stream sends the content of `reader` as the response body, without buffering it in
+// memory first, e.g., for data generated on the fly or read from object storage.
+//   - `size` is the number of bytes `reader` provides, sent as Content-Length. If `size`
+//     is negative, the length is unknown and the body is sent with chunked transfer encoding.
+//   - `contentType` sets the Content-Type; if empty, "application/octet-stream" is used.
+//
+// The status code set earlier (e.g., with `c.Status`) is kept; it defaults to 200.
+// The body is read after the handler returns, while the response is written to the
+// client. If `reader` implements `io.Closer`, it is closed once the response is sent.
+//
+// If `reader` is an `io.ReadSeeker` and `size` is known, byte range requests are
+// supported as in `c.File`: `Accept-Ranges: bytes` is sent, and a single-range `Range`
+// header on a 200 response is answered with `206 Partial Content` and `Content-Range`,
+// reading only the requested part. An unsatisfiable range returns a 416 `*HTTPError`.
+// Returns an `*HTTPError` if `reader` is nil.
+func (c *Context) Stream(reader io.Reader, size int64, contentType string) error {
+	if reader == nil {
+		return NewHTTPError(StatusInternalServerError, "No content to stream.").WithInternal(fmt.Errorf("c.Stream called with a nil reader"))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if !c.beginResponseWrite() {
+		_ = closeReader(reader) // The response was taken over; release the reader.
+		return nil
+	}
+	defer c.endResponseWrite()
+
+	body, bodySize, err := c.streamRange(reader, size)
+	if err != nil {
+		_ = closeReader(reader)
+		return err
+	}
+	c.Ctx.Response.Header.SetContentType(contentType)
+	c.setBodyStream(body, bodySize)
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for writing a streamed response body to the
+// client, in place of the one derived from `ServerConfig.WriteTimeout`. It is meant for
+// long-lived streams, such as server-sent events or long polling, which would otherwise
+// be cut off by a `WriteTimeout` that is sensible for regular responses. A zero `t`
+// removes the deadline (see `DisableWriteTimeout`).
+//
+// Call it before starting the stream with `c.Stream` or `c.SetBodyStreamWriter` (or
+// before `c.JSON`/`c.XML` with `ServerConfig.JSONStreamThreshold`); bodies set directly
+// on `c.Ctx` and buffered responses keep the regular `WriteTimeout`. fasthttp applies
+// `WriteTimeout` when it starts writing the response, after the handler has returned,
+// so the override takes effect when the first chunk of the stream is read. The setting
+// applies to the current request only and is dropped when the Context is released; once
+// the stream ends, a server without `WriteTimeout` gets its connection's deadline
+// cleared again.
+//
+// A long or missing write deadline lets a slow or stalled client hold the connection
+// (and the goroutine writing the stream) for as long as it likes. Bound streams in
+// other ways, e.g., by ending them when `c.GoContext()` is done or after a maximum
+// duration, and keep the server's `Concurrency` and `MaxConnsPerIP` limits in mind.
+//
+// Example (server-sent events):
+//
+//	c.DisableWriteTimeout()
+//	c.SetContentType("text/event-stream")
+//	c.SetBodyStreamWriter(func(w *bufio.Writer) {
+//		for event := range events {
+//			fmt.Fprintf(w, "data: %s\n\n", event)
+//			if w.Flush() != nil {
+//				return // Client went away.
+//			}
+//		}
+//	})
+func (c *Context) SetWriteDeadline(t time.Time) {
+	c.Ctx.SetUserValue(writeDeadlineUserValueKey, t)
+}
+
+// DisableWriteTimeout removes the write deadline for a streamed response body, so
+// `ServerConfig.WriteTimeout` does not cut off a long-lived stream. It is equivalent to
+// `c.SetWriteDeadline(time.Time{})`; see there for when it applies and for the risks.
+func (c *Context) DisableWriteTimeout() {
+	c.SetWriteDeadline(time.Time{})
+}
+
+// SetBodyStreamWriter sets a function that writes the response body while it is sent
+// to the client, after the handler has returned, e.g., for server-sent events. It
+// wraps `c.Ctx.SetBodyStreamWriter` and honors `SetWriteDeadline`. The body is sent
+// with chunked transfer encoding; call `w.Flush()` to push data to the client.
+// The status code and headers set earlier are kept.
+func (c *Context) SetBodyStreamWriter(sw fasthttp.StreamWriter) {
+	if !c.beginResponseWrite() {
+		return
+	}
+	defer c.endResponseWrite()
+	c.setBodyStream(fasthttp.NewStreamReader(sw), -1)
+}
+
+// writeDeadlineUserValueKey is the `fasthttp` user value key under which the deadline set
+// with `SetWriteDeadline` is kept, so derived Contexts (see WithGoContext) share it.
+const writeDeadlineUserValueKey = "xylium_write_deadline"
+
+// responseStreamFiltersUserValueKey is the `fasthttp` user value key under which the
+// filters added with `addResponseStreamFilter` are kept, so derived Contexts share them.
+const responseStreamFiltersUserValueKey = "xylium_response_stream_filters"
+
+// responseStreamFilter transforms a streamed response body of `size` bytes (-1 if
+// unknown) when it is set through the Context, and returns the new body and size. It
+// may also adjust response headers, e.g., to compress the stream (see `Gzip`).
+type responseStreamFilter func(c *Context, body io.Reader, size int) (io.Reader, int)
+
+// addResponseStreamFilter registers `filter` for streamed bodies set later in this
+// request (by `c.Stream`, `c.SetBodyStreamWriter` or streamed `c.JSON`/`c.XML`), for
+// middleware that cannot process a stream after the handler has returned. Filters run
+// innermost first, i.e., in reverse order of registration.
+func (c *Context) addResponseStreamFilter(filter responseStreamFilter) {
+	filters, _ := c.Ctx.UserValue(responseStreamFiltersUserValueKey).([]responseStreamFilter)
+	c.Ctx.SetUserValue(responseStreamFiltersUserValueKey, append(filters[:len(filters):len(filters)], filter))
+}
+
+// setBodyStream sets a streamed response body, after applying the filters registered
+// with `addResponseStreamFilter`. If a write deadline was set with
+// `SetWriteDeadline`, the body is wrapped so the deadline replaces fasthttp's
+// `WriteTimeout` once writing starts. Otherwise `body` is used as is, so fasthttp can
+// still use optimizations such as sendfile for files.
+func (c *Context) setBodyStream(body io.Reader, size int) {
+	filters, _ := c.Ctx.UserValue(responseStreamFiltersUserValueKey).([]responseStreamFilter)
+	for i := len(filters) - 1; i >= 0; i-- {
+		body, size = filters[i](c, body, size)
+	}
+	if deadline, ok := c.Ctx.UserValue(writeDeadlineUserValueKey).(time.Time); ok {
+		if conn := c.Ctx.Conn(); conn != nil {
+			body = &writeDeadlineReader{
+				body:       body,
+				conn:       conn,
+				deadline:   deadline,
+				clearAfter: c.router == nil || c.router.serverConfig.WriteTimeout <= 0,
+			}
+		}
+	}
+	c.Ctx.Response.SetBodyStream(body, size)
+}
+
+// writeDeadlineReader sets the connection's write deadline on the first read of a
+// streamed body, after fasthttp has applied its own `WriteTimeout`.
+type writeDeadlineReader struct {
+	body       io.Reader
+	conn       net.Conn
+	deadline   time.Time
+	clearAfter bool // Clear the deadline on Close, as fasthttp will not reset it.
+	applied    bool
+}
+
+// Read implements `io.Reader`.
+func (r *writeDeadlineReader) Read(p []byte) (int, error) {
+	if !r.applied {
+		r.applied = true
+		_ = r.conn.SetWriteDeadline(r.deadline)
+	}
+	return r.body.Read(p)
+}
+
+// Close implements `io.Closer`, closing the wrapped body if it is closable.
+func (r *writeDeadlineReader) Close() error {
+	if r.applied && r.clearAfter && !r.deadline.IsZero() {
+		_ = r.conn.SetWriteDeadline(time.Time{})
+	}
+	return closeReader(r.body)
+}
+
+// streamRange prepares the body of `c.Stream`. If `reader` is an `io.ReadSeeker` with a
+// known `size` and the request has a single byte range, it seeks to the start of the range,
+// sets the 206 status and `Content-Range`, and returns a reader limited to the range.
+// Otherwise it returns `reader` unchanged. The returned size is -1 for an unknown size.
+func (c *Context) streamRange(reader io.Reader, size int64) (io.Reader, int, error) {
+	if size < 0 {
+		return reader, -1, nil // fasthttp: -1 means chunked transfer encoding.
+	}
+	seeker, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return reader, int(size), nil
+	}
+	c.Ctx.Response.Header.Set("Accept-Ranges", "bytes")
+	byteRange := c.Ctx.Request.Header.Peek("Range")
+	if len(byteRange) == 0 || bytes.IndexByte(byteRange, ',') >= 0 || c.Ctx.Response.StatusCode() != StatusOK {
+		return reader, int(size), nil // No range, a multi-range request, or a non-200 response: send everything.
+	}
+
+	startPos, endPos, err := fasthttp.ParseByteRange(byteRange, int(size))
+	if err != nil {
+		c.Ctx.Response.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		return nil, 0, NewHTTPError(StatusRequestedRangeNotSatisfiable, "Requested range not satisfiable.").WithInternal(err)
+	}
+	// `size` counts from the reader's current offset, which may not be the beginning.
+	if _, err := seeker.Seek(int64(startPos), io.SeekCurrent); err != nil {
+		return nil, 0, NewHTTPError(StatusInternalServerError, "Failed to seek to the requested range.").WithInternal(err)
+	}
+	c.Ctx.SetStatusCode(StatusPartialContent)
+	c.Ctx.Response.Header.SetContentRange(startPos, endPos, int(size))
+	rangeSize := endPos - startPos + 1
+	return &rangeReader{Reader: io.LimitReader(seeker, int64(rangeSize)), source: reader}, rangeSize, nil
+}
+
+// rangeReader reads a byte range of `source` and closes `source` when it is closed,
+// so `c.Stream` still releases the original reader after a range response.
+type rangeReader struct {
+	io.Reader
+	source io.Reader
+}
+
+// Close closes the source reader if it implements `io.Closer`.
+func (r *rangeReader) Close() error {
+	return closeReader(r.source)
+}
+
+// closeReader closes `reader` if it implements `io.Closer`.
+func closeReader(reader io.Reader) error {
+	if closer, ok := reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ignoreMultiRangeRequest removes a multi-range `Range` header from the request, so
+// `fasthttp.ServeFile` (which supports a single range only) sends the whole file with
+// 200 instead of rejecting the request with 416. The returned function puts the header
+// back, so middleware running after the handler sees the request as it was received.
+func ignoreMultiRangeRequest(ctx *fasthttp.RequestCtx) (restore func()) {
+	rangeHeader := ctx.Request.Header.Peek("Range")
+	if bytes.IndexByte(rangeHeader, ',') < 0 {
+		return func() {}
+	}
+	saved := append([]byte(nil), rangeHeader...)
+	ctx.Request.Header.Del("Range")
+	return func() { ctx.Request.Header.SetBytesV("Range", saved) }
+}
+
+// AttachmentReader streams the content of `reader` as a download named `filename`,
+// without writing it to disk first (e.g., a PDF generated in memory).
+// It sets "Content-Disposition: attachment" with `filename` and detects the Content-Type
+// from the file extension (falling back to "application/octet-stream").
+// If `reader` is an `io.ReadSeeker` (e.g., an `*os.File` or `*bytes.Reader`), its size is
+// found by seeking, so the body is sent with a Content-Length and byte range requests are
+// supported as in `c.Stream`. Otherwise the body is sent with chunked transfer encoding.
+// Returns an `*HTTPError` if `reader` is nil.
+func (c *Context) AttachmentReader(reader io.Reader, filename string) error {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	c.SetHeader("Content-Disposition", attachmentDisposition(filename))
+	size := int64(-1)
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		var err error
+		if size, err = remainingSize(seeker); err != nil {
+			_ = closeReader(reader)
+			return NewHTTPError(StatusInternalServerError, "Failed to determine the size of the attachment.").WithInternal(err)
+		}
+	}
+	return c.Stream(reader, size, contentType)
+}
+
+// remainingSize returns the number of bytes from the current offset of `seeker` to its end,
+// leaving the offset unchanged.
+func remainingSize(seeker io.Seeker) (int64, error) {
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end - current, nil
+}
+
+// attachmentDisposition returns a Content-Disposition header value for downloading a file
+// named `filename`. url.PathEscape keeps the name safe for use in a header.
+func attachmentDisposition(filename string) string {
+	return `attachment; filename="` + url.PathEscape(filename) + `"`
+}
+
 // Redirect sends an HTTP redirect response (3xx) to a new `location` with the given `code`.
 //   - `location`: The URL to redirect to.
 //   - `code`: The HTTP redirect status code (e.g., `StatusFound` (302), `StatusMovedPermanently` (301)).
@@ -189,6 +721,10 @@ func (c *Context) Attachment(filepathToServe string, downloadFilename string) er
 //
 // Returns nil as `fasthttp.RequestCtx.Redirect` handles sending the response.
 func (c *Context) Redirect(location string, code int) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
 	// Validasi kode redirect. Jika tidak valid, default ke StatusFound (302).
 	if code < StatusMultipleChoices || code > StatusPermanentRedirect || code == StatusNotModified {
 		code = StatusFound // fasthttp.StatusFound
@@ -204,6 +740,10 @@ func (c *Context) Redirect(location string, code int) error {
 // `message` is the error message string. `code` is the HTTP status code.
 // Returns nil as `fasthttp.RequestCtx.Error` handles sending the response.
 func (c *Context) Error(message string, code int) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
 	c.Ctx.Error(message, code)
 	return nil
 }
@@ -213,6 +753,10 @@ func (c *Context) Error(message string, code int) error {
 // `code` should typically be `StatusNoContent` (204) or similar.
 // Returns nil as the response is fully handled.
 func (c *Context) NoContent(code int) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
 	c.Ctx.SetStatusCode(code)  // Set status code dulu
 	c.Ctx.Response.ResetBody() // Pastikan body kosong
 
@@ -232,3 +776,84 @@ func (c *Context) NoContent(code int) error {
 	// Pemanggilan SetDefaultContentType() juga tidak diperlukan di sini karena tidak ada body.
 	return nil
 }
+
+// --- Trailers ---
+
+// ErrTrailersNotSupported is returned (wrapped) when HTTP trailers are set for a response
+// that cannot carry them. fasthttp only sends trailers after a chunked body, i.e. a
+// streamed response of unknown length (e.g., `c.Stream` with `size` -1) to an HTTP/1.1
+// client. Buffered responses (`c.JSON`, `c.String`, etc.) and streams with a known length
+// are sent with `Content-Length`, which leaves no place for trailers.
+var ErrTrailersNotSupported = errors.New("xylium: HTTP trailers require a streamed response of unknown length over HTTP/1.1")
+
+// SetTrailer declares `key` as an HTTP trailer (listed in the "Trailer" response header)
+// and sets its value. The value is sent after the response body instead of with the
+// headers, e.g., `grpc-status` for gRPC-Web proxies. Calling it again for the same key
+// replaces the value.
+//
+// Trailers must be set before the handler returns. A body set with `c.Stream` or
+// `c.SetBodyStreamWriter` is only read after the handler has returned and the Context
+// has been released, so `c` cannot be used from the stream reader or writer; a value
+// that depends on the body must be known by the time the handler returns.
+//
+// Trailers are only sent for chunked responses (see `ErrTrailersNotSupported`). SetTrailer
+// returns an error instead of silently dropping the trailer if:
+//   - `key` is forbidden as a trailer (e.g., "Content-Length", "Authorization"); the error
+//     wraps `fasthttp.ErrBadTrailer`.
+//   - The request is not HTTP/1.1, or a buffered response or a stream of known length has
+//     already been written; the error wraps `ErrTrailersNotSupported`.
+//
+// If trailers are declared before the body is written and the response then turns out to
+// be buffered, the trailers are dropped and the router reports `ErrTrailersNotSupported`
+// after the handler chain returns (logged if the response was already committed).
+func (c *Context) SetTrailer(key, value string) error {
+	if !c.Ctx.Request.Header.IsHTTP11() {
+		return fmt.Errorf("%w: request protocol is %s", ErrTrailersNotSupported, c.Ctx.Request.Header.Protocol())
+	}
+	resp := &c.Ctx.Response
+	if c.ResponseCommitted() && !(resp.IsBodyStream() && resp.Header.ContentLength() < 0) {
+		return fmt.Errorf("%w: trailer '%s' set after a buffered or fixed-length response was written", ErrTrailersNotSupported, key)
+	}
+
+	declared := false
+	for _, t := range resp.Header.PeekTrailerKeys() {
+		if bytes.EqualFold(t, []byte(key)) {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		if err := resp.Header.AddTrailer(key); err != nil {
+			return fmt.Errorf("xylium: cannot use '%s' as a trailer: %w", key, err)
+		}
+	}
+	resp.Header.Set(key, value)
+	return nil
+}
+
+// DeclaredTrailers returns the names of the trailers declared with `c.SetTrailer`,
+// in canonical header form, or nil if there are none.
+func (c *Context) DeclaredTrailers() []string {
+	keys := c.Ctx.Response.Header.PeekTrailerKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+	trailers := make([]string, len(keys))
+	for i, k := range keys {
+		trailers[i] = string(k)
+	}
+	return trailers
+}
+
+// trailerModeError returns an error wrapping `ErrTrailersNotSupported` if trailers were
+// declared but the response will not be sent chunked. The declarations are cleared, so no
+// "Trailer" header announces values that will never arrive.
+func (c *Context) trailerModeError() error {
+	resp := &c.Ctx.Response
+	if len(resp.Header.PeekTrailerKeys()) == 0 || (resp.IsBodyStream() && resp.Header.ContentLength() < 0) {
+		return nil
+	}
+	trailers := c.DeclaredTrailers()
+	_ = resp.Header.SetTrailer("") // Clears the declarations.
+	return fmt.Errorf("%w: trailers %v dropped because the response is not streamed with chunked encoding", ErrTrailersNotSupported, trailers)
+}
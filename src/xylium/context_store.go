@@ -1,10 +1,19 @@
 package xylium
 
-import "fmt" // For fmt.Sprintf in MustGet panic message.
+import (
+	"fmt"     // For fmt.Sprintf in MustGet panic message.
+	"reflect" // For naming the expected type in the generic MustGet panic message.
+	"sort"    // For returning the store keys in a stable order.
+)
 
 // --- Context State Management (Store) ---
 // The Context store provides a way to pass data between middleware and handlers
 // within the scope of a single HTTP request. It is protected by a RWMutex.
+//
+// Values live for the whole request: the store is emptied when the Context is released
+// back to the pool (after the hooks registered with `OnContextRelease` have run), so a
+// value never leaks into another request. Use `c.SetScoped` for a value that should only
+// be visible while a part of the handler chain runs.
 
 // Set stores a key-value pair in the context's private store.
 // This operation is thread-safe.
@@ -28,6 +37,57 @@ func (c *Context) Get(key string) (value interface{}, exists bool) {
 	return
 }
 
+// Delete removes a key and its value from the context's store, if present.
+// This operation is thread-safe.
+func (c *Context) Delete(key string) {
+	c.mu.Lock()
+	delete(c.store, key)
+	c.mu.Unlock()
+}
+
+// Keys returns the keys currently in the context's store, sorted, e.g., for debugging
+// which middleware set what. It returns an empty slice if the store is empty.
+// This operation is thread-safe.
+func (c *Context) Keys() []string {
+	c.mu.RLock()
+	keys := make([]string, 0, len(c.store))
+	for k := range c.store {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
+
+// SetScoped stores a key-value pair like `c.Set`, for the duration of a part of the
+// handler chain only. The returned function restores the previous state of `key`: the
+// value it had before, or no value if it was not set. Middleware typically defers it
+// around `next`, so the value is cleared as soon as the sub-chain returns, before the
+// middleware earlier in the chain and the router's error handling see the context:
+//
+//	return func(c *xylium.Context) error {
+//		defer c.SetScoped("tx", tx)()
+//		return next(c)
+//	}
+//
+// The restore function must be called at most once, by the code that called SetScoped.
+// This operation is thread-safe.
+func (c *Context) SetScoped(key string, value interface{}) (restore func()) {
+	c.mu.Lock()
+	previous, existed := c.store[key]
+	c.store[key] = value
+	c.mu.Unlock()
+	return func() {
+		c.mu.Lock()
+		if existed {
+			c.store[key] = previous
+		} else {
+			delete(c.store, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
 // MustGet retrieves a value from the context's store by its key.
 // It panics if the key does not exist in the store.
 // This operation is thread-safe as it uses c.Get().
@@ -77,3 +137,60 @@ func (c *Context) GetBool(key string) (b bool, exists bool) {
 	b, exists = val.(bool)
 	return
 }
+
+// Get retrieves a value from the store of `c` and asserts it as type `T`, for values
+// other than the string, int, and bool covered by `c.GetString`, `c.GetInt`, and `c.GetBool`.
+// Returns the value and true if the key exists and the value is a `T`.
+// Otherwise, it returns the zero value of `T` and false.
+// This operation is thread-safe.
+//
+// Example:
+//
+//	user, ok := xylium.Get[*User](c, "user")
+func Get[T any](c *Context, key string) (value T, exists bool) {
+	val, ok := c.Get(key)
+	if !ok {
+		return value, false
+	}
+	value, exists = val.(T)
+	return
+}
+
+// MustGet retrieves a value from the store of `c` and asserts it as type `T`.
+// It panics if the key does not exist or the value is not a `T`, so use it for values
+// that a middleware earlier in the chain is guaranteed to set.
+// This operation is thread-safe.
+//
+// Example:
+//
+//	user := xylium.MustGet[*User](c, "user") // Set by the authentication middleware.
+func MustGet[T any](c *Context, key string) T {
+	val := c.MustGet(key)
+	value, ok := val.(T)
+	if !ok {
+		panic(fmt.Sprintf("xylium: value for key '%s' in context store has type %T, not %s", key, val, reflect.TypeFor[T]()))
+	}
+	return value
+}
+
+// PanicInfo returns the value and stack trace of a panic recovered during the current
+// request, as stored under `ContextKeyPanicInfo` and `ContextKeyPanicStack` by the
+// router's recovery (before `Router.PanicHandler` runs) or by the `Recover` middleware.
+// `ok` is false if no panic has been recovered. `stack` may be nil if the panic value
+// was stored by other code without a stack trace.
+//
+// Example (a custom `PanicHandler` forwarding crashes to an error tracker):
+//
+//	app.PanicHandler = func(c *xylium.Context) error {
+//		rec, stack, _ := c.PanicInfo()
+//		tracker.Report(rec, stack)
+//		return xylium.NewHTTPError(xylium.StatusInternalServerError, "Internal server error.")
+//	}
+func (c *Context) PanicInfo() (recovered interface{}, stack []byte, ok bool) {
+	recovered, ok = c.Get(ContextKeyPanicInfo)
+	if !ok {
+		return nil, nil, false
+	}
+	stack, _ = Get[[]byte](c, ContextKeyPanicStack)
+	return recovered, stack, true
+}
@@ -3,6 +3,7 @@ package xylium
 import (
 	"bytes"         // For pooling and using bytes.Buffer for log entry formatting.
 	"encoding/json" // For marshalling log entries to JSON and structured fields.
+	"errors"        // For inspecting error chains in WithError.
 	"fmt"           // For formatting log messages and error strings.
 	"io"            // For io.Writer interface used for log output.
 	"os"            // For os.Stdout as default log output and os.Stderr for critical errors.
@@ -40,22 +41,66 @@ const DefaultTimestampFormat = "2006-01-02T15:04:05.000Z07:00"
 // These are used to enhance readability in terminal environments when `UseColor` is true
 // and the output is a TTY.
 const (
-	colorRed    = "\033[31m" // Typically used for ERROR, FATAL, PANIC levels.
-	colorGreen  = "\033[32m" // Typically used for INFO level.
-	colorYellow = "\033[33m" // Typically used for WARN level.
-	colorBlue   = "\033[34m" // Available, currently unused by default.
-	colorPurple = "\033[35m" // Typically used for structured fields in TextFormatter.
-	colorCyan   = "\033[36m" // Typically used for DEBUG level.
-	colorGray   = "\033[90m" // Typically used for caller information in TextFormatter.
-	colorReset  = "\033[0m"  // Resets any active color.
+	colorRed     = "\033[31m"   // Typically used for ERROR, FATAL, PANIC levels.
+	colorGreen   = "\033[32m"   // Typically used for INFO level.
+	colorYellow  = "\033[33m"   // Typically used for WARN level.
+	colorBlue    = "\033[34m"   // Available, currently unused by default.
+	colorPurple  = "\033[35m"   // Typically used for structured fields in TextFormatter.
+	colorCyan    = "\033[36m"   // Typically used for DEBUG level.
+	colorDimCyan = "\033[2;36m" // Typically used for TRACE level.
+	colorGray    = "\033[90m"   // Typically used for caller information in TextFormatter.
+	colorReset   = "\033[0m"    // Resets any active color.
 )
 
+// FieldKeys defines the JSON keys used by `JSONFormatter` for the standard parts of a
+// log entry. Empty fields fall back to the corresponding key in `DefaultFieldKeys`.
+// This allows matching conventions such as ECS ("@timestamp", "log.level") or
+// Google Cloud Logging ("severity").
+type FieldKeys struct {
+	Timestamp string
+	Level     string
+	Message   string
+	Fields    string
+	Caller    string
+}
+
+// DefaultFieldKeys are the JSON keys used by `JSONFormatter` by default. They match the
+// json tags of `LogEntry`.
+var DefaultFieldKeys = FieldKeys{
+	Timestamp: "timestamp",
+	Level:     "level",
+	Message:   "message",
+	Fields:    "fields",
+	Caller:    "caller",
+}
+
+// withDefaults returns a copy of `k` with empty keys replaced by `DefaultFieldKeys`.
+func (k FieldKeys) withDefaults() FieldKeys {
+	if k.Timestamp == "" {
+		k.Timestamp = DefaultFieldKeys.Timestamp
+	}
+	if k.Level == "" {
+		k.Level = DefaultFieldKeys.Level
+	}
+	if k.Message == "" {
+		k.Message = DefaultFieldKeys.Message
+	}
+	if k.Fields == "" {
+		k.Fields = DefaultFieldKeys.Fields
+	}
+	if k.Caller == "" {
+		k.Caller = DefaultFieldKeys.Caller
+	}
+	return k
+}
+
 // LogEntry is an internal struct used by `DefaultLogger` to aggregate all data
 // for a single log event before it is formatted and written to the output.
 // When `JSONFormatter` is used, an instance of `LogEntry` is marshalled to JSON.
+// It is also the value passed to `Hook.Fire`.
 type LogEntry struct {
 	// Timestamp is the time the log entry was created, formatted as a string
-	// according to `DefaultTimestampFormat`.
+	// according to `LoggerConfig.TimestampFormat` (default: `DefaultTimestampFormat`).
 	Timestamp string `json:"timestamp"`
 	// Level is the string representation of the log level (e.g., "INFO", "DEBUG", "ERROR").
 	Level string `json:"level"`
@@ -64,6 +109,8 @@ type LogEntry struct {
 	// Fields contains optional structured key-value pairs associated with this log entry.
 	// These are added via `logger.WithFields()` or by passing a `xylium.M` map
 	// as an argument to logging methods. It is omitted from JSON output if empty.
+	// Both formatters serialize it with `encoding/json`, which writes map keys in sorted
+	// order (recursively for nested maps), so field order in the output is deterministic.
 	Fields M `json:"fields,omitempty"`
 	// Caller contains information about the source code location (file and line number)
 	// where the log call was made. It is included if `LoggerConfig.ShowCaller` is true.
@@ -92,9 +139,61 @@ type LoggerConfig struct {
 	// `UseColor` is true. It has no effect with `JSONFormatter`.
 	UseColor bool
 	// Output is the `io.Writer` to which log entries will be written.
-	// Common values are `os.Stdout`, `os.Stderr`, or a file opened for writing.
+	// Common values are `os.Stdout`, `os.Stderr`, a file opened for writing, or a
+	// `RotatingFileWriter` for size/date-based log rotation.
 	// If nil, `DefaultLogger` will default to `os.Stdout`.
 	Output io.Writer
+	// Sampling, if non-nil, enables log sampling for high-volume levels (Trace, Debug, Info, Warn):
+	// per level and per second, the first `Initial` entries are logged, then 1 in `Thereafter`.
+	// Error, Fatal, and Panic entries are never dropped. See `SamplingConfig`.
+	// If nil (default), all entries are logged. Can be changed at runtime via `DefaultLogger.SetSampling`.
+	Sampling *SamplingConfig
+	// Async, if true, makes logging calls enqueue formatted entries to a buffered channel
+	// that is drained by a background goroutine, instead of writing to `Output` synchronously.
+	// This keeps slow outputs (files, network sinks) off the request path. Call
+	// `DefaultLogger.Close` (or `Flush`) on shutdown so queued entries are written; a router
+	// that creates its own `DefaultLogger` registers it as a closer automatically.
+	Async bool
+	// BufferSize is the number of entries that can be queued in async mode.
+	// Default: `DefaultAsyncBufferSize` (1024). Ignored if `Async` is false.
+	BufferSize int
+	// OverflowPolicy determines what happens in async mode when the buffer is full:
+	// `AsyncOverflowBlock` (default) waits for room, `AsyncOverflowDrop` discards the entry.
+	// Error, Fatal, and Panic entries are never dropped. Ignored if `Async` is false.
+	OverflowPolicy AsyncOverflowPolicy
+	// TimestampFormat is the `time` layout used for entry timestamps, for both formatters
+	// (e.g., `time.RFC3339Nano`). Default: `DefaultTimestampFormat`.
+	TimestampFormat string
+	// FieldKeys remaps the JSON keys used by `JSONFormatter` (e.g., `{Timestamp: "@timestamp",
+	// Message: "msg"}`). Empty keys use `DefaultFieldKeys`. Has no effect on `TextFormatter`.
+	FieldKeys FieldKeys
+	// Sinks, if non-empty, makes the logger write every entry to several outputs (tee),
+	// each with its own formatter, minimum level, and color setting (see `SinkConfig`).
+	// `Output`, `Formatter`, and `UseColor` are then ignored. `Level` still applies to the
+	// logger as a whole: a sink never receives entries below it.
+	// Example: human-readable text to `os.Stdout` plus JSON errors to a file.
+	Sinks []SinkConfig
+}
+
+// SinkConfig configures one output of a multi-sink `DefaultLogger` (see `LoggerConfig.Sinks`).
+type SinkConfig struct {
+	// Output is the `io.Writer` this sink writes to. If nil, `os.Stdout` is used.
+	Output io.Writer
+	// Formatter is the output format for this sink. Default: `TextFormatter`.
+	Formatter FormatterType
+	// Level is the minimum `LogLevel` written to this sink. Default: `LevelDebug` (the zero
+	// value), i.e., everything the logger's own `Level` allows, except Trace.
+	Level LogLevel
+	// UseColor enables ANSI colors for a `TextFormatter` sink whose `Output` is a TTY.
+	UseColor bool
+}
+
+// logSink is the resolved form of a `SinkConfig`.
+type logSink struct {
+	out       io.Writer
+	formatter FormatterType
+	level     LogLevel
+	useColor  bool
 }
 
 // DefaultLoggerConfig returns a new `LoggerConfig` instance initialized with
@@ -123,23 +222,33 @@ func DefaultLoggerConfig() LoggerConfig {
 
 // DefaultLogger is Xylium's standard, built-in implementation of the `xylium.Logger` interface.
 // It provides flexible and efficient logging capabilities, including:
-//   - Leveled logging (Debug, Info, Warn, Error, Fatal, Panic).
+//   - Leveled logging (Trace, Debug, Info, Warn, Error, Fatal, Panic).
 //   - Structured logging with key-value fields (`WithFields` or passing `xylium.M`).
 //   - Support for both human-readable text (`TextFormatter`) and structured JSON (`JSONFormatter`) output.
 //   - Optional inclusion of caller information (file and line number).
 //   - Optional colored output for `TextFormatter` when writing to a terminal (TTY).
+//   - Optional per-level log sampling for high-volume levels (see `SamplingConfig`).
+//   - Optional asynchronous writing through a buffered queue (see `LoggerConfig.Async`).
+//   - Optional writing to several outputs, each with its own format and level (see `LoggerConfig.Sinks`).
+//   - Pluggable hooks that receive entries of selected levels (see `Hook` and `AddHook`).
 //   - Thread-safe operations for concurrent logging from multiple goroutines.
 //   - Use of a `sync.Pool` for internal `bytes.Buffer` instances to reduce memory allocations
 //     during log entry formatting.
 type DefaultLogger struct {
-	mu         sync.RWMutex  // Protects concurrent access to logger configuration fields (out, level, etc.).
-	out        io.Writer     // The output writer where log entries are sent.
-	level      LogLevel      // The current minimum log level for this logger instance.
-	formatter  FormatterType // The current log output formatter (TextFormatter or JSONFormatter).
-	baseFields M             // A map of fields to include in every log entry generated by this logger instance.
-	showCaller bool          // Flag indicating whether to include caller information.
-	useColor   bool          // Flag indicating whether to use colored output (for TextFormatter on TTY).
-	bufferPool *sync.Pool    // Pool of `*bytes.Buffer` used for formatting log entries to reduce allocations.
+	mu         sync.RWMutex    // Protects concurrent access to logger configuration fields (out, level, etc.).
+	out        io.Writer       // The output writer where log entries are sent.
+	level      LogLevel        // The current minimum log level for this logger instance.
+	formatter  FormatterType   // The current log output formatter (TextFormatter or JSONFormatter).
+	baseFields M               // A map of fields to include in every log entry generated by this logger instance.
+	showCaller bool            // Flag indicating whether to include caller information.
+	useColor   bool            // Flag indicating whether to use colored output (for TextFormatter on TTY).
+	bufferPool *sync.Pool      // Pool of `*bytes.Buffer` used for formatting log entries to reduce allocations.
+	sampler    *logSampler     // Log sampler, shared with loggers derived via `WithFields`.
+	async      *asyncLogWriter // Background writer in async mode (nil if synchronous); shared with derived loggers.
+	hooks      *logHooks       // Hooks fired for each entry; shared with derived loggers.
+	timeFormat string          // Layout for entry timestamps.
+	fieldKeys  FieldKeys       // JSON keys for the standard entry parts (defaults applied).
+	sinks      []logSink       // Outputs of a multi-sink logger, used instead of out/formatter/useColor; nil if single-output.
 }
 
 // NewDefaultLoggerWithConfig creates a new `DefaultLogger` instance configured with the
@@ -148,6 +257,7 @@ type DefaultLogger struct {
 // If `config.Output` is nil, the logger will default to writing to `os.Stdout`.
 // Color usage (`config.UseColor`) is only effectively enabled if `config.UseColor` is true
 // AND the `config.Output` writer is determined to be a TTY (terminal).
+// If `config.Sinks` is non-empty, the logger writes to those sinks instead of `config.Output`.
 func NewDefaultLoggerWithConfig(config LoggerConfig) *DefaultLogger {
 	if config.Output == nil {
 		config.Output = os.Stdout // Default to standard output if no writer is provided.
@@ -160,6 +270,27 @@ func NewDefaultLoggerWithConfig(config LoggerConfig) *DefaultLogger {
 		showCaller: config.ShowCaller,
 		useColor:   false, // Initial state; EnableColor will set based on TTY and config.UseColor.
 		bufferPool: &sync.Pool{New: func() interface{} { return new(bytes.Buffer) }},
+		sampler:    newLogSampler(config.Sampling),
+		hooks:      newLogHooks(),
+		timeFormat: config.TimestampFormat,
+		fieldKeys:  config.FieldKeys.withDefaults(),
+	}
+	if dl.timeFormat == "" {
+		dl.timeFormat = DefaultTimestampFormat
+	}
+	if config.Async {
+		dl.async = newAsyncLogWriter(config.BufferSize, config.OverflowPolicy)
+	}
+	for _, sc := range config.Sinks {
+		sink := logSink{out: sc.Output, formatter: sc.Formatter, level: sc.Level}
+		if sink.out == nil {
+			sink.out = os.Stdout
+		}
+		if sink.formatter != JSONFormatter {
+			sink.formatter = TextFormatter
+		}
+		sink.useColor = sc.UseColor && isTerminal(sink.out)
+		dl.sinks = append(dl.sinks, sink)
 	}
 	// Attempt to enable color based on config.UseColor and TTY detection.
 	// The EnableColor method handles the TTY check internally.
@@ -178,10 +309,12 @@ func NewDefaultLogger() *DefaultLogger {
 // SetOutput sets the output destination `io.Writer` for the logger.
 // All subsequent log entries will be written to this writer.
 // If `w` is nil, `os.Stdout` is used as the default output.
+// On a multi-sink logger (see `LoggerConfig.Sinks`), this replaces all sinks with `w`.
 // This method is thread-safe.
 func (l *DefaultLogger) SetOutput(w io.Writer) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.sinks = nil
 	if w == nil {
 		l.out = os.Stdout
 		return
@@ -217,6 +350,7 @@ func (l *DefaultLogger) GetLevel() LogLevel {
 // SetFormatter sets the output format for log entries generated by this logger.
 // Valid options are `xylium.TextFormatter` or `xylium.JSONFormatter`.
 // If an invalid `formatter` type is provided, it defaults to `TextFormatter`.
+// It has no effect on the sinks of a multi-sink logger (see `LoggerConfig.Sinks`).
 // This method is thread-safe.
 func (l *DefaultLogger) SetFormatter(formatter FormatterType) {
 	l.mu.Lock()
@@ -244,7 +378,8 @@ func (l *DefaultLogger) EnableCaller(enable bool) {
 // EnableColor enables or disables colored output for the `TextFormatter`.
 // If `enable` is true, color will be used if the logger's output `io.Writer`
 // is a TTY (terminal). If `enable` is false, or if the output is not a TTY,
-// color will be disabled. This setting has no effect on `JSONFormatter`.
+// color will be disabled. This setting has no effect on `JSONFormatter`, nor on the
+// sinks of a multi-sink logger (see `LoggerConfig.Sinks`).
 // This method is thread-safe.
 func (l *DefaultLogger) EnableColor(enable bool) {
 	l.mu.Lock()
@@ -257,16 +392,77 @@ func (l *DefaultLogger) EnableColor(enable bool) {
 	}
 }
 
+// SetSampling enables, reconfigures, or (with a nil `config`) disables log sampling
+// at runtime. The setting applies to this logger and to all loggers derived from it
+// via `WithFields`, since they share the same sampler.
+// Error, Fatal, and Panic entries are never dropped by sampling.
+// This method is thread-safe.
+func (l *DefaultLogger) SetSampling(config *SamplingConfig) {
+	l.sampler.setConfig(config)
+}
+
+// AddHook registers a `Hook` that receives every entry whose level is included in
+// `hook.Levels()`. Hooks are shared with loggers derived via `WithFields`, including
+// those created before the hook was added. A nil hook is ignored.
+// This method is thread-safe.
+//
+// Example:
+//
+//	logger.AddHook(mySentryHook) // e.g., Levels() returns LevelError, LevelFatal, LevelPanic.
+func (l *DefaultLogger) AddHook(hook Hook) {
+	if hook == nil || l.hooks == nil {
+		return
+	}
+	l.hooks.add(hook)
+}
+
+// Flush blocks until all log entries queued so far by this logger (and loggers derived
+// from it via `WithFields`) have been written to their output.
+// It is a no-op for a synchronous logger. Flush always returns nil; the error return
+// allows it to satisfy common flusher interfaces.
+func (l *DefaultLogger) Flush() error {
+	if l.async != nil {
+		l.async.flush()
+	}
+	return nil
+}
+
+// Close flushes all queued log entries and stops the background writer of an async logger.
+// After Close, logging calls on this logger (and its derived loggers) fall back to
+// synchronous writes, so late log entries during shutdown are not lost.
+// Close does not close the underlying `Output` writer. It is safe to call multiple times
+// and is a no-op for a synchronous logger. It implements `io.Closer`, so the logger can be
+// registered with `Router.RegisterCloser`.
+func (l *DefaultLogger) Close() error {
+	if l.async != nil {
+		l.async.close()
+	}
+	return nil
+}
+
 // isLevelEnabledRLocked is an internal helper that checks if a given `LogLevel`
 // is currently enabled for output by this logger instance.
 // It assumes the caller already holds at least a read lock (`l.mu.RLock()`) on the logger.
+// On a multi-sink logger, at least one sink must also accept `level`.
 func (l *DefaultLogger) isLevelEnabledRLocked(level LogLevel) bool {
-	return level >= l.level
+	if level < l.level {
+		return false
+	}
+	if len(l.sinks) == 0 {
+		return true
+	}
+	for _, sink := range l.sinks {
+		if level >= sink.level {
+			return true
+		}
+	}
+	return false
 }
 
 // doLog is the core internal method responsible for processing and formatting log entries.
 // It performs the following steps:
-//  1. Checks if the given `level` is enabled based on the logger's current minimum level.
+//  1. Checks if the given `level` is enabled based on the logger's current minimum level,
+//     and whether the entry survives log sampling (if configured).
 //  2. Acquires a `bytes.Buffer` from a `sync.Pool` for efficient formatting.
 //  3. Constructs a `LogEntry` struct with timestamp, level, and the initial message.
 //  4. Merges any `baseFields` (from `WithFields`) into the `LogEntry.Fields`.
@@ -274,10 +470,11 @@ func (l *DefaultLogger) isLevelEnabledRLocked(level LogLevel) bool {
 //     - If an argument is of type `xylium.M`, its key-value pairs are merged into `LogEntry.Fields`.
 //     - Other arguments are treated as formatting arguments for the `message` string (if it contains format specifiers).
 //  6. If `showCaller` is enabled, retrieves and formats caller information (file:line) and adds it to `LogEntry.Caller`.
-//  7. Formats the complete `LogEntry` into the `bytes.Buffer` according to the configured `formatter` (`TextFormatter` or `JSONFormatter`).
-//     - `TextFormatter` applies colors if `useColor` is true and output is a TTY.
-//     - `JSONFormatter` marshals the `LogEntry` to a JSON string.
-//  8. Writes the formatted log entry from the buffer to the logger's `out` (output writer).
+//  7. Fires any hooks registered for `level` (see `AddHook`).
+//  8. For each sink accepting `level` (the logger's own output if it has no `sinks`), formats
+//     the complete `LogEntry` into the `bytes.Buffer` according to the sink's formatter
+//     (see `formatLogEntry`), then writes it to the sink's output, or, in async mode,
+//     enqueues a copy of it for the background writer.
 //  9. Handles `LevelFatal` (calls `os.Exit(1)`) and `LevelPanic` (calls `panic()`) after logging.
 //
 // 10. Returns the buffer to the pool.
@@ -297,12 +494,19 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 		l.mu.RUnlock() // Release lock if level is suppressed.
 		return
 	}
+	if !l.sampler.allow(level) {
+		l.mu.RUnlock() // Release lock if the entry is dropped by sampling.
+		return
+	}
 	// Copy current configuration values while under RLock to avoid holding the lock
 	// during potentially blocking I/O operations or complex formatting.
-	currentOut := l.out
-	currentFormatter := l.formatter
+	currentSinks := l.sinks // Never modified in place; SetOutput replaces it.
+	if len(currentSinks) == 0 {
+		currentSinks = []logSink{{out: l.out, formatter: l.formatter, level: l.level, useColor: l.useColor}}
+	}
 	currentShowCaller := l.showCaller
-	currentUseColor := l.useColor
+	currentTimeFormat := l.timeFormat
+	currentFieldKeys := l.fieldKeys
 	// Deep copy baseFields to prevent race conditions if WithFields is called concurrently
 	// while this log operation is in progress.
 	copiedBaseFields := make(M, len(l.baseFields))
@@ -313,7 +517,7 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 
 	// Prepare the LogEntry struct that will hold all data for this log event.
 	entry := LogEntry{
-		Timestamp: time.Now().Format(DefaultTimestampFormat),
+		Timestamp: time.Now().Format(currentTimeFormat),
 		Level:     level.String(),
 		Message:   message, // Initial message; may be formatted later if args are for formatting.
 		Fields:    make(M), // Initialize Fields map for this specific entry.
@@ -383,18 +587,75 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 
 	// Acquire a buffer from the pool for formatting the log entry.
 	buffer := l.bufferPool.Get().(*bytes.Buffer)
-	buffer.Reset()                 // Ensure the buffer is empty for reuse.
 	defer l.bufferPool.Put(buffer) // Return the buffer to the pool when `doLog` exits.
 
-	// Format the `LogEntry` into the `buffer` based on the `currentFormatter`.
-	switch currentFormatter {
+	// Dispatch the entry to hooks registered for this level. Errors are reported to
+	// os.Stderr by the hooks themselves and never prevent the entry from being written.
+	l.hooks.fire(level, entry)
+
+	var writeError error // To store error from writing, for Fatal/Panic.
+	for _, sink := range currentSinks {
+		if level < sink.level {
+			continue // Below this sink's own minimum level.
+		}
+		buffer.Reset() // Ensure the buffer is empty for reuse.
+		formatLogEntry(buffer, entry, level, sink.formatter, sink.useColor, currentTimeFormat, currentFieldKeys)
+
+		// In async mode, hand a copy of the formatted entry to the background writer.
+		if l.async != nil {
+			rec := asyncLogRecord{
+				out:     sink.out,
+				data:    append([]byte(nil), buffer.Bytes()...), // Copy: the buffer is reused and returned to the pool.
+				message: entry.Message,
+			}
+			if l.async.enqueue(rec, level >= LevelError) {
+				continue
+			}
+			// The async writer was closed; fall through to a synchronous write.
+		}
+
+		// Write the formatted log entry from the buffer to the sink's output writer.
+		// This I/O operation is protected by a lock on the logger instance (`l.mu`)
+		// to ensure thread-safety if multiple goroutines log to the same `DefaultLogger`
+		// instance that shares an output writer (e.g., os.Stdout).
+		l.mu.Lock() // Acquire lock for writing to `sink.out`.
+		if _, err := sink.out.Write(buffer.Bytes()); err != nil {
+			// If writing to the output fails (e.g., disk full, broken pipe),
+			// attempt to write an error message to `os.Stderr` for visibility.
+			fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-ERROR] Failed to write log entry to output: %v. Original message: %s\n", err, entry.Message)
+			writeError = err // Store the error for potential use by Fatal/Panic.
+		}
+		l.mu.Unlock() // Release lock.
+	}
+
+	// Handle `LevelFatal` and `LevelPanic` after attempting to log the message.
+	// Queued entries are flushed first so they are not lost.
+	if level == LevelFatal || level == LevelPanic {
+		if l.async != nil {
+			l.async.flush()
+		}
+		if writeError != nil {
+			// If logging the message failed, ensure it is printed to os.Stderr.
+			fmt.Fprintf(os.Stderr, "%s: %s\n", entry.Level, entry.Message)
+		}
+		if level == LevelFatal {
+			os.Exit(1) // Terminate the application.
+		}
+		panic(entry.Message) // Trigger a panic.
+	}
+}
+
+// formatLogEntry writes `entry` to `buffer` in the given `formatter` format, newline-terminated.
+// `useColor` applies ANSI colors to `TextFormatter` output.
+func formatLogEntry(buffer *bytes.Buffer, entry LogEntry, level LogLevel, formatter FormatterType, useColor bool, timeFormat string, fieldKeys FieldKeys) {
+	switch formatter {
 	case JSONFormatter:
-		// Marshal the entire LogEntry struct to JSON.
-		jsonData, err := json.Marshal(entry)
+		// Marshal the entire LogEntry to JSON, using the configured field keys.
+		jsonData, err := marshalLogEntryJSON(entry, fieldKeys)
 		if err != nil {
 			// Critical: Failed to marshal the log entry itself to JSON.
 			// Log a fallback error message (also in JSON if possible, or plain text).
-			timestampFallback := time.Now().Format(DefaultTimestampFormat)
+			timestampFallback := time.Now().Format(timeFormat)
 			fallbackEntry := struct { // Anonymous struct for fallback JSON.
 				Timestamp       string `json:"timestamp"`
 				Level           string `json:"level"`
@@ -432,8 +693,10 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 		buffer.WriteString(" ") // Separator.
 
 		levelStr := entry.Level
-		if currentUseColor { // Apply ANSI color to the level string if enabled.
+		if useColor { // Apply ANSI color to the level string if enabled.
 			switch level {
+			case LevelTrace:
+				levelStr = colorDimCyan + levelStr + colorReset
 			case LevelDebug:
 				levelStr = colorCyan + levelStr + colorReset
 			case LevelInfo:
@@ -449,7 +712,7 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 
 		if entry.Caller != "" { // Add caller information if present.
 			callerStr := entry.Caller
-			if currentUseColor {
+			if useColor {
 				callerStr = colorGray + callerStr + colorReset // Color for caller info.
 			}
 			buffer.WriteString(fmt.Sprintf("<%s>", callerStr)) // Enclose caller in angle brackets.
@@ -467,7 +730,7 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 				buffer.WriteString(fmt.Sprintf("(error marshalling fields: %v)", err))
 			} else {
 				fieldStr := string(fieldBytes)
-				if currentUseColor {
+				if useColor {
 					fieldStr = colorPurple + fieldStr + colorReset // Color for fields JSON.
 				}
 				buffer.WriteString(fieldStr)
@@ -475,35 +738,6 @@ func (l *DefaultLogger) doLog(level LogLevel, skipFrames int, message string, ar
 		}
 		buffer.WriteString("\n") // Ensure log entry is newline-terminated.
 	}
-
-	// Write the formatted log entry from the buffer to the configured output writer.
-	// This I/O operation is protected by a lock on the logger instance (`l.mu`)
-	// to ensure thread-safety if multiple goroutines log to the same `DefaultLogger`
-	// instance that shares an output writer (e.g., os.Stdout).
-	l.mu.Lock()          // Acquire lock for writing to `currentOut`.
-	var writeError error // To store error from writing, for Fatal/Panic.
-	if _, err := currentOut.Write(buffer.Bytes()); err != nil {
-		// If writing to the primary output fails (e.g., disk full, broken pipe),
-		// attempt to write an error message to `os.Stderr` for visibility.
-		fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-ERROR] Failed to write log entry to primary output: %v. Original message: %s\n", err, entry.Message)
-		writeError = err // Store the error for potential use by Fatal/Panic.
-	}
-	l.mu.Unlock() // Release lock.
-
-	// Handle `LevelFatal` and `LevelPanic` after attempting to log the message.
-	if level == LevelFatal {
-		if writeError != nil {
-			// If logging the fatal message failed, ensure the message is printed to os.Stderr before exiting.
-			fmt.Fprintf(os.Stderr, "FATAL: %s\n", entry.Message)
-		}
-		os.Exit(1) // Terminate the application.
-	} else if level == LevelPanic {
-		if writeError != nil {
-			// If logging the panic message failed, print to os.Stderr before panicking.
-			fmt.Fprintf(os.Stderr, "PANIC: %s\n", entry.Message)
-		}
-		panic(entry.Message) // Trigger a panic.
-	}
 }
 
 // Printf logs a message at `LevelInfo` using `fmt.Sprintf` style formatting.
@@ -513,6 +747,12 @@ func (l *DefaultLogger) Printf(format string, args ...interface{}) {
 	l.doLog(LevelInfo, 2, format, args...)
 }
 
+// Trace logs a message at `LevelTrace`. Arguments are handled by `fmt.Sprint`.
+// Implements the `xylium.Logger` interface.
+func (l *DefaultLogger) Trace(args ...interface{}) {
+	l.doLog(LevelTrace, 2, fmt.Sprint(args...))
+}
+
 // Debug logs a message at `LevelDebug`. Arguments are handled by `fmt.Sprint`.
 // Implements the `xylium.Logger` interface.
 func (l *DefaultLogger) Debug(args ...interface{}) {
@@ -552,6 +792,12 @@ func (l *DefaultLogger) Panic(args ...interface{}) {
 	l.doLog(LevelPanic, 2, fmt.Sprint(args...))
 }
 
+// Tracef logs a formatted message at `LevelTrace` using `fmt.Sprintf` style.
+// Implements the `xylium.Logger` interface.
+func (l *DefaultLogger) Tracef(format string, args ...interface{}) {
+	l.doLog(LevelTrace, 2, format, args...)
+}
+
 // Debugf logs a formatted message at `LevelDebug` using `fmt.Sprintf` style.
 // Implements the `xylium.Logger` interface.
 func (l *DefaultLogger) Debugf(format string, args ...interface{}) {
@@ -597,7 +843,7 @@ func (l *DefaultLogger) Panicf(format string, args ...interface{}) {
 //
 // The new logger instance inherits its configuration (output writer, level, formatter,
 // caller settings, color settings) from the original logger (`l`). It also shares the
-// same underlying `bufferPool` for efficiency.
+// same underlying `bufferPool` for efficiency, and the same log sampler.
 //
 // This method is thread-safe and allows for creating context-specific loggers
 // without modifying the original logger instance. It implements the `xylium.Logger` interface.
@@ -619,6 +865,12 @@ func (l *DefaultLogger) WithFields(fields M) Logger {
 		showCaller: l.showCaller,
 		useColor:   l.useColor,
 		bufferPool: l.bufferPool, // Share the buffer pool with the parent.
+		sampler:    l.sampler,    // Share the sampler so sampling applies to the whole logger family.
+		async:      l.async,      // Share the async writer so all entries go through the same queue.
+		hooks:      l.hooks,      // Share hooks so they fire for derived loggers too.
+		timeFormat: l.timeFormat,
+		fieldKeys:  l.fieldKeys,
+		sinks:      l.sinks, // Never modified in place, so it can be shared.
 	}
 
 	// Create a new `baseFields` map for the `newLogger`.
@@ -640,6 +892,105 @@ func (l *DefaultLogger) WithFields(fields M) Logger {
 	return newLogger
 }
 
+// StackTracer can be implemented by errors that carry a stack trace (e.g., captured with
+// `runtime/debug.Stack` when the error was created). `WithError` logs the stack of the
+// first error in the chain that implements it.
+type StackTracer interface {
+	Stack() []byte
+}
+
+// WithError returns a new logger (see `WithFields`) that includes `err` in all subsequent
+// log entries as the following fields:
+//   - "error": `err.Error()`.
+//   - "error_status" and "error_cause": for an `*HTTPError` in the chain, its status code and,
+//     if set, its `Internal` error, so the underlying cause is logged even when the message
+//     shown to the client is generic.
+//   - "error_stack": the stack of the first error in the chain implementing `StackTracer`.
+//
+// If `err` is nil, the logger is returned unchanged. It implements the `xylium.Logger` interface.
+//
+// Example:
+//
+//	if err := svc.Charge(order); err != nil {
+//		c.Logger().WithError(err).Error("Payment failed.")
+//	}
+func (l *DefaultLogger) WithError(err error) Logger {
+	if err == nil {
+		return l
+	}
+	return l.WithFields(errorLogFields(err))
+}
+
+// errorLogFields builds the fields added by `WithError` for a non-nil `err`.
+func errorLogFields(err error) M {
+	fields := M{"error": err.Error()}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		fields["error_status"] = httpErr.Code
+		if httpErr.Internal != nil {
+			fields["error_cause"] = httpErr.Internal.Error()
+		}
+	}
+	var tracer StackTracer
+	if errors.As(err, &tracer) {
+		if stack := tracer.Stack(); len(stack) > 0 {
+			fields["error_stack"] = string(stack)
+		}
+	}
+	return fields
+}
+
+// marshalLogEntryJSON encodes `entry` as a JSON object using `keys` for its standard parts.
+// With `DefaultFieldKeys`, the output is identical to `json.Marshal(entry)`.
+// Empty `Fields` and `Caller` are omitted, like the `omitempty` tags on `LogEntry`.
+func marshalLogEntryJSON(entry LogEntry, keys FieldKeys) ([]byte, error) {
+	if keys == DefaultFieldKeys {
+		return json.Marshal(entry) // Fast path: keys match the struct tags.
+	}
+
+	out := make([]byte, 0, 256)
+	appendPair := func(key string, value interface{}) error {
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		out = append(out, encodedKey...)
+		out = append(out, ':')
+		out = append(out, encodedValue...)
+		return nil
+	}
+
+	out = append(out, '{')
+	if err := appendPair(keys.Timestamp, entry.Timestamp); err != nil {
+		return nil, err
+	}
+	if err := appendPair(keys.Level, entry.Level); err != nil {
+		return nil, err
+	}
+	if err := appendPair(keys.Message, entry.Message); err != nil {
+		return nil, err
+	}
+	if len(entry.Fields) > 0 {
+		if err := appendPair(keys.Fields, entry.Fields); err != nil {
+			return nil, err
+		}
+	}
+	if entry.Caller != "" {
+		if err := appendPair(keys.Caller, entry.Caller); err != nil {
+			return nil, err
+		}
+	}
+	out = append(out, '}')
+	return out, nil
+}
+
 // isTerminal checks if the given `io.Writer` (`w`) is a character device,
 // which typically indicates that it's a terminal (TTY) capable of displaying
 // ANSI color codes. This function is used by `EnableColor` to determine if
@@ -0,0 +1,123 @@
+package xylium
+
+import (
+	"fmt"         // For reporting write errors and dropped entries to os.Stderr.
+	"io"          // For the io.Writer each queued entry is written to.
+	"os"          // For os.Stderr.
+	"sync"        // For guarding enqueue against Close and for one-time shutdown.
+	"sync/atomic" // For the dropped-entries counter.
+)
+
+// DefaultAsyncBufferSize is the default number of log entries that can be queued
+// by an async `DefaultLogger` when `LoggerConfig.BufferSize` is not set.
+const DefaultAsyncBufferSize = 1024
+
+// AsyncOverflowPolicy determines what an async `DefaultLogger` does when its
+// buffer is full.
+type AsyncOverflowPolicy int
+
+const (
+	// AsyncOverflowBlock makes the logging call wait until there is room in the buffer.
+	// No entries are lost, but a slow output can still slow down callers during bursts.
+	// This is the default.
+	AsyncOverflowBlock AsyncOverflowPolicy = iota
+	// AsyncOverflowDrop discards the entry if the buffer is full. Logging calls never block.
+	// The number of dropped entries is reported to os.Stderr when the logger is closed.
+	// Error, Fatal, and Panic entries are never dropped; they always block.
+	AsyncOverflowDrop
+)
+
+// asyncLogRecord is a single queued item for the async writer.
+// A record with a non-nil `flushed` channel is a flush marker and carries no data.
+type asyncLogRecord struct {
+	out     io.Writer
+	data    []byte
+	message string        // Original message, used when reporting write errors.
+	flushed chan struct{} // Closed by the worker once all preceding records are written.
+}
+
+// asyncLogWriter drains queued log entries in a background goroutine.
+// It is shared between a `DefaultLogger` and all loggers derived from it via `WithFields`.
+type asyncLogWriter struct {
+	mu      sync.RWMutex // Read-held while enqueuing; write-held by Close to close the queue safely.
+	closed  bool
+	queue   chan asyncLogRecord
+	policy  AsyncOverflowPolicy
+	dropped atomic.Uint64
+	done    chan struct{} // Closed when the worker goroutine exits.
+	once    sync.Once
+}
+
+// newAsyncLogWriter creates an async writer and starts its worker goroutine.
+func newAsyncLogWriter(bufferSize int, policy AsyncOverflowPolicy) *asyncLogWriter {
+	if bufferSize <= 0 {
+		bufferSize = DefaultAsyncBufferSize
+	}
+	w := &asyncLogWriter{
+		queue:  make(chan asyncLogRecord, bufferSize),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run is the worker loop. It writes records in order until the queue is closed.
+func (w *asyncLogWriter) run() {
+	defer close(w.done)
+	for rec := range w.queue {
+		if rec.flushed != nil {
+			close(rec.flushed)
+			continue
+		}
+		if _, err := rec.out.Write(rec.data); err != nil {
+			fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-ERROR] Failed to write log entry to primary output: %v. Original message: %s\n", err, rec.message)
+		}
+	}
+}
+
+// enqueue queues a record for writing. `mustDeliver` forces blocking behavior regardless
+// of the overflow policy. It returns false if the writer has been closed, in which case
+// the caller should write the entry synchronously.
+func (w *asyncLogWriter) enqueue(rec asyncLogRecord, mustDeliver bool) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return false
+	}
+	if w.policy == AsyncOverflowDrop && !mustDeliver {
+		select {
+		case w.queue <- rec:
+		default:
+			w.dropped.Add(1)
+		}
+		return true
+	}
+	w.queue <- rec
+	return true
+}
+
+// flush blocks until all records queued before the call have been written.
+func (w *asyncLogWriter) flush() {
+	marker := make(chan struct{})
+	if !w.enqueue(asyncLogRecord{flushed: marker}, true) {
+		<-w.done // Already closed; wait for the worker to finish draining.
+		return
+	}
+	<-marker
+}
+
+// close stops accepting new records, drains the queue, and waits for the worker to exit.
+// It is safe to call multiple times.
+func (w *asyncLogWriter) close() {
+	w.once.Do(func() {
+		w.mu.Lock()
+		w.closed = true
+		close(w.queue)
+		w.mu.Unlock()
+	})
+	<-w.done
+	if dropped := w.dropped.Swap(0); dropped > 0 {
+		fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-WARN] Async logger dropped %d log entries because its buffer was full.\n", dropped)
+	}
+}
@@ -0,0 +1,70 @@
+package xylium
+
+import (
+	"fmt"  // For reporting hook errors and panics to os.Stderr.
+	"os"   // For os.Stderr.
+	"sync" // For guarding the hook list.
+)
+
+// Hook is implemented by types that want to receive log entries from a `DefaultLogger`,
+// for example to forward errors to Sentry or Slack.
+//
+// Hooks are called synchronously from the logging call, after the entry has been
+// formatted, for every entry whose level is included in `Levels()`. Slow hooks should
+// hand the entry off to their own goroutine or queue. Hooks must not modify
+// `entry.Fields`, which is shared with the logger's output path.
+type Hook interface {
+	// Levels returns the log levels this hook fires for.
+	Levels() []LogLevel
+	// Fire is called with the fully populated entry. A returned error is written to
+	// os.Stderr and does not prevent the entry from being logged.
+	Fire(entry LogEntry) error
+}
+
+// logHooks is the set of hooks attached to a `DefaultLogger`. It is shared with loggers
+// derived via `WithFields`, so a hook added to a parent also fires for its children.
+type logHooks struct {
+	mu      sync.RWMutex
+	byLevel map[LogLevel][]Hook
+}
+
+// newLogHooks creates an empty hook set.
+func newLogHooks() *logHooks {
+	return &logHooks{byLevel: make(map[LogLevel][]Hook)}
+}
+
+// add registers `hook` for each of its levels.
+func (h *logHooks) add(hook Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, level := range hook.Levels() {
+		h.byLevel[level] = append(h.byLevel[level], hook)
+	}
+}
+
+// fire dispatches `entry` to all hooks registered for `level`. Hook errors and panics
+// are reported to os.Stderr so that a failing hook never breaks logging.
+func (h *logHooks) fire(level LogLevel, entry LogEntry) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	hooks := h.byLevel[level]
+	h.mu.RUnlock()
+
+	for _, hook := range hooks {
+		fireHook(hook, entry)
+	}
+}
+
+// fireHook calls a single hook, recovering from panics.
+func fireHook(hook Hook, entry LogEntry) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-ERROR] Log hook (type %T) panicked: %v. Original message: %s\n", hook, rec, entry.Message)
+		}
+	}()
+	if err := hook.Fire(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "[XYLIUM-LOGGER-ERROR] Log hook (type %T) failed: %v. Original message: %s\n", hook, err, entry.Message)
+	}
+}
@@ -0,0 +1,94 @@
+package xylium
+
+import (
+	"sync/atomic" // For lock-free sampling counters and runtime-swappable config.
+	"time"        // For the one-second sampling window.
+)
+
+// SamplingConfig configures log sampling for `DefaultLogger`.
+// Within each one-second window, and separately for each log level, the first `Initial`
+// entries are logged; after that only every `Thereafter`-th entry is logged.
+// Entries at `LevelError` and above are never sampled (never dropped).
+//
+// Example: `{Initial: 100, Thereafter: 10}` logs the first 100 Info entries each second,
+// then 1 in 10 for the rest of that second.
+type SamplingConfig struct {
+	// Initial is the number of entries per level logged unconditionally in each second.
+	Initial int
+	// Thereafter controls sampling after `Initial` is exceeded: every `Thereafter`-th
+	// entry is logged. If <= 0, all entries beyond `Initial` are dropped for the rest
+	// of the second.
+	Thereafter int
+}
+
+// samplingWindow is the duration after which per-level sampling counters are reset.
+const samplingWindow = time.Second
+
+// samplerCounter tracks the number of entries seen for a single level in the current window.
+type samplerCounter struct {
+	windowStart atomic.Int64  // Start of the current window (UnixNano).
+	count       atomic.Uint64 // Entries seen in the current window.
+}
+
+// logSampler implements per-level, per-second log sampling.
+// It is shared between a `DefaultLogger` and all loggers derived from it via `WithFields`,
+// so sampling applies to the whole logger family and can be tuned at runtime.
+type logSampler struct {
+	config   atomic.Pointer[SamplingConfig] // nil means sampling is disabled.
+	counters map[LogLevel]*samplerCounter   // Read-only after construction; one entry per sampled level.
+}
+
+// newLogSampler creates a sampler with the given (possibly nil) configuration.
+func newLogSampler(config *SamplingConfig) *logSampler {
+	s := &logSampler{counters: make(map[LogLevel]*samplerCounter)}
+	for _, level := range []LogLevel{LevelTrace, LevelDebug, LevelInfo, LevelWarn} {
+		s.counters[level] = &samplerCounter{}
+	}
+	s.setConfig(config)
+	return s
+}
+
+// setConfig atomically replaces the sampling configuration. A nil config disables sampling.
+func (s *logSampler) setConfig(config *SamplingConfig) {
+	if config == nil {
+		s.config.Store(nil)
+		return
+	}
+	cfgCopy := *config // Copy so later changes by the caller have no effect.
+	s.config.Store(&cfgCopy)
+}
+
+// allow reports whether an entry at `level` should be logged.
+// It is safe for concurrent use and does not take any locks.
+func (s *logSampler) allow(level LogLevel) bool {
+	if s == nil {
+		return true // Logger not created via a constructor; no sampler.
+	}
+	cfg := s.config.Load()
+	if cfg == nil {
+		return true // Sampling disabled.
+	}
+	counter, sampled := s.counters[level]
+	if !sampled {
+		return true // Error, Fatal and Panic are never sampled.
+	}
+
+	now := time.Now().UnixNano()
+	start := counter.windowStart.Load()
+	if now-start >= int64(samplingWindow) {
+		// Start a new window. Only the goroutine that wins the CAS resets the counter;
+		// a few entries racing around the boundary may be counted in either window.
+		if counter.windowStart.CompareAndSwap(start, now) {
+			counter.count.Store(0)
+		}
+	}
+
+	n := counter.count.Add(1)
+	if n <= uint64(cfg.Initial) {
+		return true
+	}
+	if cfg.Thereafter <= 0 {
+		return false
+	}
+	return (n-uint64(cfg.Initial))%uint64(cfg.Thereafter) == 0
+}
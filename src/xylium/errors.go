@@ -252,3 +252,27 @@ func IsHTTPError(err error, code ...int) bool {
 	// The error `err` (or its chain) is not an *xylium.HTTPError.
 	return false
 }
+
+// MapErrorIs returns an `ErrorMapper` that maps any error matching `target` (via
+// `errors.Is`) to an `*HTTPError` with the given status `code` and optional `message`
+// (interpreted as by `NewHTTPError`; the status text is used if omitted). The matched
+// error becomes the `Internal` error, so it is still logged.
+//
+// Example:
+//
+//	app.RegisterErrorMapper(
+//		xylium.MapErrorIs(sql.ErrNoRows, xylium.StatusNotFound, "Resource not found."),
+//		xylium.MapErrorIs(ErrOutOfStock, xylium.StatusConflict),
+//	)
+func MapErrorIs(target error, code int, message ...interface{}) ErrorMapper {
+	return func(err error) (*HTTPError, bool) {
+		if !errors.Is(err, target) {
+			return nil, false
+		}
+		var msg interface{}
+		if len(message) > 0 {
+			msg = message[0]
+		}
+		return NewHTTPError(code, msg).WithInternal(err), true
+	}
+}
@@ -0,0 +1,396 @@
+// src/xylium/http_adapter.go
+package xylium
+
+import (
+	"crypto/tls" // For exposing the TLS state of net/http requests to fasthttp.
+	"errors"     // For the error returned by the bridge connection.
+	"fmt"        // For the 413 error message of Router.HTTPHandler.
+	"io"         // For reading request bodies and copying streamed responses.
+	"net"        // For the addresses of the bridge connection.
+	"net/http"   // For the net/http handler and middleware types being adapted.
+	"net/netip"  // For parsing the remote address of net/http requests.
+	"strconv"    // For the Content-Length of responses written to net/http.
+	"time"       // For the no-op deadlines of the bridge connection.
+
+	"github.com/valyala/fasthttp"                 // For fasthttp.RequestCtx.
+	"github.com/valyala/fasthttp/fasthttpadaptor" // For converting fasthttp requests to *http.Request.
+)
+
+// WrapHTTPHandler adapts a standard library `http.Handler` to a Xylium `HandlerFunc`,
+// for interop with existing `net/http` code that cannot easily be ported.
+//
+// The bridge has a cost, so use it knowingly:
+//   - The request is converted to an `*http.Request` (headers are copied) and the response
+//     is buffered in the fasthttp response, so it is slower than a native `HandlerFunc`.
+//   - Streaming does not survive the bridge: `Flush` is a no-op and the response is sent
+//     only after the handler returns. The `http.ResponseWriter` does not implement
+//     `http.Hijacker`, so WebSocket libraries for `net/http` do not work; use `websocket.Upgrade` (sub-package `src/xylium/websocket`).
+//
+// The request's context is `c.GoContext()`, so deadlines set by the `Timeout` middleware
+// apply. The handler never returns an error to Xylium; it writes its own response.
+//
+// Example:
+//
+//	app.GET("/metrics", xylium.WrapHTTPHandler(promhttp.Handler()))
+func WrapHTTPHandler(h http.Handler) HandlerFunc {
+	if h == nil {
+		panic("xylium: WrapHTTPHandler requires a non-nil http.Handler")
+	}
+	return func(c *Context) error {
+		req, err := newHTTPRequest(c)
+		if err != nil {
+			return err
+		}
+		h.ServeHTTP(&httpResponseBridge{ctx: c.Ctx}, req)
+		return nil
+	}
+}
+
+// WrapHTTPMiddleware adapts a standard library middleware (`func(http.Handler) http.Handler`)
+// to a Xylium `Middleware`, for middleware that cannot easily be ported (e.g., a vendor SDK).
+//
+// How the two worlds compose:
+//   - Headers written by the net/http middleware before calling its `next` handler are
+//     applied to the response, and changes it makes to the request headers or the request's
+//     context are visible to the rest of the Xylium chain (the context via `c.GoContext()`).
+//   - If the middleware does not call `next`, the Xylium chain does not run, and whatever
+//     the middleware wrote is the response.
+//   - The response produced by the Xylium chain is replayed through the middleware's
+//     `http.ResponseWriter`, so middleware that records the status or transforms the body
+//     sees it.
+//   - An error returned by the Xylium chain is returned after the net/http middleware
+//     completes, and is then handled by the error handler as usual. The middleware does
+//     not see the resulting error response.
+//
+// The cost and limits of `WrapHTTPHandler` apply: the request is converted and the
+// response buffered for every request, and streamed or hijacked responses from the Xylium
+// chain are left as they are rather than replayed.
+//
+// Example:
+//
+//	app.Use(xylium.WrapHTTPMiddleware(vendorSDK.Middleware))
+func WrapHTTPMiddleware(mw func(http.Handler) http.Handler) Middleware {
+	if mw == nil {
+		panic("xylium: WrapHTTPMiddleware requires a non-nil middleware")
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			req, err := newHTTPRequest(c)
+			if err != nil {
+				return err
+			}
+			bridge := &httpResponseBridge{ctx: c.Ctx}
+			var nextErr error
+			h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bridge.applyHeaders()
+				copyHTTPRequestHeaders(c.Ctx, r)
+
+				nextCtx := c
+				if r.Context() != c.GoContext() {
+					nextCtx = c.WithGoContext(r.Context())
+				}
+				if nextErr = next(nextCtx); nextErr != nil {
+					return
+				}
+				replayResponse(c.Ctx, w)
+			}))
+			h.ServeHTTP(bridge, req)
+			return nextErr
+		}
+	}
+}
+
+// newHTTPRequest converts the request of `c` to an `*http.Request` carrying `c.GoContext()`.
+func newHTTPRequest(c *Context) (*http.Request, error) {
+	var req http.Request
+	if err := fasthttpadaptor.ConvertRequest(c.Ctx, &req, true); err != nil {
+		return nil, NewHTTPError(StatusBadRequest, "Invalid request for net/http handler.").WithInternal(err)
+	}
+	if c.strippedPrefix != "" {
+		// Like http.StripPrefix, for groups with RouteGroup.SetStripPrefix.
+		req.URL.Path = stripPathPrefix(req.URL.Path, c.strippedPrefix)
+		req.URL.RawPath = stripPathPrefix(req.URL.RawPath, c.strippedPrefix)
+	}
+	return req.WithContext(c.GoContext()), nil
+}
+
+// copyHTTPRequestHeaders applies the headers of `r` (possibly modified by net/http
+// middleware) to the fasthttp request.
+func copyHTTPRequestHeaders(ctx *fasthttp.RequestCtx, r *http.Request) {
+	for key, values := range r.Header {
+		ctx.Request.Header.Del(key)
+		for _, value := range values {
+			ctx.Request.Header.Add(key, value)
+		}
+	}
+}
+
+// replayResponse moves the response written by the Xylium chain into `w`, so net/http
+// middleware wrapping the chain observes it. Streamed or hijacked responses are left as is.
+func replayResponse(ctx *fasthttp.RequestCtx, w http.ResponseWriter) {
+	resp := &ctx.Response
+	if ctx.Hijacked() || resp.IsBodyStream() {
+		return
+	}
+	status := resp.StatusCode()
+	body := append([]byte(nil), resp.Body()...)
+	headers := make(http.Header)
+	resp.Header.VisitAll(func(key, value []byte) {
+		k := http.CanonicalHeaderKey(string(key))
+		if k == "Content-Length" {
+			return // Recomputed from the replayed body.
+		}
+		headers[k] = append(headers[k], string(value))
+	})
+
+	resp.ResetBody()
+	for key, values := range headers {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// httpResponseBridge is an `http.ResponseWriter` that writes to a fasthttp response.
+type httpResponseBridge struct {
+	ctx         *fasthttp.RequestCtx
+	header      http.Header
+	wroteHeader bool
+}
+
+// Header returns the header map that is applied to the response on `WriteHeader`.
+func (w *httpResponseBridge) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+// WriteHeader applies the headers and sets the status code. Later calls are ignored,
+// as in net/http.
+func (w *httpResponseBridge) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.applyHeaders()
+	w.ctx.SetStatusCode(statusCode)
+}
+
+// Write appends `p` to the response body, writing the header first if needed.
+// Like net/http, it detects the Content-Type from the first write if none is set.
+func (w *httpResponseBridge) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if _, ok := w.Header()["Content-Type"]; !ok {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(StatusOK)
+	}
+	return w.ctx.Write(p)
+}
+
+// Flush implements `http.Flusher` as a no-op, since the response is buffered.
+func (w *httpResponseBridge) Flush() {}
+
+// applyHeaders sets the headers from `Header()` on the fasthttp response, replacing
+// existing values for the same keys.
+func (w *httpResponseBridge) applyHeaders() {
+	for key, values := range w.header {
+		if key == "Content-Length" {
+			continue // fasthttp sets it from the body.
+		}
+		w.ctx.Response.Header.Del(key)
+		for _, value := range values {
+			w.ctx.Response.Header.Add(key, value)
+		}
+	}
+}
+
+// HTTPHandler returns an `http.Handler` that serves requests with the router, the
+// reverse of `WrapHTTPHandler`. It lets the router run under the standard library's
+// `net/http` server, e.g., for HTTP/2, which fasthttp does not implement (see
+// `ServerConfig.EnableHTTP2`), or inside `net/http` based tooling and tests.
+//
+// Each request is converted to a `fasthttp.RequestCtx` and passed to `Router.Handler`,
+// so routing, middleware and error handling behave as usual. The limits of the bridge:
+//   - The request body is read up to `ServerConfig.MaxRequestBodySize` (413 beyond it)
+//     before the handler runs, unless `ServerConfig.StreamRequestBody` is set.
+//   - Streamed responses (`c.Stream`, `c.SetBodyStreamWriter`) are forwarded with a flush
+//     after every write, so server-sent events work, including over HTTP/2.
+//   - Connection upgrades (`c.Hijack`, `websocket.Upgrade`) and response trailers are not supported.
+//   - `c.GoContext()` derives from the request's context, which is canceled when the
+//     client goes away.
+//   - The fasthttp server settings (timeouts, `Concurrency`, ...) do not apply; configure
+//     the `http.Server` instead.
+func (r *Router) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Init2(newBridgeConn(w, req), &loggerAdapter{internalLogger: r.Logger()}, false)
+		if status, err := r.convertHTTPRequest(req, &ctx.Request); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		ctx.SetUserValue("parent_context", req.Context()) // Becomes the parent of c.GoContext().
+
+		r.Handler(ctx)
+		r.writeHTTPResponse(ctx, w, req.Method == http.MethodHead)
+	})
+}
+
+// convertHTTPRequest copies `req` into the fasthttp request `dst`. On failure it
+// returns the HTTP status to answer with.
+func (r *Router) convertHTTPRequest(req *http.Request, dst *fasthttp.Request) (int, error) {
+	dst.Header.DisableNormalizing()
+	dst.Header.SetMethod(req.Method)
+	dst.SetRequestURI(req.URL.RequestURI())
+	dst.Header.SetHost(req.Host)
+	for key, values := range req.Header {
+		for _, value := range values {
+			dst.Header.Add(key, value)
+		}
+	}
+	dst.Header.EnableNormalizing()
+	if req.Body == nil || req.Body == http.NoBody {
+		return 0, nil
+	}
+
+	limit := int64(r.serverConfig.MaxRequestBodySize)
+	if r.serverConfig.StreamRequestBody {
+		size := -1
+		if req.ContentLength >= 0 {
+			size = int(req.ContentLength)
+		}
+		dst.SetBodyStream(req.Body, size)
+		return 0, nil
+	}
+	reader := io.Reader(req.Body)
+	if limit > 0 {
+		reader = io.LimitReader(req.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return StatusBadRequest, fmt.Errorf("cannot read request body: %w", err)
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds the maximum allowed size of %d bytes", limit)
+	}
+	dst.SetBodyRaw(body)
+	return 0, nil
+}
+
+// writeHTTPResponse writes the response produced for `ctx` to `w`.
+func (r *Router) writeHTTPResponse(ctx *fasthttp.RequestCtx, w http.ResponseWriter, isHead bool) {
+	resp := &ctx.Response
+	defer resp.CloseBodyStream() //nolint:errcheck // Closes the readers of streamed bodies.
+
+	header := w.Header()
+	resp.Header.VisitAll(func(key, value []byte) {
+		switch k := http.CanonicalHeaderKey(string(key)); k {
+		case "Connection", "Keep-Alive", "Transfer-Encoding", "Trailer":
+			// Hop-by-hop headers are managed by net/http (and invalid in HTTP/2).
+		case "Content-Length":
+			// Set below from the body, as fasthttp does when writing the response.
+		default:
+			header.Add(k, string(value))
+		}
+	})
+	if !r.serverConfig.NoDefaultServerHeader && header.Get("Server") == "" && r.serverConfig.Name != "" {
+		header.Set("Server", r.serverConfig.Name)
+	}
+	if contentLength := httpResponseContentLength(resp); contentLength >= 0 {
+		header.Set("Content-Length", strconv.Itoa(contentLength))
+	}
+	w.WriteHeader(resp.StatusCode())
+	if isHead {
+		return
+	}
+
+	if !resp.IsBodyStream() {
+		_, _ = w.Write(resp.Body())
+		return
+	}
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	stream := resp.BodyStream()
+	for {
+		n, err := stream.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return // io.EOF or a failed stream; net/http ends the response.
+		}
+	}
+}
+
+// httpResponseContentLength returns the Content-Length fasthttp would send for `resp`,
+// or -1 if the response has none (a stream of unknown size, or a status without a body).
+// Without it, net/http would send buffered bodies chunked and HEAD responses without
+// the length of the GET response.
+func httpResponseContentLength(resp *fasthttp.Response) int {
+	if resp.IsBodyStream() {
+		return resp.Header.ContentLength() // Negative for streams of unknown size.
+	}
+	if body := resp.Body(); len(body) > 0 {
+		return len(body)
+	}
+	if resp.SkipBody {
+		// A HEAD response without a body, e.g., from fasthttp.ServeFile, carries the length in its header.
+		return resp.Header.ContentLength()
+	}
+	if code := resp.StatusCode(); code < StatusOK || code == StatusNoContent || code == StatusNotModified {
+		return -1
+	}
+	return 0
+}
+
+// errBridgeConn is returned by reads and writes on a `bridgeConn`.
+var errBridgeConn = errors.New("xylium: connection of a net/http request cannot be used directly")
+
+// bridgeConn stands in for the connection of a request served through
+// `Router.HTTPHandler`. It carries the addresses, so `c.RealIP` and the like work;
+// the actual connection is owned by net/http. Write deadlines (see
+// `c.SetWriteDeadline`) are applied to the response through `http.ResponseController`.
+type bridgeConn struct {
+	localAddr, remoteAddr net.Addr
+	rc                    *http.ResponseController
+}
+
+// tlsBridgeConn is a `bridgeConn` for a request received over TLS. It implements the
+// interface fasthttp uses to detect TLS, so `c.IsTLS` and `c.Scheme` report HTTPS.
+type tlsBridgeConn struct {
+	bridgeConn
+	state tls.ConnectionState
+}
+
+// newBridgeConn returns the `bridgeConn` (or `tlsBridgeConn`) for `req`.
+func newBridgeConn(w http.ResponseWriter, req *http.Request) net.Conn {
+	conn := bridgeConn{localAddr: &net.TCPAddr{}, remoteAddr: &net.TCPAddr{}, rc: http.NewResponseController(w)}
+	if addrPort, err := netip.ParseAddrPort(req.RemoteAddr); err == nil {
+		conn.remoteAddr = net.TCPAddrFromAddrPort(addrPort)
+	}
+	if localAddr, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		conn.localAddr = localAddr
+	}
+	if req.TLS != nil {
+		return &tlsBridgeConn{bridgeConn: conn, state: *req.TLS}
+	}
+	return &conn
+}
+
+func (c *bridgeConn) Read([]byte) (int, error)           { return 0, errBridgeConn }
+func (c *bridgeConn) Write([]byte) (int, error)          { return 0, errBridgeConn }
+func (c *bridgeConn) Close() error                       { return nil }
+func (c *bridgeConn) LocalAddr() net.Addr                { return c.localAddr }
+func (c *bridgeConn) RemoteAddr() net.Addr               { return c.remoteAddr }
+func (c *bridgeConn) SetDeadline(time.Time) error        { return nil }
+func (c *bridgeConn) SetReadDeadline(time.Time) error    { return nil }
+func (c *bridgeConn) SetWriteDeadline(t time.Time) error { return c.rc.SetWriteDeadline(t) }
+
+func (c *tlsBridgeConn) Handshake() error                     { return nil }
+func (c *tlsBridgeConn) ConnectionState() tls.ConnectionState { return c.state }
@@ -0,0 +1,162 @@
+// src/xylium/middleware_accesslog.go
+package xylium
+
+import (
+	"fmt"  // For the default access log message.
+	"time" // For measuring request latency.
+)
+
+// Field names that can be selected via `AccessLogConfig.Fields`.
+const (
+	AccessLogFieldMethod    = "method"
+	AccessLogFieldPath      = "path"
+	AccessLogFieldStatus    = "status"
+	AccessLogFieldLatency   = "latency"
+	AccessLogFieldClientIP  = "client_ip"
+	AccessLogFieldUserAgent = "user_agent"
+	AccessLogFieldBytesOut  = "bytes_out"
+	AccessLogFieldRequestID = "request_id"
+)
+
+// AccessLogEntry holds the data collected by the AccessLog middleware for one request.
+// It is passed to `AccessLogConfig.Formatter` to build the log message.
+type AccessLogEntry struct {
+	Method    string
+	Path      string
+	Status    int
+	Latency   time.Duration
+	ClientIP  string
+	UserAgent string
+	BytesOut  int
+	RequestID string
+	// Error is the error returned by the handler chain, if any. Note that it has not yet
+	// been processed by the `GlobalErrorHandler` when the entry is logged.
+	Error error
+}
+
+// AccessLogConfig defines the configuration for the AccessLog middleware.
+type AccessLogConfig struct {
+	// Fields selects which structured fields are attached to each log entry.
+	// Use the `AccessLogField...` constants.
+	// Default: all fields.
+	//
+	// Note: `c.Logger()` already attaches the request ID under `xylium_request_id`
+	// when the `RequestID` middleware runs before AccessLog; `AccessLogFieldRequestID`
+	// only controls the additional `request_id` field.
+	Fields []string
+
+	// Skip, if set, is called for each request; returning true disables logging for it
+	// (e.g., for health checks).
+	Skip func(c *Context) bool
+
+	// Formatter builds the log message from the collected entry.
+	// Default: "<METHOD> <PATH> <STATUS> <LATENCY>".
+	Formatter func(entry AccessLogEntry) string
+}
+
+// DefaultAccessLogFields lists all fields logged by default by AccessLog.
+var DefaultAccessLogFields = []string{
+	AccessLogFieldMethod,
+	AccessLogFieldPath,
+	AccessLogFieldStatus,
+	AccessLogFieldLatency,
+	AccessLogFieldClientIP,
+	AccessLogFieldUserAgent,
+	AccessLogFieldBytesOut,
+	AccessLogFieldRequestID,
+}
+
+// AccessLog returns a middleware that writes one structured log entry per request
+// through `c.Logger()`. The log level is chosen by status class:
+// 5xx is logged at Error, 4xx at Warn, and everything else at Info.
+//
+// For errors returned by the handler chain, the status is derived from the error
+// with `c.ErrorStatus` (the code of an `*HTTPError`, the code an error mapper translates
+// it to, or 500 otherwise), because the `GlobalErrorHandler`
+// writes the error response only after all middleware have returned.
+func AccessLog(config AccessLogConfig) Middleware {
+	if len(config.Fields) == 0 {
+		config.Fields = DefaultAccessLogFields
+	}
+	if config.Formatter == nil {
+		config.Formatter = func(e AccessLogEntry) string {
+			return fmt.Sprintf("%s %s %d %v", e.Method, e.Path, e.Status, e.Latency)
+		}
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if config.Skip != nil && config.Skip(c) {
+				return next(c)
+			}
+
+			start := time.Now()
+			err := next(c)
+			latency := time.Since(start)
+
+			status := c.ErrorStatus(err) // The status the error handler will send for `err`.
+
+			// Bytes written: buffered body length, or the declared Content-Length for streams.
+			bytesOut := len(c.Ctx.Response.Body())
+			if c.Ctx.Response.IsBodyStream() {
+				bytesOut = c.Ctx.Response.Header.ContentLength()
+				if bytesOut < 0 {
+					bytesOut = 0 // Unknown length (chunked stream).
+				}
+			}
+
+			requestID, _ := c.GetString(ContextKeyRequestID)
+			entry := AccessLogEntry{
+				Method:    c.Method(),
+				Path:      c.Path(),
+				Status:    status,
+				Latency:   latency,
+				ClientIP:  c.RealIP(),
+				UserAgent: c.UserAgent(),
+				BytesOut:  bytesOut,
+				RequestID: requestID,
+				Error:     err,
+			}
+
+			fields := make(M, len(config.Fields)+1)
+			for _, f := range config.Fields {
+				switch f {
+				case AccessLogFieldMethod:
+					fields[f] = entry.Method
+				case AccessLogFieldPath:
+					fields[f] = entry.Path
+				case AccessLogFieldStatus:
+					fields[f] = entry.Status
+				case AccessLogFieldLatency:
+					fields[f] = entry.Latency.String()
+				case AccessLogFieldClientIP:
+					fields[f] = entry.ClientIP
+				case AccessLogFieldUserAgent:
+					fields[f] = entry.UserAgent
+				case AccessLogFieldBytesOut:
+					fields[f] = entry.BytesOut
+				case AccessLogFieldRequestID:
+					if entry.RequestID != "" {
+						fields[f] = entry.RequestID
+					}
+				}
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			}
+
+			logger := c.Logger().WithFields(M{"middleware": "AccessLog"}).WithFields(fields)
+			message := config.Formatter(entry)
+			switch {
+			case status >= StatusInternalServerError:
+				logger.Error(message)
+			case status >= StatusBadRequest:
+				logger.Warn(message)
+			default:
+				logger.Info(message)
+			}
+
+			return err
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package xylium
 
 import (
+	"crypto/sha256"   // For hashing plaintext passwords to equal lengths before comparison.
+	"crypto/subtle"   // For constant-time password comparison.
 	"encoding/base64" // For decoding Basic Auth credentials.
 	"errors"          // For defining standard error types.
+	"fmt"             // For formatting panic messages of validator helpers.
 	"strings"         // For string manipulation (prefix checking, splitting).
+
+	"golang.org/x/crypto/bcrypt" // For validating bcrypt password hashes.
 )
 
 // BasicAuthConfig defines the configuration for the BasicAuth middleware.
@@ -182,3 +187,76 @@ func BasicAuthWithConfig(config BasicAuthConfig) Middleware {
 		}
 	}
 }
+
+// ConstantTimeValidator returns a `BasicAuthConfig.Validator` that checks credentials
+// against `users`, a map of usernames to plaintext passwords. The map is copied, so later
+// changes to it have no effect.
+//
+// Comparing passwords with `==` returns as soon as a byte differs, so the response time
+// leaks how much of a guessed password is correct. This validator hashes both passwords
+// with SHA-256 and compares the digests with `subtle.ConstantTimeCompare`, so the time
+// depends on neither the password contents nor their lengths. Unknown usernames are
+// compared against a placeholder, making them take the same time as a wrong password.
+//
+// Prefer `BcryptValidator` when credentials are stored (e.g., in a config file), so a leak
+// does not reveal the passwords. On success, the username is stored as the user value.
+func ConstantTimeValidator(users map[string]string) func(username, password string, c *Context) (interface{}, bool, error) {
+	digests := make(map[string][sha256.Size]byte, len(users))
+	for username, password := range users {
+		digests[username] = sha256.Sum256([]byte(password))
+	}
+	var placeholder [sha256.Size]byte
+
+	return func(username, password string, c *Context) (interface{}, bool, error) {
+		want, known := digests[username]
+		if !known {
+			want = placeholder
+		}
+		got := sha256.Sum256([]byte(password))
+		if subtle.ConstantTimeCompare(got[:], want[:]) == 1 && known {
+			return username, true, nil
+		}
+		return nil, false, nil
+	}
+}
+
+// BcryptValidator returns a `BasicAuthConfig.Validator` that checks credentials against
+// `users`, a map of usernames to bcrypt password hashes (as produced by
+// `bcrypt.GenerateFromPassword` or `htpasswd -B`). The map is copied, so later changes
+// to it have no effect.
+//
+// Storing only bcrypt hashes means a leaked credential store does not reveal passwords,
+// and bcrypt's comparison is constant-time. Unknown usernames are checked against a
+// placeholder hash of the same cost, so they take as long as a wrong password and do not
+// reveal which usernames exist. bcrypt is deliberately slow (tens of milliseconds per
+// check at the default cost); combine it with the `RateLimiter` middleware to bound the
+// cost of brute-force attempts. On success, the username is stored as the user value.
+//
+// Panics if a hash is not a valid bcrypt hash.
+func BcryptValidator(users map[string]string) func(username, password string, c *Context) (interface{}, bool, error) {
+	hashes := make(map[string][]byte, len(users))
+	placeholderCost := bcrypt.DefaultCost
+	for username, hash := range users {
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			panic(fmt.Sprintf("xylium: BcryptValidator: invalid bcrypt hash for user '%s': %v", username, err))
+		}
+		placeholderCost = cost
+		hashes[username] = []byte(hash)
+	}
+	placeholderHash, err := bcrypt.GenerateFromPassword([]byte("xylium-placeholder"), placeholderCost)
+	if err != nil {
+		panic(fmt.Sprintf("xylium: BcryptValidator: failed to generate placeholder hash: %v", err))
+	}
+
+	return func(username, password string, c *Context) (interface{}, bool, error) {
+		hash, known := hashes[username]
+		if !known {
+			hash = placeholderHash
+		}
+		if bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil && known {
+			return username, true, nil
+		}
+		return nil, false, nil
+	}
+}
@@ -0,0 +1,86 @@
+// src/xylium/middleware_bodydump.go
+package xylium
+
+// BodyDumpConfig defines the configuration for the BodyDump middleware.
+type BodyDumpConfig struct {
+	// Handler is called after the handler chain returns, with the captured request and
+	// response bodies. Both slices are copies and may be retained.
+	// This field is required.
+	Handler func(c *Context, reqBody, resBody []byte)
+
+	// MaxBodySize caps the number of bytes captured from each body, so large uploads
+	// and downloads do not blow up memory. Bodies longer than this are truncated.
+	// Default: 4096 bytes (`DefaultBodyDumpConfig.MaxBodySize`).
+	MaxBodySize int
+
+	// Skip, if set, is called for each request; returning true disables dumping for it.
+	// Useful to restrict dumping to certain paths or content types.
+	Skip func(c *Context) bool
+}
+
+// DefaultBodyDumpConfig provides the default values for BodyDumpConfig.
+var DefaultBodyDumpConfig = BodyDumpConfig{
+	MaxBodySize: 4096,
+}
+
+// BodyDump returns a middleware that captures the request and response bodies and passes
+// them to `config.Handler`. It is intended for troubleshooting during development, e.g.:
+//
+//	if app.CurrentMode() == xylium.DebugMode {
+//		app.Use(xylium.BodyDump(xylium.BodyDumpConfig{
+//			Handler: func(c *xylium.Context, req, res []byte) {
+//				c.Logger().Debugf("Request: %s\nResponse: %s", req, res)
+//			},
+//		}))
+//	}
+//
+// Capturing does not consume the request: `c.Body()` and the binder (`c.Bind`, etc.)
+// still see the full body. A streamed request body (see `ServerConfig.StreamRequestBody`)
+// is read into memory, like the binder does, and rejected with 413 Request Entity Too
+// Large if it exceeds `ServerConfig.MaxRequestBodySize`.
+//
+// The response body is captured as written by the handler chain. Streamed responses
+// (e.g., `c.Stream`, `c.File`) are not captured, and neither are error responses, which
+// the `GlobalErrorHandler` writes only after all middleware have returned. In both cases
+// `resBody` is empty.
+func BodyDump(config BodyDumpConfig) Middleware {
+	if config.Handler == nil {
+		panic("xylium: BodyDump middleware requires a 'Handler' function")
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultBodyDumpConfig.MaxBodySize
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if config.Skip != nil && config.Skip(c) {
+				return next(c)
+			}
+
+			// A streamed request body is read into memory once (up to
+			// `ServerConfig.MaxRequestBodySize`), so downstream handlers still see all of it.
+			body, err := c.bindingBody()
+			if err != nil {
+				return err
+			}
+			reqBody := truncatedCopy(body, config.MaxBodySize)
+
+			err = next(c)
+
+			var resBody []byte
+			if !c.Ctx.Response.IsBodyStream() {
+				resBody = truncatedCopy(c.Ctx.Response.Body(), config.MaxBodySize)
+			}
+			config.Handler(c, reqBody, resBody)
+			return err
+		}
+	}
+}
+
+// truncatedCopy returns a copy of at most `max` bytes of `b`.
+func truncatedCopy(b []byte, max int) []byte {
+	if len(b) > max {
+		b = b[:max]
+	}
+	return append([]byte(nil), b...)
+}
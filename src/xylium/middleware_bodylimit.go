@@ -0,0 +1,91 @@
+// src/xylium/middleware_bodylimit.go
+package xylium
+
+import (
+	"bytes" // For buffering streamed request bodies up to the limit.
+	"fmt"   // For formatting the default error message.
+	"io"    // For io.LimitReader when reading streamed bodies.
+)
+
+// BodyLimitConfig defines the configuration for the BodyLimit middleware.
+// Unlike `ServerConfig.MaxRequestBodySize`, which applies to every request handled
+// by the server, this middleware can be attached to individual routes or groups
+// (e.g., a larger limit for an upload route and a small one everywhere else).
+//
+// Note that `ServerConfig.MaxRequestBodySize` is still enforced by fasthttp before
+// any middleware runs, so it must be at least as large as the largest per-route limit.
+type BodyLimitConfig struct {
+	// Limit is the maximum allowed size of the request body, in bytes.
+	// Must be greater than 0.
+	Limit int64
+
+	// Message is the error message sent to the client when the limit is exceeded.
+	// If empty, a default message indicating the limit is used.
+	Message string
+}
+
+// BodyLimit returns a middleware that rejects requests whose body exceeds `max` bytes
+// with an HTTP 413 Request Entity Too Large error.
+func BodyLimit(max int64) Middleware {
+	return BodyLimitWithConfig(BodyLimitConfig{
+		Limit: max,
+	})
+}
+
+// BodyLimitWithConfig returns a BodyLimit middleware with the provided custom configuration.
+//
+// The middleware works in two stages:
+//  1. If the request declares a `Content-Length` larger than the limit, it is rejected
+//     immediately, without reading the body.
+//  2. For chunked or streamed bodies (see `ServerConfig.StreamRequestBody`), the body
+//     is read through a limiting reader. At most `Limit`+1 bytes are ever buffered;
+//     as soon as the limit is exceeded the request is rejected. Otherwise, the buffered
+//     body replaces the stream so that `c.Body()` and the binder (`c.Bind`, etc.) see it.
+//
+// The returned error is an `*HTTPError` with `StatusRequestEntityTooLarge`.
+func BodyLimitWithConfig(config BodyLimitConfig) Middleware {
+	if config.Limit <= 0 {
+		panic("xylium: BodyLimit middleware 'Limit' must be greater than 0")
+	}
+	if config.Message == "" {
+		config.Message = fmt.Sprintf("Request body exceeds the maximum allowed size of %d bytes.", config.Limit)
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			logger := c.Logger().WithFields(M{"middleware": "BodyLimit"})
+
+			tooLarge := func(reason string) error {
+				logger.Debugf("Rejecting request %s %s: %s (limit: %d bytes).", c.Method(), c.Path(), reason, config.Limit)
+				return NewHTTPError(StatusRequestEntityTooLarge, config.Message).
+					WithInternal(fmt.Errorf("request body too large: %s", reason))
+			}
+
+			// Stage 1: Reject early based on the declared Content-Length.
+			// fasthttp returns -1 for chunked bodies and -2 for identity bodies without length.
+			if cl := int64(c.Ctx.Request.Header.ContentLength()); cl > config.Limit {
+				return tooLarge(fmt.Sprintf("Content-Length %d", cl))
+			}
+
+			// Stage 2: Enforce the limit on the actual body.
+			if c.Ctx.Request.IsBodyStream() {
+				stream := c.Ctx.RequestBodyStream()
+				var buf bytes.Buffer
+				n, err := buf.ReadFrom(io.LimitReader(stream, config.Limit+1))
+				if err != nil {
+					return NewHTTPError(StatusBadRequest, "Failed to read request body.").WithInternal(err)
+				}
+				if n > config.Limit {
+					return tooLarge("streamed body exceeded limit")
+				}
+				// Replace the stream with the buffered body so c.Body() and binders work as usual.
+				c.Ctx.Request.SetBody(buf.Bytes())
+			} else if int64(len(c.Ctx.Request.Body())) > config.Limit {
+				// Body was already buffered by fasthttp (e.g., chunked without streaming enabled).
+				return tooLarge("buffered body exceeded limit")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// src/xylium/middleware_chain.go
+package xylium
+
+import (
+	"reflect"     // For deriving a fallback name from a middleware's function.
+	"runtime"     // For runtime.FuncForPC to resolve function names.
+	"strings"     // For shortening fully qualified function names.
+	"sync/atomic" // For the chain position, which the handler may update from another goroutine.
+)
+
+// namedMiddleware is a middleware labeled via `NamedMiddleware`. The name is kept with
+// the middleware itself, so it is released together with it.
+type namedMiddleware struct {
+	name string
+	mw   Middleware
+}
+
+// wrap implements `Middleware`. Given `middlewareNameProbe` as `next`, it returns a
+// handler reporting its name (see `namedMiddlewareName`) instead of calling `mw`.
+func (n *namedMiddleware) wrap(next HandlerFunc) HandlerFunc {
+	if next != nil && reflect.ValueOf(next).Pointer() == middlewareNameProbePC {
+		return func(*Context) error { return middlewareNameReport(n.name) }
+	}
+	return n.mw(next)
+}
+
+// middlewareNameProbe is passed as `next` to a `NamedMiddleware` wrapper to ask for its
+// name. It is never called.
+func middlewareNameProbe(*Context) error { return nil }
+
+// middlewareNameReport carries the name of a `NamedMiddleware` wrapper.
+type middlewareNameReport string
+
+// Error implements the `error` interface.
+func (r middlewareNameReport) Error() string { return "xylium: middleware name " + string(r) }
+
+var (
+	// namedMiddlewarePC is the code pointer shared by all `NamedMiddleware` wrappers.
+	namedMiddlewarePC = reflect.ValueOf(Middleware((&namedMiddleware{}).wrap)).Pointer()
+	// middlewareNameProbePC is the code pointer of `middlewareNameProbe`.
+	middlewareNameProbePC = reflect.ValueOf(HandlerFunc(middlewareNameProbe)).Pointer()
+)
+
+// namedMiddlewareName returns the name of `mw` if it was created by `NamedMiddleware`.
+// Other middleware are never called, since they may do work when wrapping a handler.
+func namedMiddlewareName(mw Middleware) (string, bool) {
+	if reflect.ValueOf(mw).Pointer() != namedMiddlewarePC {
+		return "", false
+	}
+	name, ok := mw(middlewareNameProbe)(nil).(middlewareNameReport)
+	return string(name), ok
+}
+
+// NamedMiddleware labels `mw` with `name` for introspection via `MiddlewareName` and
+// `Router.MiddlewareChain`, in the middleware chains logged at startup in `DebugMode`, and
+// in the `DebugMode` warning about a middleware that returned without calling `next`.
+// The returned middleware behaves exactly like `mw`.
+//
+// Example:
+//
+//	app.Use(xylium.NamedMiddleware("auth", authMiddleware))
+func NamedMiddleware(name string, mw Middleware) Middleware {
+	if mw == nil {
+		panic("xylium: NamedMiddleware requires a non-nil middleware")
+	}
+	return (&namedMiddleware{name: name, mw: mw}).wrap
+}
+
+// MiddlewareName returns the name given to `mw` via `NamedMiddleware`. For unnamed
+// middleware it falls back to the name of the function that created it, e.g.
+// "xylium.Gzip.func1" or "main.authMiddleware". It returns "" for a nil middleware.
+func MiddlewareName(mw Middleware) string {
+	if mw == nil {
+		return ""
+	}
+	if name, ok := namedMiddlewareName(mw); ok {
+		return name
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(mw).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	fullName := fn.Name()
+	if idx := strings.LastIndex(fullName, "/"); idx != -1 {
+		fullName = fullName[idx+1:] // Trim the import path, keep "pkg.Func...".
+	}
+	return fullName
+}
+
+// MiddlewareChain returns the names (see `MiddlewareName`) of the middleware that run for
+// a request with the given `method` and `path`, in execution order: global middleware
+// first, then group middleware (outer to inner), then route-specific middleware.
+// `path` is matched like a request path, so "/users/42" resolves a "/users/:id" route.
+// It returns nil if no route matches. Pre-routing hooks are not included.
+func (r *Router) MiddlewareChain(method, path string) []string {
+	handler, routeMw, _, _ := r.tree.Find(strings.ToUpper(method), path)
+	if handler == nil {
+		return nil
+	}
+	return middlewareChainNames(r.globalMiddleware, routeMw)
+}
+
+// middlewareChainNames returns the names of the global and route middleware, in order.
+func middlewareChainNames(global, route []Middleware) []string {
+	names := make([]string, 0, len(global)+len(route))
+	for _, mw := range global {
+		names = append(names, MiddlewareName(mw))
+	}
+	for _, mw := range route {
+		names = append(names, MiddlewareName(mw))
+	}
+	return names
+}
+
+// logMiddlewareChains logs the resolved middleware chain of every registered route at
+// Debug level. It is called at startup in `DebugMode`, after the routes are printed.
+func (r *Router) logMiddlewareChains(logger Logger) {
+	logger.Debugf("Xylium Middleware Chains (global -> group -> route):")
+	r.tree.walkRoutes(func(method, path string, target routeTarget) {
+		names := middlewareChainNames(r.globalMiddleware, target.middleware)
+		chain := "(none)"
+		if len(names) > 0 {
+			chain = strings.Join(names, " -> ")
+		}
+		logger.Debugf("  %-7s %s: %s", method, path, chain)
+	})
+}
+
+// middlewareTrace records, in `DebugMode`, how far a request got into its middleware
+// chain, so a request that ends without a response can be traced to the middleware
+// that returned without calling `next`.
+type middlewareTrace struct {
+	middleware []Middleware // The traced middleware, outermost first.
+	reached    atomic.Int32 // Number of traced middleware whose `next` was called.
+}
+
+// newMiddlewareTrace returns a trace for a chain made of `global` middleware followed by
+// `route` middleware (which includes group middleware), or nil outside `DebugMode`, so
+// the chain is built without instrumentation in production.
+func (r *Router) newMiddlewareTrace(global, route []Middleware) *middlewareTrace {
+	if r.CurrentMode() != DebugMode || len(global)+len(route) == 0 {
+		return nil
+	}
+	middleware := make([]Middleware, 0, len(global)+len(route))
+	return &middlewareTrace{middleware: append(append(middleware, global...), route...)}
+}
+
+// apply wraps `handler` in `middleware`, the first one being the outermost. `offset` is
+// the position of `middleware[0]` in the traced chain. On a nil trace, the middleware
+// are applied as is.
+func (t *middlewareTrace) apply(handler HandlerFunc, middleware []Middleware, offset int) HandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if t == nil {
+			handler = middleware[i](handler)
+			continue
+		}
+		next, depth := handler, int32(offset+i+1)
+		handler = middleware[i](func(c *Context) error {
+			for { // Keep the deepest position reached.
+				reached := t.reached.Load()
+				if reached >= depth || t.reached.CompareAndSwap(reached, depth) {
+					break
+				}
+			}
+			return next(c)
+		})
+	}
+	return handler
+}
+
+// shortCircuit returns the position (0-based, outermost first) and name (see
+// `MiddlewareName`) of the middleware that returned without calling `next`, or
+// ok=false if the whole chain ran.
+func (t *middlewareTrace) shortCircuit() (position int, name string, ok bool) {
+	if t == nil {
+		return 0, "", false
+	}
+	position = int(t.reached.Load())
+	if position >= len(t.middleware) {
+		return 0, "", false
+	}
+	return position, MiddlewareName(t.middleware[position]), true
+}
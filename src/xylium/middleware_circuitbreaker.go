@@ -0,0 +1,353 @@
+// src/xylium/middleware_circuitbreaker.go
+package xylium
+
+import (
+	"math"    // For rounding Retry-After up to whole seconds.
+	"strconv" // For formatting the Retry-After header.
+	"sync"    // For sync.Mutex protecting the circuits.
+	"time"    // For failure windows and open timeouts.
+)
+
+// CircuitState is the state of a circuit in a `Breaker`.
+type CircuitState string
+
+// Circuit states, as reported by `Breaker.State` and `CircuitBreakerConfig.OnStateChange`.
+const (
+	// CircuitClosed lets requests through while counting failures.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects requests immediately with 503 until `OpenTimeout` has passed.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets up to `HalfOpenRequests` trial requests through; the circuit
+	// closes if they all succeed and opens again on the first failure.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerConfig defines the configuration for the circuit breaker middleware.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of failures within `Window` that opens the circuit.
+	// Default: 5 (also used if zero or negative).
+	FailureThreshold int
+
+	// Window is the rolling period over which failures are counted. Failures older
+	// than `Window` no longer count towards `FailureThreshold`.
+	// Default: 60 seconds (also used if zero or negative).
+	Window time.Duration
+
+	// OpenTimeout is how long an open circuit rejects requests before it becomes
+	// half-open and lets trial requests through.
+	// Default: 30 seconds (also used if zero or negative).
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is the number of trial requests let through while half-open.
+	// The circuit closes once all of them succeed. Further requests are rejected until then.
+	// Default: 1 (also used if zero or negative).
+	HalfOpenRequests int
+
+	// KeyGenerator returns the circuit key for a request, so separate circuits are kept,
+	// e.g., per upstream service or per tenant. If nil, all requests share one circuit.
+	KeyGenerator func(c *Context) string
+
+	// IsFailure reports whether a request failed, given the error returned by the rest
+	// of the chain. If nil, `DefaultCircuitBreakerIsFailure` is used, which counts server
+	// errors (5xx) but not client errors (4xx).
+	IsFailure func(c *Context, err error) bool
+
+	// OnStateChange, if set, is called when a circuit changes state, e.g., to update
+	// metrics or log an alert. It is called synchronously, outside the breaker's lock.
+	OnStateChange func(key string, from, to CircuitState)
+
+	// Skip, if set and returning true, bypasses the circuit breaker for the request.
+	Skip func(c *Context) bool
+
+	// Message is the message of the 503 `*HTTPError` returned while the circuit is open.
+	// Default: "Service temporarily unavailable. Please try again later."
+	Message string
+}
+
+// DefaultCircuitBreakerConfig provides the default configuration for the circuit breaker.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	Window:           60 * time.Second,
+	OpenTimeout:      30 * time.Second,
+	HalfOpenRequests: 1,
+	Message:          "Service temporarily unavailable. Please try again later.",
+}
+
+// DefaultCircuitBreakerIsFailure is the default `CircuitBreakerConfig.IsFailure`.
+// A request failed if the client receives a 5xx status for it (see `c.ErrorStatus`): the
+// chain returned an `*HTTPError` with a 5xx code, an error that no error mapper translates
+// to a non-5xx `*HTTPError`, or nil with a 5xx response status. Errors answered with a
+// 4xx code, including mapped domain errors, are client errors and do not count as failures.
+func DefaultCircuitBreakerIsFailure(c *Context, err error) bool {
+	return c.ErrorStatus(err) >= StatusInternalServerError
+}
+
+// circuit is the state of one circuit (one key) in a `Breaker`.
+type circuit struct {
+	state             CircuitState
+	failures          []time.Time // Times of recent failures while closed, oldest first.
+	openedAt          time.Time   // When the circuit last opened.
+	halfOpenInFlight  int         // Trial requests currently running while half-open.
+	halfOpenSucceeded int         // Trial requests that succeeded while half-open.
+	lastSeen          time.Time   // When a request for the circuit was last allowed, rejected or recorded.
+}
+
+// circuitTransition is a state change to report to `OnStateChange`.
+type circuitTransition struct {
+	key      string
+	from, to CircuitState
+}
+
+// Breaker is a circuit breaker shared by the middleware it creates. Use `NewBreaker`
+// when the circuit states must be read, e.g., for metrics; otherwise `CircuitBreaker`
+// is simpler. It is safe for concurrent use.
+//
+// Circuits are kept only while they matter: a closed circuit is forgotten once its
+// failures are older than `Window`, and an open or half-open circuit that sees no
+// requests for `OpenTimeout` plus `Window` is forgotten (closed) as well. Stale circuits
+// are pruned as requests come in, so high-cardinality keys do not grow memory unbounded.
+type Breaker struct {
+	config    CircuitBreakerConfig
+	mu        sync.Mutex
+	circuits  map[string]*circuit // Only circuits that are not closed or have recent failures.
+	lastPrune time.Time           // When stale circuits were last pruned.
+}
+
+// NewBreaker creates a `Breaker` with the given configuration, applying defaults for
+// unset fields.
+//
+// Example:
+//
+//	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{FailureThreshold: 10})
+//	api.Use(breaker.Middleware())
+//	app.GET("/metrics/circuit", func(c *xylium.Context) error {
+//		return c.JSON(xylium.StatusOK, breaker.States())
+//	})
+func NewBreaker(config CircuitBreakerConfig) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultCircuitBreakerConfig.FailureThreshold
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultCircuitBreakerConfig.Window
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultCircuitBreakerConfig.OpenTimeout
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = DefaultCircuitBreakerConfig.HalfOpenRequests
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = DefaultCircuitBreakerIsFailure
+	}
+	if config.Message == "" {
+		config.Message = DefaultCircuitBreakerConfig.Message
+	}
+	return &Breaker{config: config, circuits: make(map[string]*circuit)}
+}
+
+// CircuitBreaker returns a circuit breaker middleware with the given configuration.
+// It counts failed requests (see `CircuitBreakerConfig.IsFailure`) and, after
+// `FailureThreshold` failures within `Window`, opens the circuit: requests are then
+// rejected immediately with a 503 `*HTTPError` and a "Retry-After" header, without
+// calling the handler. After `OpenTimeout`, the circuit becomes half-open and lets
+// `HalfOpenRequests` trial requests through; it closes if they succeed, and opens again otherwise.
+//
+// Use `NewBreaker` to read the state of the circuits.
+func CircuitBreaker(config CircuitBreakerConfig) Middleware {
+	return NewBreaker(config).Middleware()
+}
+
+// Middleware returns the circuit breaker middleware. All middleware returned by the
+// same `Breaker` share its circuits.
+func (b *Breaker) Middleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if b.config.Skip != nil && b.config.Skip(c) {
+				return next(c)
+			}
+			key := ""
+			if b.config.KeyGenerator != nil {
+				key = b.config.KeyGenerator(c)
+			}
+
+			allowed, trial, retryAfter, transition := b.allow(key)
+			b.notify(transition)
+			if !allowed {
+				c.Logger().WithFields(M{"middleware": "CircuitBreaker"}).Warnf(
+					"CircuitBreaker: Circuit '%s' is open. Rejecting %s %s.", key, c.Method(), c.Path())
+				c.SetHeader("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				return NewHTTPError(StatusServiceUnavailable, b.config.Message)
+			}
+
+			completed := false
+			defer func() {
+				if !completed { // The chain panicked; count it as a failure and let the panic continue.
+					b.notify(b.record(key, trial, true))
+				}
+			}()
+			err := next(c)
+			completed = true
+
+			if transition := b.record(key, trial, b.config.IsFailure(c, err)); transition != nil {
+				if transition.to == CircuitOpen {
+					c.Logger().WithFields(M{"middleware": "CircuitBreaker"}).Warnf(
+						"CircuitBreaker: Circuit '%s' opened after a failure of %s %s.", key, c.Method(), c.Path())
+				}
+				b.notify(transition)
+			}
+			return err
+		}
+	}
+}
+
+// State returns the current state of the circuit for `key` ("" when no `KeyGenerator`
+// is configured).
+func (b *Breaker) State(key string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked(b.circuits[key])
+}
+
+// States returns the state of every circuit that is not closed or has recent failures,
+// keyed by circuit key. Circuits that are absent are closed.
+func (b *Breaker) States() map[string]CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	states := make(map[string]CircuitState, len(b.circuits))
+	for key, cb := range b.circuits {
+		states[key] = b.stateLocked(cb)
+	}
+	return states
+}
+
+// stateLocked returns the state of `cb`, reporting an open circuit whose `OpenTimeout`
+// has passed as half-open. b.mu must be held.
+func (b *Breaker) stateLocked(cb *circuit) CircuitState {
+	if cb == nil {
+		return CircuitClosed
+	}
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= b.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// allow decides whether a request for `key` may proceed. `trial` is true for a trial
+// request of a half-open circuit. If the request is rejected, `retryAfter` is the time
+// until the circuit may let requests through again.
+func (b *Breaker) allow(key string) (allowed, trial bool, retryAfter time.Duration, transition *circuitTransition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.pruneLocked(now)
+	cb := b.circuits[key]
+	if cb == nil || cb.state == CircuitClosed {
+		return true, false, 0, nil
+	}
+	cb.lastSeen = now
+
+	if cb.state == CircuitOpen {
+		elapsed := now.Sub(cb.openedAt)
+		if elapsed < b.config.OpenTimeout {
+			return false, false, b.config.OpenTimeout - elapsed, nil
+		}
+		cb.state = CircuitHalfOpen
+		cb.halfOpenInFlight, cb.halfOpenSucceeded = 0, 0
+		transition = &circuitTransition{key: key, from: CircuitOpen, to: CircuitHalfOpen}
+	}
+
+	// Half-open: let a limited number of trial requests through.
+	if cb.halfOpenInFlight+cb.halfOpenSucceeded >= b.config.HalfOpenRequests {
+		return false, false, time.Second, transition
+	}
+	cb.halfOpenInFlight++
+	return true, true, 0, transition
+}
+
+// record records the outcome of a request for `key` that `allow` let through.
+func (b *Breaker) record(key string, trial, failed bool) *circuitTransition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	cb := b.circuits[key]
+	if cb != nil {
+		cb.lastSeen = now
+	}
+
+	if trial {
+		if cb == nil || cb.state != CircuitHalfOpen {
+			return nil // Should not happen: only trial requests change a half-open circuit.
+		}
+		cb.halfOpenInFlight--
+		if failed {
+			cb.state, cb.openedAt = CircuitOpen, now
+			return &circuitTransition{key: key, from: CircuitHalfOpen, to: CircuitOpen}
+		}
+		cb.halfOpenSucceeded++
+		if cb.halfOpenSucceeded < b.config.HalfOpenRequests {
+			return nil
+		}
+		delete(b.circuits, key)
+		return &circuitTransition{key: key, from: CircuitHalfOpen, to: CircuitClosed}
+	}
+
+	if cb != nil && cb.state != CircuitClosed {
+		return nil // The circuit was opened by another request meanwhile.
+	}
+	if !failed {
+		if cb != nil && len(b.recentFailures(cb, now)) == 0 {
+			delete(b.circuits, key) // Forget circuits without recent failures.
+		}
+		return nil
+	}
+
+	if cb == nil {
+		cb = &circuit{state: CircuitClosed, lastSeen: now}
+		b.circuits[key] = cb
+	}
+	cb.failures = append(b.recentFailures(cb, now), now)
+	if len(cb.failures) < b.config.FailureThreshold {
+		return nil
+	}
+	cb.state, cb.openedAt, cb.failures = CircuitOpen, now, nil
+	return &circuitTransition{key: key, from: CircuitClosed, to: CircuitOpen}
+}
+
+// pruneLocked forgets stale circuits: closed circuits without recent failures, and
+// open or half-open circuits without trial requests in flight that have seen no
+// requests for `OpenTimeout` plus `Window`. It scans the circuits at most once per
+// `Window`. b.mu must be held.
+func (b *Breaker) pruneLocked(now time.Time) {
+	if now.Sub(b.lastPrune) < b.config.Window {
+		return
+	}
+	b.lastPrune = now
+	idleLimit := b.config.OpenTimeout + b.config.Window
+	for key, cb := range b.circuits {
+		switch {
+		case cb.state == CircuitClosed:
+			if len(b.recentFailures(cb, now)) == 0 {
+				delete(b.circuits, key)
+			}
+		case cb.halfOpenInFlight == 0 && now.Sub(cb.lastSeen) >= idleLimit:
+			delete(b.circuits, key)
+		}
+	}
+}
+
+// recentFailures drops the failures of `cb` older than `Window` and returns the rest.
+func (b *Breaker) recentFailures(cb *circuit, now time.Time) []time.Time {
+	i := 0
+	for i < len(cb.failures) && now.Sub(cb.failures[i]) >= b.config.Window {
+		i++
+	}
+	cb.failures = cb.failures[i:]
+	return cb.failures
+}
+
+// notify reports `transition`, if any, to `OnStateChange`.
+func (b *Breaker) notify(transition *circuitTransition) {
+	if transition != nil && b.config.OnStateChange != nil {
+		b.config.OnStateChange(transition.key, transition.from, transition.to)
+	}
+}
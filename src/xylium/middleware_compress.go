@@ -3,8 +3,12 @@
 package xylium
 
 import (
-	"strconv" // Untuk mengonversi Content-Length int ke string.
-	"strings" // Untuk manipulasi string (parsing tipe konten).
+	"bytes"         // Untuk buffer keluaran kompresi stream.
+	"compress/gzip" // Untuk mengompresi body stream secara bertahap.
+	"io"            // Untuk membungkus body stream.
+	"strconv"       // Untuk mengonversi Content-Length int ke string.
+	"strings"       // Untuk manipulasi string (parsing tipe konten).
+	"sync/atomic"   // Untuk menandai stream yang dikompresi dari goroutine lain (misalnya, Timeout).
 
 	"github.com/valyala/fasthttp" // Digunakan secara internal untuk operasi kompresi dan konstanta HTTP.
 )
@@ -39,6 +43,27 @@ type GzipConfig struct {
 	//
 	// Contoh: `[]string{"application/json", "text/html; charset=utf-8"}`
 	ContentTypes []string
+
+	// SkipContentTypes adalah daftar tipe MIME yang tidak pernah dikompresi, karena
+	// umumnya sudah terkompresi (gambar, video, audio, arsip) sehingga kompresi ulang
+	// hanya membuang CPU. Daftar ini didahulukan atas `ContentTypes`. Entri berbentuk
+	// "tipe/*" (misalnya, "video/*") mencocokkan semua subtipe.
+	//
+	// Default: `defaultGzipSkipContentTypes` jika nil. Setel ke slice kosong
+	// (`[]string{}`) untuk tidak melewati tipe apa pun.
+	SkipContentTypes []string
+
+	// StreamSkipContentTypes adalah daftar tipe MIME tambahan yang tidak dikompresi
+	// jika body dikirim sebagai stream (`c.Stream`, `c.SetBodyStreamWriter`), misalnya
+	// "text/event-stream" jika proxy di depan aplikasi menahan SSE terkompresi. Respons
+	// biasa dengan tipe yang sama tetap dikompresi. Entri "tipe/*" didukung.
+	//
+	// Default: nil (stream dikompresi dengan aturan yang sama seperti respons biasa).
+	StreamSkipContentTypes []string
+
+	// Skip, jika disetel, dipanggil untuk setiap request; mengembalikan true akan
+	// melewati kompresi untuk request tersebut (handler tetap dijalankan).
+	Skip func(c *Context) bool
 }
 
 // defaultCompressContentTypes adalah daftar tipe MIME umum yang biasanya
@@ -48,6 +73,17 @@ var defaultCompressContentTypes = []string{
 	"text/plain", "text/html", "text/css", "text/xml", "text/javascript",
 	"application/json", "application/xml", "application/javascript", "application/x-javascript",
 	"application/rss+xml", "application/atom+xml", "image/svg+xml",
+	"text/event-stream", "application/x-ndjson",
+}
+
+// defaultGzipSkipContentTypes adalah daftar tipe MIME yang sudah terkompresi dan
+// dilewati secara default oleh middleware Gzip (lihat `GzipConfig.SkipContentTypes`).
+var defaultGzipSkipContentTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp", "image/avif", "image/heic",
+	"video/*", "audio/*", "font/woff", "font/woff2",
+	"application/zip", "application/gzip", "application/x-gzip", "application/x-bzip2",
+	"application/x-xz", "application/x-7z-compressed", "application/x-rar-compressed",
+	"application/zstd", "application/pdf",
 }
 
 // Gzip mengembalikan middleware kompresi Gzip dengan konfigurasi default.
@@ -59,6 +95,19 @@ var defaultCompressContentTypes = []string{
 //  3. Mengompres body respons.
 //  4. Menyetel header "Content-Encoding: gzip" dan "Vary: Accept-Encoding".
 //  5. Memperbarui header "Content-Length" dengan ukuran body yang terkompresi.
+//
+// "Vary: Accept-Encoding" juga disetel pada respons yang memenuhi syarat tetapi tidak
+// dikompresi karena klien tidak menerima gzip, agar cache tidak menyajikan versi yang
+// salah. Respons yang sudah memiliki "Content-Encoding" dan respons error tidak pernah
+// dikompresi.
+//
+// Body stream yang disetel melalui Context (`c.Stream`, `c.SetBodyStreamWriter`,
+// misalnya untuk SSE atau NDJSON) dikompresi secara bertahap tanpa di-buffer: setiap
+// potongan yang ditulis handler langsung di-flush ke klien dalam bentuk terkompresi,
+// sehingga event tetap tiba tepat waktu. Stream dengan ukuran diketahui yang lebih kecil
+// dari `MinLength`, respons range (206) dan stream yang disetel langsung pada `c.Ctx`
+// tidak dikompresi. Gunakan `GzipConfig.StreamSkipContentTypes` untuk mengecualikan
+// tipe tertentu khusus untuk stream.
 func Gzip() Middleware {
 	return GzipWithConfig(GzipConfig{}) // Melewatkan struct kosong untuk menggunakan default di GzipWithConfig
 }
@@ -87,20 +136,89 @@ func GzipWithConfig(config GzipConfig) Middleware {
 		}
 	}
 
+	// Siapkan daftar tipe konten yang dilewati (mendukung wildcard "tipe/*").
+	skipTypesToUse := config.SkipContentTypes
+	if skipTypesToUse == nil {
+		skipTypesToUse = defaultGzipSkipContentTypes
+	}
+	skippedTypes := make(map[string]struct{})
+	var skippedTypePrefixes []string // Untuk entri wildcard, misalnya "video/".
+	for _, t := range skipTypesToUse {
+		normalizedType := strings.ToLower(strings.TrimSpace(strings.Split(t, ";")[0]))
+		if strings.HasSuffix(normalizedType, "/*") {
+			skippedTypePrefixes = append(skippedTypePrefixes, strings.TrimSuffix(normalizedType, "*"))
+		} else if normalizedType != "" {
+			skippedTypes[normalizedType] = struct{}{}
+		}
+	}
+	isSkippedType := func(normalizedContentType string) bool {
+		if _, ok := skippedTypes[normalizedContentType]; ok {
+			return true
+		}
+		for _, prefix := range skippedTypePrefixes {
+			if strings.HasPrefix(normalizedContentType, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	// Tipe yang dilewati khusus untuk body stream (lihat `GzipConfig.StreamSkipContentTypes`).
+	isStreamSkippedType := func(normalizedContentType string) bool {
+		for _, t := range config.StreamSkipContentTypes {
+			skipped := strings.ToLower(strings.TrimSpace(strings.Split(t, ";")[0]))
+			if skipped == normalizedContentType ||
+				(strings.HasSuffix(skipped, "/*") && strings.HasPrefix(normalizedContentType, strings.TrimSuffix(skipped, "*"))) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Fungsi middleware yang sebenarnya.
 	return func(next HandlerFunc) HandlerFunc {
 		return func(c *Context) error {
+			// Lewati seluruh logika kompresi jika fungsi Skip mengembalikan true.
+			if config.Skip != nil && config.Skip(c) {
+				return next(c)
+			}
+
 			// Dapatkan logger yang sudah request-scoped dari context Xylium.
 			// Middleware ini menambahkan field "middleware": "Gzip" untuk konteks logging tambahan.
 			logger := c.Logger().WithFields(M{"middleware": "Gzip"})
 
-			// 1. Periksa apakah klien mendukung encoding gzip.
+			// 1. Periksa apakah klien mendukung encoding gzip (dengan memperhatikan q-value,
+			// misalnya "gzip;q=0" berarti gzip ditolak). Keputusan akhir diambil setelah
+			// handler dijalankan, karena respons yang memenuhi syarat tetap memerlukan header Vary.
 			acceptEncoding := c.Header("Accept-Encoding")
-			if !strings.Contains(acceptEncoding, "gzip") {
-				logger.Debugf("Klien tidak menerima encoding gzip ('%s'). Melewati kompresi untuk %s %s.",
-					acceptEncoding, c.Method(), c.Path())
-				return next(c) // Lanjutkan ke handler berikutnya tanpa kompresi.
-			}
+			clientAcceptsGzip := acceptsGzipEncoding(acceptEncoding)
+
+			// Body stream tidak dapat dikompresi setelah handler selesai tanpa membacanya
+			// seluruhnya, jadi daftarkan filter yang membungkusnya saat disetel.
+			var streamCompressed atomic.Bool
+			c.addResponseStreamFilter(func(sc *Context, body io.Reader, size int) (io.Reader, int) {
+				resp := &sc.Ctx.Response
+				if size == 0 || (size > 0 && size < config.MinLength) ||
+					resp.StatusCode() >= StatusBadRequest || resp.StatusCode() == StatusPartialContent ||
+					len(resp.Header.Peek("Content-Encoding")) > 0 || len(resp.Header.Peek("Content-Range")) > 0 {
+					return body, size
+				}
+				normalizedContentType := strings.ToLower(strings.TrimSpace(strings.Split(string(resp.Header.ContentType()), ";")[0]))
+				if _, typeIsCompressible := compressibleTypes[normalizedContentType]; !typeIsCompressible ||
+					isSkippedType(normalizedContentType) || isStreamSkippedType(normalizedContentType) {
+					return body, size
+				}
+				addVaryHeader(sc, "Accept-Encoding")
+				if !clientAcceptsGzip {
+					return body, size
+				}
+				logger.Debugf("Mengompresi body stream untuk %s %s secara bertahap (Content-Type: %s, Level: %d).",
+					sc.Method(), sc.Path(), normalizedContentType, config.Level)
+				sc.SetHeader("Content-Encoding", "gzip")
+				// Range byte merujuk pada body asli, bukan body terkompresi.
+				resp.Header.Del("Accept-Ranges")
+				streamCompressed.Store(true)
+				return newGzipStreamReader(body, int(config.Level)), -1
+			})
 
 			// 2. Panggil handler berikutnya dalam chain untuk menyiapkan respons.
 			err := next(c)
@@ -108,6 +226,10 @@ func GzipWithConfig(config GzipConfig) Middleware {
 				// Jika ada error dari handler/middleware berikutnya, jangan lakukan kompresi.
 				// Biarkan GlobalErrorHandler yang menangani error ini.
 				logger.Debugf("Error terjadi dalam chain handler. Melewati kompresi untuk %s %s.", c.Method(), c.Path())
+				if streamCompressed.Load() {
+					// Respons error akan menggantikan stream terkompresi.
+					c.Ctx.Response.Header.Del("Content-Encoding")
+				}
 				return err
 			}
 
@@ -124,6 +246,12 @@ func GzipWithConfig(config GzipConfig) Middleware {
 					string(c.Ctx.Response.Header.Peek("Content-Encoding")), c.Method(), c.Path())
 				return nil
 			}
+			// Body stream: Sudah ditangani oleh filter stream di atas (jika disetel melalui
+			// Context); membaca body akan menghabiskan stream, jadi biarkan apa adanya.
+			if c.Ctx.Response.IsBodyStream() {
+				logger.Debugf("Body respons adalah stream. Melewati kompresi buffer untuk %s %s.", c.Method(), c.Path())
+				return nil
+			}
 
 			// Ambil body respons yang telah disiapkan oleh handler.
 			responseBody := c.Ctx.Response.Body()
@@ -140,15 +268,30 @@ func GzipWithConfig(config GzipConfig) Middleware {
 				return nil
 			}
 
-			// Tipe konten: Periksa apakah tipe konten respons ada dalam daftar yang dapat dikompresi.
+			// Tipe konten: Periksa apakah tipe konten respons ada dalam daftar yang dapat dikompresi
+			// dan tidak ada dalam daftar tipe yang dilewati (misalnya, gambar yang sudah terkompresi).
 			contentType := string(c.Ctx.Response.Header.ContentType())
-			normalizedContentType := strings.ToLower(strings.Split(contentType, ";")[0])
+			normalizedContentType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+			if isSkippedType(normalizedContentType) {
+				logger.Debugf("Content-Type '%s' ada dalam daftar tipe yang dilewati. Melewati kompresi untuk %s %s.",
+					contentType, c.Method(), c.Path())
+				return nil
+			}
 			if _, typeIsCompressible := compressibleTypes[normalizedContentType]; !typeIsCompressible {
 				logger.Debugf("Content-Type '%s' (dinormalisasi: '%s') tidak ada dalam daftar tipe yang dapat dikompresi. Melewati kompresi untuk %s %s.",
 					contentType, normalizedContentType, c.Method(), c.Path())
 				return nil
 			}
 
+			// Respons ini memenuhi syarat, jadi isinya bergantung pada Accept-Encoding klien.
+			// Tambahkan header Vary (sekali saja). Penting untuk caching.
+			addVaryHeader(c, "Accept-Encoding")
+			if !clientAcceptsGzip {
+				logger.Debugf("Klien tidak menerima encoding gzip ('%s'). Melewati kompresi untuk %s %s.",
+					acceptEncoding, c.Method(), c.Path())
+				return nil
+			}
+
 			// 4. Lakukan kompresi Gzip.
 			logger.Debugf("Mengompresi respons untuk %s %s (Content-Type: %s, Ukuran Asli: %d byte, Level: %d).",
 				c.Method(), c.Path(), contentType, len(responseBody), config.Level)
@@ -160,7 +303,6 @@ func GzipWithConfig(config GzipConfig) Middleware {
 			c.Ctx.Response.SetBodyRaw(compressedBody)                        // Setel body yang sudah dikompresi.
 			c.SetHeader("Content-Encoding", "gzip")                          // Tambahkan header Content-Encoding.
 			c.SetHeader("Content-Length", strconv.Itoa(len(compressedBody))) // Update Content-Length.
-			c.Ctx.Response.Header.Add("Vary", "Accept-Encoding")             // Tambahkan header Vary. Penting untuk caching.
 
 			logger.Debugf("Kompresi berhasil untuk %s %s. Ukuran baru: %d byte.",
 				c.Method(), c.Path(), len(compressedBody))
@@ -169,3 +311,86 @@ func GzipWithConfig(config GzipConfig) Middleware {
 		}
 	}
 }
+
+// acceptsGzipEncoding melaporkan apakah nilai header "Accept-Encoding" menerima gzip
+// (RFC 9110, bagian 12.5.3): "gzip" atau "x-gzip" dengan q-value > 0, atau "*" dengan
+// q-value > 0 jika gzip tidak disebutkan secara eksplisit.
+func acceptsGzipEncoding(acceptEncoding string) bool {
+	wildcardAccepted := false
+	for _, item := range parseQualityList(acceptEncoding) {
+		switch strings.ToLower(item.value) {
+		case "*":
+			wildcardAccepted = item.q > 0
+		case "gzip", "x-gzip":
+			return item.q > 0 // Gzip disebutkan secara eksplisit: q-value-nya yang menentukan.
+		}
+	}
+	return wildcardAccepted
+}
+
+// addVaryHeader menambahkan `field` ke header "Vary" respons jika belum tercantum.
+func addVaryHeader(c *Context, field string) {
+	for _, value := range c.Ctx.Response.Header.PeekAll("Vary") {
+		for _, existing := range strings.Split(string(value), ",") {
+			existing = strings.TrimSpace(existing)
+			if existing == "*" || strings.EqualFold(existing, field) {
+				return
+			}
+		}
+	}
+	c.Ctx.Response.Header.Add("Vary", field)
+}
+
+// gzipStreamReader mengompresi body stream secara bertahap. Setiap potongan yang dibaca
+// dari sumber langsung di-flush, sehingga data (misalnya, event SSE) dapat dikirim ke
+// klien tanpa menunggu stream selesai.
+type gzipStreamReader struct {
+	src  io.Reader
+	gz   *gzip.Writer
+	out  bytes.Buffer // Data terkompresi yang belum dibaca.
+	buf  []byte
+	done bool
+}
+
+// newGzipStreamReader mengembalikan reader yang menghasilkan `src` dalam bentuk terkompresi gzip.
+func newGzipStreamReader(src io.Reader, level int) *gzipStreamReader {
+	r := &gzipStreamReader{src: src, buf: make([]byte, 32*1024)}
+	gz, err := gzip.NewWriterLevel(&r.out, level)
+	if err != nil { // Level tidak valid: gunakan level default.
+		gz = gzip.NewWriter(&r.out)
+	}
+	r.gz = gz
+	return r
+}
+
+// Read mengembalikan data terkompresi, membaca sumber hanya jika buffer keluaran kosong.
+func (r *gzipStreamReader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := r.src.Read(r.buf)
+		if n > 0 {
+			if _, werr := r.gz.Write(r.buf[:n]); werr != nil {
+				return 0, werr
+			}
+			if ferr := r.gz.Flush(); ferr != nil {
+				return 0, ferr
+			}
+		}
+		if err == io.EOF {
+			if cerr := r.gz.Close(); cerr != nil {
+				return 0, cerr
+			}
+			r.done = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	return r.out.Read(p)
+}
+
+// Close menutup reader sumber, misalnya agar goroutine `SetBodyStreamWriter` berhenti.
+func (r *gzipStreamReader) Close() error {
+	return closeReader(r.src)
+}
@@ -19,6 +19,14 @@ type CORSConfig struct {
 	// Default (from DefaultCORSConfig): `[]string{}` (empty slice, more secure).
 	AllowOrigins []string
 
+	// AllowOriginFunc, if set, decides whether a request's origin is allowed, taking
+	// precedence over `AllowOrigins` (which is then ignored). Use it for origins that
+	// cannot be listed statically, e.g., per-tenant subdomains validated against a database.
+	// An allowed origin is always reflected in 'Access-Control-Allow-Origin' (never "*"),
+	// so it is safe to combine with `AllowCredentials`. It is called for every CORS request
+	// and must be safe for concurrent use.
+	AllowOriginFunc func(origin string) bool
+
 	// AllowMethods specifies a list of HTTP methods (e.g., "GET", "POST") that are allowed
 	// when accessing the resource from a different origin.
 	AllowMethods []string
@@ -42,6 +50,17 @@ type CORSConfig struct {
 	// can be cached by the browser. A value of 0 means no caching.
 	// Default: 0.
 	MaxAge int
+
+	// PreflightContinue, if true, passes preflight requests on to the next handler after
+	// setting the CORS headers, instead of answering them directly. Use it when a route
+	// needs custom preflight handling (e.g., its own OPTIONS handler). The next handlers
+	// are then responsible for sending the response.
+	//
+	// By default (false), every preflight request (an OPTIONS request carrying an
+	// 'Access-Control-Request-Method' header) is answered with 204 No Content and never
+	// reaches downstream middleware such as authentication or rate limiting, even when
+	// its origin is not allowed (the response then simply lacks the CORS headers).
+	PreflightContinue bool
 }
 
 // DefaultCORSConfig provides a common default configuration for CORS.
@@ -86,6 +105,8 @@ func CORSWithConfig(config CORSConfig) Middleware {
 		return func(c *Context) error {
 			logger := c.Logger()
 			requestOrigin := c.Header("Origin")
+			// A preflight is an OPTIONS request announcing the method of the actual request.
+			isPreflight := c.Method() == MethodOptions && c.Header("Access-Control-Request-Method") != ""
 
 			if requestOrigin == "" {
 				logger.Debugf("CORS: No 'Origin' header found. Not a CORS request, skipping for %s %s.", c.Method(), c.Path())
@@ -93,11 +114,14 @@ func CORSWithConfig(config CORSConfig) Middleware {
 			}
 
 			// Handle empty AllowOrigins: If no origins are configured, deny by not setting ACAO.
-			if len(config.AllowOrigins) == 0 {
+			if len(config.AllowOrigins) == 0 && config.AllowOriginFunc == nil {
 				logger.Warnf("CORS: No 'AllowOrigins' configured. Denying cross-origin request from '%s' for %s %s by not setting ACAO header. Please configure allowed origins.",
 					requestOrigin, c.Method(), c.Path())
 				c.SetHeader("Vary", "Origin") // Still good practice.
-				return next(c)                // Proceed, but browser will block due to missing ACAO.
+				if isPreflight && !config.PreflightContinue {
+					return c.NoContent(StatusNoContent) // Browser will block due to missing ACAO.
+				}
+				return next(c) // Proceed, but browser will block due to missing ACAO.
 			}
 
 			logger.Debugf("CORS: Processing request from Origin '%s' for %s %s.", requestOrigin, c.Method(), c.Path())
@@ -111,7 +135,12 @@ func CORSWithConfig(config CORSConfig) Middleware {
 				}
 			}
 
-			if isWildcardConfigured {
+			if config.AllowOriginFunc != nil {
+				if config.AllowOriginFunc(requestOrigin) {
+					allowedOriginValue = requestOrigin // Always reflect the origin, never '*'.
+					logger.Debugf("CORS: Origin '%s' allowed by AllowOriginFunc. Setting ACAO to '%s'.", requestOrigin, allowedOriginValue)
+				}
+			} else if isWildcardConfigured {
 				if !config.AllowCredentials {
 					allowedOriginValue = "*"
 					logger.Debugf("CORS: Wildcard origin '*' configured and credentials NOT required. Setting ACAO to '*'.")
@@ -139,11 +168,14 @@ func CORSWithConfig(config CORSConfig) Middleware {
 				logger.Warnf("CORS: Origin '%s' is not in the allowed list (%v) or incompatible with AllowCredentials. Denying CORS request for %s %s by not setting ACAO header.",
 					requestOrigin, config.AllowOrigins, c.Method(), c.Path())
 				c.SetHeader("Vary", "Origin")
+				if isPreflight && !config.PreflightContinue {
+					return c.NoContent(StatusNoContent)
+				}
 				return next(c)
 			}
 
 			// Handle Preflight (OPTIONS) Requests
-			if c.Method() == MethodOptions {
+			if isPreflight {
 				logger.Debugf("CORS: Handling preflight (OPTIONS) request for Origin '%s', Path %s.", requestOrigin, c.Path())
 				c.SetHeader("Access-Control-Allow-Origin", allowedOriginValue)
 				c.SetHeader("Vary", "Origin")
@@ -166,6 +198,9 @@ func CORSWithConfig(config CORSConfig) Middleware {
 					c.SetHeader("Access-Control-Max-Age", maxAgeStr)
 					logger.Debugf("CORS: Preflight: Setting ACMA (Max-Age) to '%s' seconds.", maxAgeStr)
 				}
+				if config.PreflightContinue {
+					return next(c)
+				}
 				return c.NoContent(StatusNoContent)
 			}
 
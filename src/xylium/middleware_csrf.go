@@ -4,6 +4,7 @@ import (
 	"crypto/rand"     // For cryptographically secure random number generation for tokens.
 	"crypto/subtle"   // For constant-time string comparison to prevent timing attacks.
 	"encoding/base64" // For encoding random bytes into a string token.
+	"encoding/json"   // For extracting the token from a JSON request body field.
 	"errors"          // For defining standard error types like ErrorCSRFTokenInvalid.
 	"fmt"             // For formatting error messages and panic messages.
 	"reflect"         // Added for reflect.DeepEqual (or other reflection needs if any)
@@ -26,8 +27,9 @@ import (
 //  4. The middleware then validates that the token from the request data matches the
 //     token found in the request's CSRF cookie.
 //
-// A new token is typically generated and set in the response cookie for each request
-// (rolling token) to enhance security.
+// By default, a new token is generated and set in the response cookie for each request
+// (rolling token) to enhance security. Set `PerSessionToken` to keep one token per
+// session instead, and rotate it explicitly with `RotateCSRFToken`.
 type CSRFConfig struct {
 	// TokenLength specifies the length, in bytes, of the random data used to generate
 	// the CSRF token before Base64 encoding. A longer length increases entropy.
@@ -97,7 +99,10 @@ type CSRFConfig struct {
 	// TokenLookup specifies a comma-separated string defining where and in what order
 	// to look for the submitted CSRF token in the incoming request.
 	// Each part is "source:name", e.g., "header:X-CSRF-Token,form:_csrf,query:csrf_value".
-	// Supported sources: "header", "form", "query".
+	// Sources are tried in the listed order and the first non-empty token is used.
+	// Supported sources: "header", "form", "query", "json". The "json" source reads a
+	// top-level string field of a JSON request body (Content-Type "application/json"),
+	// e.g., "json:_csrf"; a body that is not a JSON object yields no token.
 	// If `Extractor` is set, `TokenLookup` is ignored.
 	// If both `Extractor` and `TokenLookup` are empty, it defaults to looking in
 	// the header specified by `HeaderName`, then the form field by `FormFieldName`.
@@ -117,6 +122,15 @@ type CSRFConfig struct {
 	// or provide it to client-side JavaScript.
 	// Default: `xylium.ContextKeyCSRFToken` (value: "csrf_token") (from `DefaultCSRFConfig`).
 	ContextTokenKey string
+
+	// PerSessionToken, if true, keeps the token from the request's CSRF cookie instead of
+	// generating a new one for every request, so a token stays valid for the lifetime of
+	// the cookie. This suits SPAs that issue concurrent requests, which a rolling token
+	// would invalidate. A new token is generated only if the cookie is missing or does
+	// not hold a well-formed token of `TokenLength` bytes.
+	// Rotate the token at privilege changes (e.g., after login) with `RotateCSRFToken`.
+	// Default: false (a new token for every request).
+	PerSessionToken bool
 }
 
 // ErrorCSRFTokenInvalid is a standard error returned or used as a cause when
@@ -130,6 +144,29 @@ var ErrorCSRFTokenInvalid = errors.New("xylium: invalid, missing, or mismatched
 // The custom error handler can retrieve this using `c.Get(ConfiguredCSRFErrorHandlerErrorKey)`.
 const ConfiguredCSRFErrorHandlerErrorKey = "xylium_csrf_validation_cause_error"
 
+// csrfRotatorContextKey is the `c.store` key under which the CSRF middleware stores the
+// function used by `RotateCSRFToken`.
+const csrfRotatorContextKey = "xylium_csrf_rotator"
+
+// RotateCSRFToken replaces the CSRF token of the current request with a new one: the
+// response cookie and the value under `CSRFConfig.ContextTokenKey` are updated, and the
+// new token is returned. Call it at privilege changes, such as after a successful login,
+// especially with `CSRFConfig.PerSessionToken`. The token of the current request has
+// already been validated by then; the client must use the new token from the next request on.
+//
+// It returns an error if the CSRF middleware did not run for this request.
+func RotateCSRFToken(c *Context) (string, error) {
+	rotator, ok := c.Get(csrfRotatorContextKey)
+	if !ok {
+		return "", errors.New("xylium: RotateCSRFToken requires the CSRF middleware")
+	}
+	rotate, ok := rotator.(func(c *Context) (string, error))
+	if !ok {
+		return "", errors.New("xylium: invalid CSRF rotator in context store")
+	}
+	return rotate(c)
+}
+
 // DefaultCSRFConfig provides a `CSRFConfig` instance initialized with sensible default values.
 // These defaults aim for a good balance of security and usability.
 //
@@ -218,7 +255,7 @@ var GenerateRandomStringBase64 = func(lengthInBytes int) (string, error) {
 //
 // Panics:
 //   - If `TokenLookup` is malformed (e.g., "header:", "form:name1,badsyntax").
-//   - If `TokenLookup` sources are unsupported (valid: "header", "form", "query").
+//   - If `TokenLookup` sources are unsupported (valid: "header", "form", "query", "json").
 //   - If, after resolving `Extractor` and `TokenLookup`, no token extraction methods are defined.
 func CSRFWithConfig(config CSRFConfig) Middleware {
 	// --- Normalize Configuration: Apply defaults if fields are not set ---
@@ -305,8 +342,10 @@ func CSRFWithConfig(config CSRFConfig) Middleware {
 				tokenExtractors = append(tokenExtractors, func(c *Context) (string, error) { return c.FormValue(name), nil })
 			case "query":
 				tokenExtractors = append(tokenExtractors, func(c *Context) (string, error) { return c.QueryParam(name), nil })
+			case "json":
+				tokenExtractors = append(tokenExtractors, func(c *Context) (string, error) { return csrfTokenFromJSONBody(c, name), nil })
 			default:
-				panic(fmt.Errorf("xylium: unsupported CSRF TokenLookup source: '%s'. Supported sources are 'header', 'form', 'query'.", source))
+				panic(fmt.Errorf("xylium: unsupported CSRF TokenLookup source: '%s'. Supported sources are 'header', 'form', 'query', 'json'.", source))
 			}
 		}
 	}
@@ -326,46 +365,71 @@ func CSRFWithConfig(config CSRFConfig) Middleware {
 		safeMethodsMap[strings.ToUpper(method)] = struct{}{}
 	}
 
+	// setResponseToken sets `token` in the response cookie and the context store.
+	setResponseToken := func(c *Context, token string) {
+		responseCookie := fasthttp.AcquireCookie()
+		responseCookie.SetKey(config.CookieName)
+		responseCookie.SetValue(token)
+		responseCookie.SetPath(config.CookiePath)
+		responseCookie.SetDomain(config.CookieDomain)
+
+		if config.CookieMaxAge > 0 {
+			responseCookie.SetMaxAge(int(config.CookieMaxAge.Seconds()))
+		} else if config.CookieMaxAge < 0 {
+			responseCookie.SetMaxAge(-1)
+		} else {
+			responseCookie.SetMaxAge(0)
+		}
+
+		responseCookie.SetSecure(*config.CookieSecure)
+		responseCookie.SetHTTPOnly(*config.CookieHTTPOnly)
+		responseCookie.SetSameSite(config.CookieSameSite)
+
+		c.SetCookie(responseCookie)
+		fasthttp.ReleaseCookie(responseCookie)
+
+		c.Set(config.ContextTokenKey, token)
+	}
+
+	rotateToken := func(c *Context) (string, error) {
+		token, err := GenerateRandomStringBase64(config.TokenLength)
+		if err != nil {
+			return "", err
+		}
+		setResponseToken(c, token)
+		return token, nil
+	}
+
 	return func(next HandlerFunc) HandlerFunc {
 		return func(c *Context) error {
 			logger := c.Logger().WithFields(M{"middleware": "CSRF"})
 
-			tokenForResponseCookie, errGen := GenerateRandomStringBase64(config.TokenLength)
-			if errGen != nil {
-				logger.Errorf("Failed to generate new CSRF security token for response: %v", errGen)
-				c.Set(ConfiguredCSRFErrorHandlerErrorKey, errGen)
-				return errorHandler(c, NewHTTPError(StatusInternalServerError, "Could not generate security token for CSRF protection.").WithInternal(errGen))
+			tokenForResponseCookie := ""
+			if config.PerSessionToken {
+				existingToken := string(c.Ctx.Request.Header.Cookie(config.CookieName))
+				if isWellFormedCSRFToken(existingToken, config.TokenLength) {
+					tokenForResponseCookie = existingToken
+				}
 			}
-
-			responseCookie := fasthttp.AcquireCookie()
-			responseCookie.SetKey(config.CookieName)
-			responseCookie.SetValue(tokenForResponseCookie)
-			responseCookie.SetPath(config.CookiePath)
-			responseCookie.SetDomain(config.CookieDomain)
-
-			if config.CookieMaxAge > 0 {
-				responseCookie.SetMaxAge(int(config.CookieMaxAge.Seconds()))
-			} else if config.CookieMaxAge < 0 {
-				responseCookie.SetMaxAge(-1)
-			} else {
-				responseCookie.SetMaxAge(0)
+			if tokenForResponseCookie == "" {
+				var errGen error
+				tokenForResponseCookie, errGen = GenerateRandomStringBase64(config.TokenLength)
+				if errGen != nil {
+					logger.Errorf("Failed to generate new CSRF security token for response: %v", errGen)
+					c.Set(ConfiguredCSRFErrorHandlerErrorKey, errGen)
+					return errorHandler(c, NewHTTPError(StatusInternalServerError, "Could not generate security token for CSRF protection.").WithInternal(errGen))
+				}
 			}
 
-			responseCookie.SetSecure(*config.CookieSecure)
-			responseCookie.SetHTTPOnly(*config.CookieHTTPOnly)
-			responseCookie.SetSameSite(config.CookieSameSite)
-
-			c.SetCookie(responseCookie)
-			fasthttp.ReleaseCookie(responseCookie)
-
-			c.Set(config.ContextTokenKey, tokenForResponseCookie)
+			setResponseToken(c, tokenForResponseCookie)
+			c.Set(csrfRotatorContextKey, rotateToken)
 
 			if c.RouterMode() == DebugMode {
 				tokenSuffix := ""
 				if len(tokenForResponseCookie) > 4 {
 					tokenSuffix = tokenForResponseCookie[len(tokenForResponseCookie)-4:]
 				}
-				logger.Debugf("CSRF: New token for next request (ends with ...%s) set in context key '%s' and response cookie '%s'. Request: %s %s",
+				logger.Debugf("CSRF: Token for next request (ends with ...%s) set in context key '%s' and response cookie '%s'. Request: %s %s",
 					tokenSuffix, config.ContextTokenKey, config.CookieName, c.Method(), c.Path())
 			}
 
@@ -438,3 +502,30 @@ func CSRFWithConfig(config CSRFConfig) Middleware {
 		}
 	}
 }
+
+// isWellFormedCSRFToken reports whether `token` is a Base64 (raw URL) encoding of exactly
+// `lengthInBytes` bytes, as produced by `GenerateRandomStringBase64`.
+func isWellFormedCSRFToken(token string, lengthInBytes int) bool {
+	if token == "" || len(token) != base64.RawURLEncoding.EncodedLen(lengthInBytes) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil
+}
+
+// csrfTokenFromJSONBody returns the top-level string field `name` of a JSON request body,
+// or "" if the request is not JSON or the field is missing or not a string.
+func csrfTokenFromJSONBody(c *Context, name string) string {
+	if !strings.HasPrefix(c.ContentType(), "application/json") {
+		return ""
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(c.Body(), &fields); err != nil {
+		return ""
+	}
+	var token string
+	if err := json.Unmarshal(fields[name], &token); err != nil {
+		return ""
+	}
+	return token
+}
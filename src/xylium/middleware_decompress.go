@@ -0,0 +1,155 @@
+// src/xylium/middleware_decompress.go
+package xylium
+
+import (
+	"bufio"          // For peeking at the zlib header of deflate bodies.
+	"compress/flate" // For raw deflate bodies sent by non-conforming clients.
+	"compress/gzip"  // For "gzip" request bodies.
+	"compress/zlib"  // For "deflate" request bodies (zlib-wrapped, per RFC 9110).
+	"errors"         // For the size limit error.
+	"fmt"            // For error messages.
+	"io"             // For chaining decoders and limiting reads.
+	"strings"        // For parsing the Content-Encoding header.
+
+	"github.com/andybalholm/brotli" // For "br" request bodies.
+)
+
+// DecompressConfig defines the configuration for the Decompress middleware.
+type DecompressConfig struct {
+	// MaxDecompressedSize is the maximum size of the request body after decompression,
+	// in bytes. It protects against "zip bombs", where a tiny compressed body expands
+	// to gigabytes. Requests exceeding it are rejected with 413 Request Entity Too Large.
+	// Default: 4 MiB (`DefaultDecompressConfig.MaxDecompressedSize`), matching fasthttp's
+	// default maximum request body size.
+	MaxDecompressedSize int64
+
+	// Skip, if set, is called for each request; returning true leaves the body untouched.
+	Skip func(c *Context) bool
+}
+
+// DefaultDecompressConfig provides the default values for DecompressConfig.
+var DefaultDecompressConfig = DecompressConfig{
+	MaxDecompressedSize: 4 * 1024 * 1024,
+}
+
+// errDecompressedBodyTooLarge is returned when a body expands beyond MaxDecompressedSize.
+var errDecompressedBodyTooLarge = errors.New("decompressed request body exceeds limit")
+
+// Decompress returns a middleware that transparently decompresses request bodies sent
+// with `Content-Encoding: gzip`, `deflate` or `br`, using the default configuration.
+func Decompress() Middleware {
+	return DecompressWithConfig(DefaultDecompressConfig)
+}
+
+// DecompressWithConfig returns a Decompress middleware with the provided custom configuration.
+//
+// The decompressed body replaces the request body, and the `Content-Encoding` header is
+// removed (with `Content-Length` updated), so `c.Body()` and the binder (`c.Bind`, etc.)
+// work unchanged. Multiple encodings (e.g., `Content-Encoding: gzip, br`) are decoded in
+// reverse order of application. Requests without `Content-Encoding` (or with `identity`)
+// pass through untouched.
+//
+// Errors are returned as `*HTTPError`:
+//   - 415 Unsupported Media Type for an unknown encoding.
+//   - 400 Bad Request for a malformed compressed body.
+//   - 413 Request Entity Too Large if the decompressed body exceeds `MaxDecompressedSize`.
+func DecompressWithConfig(config DecompressConfig) Middleware {
+	if config.MaxDecompressedSize <= 0 {
+		config.MaxDecompressedSize = DefaultDecompressConfig.MaxDecompressedSize
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if config.Skip != nil && config.Skip(c) {
+				return next(c)
+			}
+
+			encodings := parseContentEncodings(c.Header("Content-Encoding"))
+			if len(encodings) == 0 {
+				return next(c)
+			}
+			logger := c.Logger().WithFields(M{"middleware": "Decompress"})
+
+			body, err := decompressBody(c.BodyReader(), encodings, config.MaxDecompressedSize)
+			if err != nil {
+				var httpErr *HTTPError
+				switch {
+				case errors.As(err, &httpErr):
+					return httpErr
+				case errors.Is(err, errDecompressedBodyTooLarge):
+					logger.Warnf("Rejecting request %s %s: decompressed body exceeds %d bytes.", c.Method(), c.Path(), config.MaxDecompressedSize)
+					return NewHTTPError(StatusRequestEntityTooLarge,
+						fmt.Sprintf("Decompressed request body exceeds the maximum allowed size of %d bytes.", config.MaxDecompressedSize)).WithInternal(err)
+				default:
+					logger.Debugf("Failed to decompress request body for %s %s: %v", c.Method(), c.Path(), err)
+					return NewHTTPError(StatusBadRequest, "Malformed compressed request body.").WithInternal(err)
+				}
+			}
+
+			c.Ctx.Request.SetBody(body)
+			c.Ctx.Request.Header.Del("Content-Encoding")
+			c.Ctx.Request.Header.SetContentLength(len(body))
+			return next(c)
+		}
+	}
+}
+
+// parseContentEncodings returns the lowercased codings listed in a Content-Encoding
+// header, ignoring empty entries and "identity".
+func parseContentEncodings(header string) []string {
+	var encodings []string
+	for _, part := range strings.Split(header, ",") {
+		encoding := strings.ToLower(strings.TrimSpace(part))
+		if encoding != "" && encoding != "identity" {
+			encodings = append(encodings, encoding)
+		}
+	}
+	return encodings
+}
+
+// decompressBody decodes `src` through each of `encodings` (in reverse order) and
+// reads at most `limit` decompressed bytes.
+func decompressBody(src io.Reader, encodings []string, limit int64) ([]byte, error) {
+	reader := src
+	for i := len(encodings) - 1; i >= 0; i-- {
+		decoder, err := newBodyDecoder(encodings[i], reader)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		reader = decoder
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, errDecompressedBodyTooLarge
+	}
+	return body, nil
+}
+
+// newBodyDecoder returns a decompressing reader for a single content coding.
+func newBodyDecoder(encoding string, src io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(src)
+	case "deflate":
+		// "deflate" is zlib-wrapped per the spec, but some clients send raw deflate data.
+		buffered := bufio.NewReader(src)
+		header, err := buffered.Peek(2)
+		if err != nil {
+			return nil, err
+		}
+		if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	case "br":
+		return io.NopCloser(brotli.NewReader(src)), nil
+	default:
+		return nil, NewHTTPError(StatusUnsupportedMediaType,
+			fmt.Sprintf("Unsupported Content-Encoding '%s'.", encoding))
+	}
+}
@@ -0,0 +1,69 @@
+// src/xylium/middleware_defaultheaders.go
+package xylium
+
+import (
+	"fmt"      // For the panic message on unsupported headers.
+	"net/http" // For canonicalizing header names.
+)
+
+// defaultHeadersReserved lists headers that `DefaultHeaders` refuses to manage because
+// they describe the body framing and are set by the response helpers and fasthttp.
+var defaultHeadersReserved = map[string]bool{
+	"Content-Type":      true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+}
+
+// DefaultHeaders returns a middleware that adds the given headers to every response
+// unless the response already has them, e.g., shared "X-App-Version" or "Cache-Control"
+// defaults across services.
+//
+// The headers are applied after the next handler returns, so values set explicitly by
+// handlers or by other middleware (such as `SecureHeaders`) are never overridden. They
+// are also applied to bodiless responses like 204 and 304, which is why body framing
+// headers ("Content-Type", "Content-Length", "Transfer-Encoding", "Connection") cannot be
+// used as defaults. Responses to upgraded or hijacked connections (e.g., WebSocket) are
+// left untouched.
+//
+// Panics if `headers` contains an empty or reserved header name.
+//
+// Example:
+//
+//	app.Use(xylium.DefaultHeaders(map[string]string{
+//		"X-App-Version": "1.4.2",
+//		"Cache-Control": "no-store",
+//	}))
+func DefaultHeaders(headers map[string]string) Middleware {
+	// Copy the map with canonical names, so later changes by the caller have no effect.
+	defaults := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if name == "" {
+			panic("xylium: DefaultHeaders header name cannot be empty")
+		}
+		name = http.CanonicalHeaderKey(name)
+		if defaultHeadersReserved[name] {
+			panic(fmt.Sprintf("xylium: DefaultHeaders cannot set the '%s' header", name))
+		}
+		defaults[name] = value
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			err := next(c)
+			if !c.beginResponseWrite() { // The response was taken over (e.g., by Timeout).
+				return err
+			}
+			defer c.endResponseWrite()
+			if c.Ctx.Hijacked() || c.Ctx.Response.StatusCode() == StatusSwitchingProtocols {
+				return err
+			}
+			for name, value := range defaults {
+				if len(c.Ctx.Response.Header.Peek(name)) == 0 {
+					c.Ctx.Response.Header.Set(name, value)
+				}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// src/xylium/middleware_disconnect.go
+package xylium
+
+import (
+	"context" // For deriving a cancelable request context.
+	"errors"  // For ErrClientDisconnected.
+	"sync"    // For waiting on the watcher goroutine.
+	"time"    // For the polling interval.
+)
+
+// DefaultDisconnectPollInterval is the default interval at which `CancelOnDisconnect`
+// checks whether the client is still connected.
+const DefaultDisconnectPollInterval = 500 * time.Millisecond
+
+// ErrClientDisconnected is the cancellation cause of the request context when
+// `CancelOnDisconnect` detects that the client has gone away. Handlers can tell it apart
+// from a timeout with `context.Cause(c.Context())`.
+var ErrClientDisconnected = errors.New("xylium: client disconnected")
+
+// CancelOnDisconnectConfig defines the configuration for the CancelOnDisconnect middleware.
+type CancelOnDisconnectConfig struct {
+	// PollInterval is how often the connection is probed while the handler runs.
+	// Shorter intervals detect disconnects sooner at a small CPU cost per request.
+	// Defaults to `DefaultDisconnectPollInterval`.
+	PollInterval time.Duration
+}
+
+// CancelOnDisconnect returns a middleware that cancels the request context (`c.Context()`)
+// when the client closes the connection, using the default configuration.
+func CancelOnDisconnect() Middleware {
+	return CancelOnDisconnectWithConfig(CancelOnDisconnectConfig{})
+}
+
+// CancelOnDisconnectWithConfig returns a CancelOnDisconnect middleware with the provided
+// configuration.
+//
+// While the rest of the chain runs, the connection is probed every `PollInterval` (see
+// `c.IsClientDisconnected` for how, and for its caveats). Once the client is gone, the
+// request context is canceled with the cause `ErrClientDisconnected`, so
+// context-aware work (database queries, outgoing HTTP calls, `select` on
+// `c.Context().Done()`) stops early. The handler's error, if any, is returned unchanged;
+// any response written after the disconnect is discarded by the network layer.
+//
+// Probing stops when the handler returns. Streaming responses (e.g., Server-Sent Events
+// written via `c.Ctx.SetBodyStreamWriter`) run after that, so they detect disconnects
+// through the error returned when writing or flushing instead.
+func CancelOnDisconnectWithConfig(config CancelOnDisconnectConfig) Middleware {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultDisconnectPollInterval
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			conn := c.Ctx.Conn()
+			if conn == nil {
+				return next(c) // No network connection to watch (e.g., in tests).
+			}
+
+			ctx, cancel := context.WithCancelCause(c.GoContext())
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(config.PollInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if connPeerClosed(conn) {
+							cancel(ErrClientDisconnected)
+							return
+						}
+					}
+				}
+			}()
+
+			err := next(c.WithGoContext(ctx))
+
+			close(stop)
+			wg.Wait() // Never probe the connection after the handler returned.
+			cancel(nil)
+			return err
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// src/xylium/middleware_enforcecontenttype.go
+package xylium
+
+import (
+	"fmt"     // For error messages.
+	"strings" // For normalizing media types.
+)
+
+// EnforceContentTypeConfig defines the configuration for the EnforceContentType middleware.
+type EnforceContentTypeConfig struct {
+	// Types lists the accepted media types, e.g., "application/json". Matching ignores
+	// case and parameters such as "; charset=utf-8". A subtype wildcard ("text/*")
+	// accepts every subtype. Required.
+	Types []string
+
+	// Methods lists the HTTP methods whose requests are checked.
+	// Default: POST, PUT and PATCH (`DefaultEnforceContentTypeMethods`) if nil.
+	Methods []string
+
+	// RejectEmptyBody, if true, also requires an accepted `Content-Type` for requests of
+	// the checked methods that have no body. By default, a request with an empty body
+	// (`Content-Length: 0`) passes, since there is nothing to mis-parse.
+	RejectEmptyBody bool
+
+	// Skip, if set, is called for each request; returning true bypasses the check, e.g.,
+	// for a webhook route that receives a different format.
+	Skip func(c *Context) bool
+}
+
+// DefaultEnforceContentTypeMethods are the methods checked by EnforceContentType by default.
+var DefaultEnforceContentTypeMethods = []string{MethodPost, MethodPut, MethodPatch}
+
+// EnforceContentType returns a middleware that rejects POST, PUT and PATCH requests
+// whose `Content-Type` is missing or not one of `types`, with 415 Unsupported Media
+// Type, before the handler runs. It prevents silent binding bugs when clients send,
+// e.g., JSON without a `Content-Type`:
+//
+//	api.Use(xylium.EnforceContentType("application/json"))
+//
+// Requests with an empty body are allowed through. Panics if `types` is empty.
+func EnforceContentType(types ...string) Middleware {
+	return EnforceContentTypeWithConfig(EnforceContentTypeConfig{Types: types})
+}
+
+// EnforceContentTypeWithConfig returns an EnforceContentType middleware with the provided
+// custom configuration. Requests with a rejected `Content-Type` fail with a 415
+// `*HTTPError` whose message lists the accepted types.
+//
+// Panics if `config.Types` is empty or contains an empty type.
+func EnforceContentTypeWithConfig(config EnforceContentTypeConfig) Middleware {
+	if len(config.Types) == 0 {
+		panic("xylium: EnforceContentType requires at least one content type")
+	}
+	accepted := make([]string, len(config.Types))
+	for i, t := range config.Types {
+		accepted[i] = normalizeMediaType(t)
+		if accepted[i] == "" {
+			panic("xylium: EnforceContentType content type cannot be empty")
+		}
+	}
+	methods := config.Methods
+	if methods == nil {
+		methods = DefaultEnforceContentTypeMethods
+	}
+	checkedMethods := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		checkedMethods[strings.ToUpper(m)] = true
+	}
+	acceptedList := strings.Join(config.Types, ", ")
+
+	isAccepted := func(mediaType string) bool {
+		for _, a := range accepted {
+			if a == mediaType {
+				return true
+			}
+			if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if !checkedMethods[c.Method()] || (config.Skip != nil && config.Skip(c)) {
+				return next(c)
+			}
+			if !config.RejectEmptyBody && requestBodyIsEmpty(c) {
+				return next(c)
+			}
+
+			contentType := c.ContentType()
+			if contentType == "" {
+				return NewHTTPError(StatusUnsupportedMediaType,
+					fmt.Sprintf("Missing Content-Type header; expected one of: %s.", acceptedList))
+			}
+			if !isAccepted(normalizeMediaType(contentType)) {
+				return NewHTTPError(StatusUnsupportedMediaType,
+					fmt.Sprintf("Unsupported Content-Type %q; expected one of: %s.", contentType, acceptedList))
+			}
+			return next(c)
+		}
+	}
+}
+
+// normalizeMediaType returns the lowercased media type of a `Content-Type` value,
+// without parameters, e.g., "application/json" for "Application/JSON; charset=utf-8".
+func normalizeMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// requestBodyIsEmpty reports whether the request has no body, without reading a
+// streamed body.
+func requestBodyIsEmpty(c *Context) bool {
+	if c.Ctx.Request.Header.ContentLength() == 0 {
+		return true
+	}
+	return !c.Ctx.Request.IsBodyStream() && len(c.Ctx.Request.Body()) == 0
+}
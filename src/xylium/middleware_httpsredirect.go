@@ -0,0 +1,163 @@
+// src/xylium/middleware_httpsredirect.go
+package xylium
+
+import (
+	"fmt"     // For panic messages on invalid configuration.
+	"net"     // For parsing trusted proxy addresses and splitting host and port.
+	"strings" // For parsing the X-Forwarded-Proto header.
+)
+
+// HTTPSRedirectConfig defines the configuration for the HTTPSRedirect middleware.
+type HTTPSRedirectConfig struct {
+	// Reject, if true, answers plain HTTP requests with 400 Bad Request instead of
+	// redirecting them. Use it for APIs, whose clients do not follow redirects for
+	// non-GET requests and would otherwise have sent credentials over plain HTTP anyway.
+	// Default: false (redirect).
+	Reject bool
+
+	// Code is the status code of the redirect: `StatusMovedPermanently` (301) or
+	// `StatusPermanentRedirect` (308, which makes clients keep the method and body).
+	// The temporary redirects `StatusFound` (302), `StatusSeeOther` (303) and
+	// `StatusTemporaryRedirect` (307) are accepted too; other codes panic.
+	// Default: 301.
+	Code int
+
+	// Host, if set, replaces the request's host in the redirect URL (it may include a
+	// port, e.g., "example.com:8443"). By default the request's host is used, without
+	// its port, so "example.com:8080" redirects to "https://example.com".
+	Host string
+
+	// TrustedProxies lists the IP addresses or CIDR ranges (e.g., "10.0.0.0/8") of the
+	// TLS-terminating proxies in front of the server. The "X-Forwarded-Proto" header is
+	// only honored for requests whose connection comes from one of them; otherwise anyone
+	// could claim HTTPS by sending the header. Only its last value is used, which is the
+	// one written by the trusted proxy itself: proxies that append to the header keep
+	// whatever the client sent in front of it. If empty, only the connection itself
+	// (`c.IsTLS()`) decides the scheme.
+	TrustedProxies []string
+
+	// SkipPaths lists request paths that are never redirected or rejected, e.g., health
+	// checks probed over plain HTTP by a load balancer. Endpoints registered with
+	// `Liveness`, `Health` or `HealthWithConfig` are always skipped.
+	SkipPaths []string
+
+	// Skip, if set, is called for each request; returning true bypasses the middleware.
+	Skip func(c *Context) bool
+}
+
+// DefaultHTTPSRedirectConfig provides the default values for HTTPSRedirectConfig.
+var DefaultHTTPSRedirectConfig = HTTPSRedirectConfig{
+	Code: StatusMovedPermanently,
+}
+
+// HTTPSRedirect returns a middleware that enforces HTTPS: plain HTTP requests are
+// redirected to the same URL with the "https" scheme, or rejected with 400 Bad Request
+// if `config.Reject` is set. Requests are considered HTTPS if the connection uses TLS,
+// or if a trusted proxy (see `TrustedProxies`) reports "https" in "X-Forwarded-Proto".
+//
+// Register it as the first global middleware so nothing else runs for plain HTTP
+// requests, and combine it with `SecureHeaders`, whose "Strict-Transport-Security"
+// header makes browsers use HTTPS directly after their first visit:
+//
+//	app.Use(xylium.HTTPSRedirect(xylium.HTTPSRedirectConfig{TrustedProxies: []string{"10.0.0.0/8"}}))
+//	app.Use(xylium.SecureHeaders(xylium.SecureHeadersConfig{}))
+//
+// Note that `SecureHeaders` decides whether to send HSTS with `c.Scheme()`, which trusts
+// "X-Forwarded-Proto" from any client; that is harmless there, since HSTS over plain
+// HTTP is ignored by browsers.
+//
+// It panics if an entry of `TrustedProxies` is neither an IP address nor a CIDR range,
+// or if `Code` is not one of 301, 302, 303, 307 or 308.
+func HTTPSRedirect(config HTTPSRedirectConfig) Middleware {
+	if config.Code == 0 {
+		config.Code = DefaultHTTPSRedirectConfig.Code
+	}
+	switch config.Code {
+	case StatusMovedPermanently, StatusFound, StatusSeeOther, StatusTemporaryRedirect, StatusPermanentRedirect:
+	default:
+		panic(fmt.Sprintf("xylium: HTTPSRedirect requires a redirect code (301, 302, 303, 307 or 308), got %d", config.Code))
+	}
+	trustedNets := make([]*net.IPNet, 0, len(config.TrustedProxies))
+	for _, proxy := range config.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				panic(fmt.Sprintf("xylium: invalid HTTPSRedirect trusted proxy '%s'", proxy))
+			}
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			trustedNets = append(trustedNets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			panic(fmt.Sprintf("xylium: invalid HTTPSRedirect trusted proxy '%s': %v", proxy, err))
+		}
+		trustedNets = append(trustedNets, ipNet)
+	}
+	skipPaths := make(map[string]struct{}, len(config.SkipPaths))
+	for _, p := range config.SkipPaths {
+		skipPaths[p] = struct{}{}
+	}
+
+	isTrustedProxy := func(c *Context) bool {
+		ip := c.Ctx.RemoteIP()
+		for _, n := range trustedNets {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if c.IsTLS() {
+				return next(c)
+			}
+			if len(trustedNets) > 0 && isTrustedProxy(c) && strings.EqualFold(lastForwardedProto(c), "https") {
+				return next(c)
+			}
+			if _, skip := skipPaths[c.Path()]; skip {
+				return next(c)
+			}
+			if (c.router != nil && c.router.isHealthPath(c.Path())) || (config.Skip != nil && config.Skip(c)) {
+				return next(c)
+			}
+
+			if config.Reject {
+				c.Logger().WithFields(M{"middleware": "HTTPSRedirect"}).Debugf("Rejecting plain HTTP request %s %s.", c.Method(), c.Path())
+				return NewHTTPError(StatusBadRequest, "HTTPS is required.")
+			}
+
+			host := config.Host
+			if host == "" {
+				host = c.Host()
+				if h, _, err := net.SplitHostPort(host); err == nil {
+					host = h
+					if strings.Contains(host, ":") {
+						host = "[" + host + "]" // IPv6 literal.
+					}
+				}
+			}
+			return c.Redirect("https://"+host+string(c.Ctx.URI().RequestURI()), config.Code)
+		}
+	}
+}
+
+// lastForwardedProto returns the last value of the request's "X-Forwarded-Proto" header
+// (across repeated header lines and comma-separated lists), i.e. the one added by the
+// proxy the request came from. Earlier values may have been sent by the client.
+func lastForwardedProto(c *Context) string {
+	values := c.Ctx.Request.Header.PeekAll("X-Forwarded-Proto")
+	if len(values) == 0 {
+		return ""
+	}
+	last := string(values[len(values)-1])
+	if i := strings.LastIndexByte(last, ','); i >= 0 {
+		last = last[i+1:]
+	}
+	return strings.TrimSpace(last)
+}
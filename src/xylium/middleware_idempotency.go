@@ -0,0 +1,319 @@
+// src/xylium/middleware_idempotency.go
+package xylium
+
+import (
+	"crypto/sha256" // For fingerprinting request bodies.
+	"encoding/hex"  // For encoding fingerprints as strings.
+	"sync"          // For thread-safety in InMemoryIdempotencyStore and one-time store registration.
+	"time"          // For record TTLs and in-flight timeouts.
+)
+
+// IdempotencyRecord is the data kept by an `IdempotencyStore` for one idempotency key.
+// A record is created in the in-flight state (Completed false) when a request first
+// claims the key, and is completed with the captured response once the handler succeeds.
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request payload (a hash of the request body), so that a
+	// key reused with a different payload can be rejected instead of replayed.
+	Fingerprint string
+
+	// Completed reports whether the response below has been captured.
+	Completed bool
+
+	// StatusCode, Header and Body hold the captured response.
+	// Header is a list of name/value pairs to preserve repeated headers.
+	StatusCode int
+	Header     [][2]string
+	Body       []byte
+}
+
+// IdempotencyStore defines the interface for storage backends used by the Idempotency
+// middleware. Like `LimiterStore`, it can be implemented on top of shared storage
+// (e.g., Redis) for deployments with several instances. Implementations must be safe
+// for concurrent use.
+type IdempotencyStore interface {
+	// Reserve atomically claims `key` with the given in-flight `record` for `ttl`, unless
+	// a non-expired record already exists for it.
+	//
+	// Returns:
+	//   - `existing` (*IdempotencyRecord): nil if the key was claimed by this call;
+	//     otherwise the record currently stored (in-flight or completed).
+	//   - `err` (error): An error if the store could not be accessed.
+	Reserve(key string, record *IdempotencyRecord, ttl time.Duration) (existing *IdempotencyRecord, err error)
+
+	// Save stores the completed `record` for `key`, replacing the in-flight one, for `ttl`.
+	Save(key string, record *IdempotencyRecord, ttl time.Duration) error
+
+	// Delete removes the record for `key`, releasing an in-flight claim so that the
+	// request can be retried (e.g., after a failed handler).
+	Delete(key string) error
+
+	// Close releases any resources held by the store. It should be safe to call multiple times.
+	Close() error
+}
+
+// idempotencyEntry is a record with its expiry, as kept by InMemoryIdempotencyStore.
+type idempotencyEntry struct {
+	record    *IdempotencyRecord
+	expiresAt time.Time
+}
+
+// InMemoryIdempotencyStore is an `IdempotencyStore` that keeps records in memory.
+// It is suitable for single-instance deployments. Expired records are removed lazily
+// while new keys are reserved, so no background goroutine is needed.
+type InMemoryIdempotencyStore struct {
+	mu          sync.Mutex
+	entries     map[string]idempotencyEntry
+	lastCleanup time.Time
+}
+
+// NewInMemoryIdempotencyStore creates a new, empty InMemoryIdempotencyStore.
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		entries:     make(map[string]idempotencyEntry),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Reserve implements `IdempotencyStore`.
+func (s *InMemoryIdempotencyStore) Reserve(key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastCleanup) > time.Minute {
+		for k, e := range s.entries {
+			if now.After(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastCleanup = now
+	}
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		return e.record, nil
+	}
+	s.entries[key] = idempotencyEntry{record: record, expiresAt: now.Add(ttl)}
+	return nil, nil
+}
+
+// Save implements `IdempotencyStore`.
+func (s *InMemoryIdempotencyStore) Save(key string, record *IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = idempotencyEntry{record: record, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Delete implements `IdempotencyStore`.
+func (s *InMemoryIdempotencyStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Close implements `IdempotencyStore`. It drops all records.
+func (s *InMemoryIdempotencyStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]idempotencyEntry)
+	return nil
+}
+
+// IdempotencyConfig defines the configuration for the Idempotency middleware.
+type IdempotencyConfig struct {
+	// HeaderName is the request header carrying the idempotency key.
+	// Default: "Idempotency-Key".
+	HeaderName string
+
+	// Methods lists the HTTP methods the middleware applies to. Requests with other
+	// methods pass through untouched.
+	// Default: POST and PATCH (PUT and DELETE are idempotent by definition).
+	Methods []string
+
+	// Required, if true, rejects requests without an idempotency key with 400 Bad Request.
+	// Default: false (requests without a key are processed normally, without replay).
+	Required bool
+
+	// TTL is how long a completed response is kept and replayed for retries.
+	// Default: 24 hours.
+	TTL time.Duration
+
+	// InFlightTimeout is how long a key stays claimed by a request that has not completed
+	// yet. It bounds how long a crashed request blocks retries. It should exceed the
+	// longest expected handler duration.
+	// Default: 1 minute.
+	InFlightTimeout time.Duration
+
+	// KeyGenerator builds the store key from the request and the client's idempotency key.
+	// Default: the request method and path followed by the idempotency key, so the same
+	// key can be used on different routes. Include the authenticated user if keys are only
+	// unique per client.
+	KeyGenerator func(c *Context, idempotencyKey string) string
+
+	// Store is the backend holding idempotency records. If nil, a new
+	// `InMemoryIdempotencyStore` is created and registered with the router for
+	// graceful shutdown. Register custom stores with `app.RegisterCloser()` if needed.
+	Store IdempotencyStore
+
+	// Skip, if set, is called for each request; returning true bypasses the middleware.
+	Skip func(c *Context) bool
+}
+
+// DefaultIdempotencyConfig provides the default values for IdempotencyConfig.
+var DefaultIdempotencyConfig = IdempotencyConfig{
+	HeaderName:      "Idempotency-Key",
+	Methods:         []string{MethodPost, MethodPatch},
+	TTL:             24 * time.Hour,
+	InFlightTimeout: time.Minute,
+}
+
+// idempotencyReplayHeader is set on responses replayed from the store.
+const idempotencyReplayHeader = "Idempotent-Replayed"
+
+// idempotencySkippedHeaders are response headers that are not captured for replay,
+// because fasthttp sets them for every response.
+var idempotencySkippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Date":              true,
+	"Server":            true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+}
+
+// Idempotency returns a middleware that makes retried requests safe: the first request
+// with a given idempotency key is processed and its response is stored; later requests
+// with the same key (within `TTL`) get the stored response replayed, with an
+// `Idempotent-Replayed: true` header, instead of running the handler again.
+//
+// Behavior for requests carrying a key:
+//   - While the first request is still being processed, concurrent requests with the
+//     same key are rejected with 409 Conflict.
+//   - A key reused with a different request body is rejected with 422 Unprocessable Entity.
+//   - Only responses written by the handler with a status below 500 are stored. If the
+//     handler returns an error, responds with a 5xx status, or streams its response,
+//     the key is released so the client can retry.
+func Idempotency(config IdempotencyConfig) Middleware {
+	if config.HeaderName == "" {
+		config.HeaderName = DefaultIdempotencyConfig.HeaderName
+	}
+	if len(config.Methods) == 0 {
+		config.Methods = DefaultIdempotencyConfig.Methods
+	}
+	if config.TTL <= 0 {
+		config.TTL = DefaultIdempotencyConfig.TTL
+	}
+	if config.InFlightTimeout <= 0 {
+		config.InFlightTimeout = DefaultIdempotencyConfig.InFlightTimeout
+	}
+	if config.KeyGenerator == nil {
+		config.KeyGenerator = func(c *Context, idempotencyKey string) string {
+			return c.Method() + " " + c.Path() + " " + idempotencyKey
+		}
+	}
+	var internallyCreatedStore IdempotencyStore
+	if config.Store == nil {
+		config.Store = NewInMemoryIdempotencyStore()
+		internallyCreatedStore = config.Store
+	}
+	methods := make(map[string]bool, len(config.Methods))
+	for _, m := range config.Methods {
+		methods[m] = true
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		var registerStoreOnce sync.Once
+
+		return func(c *Context) error {
+			if internallyCreatedStore != nil && c.router != nil {
+				registerStoreOnce.Do(func() {
+					c.router.RegisterCloser(internallyCreatedStore)
+				})
+			}
+			if !methods[c.Method()] || (config.Skip != nil && config.Skip(c)) {
+				return next(c)
+			}
+
+			idempotencyKey := c.Header(config.HeaderName)
+			if idempotencyKey == "" {
+				if config.Required {
+					return NewHTTPError(StatusBadRequest, "Missing required '"+config.HeaderName+"' header.")
+				}
+				return next(c)
+			}
+
+			logger := c.Logger().WithFields(M{"middleware": "Idempotency"})
+			key := config.KeyGenerator(c, idempotencyKey)
+			sum := sha256.Sum256(c.Body())
+			fingerprint := hex.EncodeToString(sum[:])
+
+			existing, err := config.Store.Reserve(key, &IdempotencyRecord{Fingerprint: fingerprint}, config.InFlightTimeout)
+			if err != nil {
+				return NewHTTPError(StatusInternalServerError, "Failed to check idempotency key.").WithInternal(err)
+			}
+			if existing != nil {
+				switch {
+				case existing.Fingerprint != fingerprint:
+					logger.Debugf("Idempotency key '%s' reused with a different payload for %s %s.", idempotencyKey, c.Method(), c.Path())
+					return NewHTTPError(StatusUnprocessableEntity, "Idempotency key has already been used with a different request payload.")
+				case !existing.Completed:
+					logger.Debugf("Idempotency key '%s' is in flight for %s %s.", idempotencyKey, c.Method(), c.Path())
+					return NewHTTPError(StatusConflict, "A request with this idempotency key is already being processed.")
+				default:
+					logger.Debugf("Replaying stored response for idempotency key '%s' on %s %s.", idempotencyKey, c.Method(), c.Path())
+					return replayIdempotentResponse(c, existing)
+				}
+			}
+
+			// This request claimed the key. Release it unless a response is stored below,
+			// including when the handler panics.
+			stored := false
+			defer func() {
+				if !stored {
+					if err := config.Store.Delete(key); err != nil {
+						logger.Errorf("Failed to release idempotency key '%s': %v", idempotencyKey, err)
+					}
+				}
+			}()
+
+			err = next(c)
+			resp := &c.Ctx.Response
+			if err != nil || resp.StatusCode() >= StatusInternalServerError || resp.IsBodyStream() {
+				return err
+			}
+
+			record := &IdempotencyRecord{
+				Fingerprint: fingerprint,
+				Completed:   true,
+				StatusCode:  resp.StatusCode(),
+				Body:        append([]byte(nil), resp.Body()...),
+			}
+			resp.Header.VisitAll(func(k, v []byte) {
+				if name := string(k); !idempotencySkippedHeaders[name] {
+					record.Header = append(record.Header, [2]string{name, string(v)})
+				}
+			})
+			if saveErr := config.Store.Save(key, record, config.TTL); saveErr != nil {
+				logger.Errorf("Failed to store response for idempotency key '%s': %v", idempotencyKey, saveErr)
+				return nil
+			}
+			stored = true
+			return nil
+		}
+	}
+}
+
+// replayIdempotentResponse writes a stored response to the client.
+func replayIdempotentResponse(c *Context, record *IdempotencyRecord) error {
+	if !c.beginResponseWrite() {
+		return nil
+	}
+	defer c.endResponseWrite()
+	for _, h := range record.Header {
+		c.Ctx.Response.Header.Add(h[0], h[1])
+	}
+	c.Ctx.Response.Header.Set(idempotencyReplayHeader, "true")
+	c.Ctx.Response.SetStatusCode(record.StatusCode)
+	c.Ctx.Response.SetBody(record.Body)
+	return nil
+}
@@ -0,0 +1,88 @@
+// src/xylium/middleware_methodoverride.go
+package xylium
+
+import (
+	"strings" // For case-insensitive method matching.
+)
+
+// DefaultMethodOverrideHeader is the default header inspected by `MethodOverride`.
+const DefaultMethodOverrideHeader = "X-HTTP-Method-Override"
+
+// DefaultMethodOverrideParam is the default form/query field inspected by `MethodOverride`.
+const DefaultMethodOverrideParam = "_method"
+
+// ContextKeyOriginalMethod is the context store key under which `MethodOverride`
+// stores the original request method (e.g., "POST") when it rewrites the method.
+const ContextKeyOriginalMethod = "xylium_original_method"
+
+// MethodOverrideConfig defines the configuration for the MethodOverride pre-routing hook.
+// It allows clients that can only send GET/POST (e.g., HTML forms) to trigger other
+// methods such as PUT or DELETE.
+type MethodOverrideConfig struct {
+	// HeaderName is the request header containing the desired method.
+	// Default: "X-HTTP-Method-Override".
+	HeaderName string
+
+	// ParamName is the form field or URL query parameter containing the desired method.
+	// The form body is checked first, then the query string. Set to "-" to disable.
+	// Default: "_method".
+	ParamName string
+
+	// AllowedMethods is the allow-list of methods a POST request may be overridden to.
+	// Default: PUT, PATCH, DELETE.
+	AllowedMethods []string
+}
+
+// MethodOverride returns a `PreRoutingHook` that rewrites the method of POST requests
+// to the one specified in the override header or form/query field, provided it is in
+// `config.AllowedMethods`. Only POST requests are ever rewritten.
+//
+// Because the method must be changed before the route is looked up, this is not a
+// regular `Middleware`; register it with `Router.UsePreRouting`:
+//
+//	app.UsePreRouting(xylium.MethodOverride(xylium.MethodOverrideConfig{}))
+//
+// When the method is rewritten, the original method is stored in the context
+// under `ContextKeyOriginalMethod`.
+func MethodOverride(config MethodOverrideConfig) PreRoutingHook {
+	if config.HeaderName == "" {
+		config.HeaderName = DefaultMethodOverrideHeader
+	}
+	if config.ParamName == "" {
+		config.ParamName = DefaultMethodOverrideParam
+	}
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = []string{MethodPut, MethodPatch, MethodDelete}
+	}
+	allowed := make(map[string]struct{}, len(config.AllowedMethods))
+	for _, m := range config.AllowedMethods {
+		allowed[strings.ToUpper(m)] = struct{}{}
+	}
+
+	return func(c *Context) {
+		if c.Method() != MethodPost {
+			return
+		}
+
+		override := c.Header(config.HeaderName)
+		if override == "" && config.ParamName != "-" {
+			override = string(c.Ctx.PostArgs().Peek(config.ParamName))
+			if override == "" {
+				override = string(c.Ctx.QueryArgs().Peek(config.ParamName))
+			}
+		}
+		if override == "" {
+			return
+		}
+
+		override = strings.ToUpper(strings.TrimSpace(override))
+		if _, ok := allowed[override]; !ok {
+			c.Logger().WithFields(M{"hook": "MethodOverride"}).Debugf(
+				"Ignoring method override to '%s' for %s: method not in allow-list.", override, c.Path())
+			return
+		}
+
+		c.Set(ContextKeyOriginalMethod, MethodPost)
+		c.RewriteMethod(override)
+	}
+}
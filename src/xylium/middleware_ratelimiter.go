@@ -1,6 +1,7 @@
 package xylium
 
 import (
+	"context"  // For honoring request cancellation while waiting in WaitMode.
 	"fmt"      // For formatting error messages and log messages.
 	"log"      // Standard Go logger, used by InMemoryStore as a fallback if no Xylium logger is provided.
 	"net/http" // For http.TimeFormat, used when RetryAfterMode is RetryAfterHTTPDate.
@@ -14,12 +15,17 @@ import (
 // This helps prevent unbounded memory growth in long-running applications.
 const DefaultCleanupInterval = 10 * time.Minute
 
+// DefaultRateLimitMaxWait is the default `RateLimiterConfig.MaxWait` in `WaitMode`.
+const DefaultRateLimitMaxWait = time.Second
+
 // visitor is an internal struct used by `InMemoryStore` to track the request count
 // and window information for a specific key (typically a client identifier like an IP address).
 type visitor struct {
-	count      int       // Number of requests received from this visitor in the current window.
-	lastSeen   time.Time // Timestamp of the last request received from this visitor.
-	windowEnds time.Time // Timestamp when the current rate limit window for this visitor expires.
+	count      int           // Number of requests received from this visitor in the current window.
+	lastSeen   time.Time     // Timestamp of the last request received from this visitor.
+	windowEnds time.Time     // Timestamp when the current rate limit window for this visitor expires.
+	nextCount  int           // Requests reserved (via Reserve) in the window following the current one.
+	window     time.Duration // Window duration, used to expire the following window of reserved requests.
 }
 
 // LimiterStore defines the interface for storage mechanisms used by the rate limiter middleware.
@@ -32,18 +38,23 @@ type visitor struct {
 type LimiterStore interface {
 	// Allow checks if a request associated with the given `key` should be permitted
 	// based on the `limit` (maximum number of requests) and `window` (duration).
+	// An allowed request consumes `cost` units of the limit: the count is incremented by
+	// `cost` rather than 1. A rejected request consumes nothing, so a cheaper request may
+	// still fit in the rest of the window. Implementations should treat a `cost` below 1 as 1.
 	//
 	// Parameters:
 	//   - `key` (string): A unique identifier for the entity being rate-limited (e.g., client IP).
-	//   - `limit` (int): The maximum number of requests allowed for this key within the `window`.
+	//   - `limit` (int): The maximum number of requests (cost units) allowed for this key within the `window`.
 	//   - `window` (time.Duration): The time duration for the rate limit window.
+	//   - `cost` (int): The cost of this request (see `RateLimiterConfig.Cost`).
 	//
 	// Returns:
 	//   - `allowed` (bool): True if the request is within the limit, false otherwise.
-	//   - `currentCount` (int): The current request count for the key within its window after this request.
+	//   - `currentCount` (int): The request count for the key within its window after this request
+	//     (for a rejected request, the count it would have reached).
 	//   - `configuredLimit` (int): The `limit` that was applied for this check.
 	//   - `windowEnds` (time.Time): The time when the current window for this key will reset/expire.
-	Allow(key string, limit int, window time.Duration) (allowed bool, currentCount int, configuredLimit int, windowEnds time.Time)
+	Allow(key string, limit int, window time.Duration, cost int) (allowed bool, currentCount int, configuredLimit int, windowEnds time.Time)
 
 	// Close is called to release any resources held by the LimiterStore, such as
 	// background cleanup goroutines or connections to external storage.
@@ -55,6 +66,24 @@ type LimiterStore interface {
 	Close() error
 }
 
+// LimiterReserver is an optional interface for a `LimiterStore` that supports
+// `RateLimiterConfig.WaitMode`. `InMemoryStore` implements it.
+type LimiterReserver interface {
+	// Reserve reserves a request of the given `cost` (see `LimiterStore.Allow`) for `key`
+	// in the window following the current one, for a key that has already exhausted its
+	// `limit` in the current window. If a slot is free (and the current window still allows requests, a slot is
+	// taken in the current window instead, with a zero delay) and the request can be
+	// served within `maxWait`, it returns the delay until the slot is usable and
+	// true. Otherwise nothing is reserved, and it returns the delay until the next
+	// window and false.
+	Reserve(key string, limit int, window time.Duration, cost int, maxWait time.Duration) (delay time.Duration, ok bool)
+
+	// CancelReservation gives back a request of the given `cost` reserved for `key` by
+	// a `Reserve` call that returned a positive delay, when the request does not wait
+	// for its slot (e.g., the client disconnected). The slot becomes free for other requests.
+	CancelReservation(key string, window time.Duration, cost int)
+}
+
 // InMemoryStore is a `LimiterStore` implementation that uses an in-memory map
 // to store visitor request counts. It is suitable for single-instance deployments.
 // For distributed environments, a shared store (e.g., Redis-based) is recommended.
@@ -197,9 +226,10 @@ func (s *InMemoryStore) startCleanupRoutine() {
 
 // Allow implements the `LimiterStore` interface. It checks if a request associated
 // with `key` should be permitted based on the `limit` (max requests) and `window` duration.
-// It updates the request count and window information for the `key`.
+// If the request fits, it adds `cost` (at least 1) to the request count; a rejected request
+// is not counted. It updates the window information for the `key`.
 // This method is thread-safe.
-func (s *InMemoryStore) Allow(key string, limit int, window time.Duration) (bool, int, int, time.Time) {
+func (s *InMemoryStore) Allow(key string, limit int, window time.Duration, cost int) (bool, int, int, time.Time) {
 	s.mu.Lock() // Acquire full lock as we might modify the `visitors` map.
 	defer s.mu.Unlock()
 
@@ -211,28 +241,118 @@ func (s *InMemoryStore) Allow(key string, limit int, window time.Duration) (bool
 		return false, limit + 1, limit, time.Now()
 	}
 
+	if cost < 1 {
+		cost = 1
+	}
 	now := time.Now()
-	v, exists := s.visitors[key]
+	v := s.currentVisitorLocked(key, window, now)
 
 	// If the visitor `key` doesn't exist in the map, or if their previous window has expired.
-	if !exists || now.After(v.windowEnds) {
+	if v == nil {
 		// This is the first request in a new window for this key.
 		newWindowEnds := now.Add(window) // Calculate when the new window will end.
+		if cost > limit {
+			// A single request costing more than the limit is rejected without opening a window.
+			return false, cost, limit, newWindowEnds
+		}
 		s.visitors[key] = &visitor{
-			count:      1,             // First request in this window.
+			count:      cost,          // First request in this window.
 			lastSeen:   now,           // Record the time of this request.
 			windowEnds: newWindowEnds, // Store the new window end time.
+			window:     window,
 		}
-		// Request is allowed. Return true, current count (1), configured limit, and new window end time.
-		return true, 1, limit, newWindowEnds
+		// Return allowance status, current count, configured limit, and new window end time.
+		return true, cost, limit, newWindowEnds
 	}
 
 	// Visitor `key` exists and is within their current rate limit window.
-	v.count++        // Increment their request count.
 	v.lastSeen = now // Update their last seen time.
-	// Request is allowed if their new count is less than or equal to the limit.
+	if v.count+cost > limit {
+		// Rejected: report the count the request would have reached without charging it,
+		// so the rest of the window stays available to cheaper requests.
+		return false, v.count + cost, limit, v.windowEnds
+	}
+	v.count += cost // Increment their request count by the request's cost.
 	// Return allowance status, new count, configured limit, and existing window end time.
-	return v.count <= limit, v.count, limit, v.windowEnds
+	return true, v.count, limit, v.windowEnds
+}
+
+// Reserve implements the `LimiterReserver` interface, enabling `RateLimiterConfig.WaitMode`.
+// Reserved requests count against the limit of the window following the current one.
+// This method is thread-safe.
+func (s *InMemoryStore) Reserve(key string, limit int, window time.Duration, cost int, maxWait time.Duration) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isClosed || cost > limit {
+		return 0, false
+	}
+	if cost < 1 {
+		cost = 1
+	}
+
+	now := time.Now()
+	v := s.currentVisitorLocked(key, window, now)
+	if v == nil {
+		s.visitors[key] = &visitor{count: cost, lastSeen: now, windowEnds: now.Add(window), window: window}
+		return 0, true
+	}
+	v.lastSeen = now
+	v.window = window
+	if v.count+cost <= limit {
+		v.count += cost
+		return 0, true
+	}
+	delay := v.windowEnds.Sub(now)
+	if v.nextCount+cost > limit || delay > maxWait {
+		return delay, false
+	}
+	v.nextCount += cost
+	return delay, true
+}
+
+// CancelReservation implements the `LimiterReserver` interface. It frees a slot
+// reserved by `Reserve` in the window following the current one, or in the current
+// window if the reserved window has already started.
+// This method is thread-safe.
+func (s *InMemoryStore) CancelReservation(key string, window time.Duration, cost int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isClosed {
+		return
+	}
+	if cost < 1 {
+		cost = 1
+	}
+
+	v := s.currentVisitorLocked(key, window, time.Now())
+	if v == nil {
+		return // The reserved window is already over.
+	}
+	if v.nextCount >= cost {
+		v.nextCount -= cost
+	} else if v.count >= cost {
+		// The reserved window has started and holds the reservation in its count.
+		v.count -= cost
+	}
+}
+
+// currentVisitorLocked returns the visitor for `key` with a window containing `now`,
+// or nil if there is none. An expired window with reserved requests is advanced to
+// the following window, which starts with the reserved requests as its count.
+// The caller must hold `s.mu`.
+func (s *InMemoryStore) currentVisitorLocked(key string, window time.Duration, now time.Time) *visitor {
+	v, exists := s.visitors[key]
+	if !exists || !now.After(v.windowEnds) {
+		return v
+	}
+	if v.nextCount > 0 && !now.After(v.windowEnds.Add(window)) {
+		v.count, v.nextCount = v.nextCount, 0
+		v.windowEnds = v.windowEnds.Add(window)
+		return v
+	}
+	return nil
 }
 
 // cleanup is an internal method called periodically by the background goroutine
@@ -252,7 +372,8 @@ func (s *InMemoryStore) cleanup() {
 	now := time.Now()
 	cleanedCount := 0
 	for key, v := range s.visitors {
-		if now.After(v.windowEnds) { // If the visitor's window has expired.
+		// If the visitor's window has expired (including the following window, if requests are reserved in it).
+		if now.After(v.windowEnds) && (v.nextCount == 0 || now.After(v.windowEnds.Add(v.window))) {
 			delete(s.visitors, key) // Remove the entry from the map.
 			cleanedCount++
 		}
@@ -312,7 +433,8 @@ func (s *InMemoryStore) Close() error {
 // typically IP address) can make within a specified time window.
 type RateLimiterConfig struct {
 	// MaxRequests is the maximum number of requests allowed from a single key
-	// within the `WindowDuration`. Must be greater than 0.
+	// within the `WindowDuration` (or, with `Cost`, the total cost of those requests).
+	// Must be greater than 0.
 	MaxRequests int
 	// WindowDuration is the time duration of the rate-limiting window.
 	// Must be greater than 0.
@@ -347,6 +469,12 @@ type RateLimiterConfig struct {
 	// register it with the router via `app.RegisterCloser()` if it needs cleanup.
 	Store LimiterStore
 
+	// Cost is an optional function returning how many units of `MaxRequests` the
+	// current request consumes, so expensive endpoints (e.g., a bulk import) use more
+	// of a client's budget than cheap ones. Values below 1 are treated as 1.
+	// If nil, every request costs 1.
+	Cost func(c *Context) int
+
 	// Skip is an optional function that, if provided and returns true, will cause
 	// the rate limiter middleware to bypass rate limiting for the current request.
 	// This can be used to exclude certain paths (e.g., health checks, static assets)
@@ -367,6 +495,18 @@ type RateLimiterConfig struct {
 	// If empty, defaults to `RetryAfterSeconds`.
 	RetryAfterMode string // Use constants RetryAfterSeconds, RetryAfterHTTPDate.
 
+	// WaitMode, if true, makes a request that exceeds the limit wait for a slot in the
+	// next window instead of being rejected immediately, as long as it can be served
+	// within `MaxWait`. Otherwise (or if the request context is canceled while waiting,
+	// e.g., by a timeout), it is rejected with 429 as usual. The waiting request still
+	// counts against the limit of the next window. Requires a `Store` that implements
+	// `LimiterReserver` (like `InMemoryStore`); with other stores, requests are rejected.
+	// Default: false (reject immediately).
+	WaitMode bool
+	// MaxWait is the longest a request may wait in `WaitMode`.
+	// Default: `DefaultRateLimitMaxWait` (1 second). Ignored if `WaitMode` is false.
+	MaxWait time.Duration
+
 	// LoggerForStore is an optional `xylium.Logger` instance that will be passed to
 	// an internally created `InMemoryStore` (if `config.Store` is nil).
 	// This allows the internal store to use the application's logging conventions.
@@ -433,6 +573,9 @@ func RateLimiter(config RateLimiterConfig) Middleware {
 	if config.RetryAfterMode == "" {
 		config.RetryAfterMode = RetryAfterSeconds // Default Retry-After format.
 	}
+	if config.WaitMode && config.MaxWait <= 0 {
+		config.MaxWait = DefaultRateLimitMaxWait
+	}
 
 	// --- Return the Middleware Handler Function ---
 	return func(next HandlerFunc) HandlerFunc {
@@ -465,8 +608,38 @@ func RateLimiter(config RateLimiterConfig) Middleware {
 			// Generate the key for this request.
 			key := config.KeyGenerator(c)
 
+			// Determine the cost of this request.
+			cost := 1
+			if config.Cost != nil {
+				if cost = config.Cost(c); cost < 1 {
+					cost = 1
+				}
+			}
+
 			// Check with the store if the request is allowed.
-			allowed, currentCount, configuredLimit, windowEnds := config.Store.Allow(key, config.MaxRequests, config.WindowDuration)
+			allowed, currentCount, configuredLimit, windowEnds := config.Store.Allow(key, config.MaxRequests, config.WindowDuration, cost)
+
+			// In WaitMode, try to wait for a slot in the next window instead of rejecting.
+			var waitErr error // Why waiting ended without a slot (e.g., request canceled), if it did.
+			if !allowed && config.WaitMode {
+				if reserver, ok := config.Store.(LimiterReserver); ok {
+					if delay, reserved := reserver.Reserve(key, config.MaxRequests, config.WindowDuration, cost, config.MaxWait); reserved {
+						logger.Debugf("RateLimiter: Limit exceeded for key '%s'; waiting %v for the next window.", key, delay)
+						if waitErr = waitForRateLimitSlot(c.GoContext(), delay); waitErr == nil {
+							// The request now belongs to the next window, whose slots are all reserved or used.
+							allowed, currentCount = true, configuredLimit
+							if delay > 0 {
+								windowEnds = windowEnds.Add(config.WindowDuration)
+							} // Otherwise the slot is in the current window (e.g., it rolled over after Allow).
+						} else {
+							// The request will not use its slot; give it back to the next window.
+							reserver.CancelReservation(key, config.WindowDuration, cost)
+						}
+					}
+				} else {
+					logger.Warnf("RateLimiter: WaitMode requires a store implementing LimiterReserver; store %T does not. Rejecting request.", config.Store)
+				}
+			}
 			now := time.Now()
 
 			// Calculate remaining requests. Ensure it's not negative.
@@ -530,6 +703,9 @@ func RateLimiter(config RateLimiterConfig) Middleware {
 					errorResponseMessage = fmt.Sprintf("Rate limit exceeded. Try again in %d seconds.", secondsToReset)
 				}
 				// Return an HTTP 429 Too Many Requests error.
+				if waitErr != nil {
+					return NewHTTPError(StatusTooManyRequests, errorResponseMessage).WithInternal(waitErr)
+				}
 				return NewHTTPError(StatusTooManyRequests, errorResponseMessage)
 			}
 
@@ -545,3 +721,19 @@ func RateLimiter(config RateLimiterConfig) Middleware {
 		}
 	}
 }
+
+// waitForRateLimitSlot waits for `delay`, returning early with the cancellation cause
+// if `ctx` is done first.
+func waitForRateLimitSlot(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,82 @@
+// src/xylium/middleware_recover.go
+package xylium
+
+import (
+	"fmt"     // For wrapping the recovered value into an error.
+	"runtime" // For runtime.Stack to capture the goroutine stack trace.
+)
+
+// DefaultRecoverStackSize is the default maximum number of bytes of stack trace
+// captured by the Recover middleware.
+const DefaultRecoverStackSize = 4 << 10 // 4 KB
+
+// RecoverConfig defines the configuration for the Recover middleware.
+type RecoverConfig struct {
+	// StackSize is the maximum number of bytes of stack trace to capture.
+	// Default: `DefaultRecoverStackSize` (4 KB).
+	StackSize int
+
+	// DisableStackAll, if true, captures only the stack of the panicking goroutine.
+	// If false (default), the stacks of all goroutines are captured.
+	DisableStackAll bool
+
+	// OnPanic is called with the recovered value and the captured stack trace.
+	// Its return value becomes the error returned by the middleware (and is then
+	// processed by the router's `GlobalErrorHandler`). Return nil if OnPanic has
+	// already sent a response. Use this to report panics to external services
+	// (e.g., Sentry) or to suppress stack traces in `ReleaseMode`.
+	// If nil, the panic and stack trace are logged and an HTTP 500 `*HTTPError` is returned.
+	OnPanic func(c *Context, recovered interface{}, stack []byte) error
+}
+
+// Recover returns a middleware that recovers from panics in subsequent handlers.
+// Pass a zero `RecoverConfig{}` for the default behavior (log the panic with its stack
+// trace and return an HTTP 500 `*HTTPError`).
+//
+// The router itself already recovers panics at the top level of `Router.Handler` (and
+// invokes `Router.PanicHandler`). This middleware allows route groups or individual
+// routes to install their own recovery behavior. A panic recovered by this middleware
+// is converted into an error and never reaches the router's top-level recovery, so it
+// is handled exactly once: `Router.PanicHandler` is NOT called for it. Panics raised
+// outside this middleware's scope (e.g., in global middleware registered before it)
+// are still handled by the router.
+func Recover(config RecoverConfig) Middleware {
+	if config.StackSize <= 0 {
+		config.StackSize = DefaultRecoverStackSize
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) (returnErr error) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				stack := make([]byte, config.StackSize)
+				stack = stack[:runtime.Stack(stack, !config.DisableStackAll)]
+
+				// Make the panic value and stack available like the router's own recovery does.
+				c.Set(ContextKeyPanicInfo, rec)
+				c.Set(ContextKeyPanicStack, stack)
+
+				if config.OnPanic != nil {
+					returnErr = config.OnPanic(c, rec, stack)
+					return
+				}
+
+				logger := c.Logger().WithFields(M{"middleware": "Recover"})
+				logger.Errorf("PANIC RECOVERED: %v for %s %s\nStack Trace:\n%s", rec, c.Method(), c.Path(), stack)
+
+				err, ok := rec.(error)
+				if !ok {
+					err = fmt.Errorf("%v", rec)
+				}
+				returnErr = NewHTTPError(StatusInternalServerError,
+					"An unexpected server error occurred. Please try again later or contact support.").
+					WithInternal(fmt.Errorf("panic recovery: %w", err))
+			}()
+			return next(c)
+		}
+	}
+}
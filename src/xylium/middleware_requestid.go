@@ -13,8 +13,20 @@ const DefaultRequestIDHeader = "X-Request-ID"
 
 // RequestIDConfig defines the configuration options for the RequestID middleware.
 type RequestIDConfig struct {
-	Generator  func() string
+	// Generator returns a new request ID. Defaults to a random UUID (v4).
+	// Plug in another scheme (e.g., UUIDv7 or ULID) for time-ordered IDs.
+	Generator func() string
+
+	// HeaderName is the header read for an incoming request ID and set on the response.
+	// Defaults to `DefaultRequestIDHeader` ("X-Request-ID").
 	HeaderName string
+
+	// IgnoreIncoming, if true, always generates a new ID instead of honoring the ID sent
+	// by the client or an upstream proxy. Use it when the edge is not trusted to set IDs.
+	IgnoreIncoming bool
+
+	// DisableResponseHeader, if true, does not echo the request ID in the response header.
+	DisableResponseHeader bool
 }
 
 // RequestID returns a new RequestID middleware with default configuration.
@@ -23,6 +35,7 @@ func RequestID() Middleware {
 }
 
 // RequestIDWithConfig returns a new RequestID middleware with the provided configuration.
+// The ID is stored under `ContextKeyRequestID`, so `c.Logger()` includes it in log entries.
 func RequestIDWithConfig(config RequestIDConfig) Middleware {
 	if config.Generator == nil {
 		config.Generator = func() string {
@@ -35,14 +48,19 @@ func RequestIDWithConfig(config RequestIDConfig) Middleware {
 
 	return func(next HandlerFunc) HandlerFunc {
 		return func(c *Context) error {
-			requestID := c.Header(config.HeaderName)
+			var requestID string
+			if !config.IgnoreIncoming {
+				requestID = c.Header(config.HeaderName)
+			}
 			if requestID == "" {
 				requestID = config.Generator()
 			}
 
 			// Use the globally defined ContextKeyRequestID from types.go (implicitly, as it's in the same package)
 			c.Set(ContextKeyRequestID, requestID)
-			c.SetHeader(config.HeaderName, requestID)
+			if !config.DisableResponseHeader {
+				c.SetHeader(config.HeaderName, requestID)
+			}
 
 			return next(c)
 		}
@@ -0,0 +1,75 @@
+// src/xylium/middleware_requireheaders.go
+package xylium
+
+import (
+	"net/http" // For canonicalizing header names.
+
+	"github.com/go-playground/validator/v10" // For validating header values.
+)
+
+// RequireHeaders returns a middleware that requires the request headers named in `spec`
+// and validates their values with the `go-playground/validator` tags given as the map
+// values, e.g., "uuid4" or "oneof=web mobile". It saves binding a header-only struct
+// in every handler of a group:
+//
+//	api.Use(xylium.RequireHeaders(map[string]string{
+//		"X-Tenant-ID": "uuid4",
+//		"X-Client":    "oneof=web mobile",
+//	}))
+//
+// Every listed header is required; a value of "" only checks for presence. All headers
+// are checked before responding, and failures are returned as a 400 `*HTTPError` with
+// the same shape as `c.BindAndValidate`: `"message": "Validation failed."` and
+// `"details"` mapping each canonical header name to its error (localized if
+// `SetValidationTranslators` is used). A missing header is reported for the tag
+// "required". Validation uses the validator returned by `GetValidator` at request time,
+// so custom tags registered with `SetCustomValidator` are available.
+//
+// Panics if `spec` is empty or contains an empty header name.
+func RequireHeaders(spec map[string]string) Middleware {
+	if len(spec) == 0 {
+		panic("xylium: RequireHeaders requires at least one header")
+	}
+	// Copy the map with canonical names, so later changes by the caller have no effect.
+	rules := make(map[string]string, len(spec))
+	for name, tag := range spec {
+		if name == "" {
+			panic("xylium: RequireHeaders header name cannot be empty")
+		}
+		rules[http.CanonicalHeaderKey(name)] = tag
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			var errFields map[string]string
+			var validationErrs validator.ValidationErrors
+			validate := GetValidator()
+			trans := c.validationTranslator() // Nil unless messages are localized.
+			for name, tag := range rules {
+				value := c.Header(name)
+				if value == "" {
+					tag = "required" // Reported like a missing struct field.
+				} else if tag == "" {
+					continue // Presence is all that is required.
+				}
+				err := validate.Var(value, tag)
+				if err == nil {
+					continue
+				}
+				vErrs, ok := err.(validator.ValidationErrors)
+				if !ok || len(vErrs) == 0 {
+					return NewHTTPError(StatusBadRequest, "Validation processing error occurred.").WithInternal(err)
+				}
+				if errFields == nil {
+					errFields = make(map[string]string)
+				}
+				errFields[name] = validationErrorMessage(vErrs[0], trans)
+				validationErrs = append(validationErrs, vErrs...)
+			}
+			if errFields != nil {
+				return NewHTTPError(StatusBadRequest, M{"message": "Validation failed.", "details": errFields}).WithInternal(validationErrs)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// src/xylium/middleware_secureheaders.go
+package xylium
+
+import (
+	"strconv" // For formatting the HSTS max-age value.
+)
+
+// SecureHeadersConfig defines the configuration for the SecureHeaders middleware.
+// Each header has a sensible default (see `DefaultSecureHeadersConfig`) that is used
+// when the corresponding field is left empty, and can be turned off individually
+// via its `Disable...` field.
+type SecureHeadersConfig struct {
+	// ContentTypeOptions is the value of the "X-Content-Type-Options" header.
+	// Default: "nosniff".
+	ContentTypeOptions        string
+	DisableContentTypeOptions bool
+
+	// FrameOptions is the value of the "X-Frame-Options" header (e.g., "DENY", "SAMEORIGIN").
+	// Default: "SAMEORIGIN".
+	FrameOptions        string
+	DisableFrameOptions bool
+
+	// HSTSMaxAge is the "max-age" directive (in seconds) of the "Strict-Transport-Security" header.
+	// If 0, the default is used.
+	// Default: 31536000 (1 year).
+	HSTSMaxAge int
+	// HSTSExcludeSubdomains, if true, omits the "includeSubDomains" directive.
+	// Default: false ("includeSubDomains" is sent).
+	HSTSExcludeSubdomains bool
+	// HSTSPreload, if true, adds the "preload" directive. Only enable this if you intend
+	// to submit your domain to browsers' HSTS preload lists.
+	// Default: false.
+	HSTSPreload bool
+	// DisableHSTS turns off the "Strict-Transport-Security" header.
+	// Note: HSTS is only ever sent on HTTPS requests (see `c.Scheme()`), since browsers
+	// ignore it over plain HTTP.
+	DisableHSTS bool
+
+	// ContentSecurityPolicy is the value of the "Content-Security-Policy" header.
+	// Default: "default-src 'self'".
+	ContentSecurityPolicy        string
+	DisableContentSecurityPolicy bool
+
+	// ReferrerPolicy is the value of the "Referrer-Policy" header.
+	// Default: "strict-origin-when-cross-origin".
+	ReferrerPolicy        string
+	DisableReferrerPolicy bool
+
+	// PermissionsPolicy is the value of the "Permissions-Policy" header.
+	// Default: "geolocation=(), microphone=(), camera=()".
+	PermissionsPolicy        string
+	DisablePermissionsPolicy bool
+}
+
+// DefaultSecureHeadersConfig provides the default values used by `SecureHeaders`
+// for any header whose value is left empty in the supplied config.
+var DefaultSecureHeadersConfig = SecureHeadersConfig{
+	ContentTypeOptions:    "nosniff",
+	FrameOptions:          "SAMEORIGIN",
+	HSTSMaxAge:            31536000,
+	ContentSecurityPolicy: "default-src 'self'",
+	ReferrerPolicy:        "strict-origin-when-cross-origin",
+	PermissionsPolicy:     "geolocation=(), microphone=(), camera=()",
+}
+
+// SecureHeaders returns a middleware that sets common security-related response headers:
+// "X-Content-Type-Options", "X-Frame-Options", "Strict-Transport-Security" (HTTPS only),
+// "Content-Security-Policy", "Referrer-Policy", and "Permissions-Policy".
+//
+// Headers are set before calling the next handler, so handlers can still override them
+// (e.g., a route serving embeddable content may relax "X-Frame-Options").
+//
+// Example:
+//
+//	app.Use(xylium.SecureHeaders(xylium.SecureHeadersConfig{
+//		FrameOptions: "DENY",
+//		HSTSPreload:  true,
+//		DisablePermissionsPolicy: true,
+//	}))
+func SecureHeaders(config SecureHeadersConfig) Middleware {
+	if config.ContentTypeOptions == "" {
+		config.ContentTypeOptions = DefaultSecureHeadersConfig.ContentTypeOptions
+	}
+	if config.FrameOptions == "" {
+		config.FrameOptions = DefaultSecureHeadersConfig.FrameOptions
+	}
+	if config.HSTSMaxAge == 0 {
+		config.HSTSMaxAge = DefaultSecureHeadersConfig.HSTSMaxAge
+	}
+	if config.HSTSMaxAge < 0 {
+		panic("xylium: SecureHeaders 'HSTSMaxAge' cannot be negative; use DisableHSTS to turn off HSTS")
+	}
+	if config.ContentSecurityPolicy == "" {
+		config.ContentSecurityPolicy = DefaultSecureHeadersConfig.ContentSecurityPolicy
+	}
+	if config.ReferrerPolicy == "" {
+		config.ReferrerPolicy = DefaultSecureHeadersConfig.ReferrerPolicy
+	}
+	if config.PermissionsPolicy == "" {
+		config.PermissionsPolicy = DefaultSecureHeadersConfig.PermissionsPolicy
+	}
+
+	// Pre-build the HSTS header value once, as it does not depend on the request.
+	hstsValue := "max-age=" + strconv.Itoa(config.HSTSMaxAge)
+	if !config.HSTSExcludeSubdomains {
+		hstsValue += "; includeSubDomains"
+	}
+	if config.HSTSPreload {
+		hstsValue += "; preload"
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if !config.DisableContentTypeOptions {
+				c.SetHeader("X-Content-Type-Options", config.ContentTypeOptions)
+			}
+			if !config.DisableFrameOptions {
+				c.SetHeader("X-Frame-Options", config.FrameOptions)
+			}
+			if !config.DisableHSTS && c.Scheme() == "https" {
+				c.SetHeader("Strict-Transport-Security", hstsValue)
+			}
+			if !config.DisableContentSecurityPolicy {
+				c.SetHeader("Content-Security-Policy", config.ContentSecurityPolicy)
+			}
+			if !config.DisableReferrerPolicy {
+				c.SetHeader("Referrer-Policy", config.ReferrerPolicy)
+			}
+			if !config.DisablePermissionsPolicy {
+				c.SetHeader("Permissions-Policy", config.PermissionsPolicy)
+			}
+			return next(c)
+		}
+	}
+}
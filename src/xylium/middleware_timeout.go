@@ -4,6 +4,7 @@ package xylium
 import (
 	"context"
 	"fmt"
+	"sync" // Untuk sinkronisasi timeoutScope
 	"time" // Diperlukan untuk time.Duration dan time.After
 )
 
@@ -11,6 +12,26 @@ import (
 // This middleware sets a maximum duration for processing a request by subsequent
 // handlers in the chain. If this duration is exceeded, the request context is canceled,
 // and an error response (typically HTTP 503 Service Unavailable) is sent.
+//
+// The deadline is propagated to the request-scoped Go context, so handlers can use
+// `c.Context()` (or `c.GoContext()`) for cooperative cancellation, e.g.,
+// `db.QueryContext(c.Context(), ...)`. Once the timeout fires, the handler's response
+// is considered committed and any late writes it makes are discarded.
+//
+// If the handler has already started a streamed response (with `c.Stream`,
+// `c.SetBodyStreamWriter` or a streamed `c.JSON`/`c.XML`) when the timeout fires, its
+// status and headers can no longer be replaced without corrupting the stream. The
+// middleware then aborts the response instead of sending an error: the stream is closed
+// (further writes by a `SetBodyStreamWriter` function fail) and the connection is closed
+// without a complete response, so the client sees a failed request rather than a
+// truncated stream that looks complete. `ErrorHandler` is not called in that case.
+//
+// Timeout middleware is safe to stack. When a Timeout middleware runs inside another
+// one (e.g., a per-route `WithTimeout` under a global `Timeout`), the inner middleware
+// does not start a second timer racing the first. Instead it replaces the deadline of
+// the enclosing timeout with its own (measured from when the inner middleware starts)
+// and takes over its message and error handling, so a longer per-route timeout
+// supersedes a shorter global one and both cancel the same request context.
 type TimeoutConfig struct {
 	// Timeout is the maximum duration allowed for processing a request.
 	// This duration starts when the timeout middleware begins processing.
@@ -32,6 +53,7 @@ type TimeoutConfig struct {
 	// The ErrorHandler is responsible for formulating and sending the complete client response.
 	// It should return an error if it fails to handle the timeout, though typically it returns nil
 	// after sending the response.
+	// It is not called if the response body had already started streaming; see TimeoutConfig.
 	ErrorHandler func(c *Context, err error) error
 }
 
@@ -44,6 +66,108 @@ func Timeout(timeout time.Duration) Middleware {
 	})
 }
 
+// WithTimeout returns a minimal Timeout middleware intended to be attached to a single
+// route or group, overriding any timeout applied globally:
+//
+//	app.Use(xylium.Timeout(5 * time.Second))
+//	app.GET("/reports", GenerateReport, xylium.WithTimeout(2*time.Minute))
+func WithTimeout(timeout time.Duration) Middleware {
+	return Timeout(timeout)
+}
+
+// timeoutScopeKey is the Go context key under which the active timeoutScope is stored.
+type timeoutScopeKey struct{}
+
+// timeoutScope is the deadline shared by stacked Timeout middlewares of a single request.
+// The outermost Timeout middleware owns it; nested ones only move its deadline.
+type timeoutScope struct {
+	mu           sync.Mutex
+	timer        *time.Timer
+	deadline     time.Time
+	errorHandler func(c *Context, err error) error
+	done         bool
+}
+
+// override replaces the scope's deadline and error handler. It returns false if the
+// scope has already timed out or finished.
+func (s *timeoutScope) override(timeout time.Duration, errorHandler func(c *Context, err error) error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done || !s.timer.Stop() {
+		return false
+	}
+	s.deadline = time.Now().Add(timeout)
+	s.errorHandler = errorHandler
+	s.timer.Reset(timeout)
+	return true
+}
+
+// currentErrorHandler returns the error handler of the innermost Timeout middleware.
+func (s *timeoutScope) currentErrorHandler() func(c *Context, err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.errorHandler
+}
+
+// currentDeadline returns the deadline set by the innermost Timeout middleware.
+func (s *timeoutScope) currentDeadline() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deadline
+}
+
+// finish stops the scope's timer once the owning middleware returns.
+func (s *timeoutScope) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.timer.Stop()
+}
+
+// timeoutContext is the Go context handed to handlers under a Timeout middleware.
+// Unlike `context.WithTimeout`, its deadline can be moved by a nested Timeout middleware.
+type timeoutContext struct {
+	context.Context // Cancelable context carrying the timeoutScope value.
+	scope           *timeoutScope
+}
+
+// Deadline returns the earlier of the scope's deadline and any parent deadline.
+func (ctx *timeoutContext) Deadline() (time.Time, bool) {
+	deadline := ctx.scope.currentDeadline()
+	if parentDeadline, ok := ctx.Context.Deadline(); ok && parentDeadline.Before(deadline) {
+		return parentDeadline, true
+	}
+	return deadline, true
+}
+
+// Err reports `context.DeadlineExceeded` when the scope's timer canceled the context.
+func (ctx *timeoutContext) Err() error {
+	err := ctx.Context.Err()
+	if err != nil && context.Cause(ctx.Context) == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// newTimeoutContext creates a timeoutContext derived from `parent` that is canceled
+// after `timeout`, unless a nested Timeout middleware moves the deadline.
+func newTimeoutContext(parent context.Context, timeout time.Duration, errorHandler func(c *Context, err error) error) (*timeoutContext, *timeoutScope, context.CancelFunc) {
+	cancelCtx, cancel := context.WithCancelCause(parent)
+	scope := &timeoutScope{
+		deadline:     time.Now().Add(timeout),
+		errorHandler: errorHandler,
+	}
+	scope.timer = time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+	ctx := &timeoutContext{
+		Context: context.WithValue(cancelCtx, timeoutScopeKey{}, scope),
+		scope:   scope,
+	}
+	return ctx, scope, func() {
+		scope.finish()
+		cancel(context.Canceled)
+	}
+}
+
 // TimeoutWithConfig returns a Timeout middleware with the provided custom configuration.
 func TimeoutWithConfig(config TimeoutConfig) Middleware {
 	if config.Timeout <= 0 {
@@ -99,11 +223,42 @@ func TimeoutWithConfig(config TimeoutConfig) Middleware {
 			logger := c.Logger().WithFields(M{"middleware": "Timeout"})
 
 			parentCtx := c.GoContext() // Go context dari 'c'
-			ctxWithTimeout, cancelFunc := context.WithTimeout(parentCtx, config.Timeout)
+
+			// Jika sudah berada di dalam Timeout middleware lain, ambil alih deadline-nya
+			// alih-alih membuat timer kedua yang saling berlomba.
+			if scope, ok := parentCtx.Value(timeoutScopeKey{}).(*timeoutScope); ok {
+				if scope.override(config.Timeout, errorHandlerToUse) {
+					logger.Debugf("Overriding enclosing timeout with %v for %s %s.", config.Timeout, c.Method(), c.Path())
+				}
+				return next(c)
+			}
+
+			ctxWithTimeout, scope, cancelFunc := newTimeoutContext(parentCtx, config.Timeout, errorHandlerToUse)
 			defer cancelFunc() // Pastikan cancel selalu dipanggil
 
-			// timedXyliumCtx adalah context Xylium yang membawa Go context yang di-timeout
+			// timedXyliumCtx adalah context Xylium yang membawa Go context yang di-timeout.
+			// Handler yang memakai c.Context() / c.GoContext() (misalnya db.QueryContext)
+			// akan ikut dibatalkan saat deadline ini tercapai.
 			timedXyliumCtx := c.WithGoContext(ctxWithTimeout)
+			// Guard ini memungkinkan middleware "mengambil alih" response saat timeout,
+			// sehingga penulisan terlambat oleh handler dibuang tanpa data race.
+			guard := &responseGuard{parent: c.respGuard}
+			timedXyliumCtx.respGuard = guard
+
+			// onTimeout menandai response handler sebagai committed (detach menunggu
+			// penulisan yang sedang berjalan selesai), lalu memanggil ErrorHandler
+			// milik Timeout middleware terdalam.
+			onTimeout := func(timeoutError error) error {
+				guard.detach()
+				// Jika handler sudah memulai streaming body, response error tidak bisa lagi
+				// dikirim tanpa merusak stream; koneksi dibatalkan alih-alih memanggil ErrorHandler.
+				if c.abortBodyStream(errStreamDeadlineExceeded) {
+					logger.Warnf("Request %s %s timed out after its response body started streaming. Aborting the stream and closing the connection instead of sending an error response. Original context error: %v",
+						c.Method(), c.Path(), timeoutError)
+					return timeoutError
+				}
+				return scope.currentErrorHandler()(c, timeoutError)
+			}
 
 			resultChan := make(chan error, 1)
 			panicValChan := make(chan interface{}, 1) // Menggunakan nama yang berbeda untuk kejelasan
@@ -149,7 +304,7 @@ func TimeoutWithConfig(config TimeoutConfig) Middleware {
 					// agar ErrorHandler bisa menggunakan c.ResponseCommitted() yang merefleksikan
 					// state response dari handler 'next' yang berjalan dengan 'timedXyliumCtx'.
 					// Namun, untuk kejelasan, errorHandlerToUse sudah memeriksa c.ResponseCommitted().
-					return errorHandlerToUse(c, timeoutError)
+					return onTimeout(timeoutError)
 				default:
 					// Timeout belum terjadi (atau belum terdeteksi di sini).
 					// Kembalikan hasil dari handler.
@@ -177,7 +332,7 @@ func TimeoutWithConfig(config TimeoutConfig) Middleware {
 				select {
 				case <-ctxWithTimeout.Done():
 					timeoutError := ctxWithTimeout.Err()
-					return errorHandlerToUse(c, timeoutError)
+					return onTimeout(timeoutError)
 				default:
 					return errFromHandlerAfterEmptyPanic
 				}
@@ -200,7 +355,7 @@ func TimeoutWithConfig(config TimeoutConfig) Middleware {
 
 				// Panggil ErrorHandler. ErrorHandler (default atau custom)
 				// akan memeriksa c.ResponseCommitted() dari context asli 'c'.
-				return errorHandlerToUse(c, timeoutError)
+				return onTimeout(timeoutError)
 			}
 		}
 	}
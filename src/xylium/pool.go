@@ -1,8 +1,9 @@
 package xylium
 
 import (
-	"context" // For Go's context.Context
-	"sync"    // For sync.Pool and sync.RWMutex
+	"context"     // For Go's context.Context
+	"sync"        // For sync.Pool and sync.RWMutex
+	"sync/atomic" // For lock-free reads of the registered release hooks.
 
 	"github.com/valyala/fasthttp" // For fasthttp.RequestCtx
 )
@@ -61,8 +62,62 @@ func acquireCtx(originalFasthttpCtx *fasthttp.RequestCtx) *Context {
 	return c
 }
 
-// releaseCtx resets the provided `Context` and returns it to the `ctxPool`.
+// contextReleaseHooks holds the hooks registered via `OnContextRelease`.
+// It is replaced as a whole on registration (copy-on-write), so `releaseCtx`
+// can read it on every request without locking.
+var (
+	contextReleaseHooks   atomic.Pointer[[]func(*Context)]
+	contextReleaseHooksMu sync.Mutex // Serializes registrations.
+)
+
+// OnContextRelease registers a hook that is called for every pooled `Context` right
+// before it is reset and returned to the pool, i.e., after the response for the
+// request has been fully handled (including the `GlobalErrorHandler`).
+//
+// Use it to release per-request state kept outside the Context (e.g., pooled buffers,
+// per-request entries in a library's own maps) or to clear references held in values
+// stored with `c.Set`. The store itself does not need clearing: `reset` always empties
+// it, along with every other request-specific field (see `Context.reset`).
+//
+// Hooks run in registration order, on the request's goroutine, and must be fast.
+// The Context is still fully usable inside a hook (`c.Get`, `c.Logger()`, etc.), but
+// it must not be retained afterwards. A panic in a hook is recovered and logged so
+// that the Context is still returned to the pool.
+//
+// Hooks are global to the process and typically registered in `init` or during
+// application setup. Panics if `hook` is nil.
+func OnContextRelease(hook func(c *Context)) {
+	if hook == nil {
+		panic("xylium: OnContextRelease hook cannot be nil")
+	}
+	contextReleaseHooksMu.Lock()
+	defer contextReleaseHooksMu.Unlock()
+	var hooks []func(*Context)
+	if current := contextReleaseHooks.Load(); current != nil {
+		hooks = append(hooks, *current...)
+	}
+	hooks = append(hooks, hook)
+	contextReleaseHooks.Store(&hooks)
+}
+
+// releaseCtx runs the registered release hooks, resets the provided `Context`,
+// and returns it to the `ctxPool`.
 func releaseCtx(c *Context) {
+	if hooks := contextReleaseHooks.Load(); hooks != nil {
+		for _, hook := range *hooks {
+			runContextReleaseHook(c, hook)
+		}
+	}
 	c.reset()      // Call the Context's reset method to clear its state.
 	ctxPool.Put(c) // Return the cleaned Context instance to the pool.
 }
+
+// runContextReleaseHook calls a single release hook, recovering from any panic.
+func runContextReleaseHook(c *Context, hook func(*Context)) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			c.Logger().Errorf("xylium: recovered from panic in OnContextRelease hook: %v", rec)
+		}
+	}()
+	hook(c)
+}
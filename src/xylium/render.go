@@ -0,0 +1,61 @@
+// src/xylium/render.go
+package xylium
+
+import (
+	"fmt" // For formatting "renderer not configured" messages.
+	"io"  // For the Renderer interface.
+)
+
+// RendererHTML is the name under which `c.Render` finds the HTML renderer. Unless a
+// renderer is registered under this name with `Router.AddRenderer`, it resolves to
+// `Router.HTMLRenderer`, which is also what `c.HTML` uses.
+const RendererHTML = "html"
+
+// Renderer renders a named template (or document) for `c.Render`, writing the output to
+// `w` (the HTTP response body writer). Its method set matches `HTMLRenderer`, so existing
+// HTML renderers can be registered as named renderers as they are.
+//
+// A renderer may also implement `ContentType() string`; `c.Render` then sets the
+// response Content-Type to the returned value before calling `Render`. Otherwise,
+// Content-Type is "text/html; charset=utf-8" for the "html" renderer and is left to the
+// renderer (e.g., via `c.SetContentType`) for others.
+type Renderer interface {
+	Render(w io.Writer, name string, data interface{}, c *Context) error
+}
+
+// AddRenderer registers `renderer` under `name` for use with `c.Render`, replacing any
+// renderer previously registered under that name. Registering a renderer as
+// `RendererHTML` ("html") makes it the renderer used by `c.HTML` as well, instead of
+// `Router.HTMLRenderer`. Renderers should be registered before the server starts.
+// It panics if `name` is empty or `renderer` is nil.
+//
+// Example:
+//
+//	app.AddRenderer("pdf", pdfRenderer)
+//	app.AddRenderer("csv", csvRenderer)
+//	// In a handler:
+//	return c.Render(xylium.StatusOK, "pdf", "invoice", invoice)
+func (r *Router) AddRenderer(name string, renderer Renderer) {
+	if name == "" {
+		panic("xylium: AddRenderer requires a non-empty renderer name")
+	}
+	if renderer == nil {
+		panic(fmt.Sprintf("xylium: AddRenderer called with a nil renderer for '%s'", name))
+	}
+	if r.renderers == nil {
+		r.renderers = make(map[string]Renderer)
+	}
+	r.renderers[name] = renderer
+}
+
+// renderer returns the renderer registered under `name`, falling back to `HTMLRenderer`
+// for `RendererHTML`. It returns nil if no renderer is configured for `name`.
+func (r *Router) renderer(name string) Renderer {
+	if renderer, ok := r.renderers[name]; ok {
+		return renderer
+	}
+	if name == RendererHTML && r.HTMLRenderer != nil {
+		return r.HTMLRenderer
+	}
+	return nil
+}
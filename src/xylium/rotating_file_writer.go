@@ -0,0 +1,336 @@
+// src/xylium/rotating_file_writer.go
+package xylium
+
+import (
+	"compress/gzip" // For compressing rotated backup files.
+	"errors"        // For creating configuration errors.
+	"fmt"           // For formatting errors.
+	"io"            // For io.Copy when compressing backups.
+	"os"            // For file operations.
+	"path/filepath" // For deriving backup file names and listing backups.
+	"sort"          // For ordering backups by age.
+	"strings"       // For matching backup file names.
+	"sync"          // For thread-safe writes and tracking background compression.
+	"time"          // For backup timestamps, max age, and daily rotation.
+)
+
+// rotatingBackupTimeFormat is the timestamp layout embedded in backup file names.
+// It avoids characters (like ':') that are invalid in file names on some platforms.
+const rotatingBackupTimeFormat = "2006-01-02T15-04-05.000"
+
+// rotatingCompressSuffix is appended to the names of compressed backup files.
+const rotatingCompressSuffix = ".gz"
+
+// RotatingFileWriterConfig defines the configuration for a `RotatingFileWriter`.
+type RotatingFileWriterConfig struct {
+	// Filename is the path of the active log file. Its directory is created if needed.
+	// Backups are written to the same directory as "<name>-<timestamp><ext>",
+	// e.g., "app-2024-01-02T15-04-05.000.log" for "app.log".
+	// Required.
+	Filename string
+
+	// MaxSize is the maximum size in bytes of the active log file before it is rotated.
+	// If 0, the file is not rotated based on size.
+	MaxSize int64
+
+	// Daily, if true, rotates the file when the local calendar day changes.
+	// Can be combined with `MaxSize`.
+	Daily bool
+
+	// MaxAge is the maximum age of backup files (based on the timestamp in their name).
+	// Older backups are removed after each rotation. If 0, backups are not removed based on age.
+	MaxAge time.Duration
+
+	// MaxBackups is the maximum number of backup files to keep. The oldest backups are
+	// removed after each rotation. If 0, all backups are kept (subject to `MaxAge`).
+	MaxBackups int
+
+	// Compress, if true, gzips backup files after rotation. Compression runs in the
+	// background so it does not block logging.
+	Compress bool
+}
+
+// RotatingFileWriter is an `io.Writer` and `io.Closer` that writes to a file and rotates it
+// based on size and/or the calendar day, keeping a bounded set of (optionally compressed)
+// backups. It is safe for concurrent use and can be used as `LoggerConfig.Output`.
+//
+// Since it is not an `*os.File`, `DefaultLogger` never detects it as a terminal, so ANSI
+// colors are never written to the log file. When a router creates its own `DefaultLogger`
+// with a `RotatingFileWriter` as output, the writer is registered as a closer and is closed
+// during graceful shutdown.
+//
+// Writing after `Close` reopens the file, so late log entries are not lost.
+type RotatingFileWriter struct {
+	config RotatingFileWriterConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64  // Current size of the active file.
+	openedOn string // Local date ("2006-01-02") of the active file, for daily rotation.
+
+	millMu sync.Mutex     // Serializes background compression and cleanup.
+	millWg sync.WaitGroup // Tracks background compression and cleanup, awaited by Close.
+}
+
+// NewRotatingFileWriter creates a `RotatingFileWriter` and opens (or creates) the log file
+// in append mode. It returns an error if `config.Filename` is empty, if any limit is
+// negative, or if the file cannot be opened.
+func NewRotatingFileWriter(config RotatingFileWriterConfig) (*RotatingFileWriter, error) {
+	if config.Filename == "" {
+		return nil, errors.New("xylium: RotatingFileWriter requires a Filename")
+	}
+	if config.MaxSize < 0 || config.MaxAge < 0 || config.MaxBackups < 0 {
+		return nil, errors.New("xylium: RotatingFileWriter limits (MaxSize, MaxAge, MaxBackups) cannot be negative")
+	}
+	w := &RotatingFileWriter{config: config}
+	if err := w.openExistingOrNew(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes `p` to the active log file, rotating it first if the write would exceed
+// `MaxSize` or if the calendar day has changed (with `Daily`).
+// A single write larger than `MaxSize` is written to a fresh file rather than split.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.openExistingOrNew(); err != nil {
+			return 0, err
+		}
+	}
+	if w.shouldRotateLocked(int64(len(p))) {
+		if err := w.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate forces a rotation of the active log file, regardless of size or date.
+func (w *RotatingFileWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateLocked()
+}
+
+// Close syncs and closes the active log file and waits for any background compression
+// and cleanup to finish. It is safe to call multiple times.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	err := w.closeFileLocked()
+	w.mu.Unlock()
+	w.millWg.Wait()
+	return err
+}
+
+// shouldRotateLocked reports whether the active file must be rotated before writing
+// `writeLen` more bytes. Must be called with `w.mu` held.
+func (w *RotatingFileWriter) shouldRotateLocked(writeLen int64) bool {
+	if w.config.MaxSize > 0 && w.size > 0 && w.size+writeLen > w.config.MaxSize {
+		return true
+	}
+	if w.config.Daily && w.openedOn != time.Now().Format("2006-01-02") {
+		return true
+	}
+	return false
+}
+
+// openExistingOrNew opens the log file in append mode, creating it (and its directory)
+// if needed. Must be called with `w.mu` held (or before the writer is shared).
+func (w *RotatingFileWriter) openExistingOrNew() error {
+	if err := os.MkdirAll(filepath.Dir(w.config.Filename), 0o755); err != nil {
+		return fmt.Errorf("xylium: RotatingFileWriter failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(w.config.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("xylium: RotatingFileWriter failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("xylium: RotatingFileWriter failed to stat log file: %w", err)
+	}
+	w.file = file
+	w.size = info.Size()
+	w.openedOn = time.Now().Format("2006-01-02")
+	if info.Size() > 0 {
+		// An existing file belongs to the day it was last written.
+		w.openedOn = info.ModTime().Format("2006-01-02")
+	}
+	return nil
+}
+
+// closeFileLocked closes the active file, if open. Must be called with `w.mu` held.
+func (w *RotatingFileWriter) closeFileLocked() error {
+	if w.file == nil {
+		return nil
+	}
+	syncErr := w.file.Sync()
+	closeErr := w.file.Close()
+	w.file = nil
+	if closeErr != nil {
+		return closeErr
+	}
+	return syncErr
+}
+
+// rotateLocked renames the active file to a timestamped backup, opens a new active file,
+// and starts background compression/cleanup of backups. Must be called with `w.mu` held.
+func (w *RotatingFileWriter) rotateLocked() error {
+	if err := w.closeFileLocked(); err != nil {
+		return fmt.Errorf("xylium: RotatingFileWriter failed to close log file for rotation: %w", err)
+	}
+
+	backupName := w.backupName(time.Now())
+	if err := os.Rename(w.config.Filename, backupName); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("xylium: RotatingFileWriter failed to rename log file: %w", err)
+	}
+	if err := w.openExistingOrNew(); err != nil {
+		return err
+	}
+
+	w.millWg.Add(1)
+	go w.mill()
+	return nil
+}
+
+// backupName returns an unused backup file name for a rotation at time `t`.
+// If rotations happen within the same millisecond, the timestamp is advanced
+// so an existing backup is never overwritten.
+func (w *RotatingFileWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.config.Filename)
+	base := filepath.Base(w.config.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext)
+	for {
+		name := filepath.Join(dir, prefix+"-"+t.Format(rotatingBackupTimeFormat)+ext)
+		if !fileExists(name) && !fileExists(name+rotatingCompressSuffix) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// fileExists reports whether a file exists at `path`.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// rotatingBackup describes a backup file found on disk.
+type rotatingBackup struct {
+	path      string
+	timestamp time.Time
+}
+
+// listBackups returns all backups of the log file, newest first.
+func (w *RotatingFileWriter) listBackups() ([]rotatingBackup, error) {
+	dir := filepath.Dir(w.config.Filename)
+	base := filepath.Base(w.config.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []rotatingBackup
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		trimmed := strings.TrimSuffix(name, rotatingCompressSuffix)
+		if !strings.HasPrefix(trimmed, prefix) || !strings.HasSuffix(trimmed, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(trimmed, prefix), ext)
+		ts, err := time.ParseInLocation(rotatingBackupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue // Not one of our backups.
+		}
+		backups = append(backups, rotatingBackup{path: filepath.Join(dir, name), timestamp: ts})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].timestamp.After(backups[j].timestamp) })
+	return backups, nil
+}
+
+// mill compresses uncompressed backups (if enabled) and removes backups exceeding
+// `MaxBackups` or `MaxAge`. It runs in the background after each rotation; errors are
+// reported to os.Stderr, since the writer may itself be the logger's output.
+func (w *RotatingFileWriter) mill() {
+	defer w.millWg.Done()
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	backups, err := w.listBackups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[XYLIUM-ROTATING-WRITER-ERROR] Failed to list log backups: %v\n", err)
+		return
+	}
+
+	cutoff := time.Now().Add(-w.config.MaxAge)
+	var kept []rotatingBackup
+	for i, b := range backups {
+		tooMany := w.config.MaxBackups > 0 && i >= w.config.MaxBackups
+		tooOld := w.config.MaxAge > 0 && b.timestamp.Before(cutoff)
+		if tooMany || tooOld {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "[XYLIUM-ROTATING-WRITER-ERROR] Failed to remove old log backup %s: %v\n", b.path, err)
+			}
+			continue
+		}
+		kept = append(kept, b)
+	}
+
+	if !w.config.Compress {
+		return
+	}
+	for _, b := range kept {
+		if strings.HasSuffix(b.path, rotatingCompressSuffix) {
+			continue
+		}
+		if err := compressFile(b.path, b.path+rotatingCompressSuffix); err != nil {
+			fmt.Fprintf(os.Stderr, "[XYLIUM-ROTATING-WRITER-ERROR] Failed to compress log backup %s: %v\n", b.path, err)
+		}
+	}
+}
+
+// compressFile gzips `src` into `dst` and removes `src` on success.
+func compressFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(dst) // Do not leave a partial archive behind.
+		}
+	}()
+
+	gz := gzip.NewWriter(out)
+	if _, err = io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
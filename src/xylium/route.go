@@ -0,0 +1,204 @@
+// src/xylium/route.go
+package xylium
+
+import (
+	"errors"  // For joining the problems found in a route table.
+	"fmt"     // For describing invalid route table entries.
+	"strings" // For normalizing methods and paths of a route table.
+	"time"    // For per-route timeouts.
+)
+
+// Route is a handle to a registered route, returned by the route registration methods
+// (e.g., `GET`, `Match`, `RouteGroup.POST`) so the route can be configured further
+// with chained calls:
+//
+//	app.GET("/reports/:id", GenerateReport).Timeout(30 * time.Second)
+//
+// The return value can be ignored when no further configuration is needed. A route
+// registered for several methods at once (`Any`, `Match`) has a single handle shared by
+// all of them. Configure routes during setup, before the server starts.
+type Route struct {
+	// name is the route's name (empty if unnamed); see `Name`.
+	name string
+	// meta holds the route's metadata (nil until set); see `Meta`.
+	meta map[string]interface{}
+	// timeout is the route's own timeout (0 if none); see `Timeout`.
+	timeout time.Duration
+	// timeoutMiddleware is the `Timeout` middleware built for `timeout`, nil if unset.
+	timeoutMiddleware Middleware
+}
+
+// Name sets a name for the route, reported by `Router.Routes`, e.g., to label the
+// route in documentation or metrics. Names are not required to be unique.
+func (rt *Route) Name(name string) *Route {
+	rt.name = name
+	return rt
+}
+
+// Meta attaches the metadata `value` under `key` to the route, replacing any value
+// previously set for `key`. Metadata is reported by `Router.Routes` and readable by
+// middleware and handlers for requests to the route via `c.RouteMeta`.
+//
+// Example:
+//
+//	app.DELETE("/users/:id", DeleteUser).Name("users.delete").Meta("scope", "admin")
+func (rt *Route) Meta(key string, value interface{}) *Route {
+	if rt.meta == nil {
+		rt.meta = make(map[string]interface{})
+	}
+	rt.meta[key] = value
+	return rt
+}
+
+// Timeout sets a timeout for requests to this route, as if the `Timeout` middleware
+// with duration `d` wrapped the route's group and route middleware and its handler.
+// It takes precedence over the timeout of the route's group (`RouteGroup.SetTimeout`)
+// and the router-wide `ServerConfig.RouteTimeout`. A `Timeout` middleware registered
+// globally is superseded by it (see `TimeoutConfig` on stacked timeouts).
+//
+// Pass 0 to remove the route's own timeout again. It panics if `d` is negative.
+func (rt *Route) Timeout(d time.Duration) *Route {
+	rt.timeout, rt.timeoutMiddleware = d, newRouteTimeoutMiddleware(d, "Route.Timeout")
+	return rt
+}
+
+// newRouteTimeoutMiddleware returns the `Timeout` middleware for a declarative timeout
+// of `d`, or nil for 0. `setter` names the caller in the panic for a negative `d`.
+func newRouteTimeoutMiddleware(d time.Duration, setter string) Middleware {
+	if d < 0 {
+		panic("xylium: " + setter + " requires a non-negative duration, got " + d.String())
+	}
+	if d == 0 {
+		return nil
+	}
+	return Timeout(d)
+}
+
+// RouteInfo describes a registered route, as reported by `Router.Routes`.
+type RouteInfo struct {
+	Method string // HTTP method, e.g., "GET".
+	Path   string // Full path pattern, e.g., "/users/:id".
+	Name   string // Name set with `Route.Name` (empty if unnamed).
+	// Timeout is the effective declarative timeout of the route: its own `Route.Timeout`,
+	// else its group's `RouteGroup.SetTimeout`, else `ServerConfig.RouteTimeout` (0 if none).
+	Timeout time.Duration
+	// Meta is a copy of the metadata set with `Route.Meta` (nil if none).
+	Meta map[string]interface{}
+}
+
+// Routes returns all registered routes, one entry per method, ordered like the routes
+// printed at startup in `DebugMode` (by path pattern, methods sorted alphabetically).
+// Routes registered by the framework itself (e.g., health checks) are included.
+func (r *Router) Routes() []RouteInfo {
+	var routes []RouteInfo
+	r.tree.walkRoutes(func(method, path string, target routeTarget) {
+		info := RouteInfo{Method: method, Path: path, Timeout: r.routeTimeout(target)}
+		if target.route != nil {
+			info.Name = target.route.name
+			if len(target.route.meta) > 0 {
+				info.Meta = make(map[string]interface{}, len(target.route.meta))
+				for k, v := range target.route.meta {
+					info.Meta[k] = v
+				}
+			}
+		}
+		routes = append(routes, info)
+	})
+	return routes
+}
+
+// routeTimeout returns the duration of the timeout selected by `routeTimeoutMiddleware`.
+func (r *Router) routeTimeout(target routeTarget) time.Duration {
+	if target.route != nil && target.route.timeoutMiddleware != nil {
+		return target.route.timeout
+	}
+	for g := target.group; g != nil; g = g.parent {
+		if g.timeoutMiddleware != nil {
+			return g.timeout
+		}
+	}
+	if r.defaultRouteTimeoutMiddleware == nil {
+		return 0
+	}
+	return r.serverConfig.RouteTimeout
+}
+
+// routeTimeoutMiddleware returns the `Timeout` middleware that applies to `target`:
+// the route's own, else the closest group's, else the router-wide default (nil if none).
+func (r *Router) routeTimeoutMiddleware(target routeTarget) Middleware {
+	if target.route != nil && target.route.timeoutMiddleware != nil {
+		return target.route.timeoutMiddleware
+	}
+	for g := target.group; g != nil; g = g.parent {
+		if g.timeoutMiddleware != nil {
+			return g.timeoutMiddleware
+		}
+	}
+	return r.defaultRouteTimeoutMiddleware
+}
+
+// RouteDef describes a route for `Router.Register`, so routes can be declared as data.
+type RouteDef struct {
+	Method     string       // HTTP method, e.g., "GET" (case-insensitive). Required.
+	Path       string       // Path pattern, e.g., "/users/:id". Must begin with "/".
+	Handler    HandlerFunc  // Route handler. Required.
+	Middleware []Middleware // Route-specific middleware, outermost first (optional).
+	Name       string       // Route name, as set with `Route.Name` (optional).
+}
+
+// Register registers every route of `routes`, like calling `GET`, `POST`, etc. once per
+// entry followed by `Route.Name` for named ones, e.g., to keep a large API in one
+// reviewable table:
+//
+//	err := app.Register([]xylium.RouteDef{
+//		{Method: xylium.MethodGet, Path: "/users", Handler: ListUsers, Name: "users.list"},
+//		{Method: xylium.MethodPost, Path: "/users", Handler: CreateUser, Middleware: []xylium.Middleware{auth}},
+//	})
+//
+// The whole table is validated first. If an entry has no method or handler, a path not
+// beginning with "/", or the same method and path as another entry or as an already
+// registered route (trailing slashes are ignored), an error describing every such
+// problem is returned and no route is registered. Malformed path patterns (see
+// `Tree.Add`) still panic like with the other registration methods.
+func (r *Router) Register(routes []RouteDef) error {
+	existing := make(map[string]bool)
+	r.tree.walkRoutes(func(method, path string, _ routeTarget) {
+		existing[method+" "+path] = true
+	})
+	defined := make(map[string]int, len(routes)) // "METHOD /path" -> index of the entry.
+	var problems []error
+	for i, def := range routes {
+		method := strings.ToUpper(strings.TrimSpace(def.Method))
+		path := def.Path
+		if len(path) > 1 && path[len(path)-1] == '/' {
+			path = path[:len(path)-1] // Normalized like in `Tree.Add`.
+		}
+		switch {
+		case method == "":
+			problems = append(problems, fmt.Errorf("route %d (%s): method is required", i, def.Path))
+		case path == "" || path[0] != '/':
+			problems = append(problems, fmt.Errorf("route %d (%s %s): path must begin with '/'", i, method, def.Path))
+		case def.Handler == nil:
+			problems = append(problems, fmt.Errorf("route %d (%s %s): handler is required", i, method, def.Path))
+		case existing[method+" "+path]:
+			problems = append(problems, fmt.Errorf("route %d (%s %s): a route is already registered for this method and path", i, method, def.Path))
+		default:
+			if first, ok := defined[method+" "+path]; ok {
+				problems = append(problems, fmt.Errorf("route %d (%s %s): duplicates route %d", i, method, def.Path, first))
+			} else {
+				defined[method+" "+path] = i
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("xylium: invalid route table, no route registered: %w", errors.Join(problems...))
+	}
+
+	for _, def := range routes {
+		route := r.addRoute(strings.ToUpper(strings.TrimSpace(def.Method)), def.Path, def.Handler, def.Middleware...)
+		if def.Name != "" {
+			route.Name(def.Name)
+		}
+	}
+	return nil
+}
@@ -1,14 +1,21 @@
 package xylium
 
 import (
+	"crypto/tls"    // For the certificate held for ReloadCertificate.
 	"encoding/json" // For ServeFiles PathNotFound JSON response.
+	"errors"        // For recognizing errors wrapping ErrResponseWritten.
 	"fmt"           // For error formatting and path/panic messages.
 	"io"            // For HTMLRenderer interface and io.Closer.
+	"io/fs"         // For fs.FS in ServeFilesFS.
 	"os"            // For os.Stdout in logger config adjustments (NewWithConfig).
 	"path/filepath" // For path cleaning and manipulation in ServeFiles.
+	"reflect"       // For the MustAppGet panic message and comparing closers in AppDelete.
 	"runtime/debug" // For capturing stack traces on panic.
+	"sort"          // For keeping allowed methods lists (Allow header) sorted.
 	"strings"       // For string manipulation (path normalization, joining).
 	"sync"          // For sync.RWMutex and sync.Mutex.
+	"sync/atomic"   // For the readiness flag.
+	"time"          // For ServeFilesConfig.MaxAge and RouteGroup.SetTimeout.
 
 	"github.com/valyala/fasthttp" // The underlying HTTP engine.
 )
@@ -48,6 +55,9 @@ type Router struct {
 	// every request handled by this router, before any group-specific or
 	// route-specific middleware.
 	globalMiddleware []Middleware
+	// preRoutingHooks are invoked for every request before the route lookup in the
+	// radix tree, allowing the request method or path to be rewritten.
+	preRoutingHooks []PreRoutingHook
 
 	// PanicHandler is invoked when a panic is recovered during the processing of a request
 	// (e.g., in a handler or middleware). If not explicitly set by the user,
@@ -56,6 +66,9 @@ type Router struct {
 	// NotFoundHandler is invoked when no registered route matches the requested URL path.
 	// If not set, Xylium uses `defaultNotFoundHandler` (responds with HTTP 404).
 	NotFoundHandler HandlerFunc
+	// fallbacks holds the prefix-scoped 404 handlers registered via `Fallback`,
+	// consulted before `NotFoundHandler`.
+	fallbacks []routeFallback
 	// MethodNotAllowedHandler is invoked when a route matches the requested URL path,
 	// but not the HTTP method used in the request. The router automatically sets the
 	// "Allow" header with permitted methods before calling this handler.
@@ -64,8 +77,13 @@ type Router struct {
 	// GlobalErrorHandler is the central handler for processing all errors returned by
 	// route handlers, middleware, or the `PanicHandler`. It is responsible for
 	// logging the error and sending an appropriate HTTP response to the client.
+	// Routes in a `RouteGroup` with its own error handler (see `RouteGroup.SetErrorHandler`)
+	// use that handler instead.
 	// If not set, Xylium uses `defaultGlobalErrorHandler`.
 	GlobalErrorHandler HandlerFunc
+	// errorMappers translate non-`*HTTPError` errors into `*HTTPError` values for the
+	// default error handler (see `RegisterErrorMapper`).
+	errorMappers []ErrorMapper
 
 	// serverConfig holds the configuration for the underlying `fasthttp.Server`
 	// and Xylium-specific server operational settings.
@@ -73,6 +91,8 @@ type Router struct {
 	// HTMLRenderer is an optional instance that implements the `HTMLRenderer` interface.
 	// If set, it enables the use of `c.HTML()` for rendering HTML templates.
 	HTMLRenderer HTMLRenderer
+	// renderers holds the named renderers registered with `AddRenderer` for `c.Render`.
+	renderers map[string]Renderer
 	// instanceMode stores the operating mode (e.g., "debug", "release", "test")
 	// for this specific router instance. This mode influences behaviors like
 	// default logger configuration and error reporting verbosity.
@@ -104,6 +124,33 @@ type Router struct {
 	internalRateLimitStores []LimiterStore
 	// internalRateLimitStoresMux is a mutex protecting `internalRateLimitStores`.
 	internalRateLimitStoresMux sync.Mutex
+
+	// defaultRouteTimeoutMiddleware is the `Timeout` middleware built from
+	// `ServerConfig.RouteTimeout`, or nil if no router-wide route timeout is set.
+	defaultRouteTimeoutMiddleware Middleware
+
+	// mountedOn is the router this router was mounted on (see `Mount`), whose readiness
+	// it follows.
+	mountedOn *Router
+
+	// healthPaths holds the paths of the endpoints registered with `Liveness`, `Health`
+	// and `HealthWithConfig`, which middleware like `HTTPSRedirect` leave untouched.
+	healthPaths map[string]struct{}
+
+	// notReady is true while the router reports itself as not ready to receive traffic
+	// (see `SetReady`). Its zero value means ready.
+	notReady atomic.Bool
+
+	// tlsCertificate is the certificate served by the `ListenAndServeTLS*` methods, loaded
+	// when they start and replaced by `ReloadCertificate` (nil until then).
+	tlsCertificate atomic.Pointer[tls.Certificate]
+
+	// shutdownRequests receives the requests of `Shutdown` while a server started by a
+	// graceful method runs (nil otherwise), and shutdownStopped is closed once that server
+	// has stopped. Both are protected by `shutdownMux`.
+	shutdownRequests chan shutdownRequest
+	shutdownStopped  chan struct{}
+	shutdownMux      sync.Mutex
 }
 
 // Logger returns the configured `xylium.Logger` instance for this router.
@@ -161,6 +208,7 @@ func NewWithConfig(config ServerConfig) *Router {
 
 	// --- Logger Initialization and Configuration ---
 	// This block ensures r.serverConfig.Logger is always non-nil.
+	var ownedLogger *DefaultLogger // Set only if the router creates the DefaultLogger itself.
 	if config.Logger == nil {
 		// Start with Xylium's base default logger configuration.
 		baseLogCfg := DefaultLoggerConfig()
@@ -175,6 +223,13 @@ func NewWithConfig(config ServerConfig) *Router {
 			if userProvidedLogCfg.Formatter != "" { // Ensure formatter is a valid FormatterType.
 				baseLogCfg.Formatter = userProvidedLogCfg.Formatter
 			}
+			baseLogCfg.Sampling = userProvidedLogCfg.Sampling
+			baseLogCfg.Async = userProvidedLogCfg.Async
+			baseLogCfg.BufferSize = userProvidedLogCfg.BufferSize
+			baseLogCfg.OverflowPolicy = userProvidedLogCfg.OverflowPolicy
+			baseLogCfg.TimestampFormat = userProvidedLogCfg.TimestampFormat
+			baseLogCfg.FieldKeys = userProvidedLogCfg.FieldKeys
+			baseLogCfg.Sinks = userProvidedLogCfg.Sinks
 			// Level, ShowCaller, UseColor will be handled with precedence below.
 		}
 
@@ -223,7 +278,8 @@ func NewWithConfig(config ServerConfig) *Router {
 		}
 
 		// Create the DefaultLogger with the finalized configuration.
-		config.Logger = NewDefaultLoggerWithConfig(finalLogCfg)
+		ownedLogger = NewDefaultLoggerWithConfig(finalLogCfg)
+		config.Logger = ownedLogger
 		// Log that DefaultLogger is being used and how it was configured.
 		config.Logger.Debugf("Router using DefaultLogger, configured. EffectiveMode: %s, FinalLoggerConfig: %+v", effectiveMode, finalLogCfg)
 	} else {
@@ -252,6 +308,25 @@ func NewWithConfig(config ServerConfig) *Router {
 	routerInstance.PanicHandler = defaultPanicHandler
 	routerInstance.GlobalErrorHandler = defaultGlobalErrorHandler
 
+	if config.RouteTimeout > 0 {
+		routerInstance.defaultRouteTimeoutMiddleware = Timeout(config.RouteTimeout)
+	}
+
+	// A RotatingFileWriter used as the output of a DefaultLogger created here must be
+	// closed on shutdown to sync the file. Registered before the logger, so it is closed after it.
+	if ownedLogger != nil {
+		if rfw, ok := ownedLogger.out.(*RotatingFileWriter); ok {
+			routerInstance.RegisterCloser(rfw)
+		}
+	}
+	// An async DefaultLogger created here is owned by the router: register it so queued
+	// log entries are flushed during graceful shutdown. It is registered before any user
+	// closers, so it is closed after them (closers run in reverse order) and their shutdown
+	// logs still go through it.
+	if ownedLogger != nil && ownedLogger.async != nil {
+		routerInstance.RegisterCloser(ownedLogger)
+	}
+
 	// Log router initialization details. `modeSource` is a global variable from mode.go.
 	routerInstance.Logger().Infof("Xylium Router initialized (Adopting Mode: %s, Determined By: %s)", routerInstance.instanceMode, modeSource)
 	return routerInstance
@@ -276,6 +351,122 @@ func (r *Router) Use(middlewares ...Middleware) {
 	r.globalMiddleware = append(r.globalMiddleware, middlewares...)
 }
 
+// UsePreRouting adds one or more `PreRoutingHook` functions that are invoked for every
+// request before the router looks up the matching route in the radix tree.
+// Hooks run in the order they are added, immediately after the `xylium.Context` is
+// acquired, and may rewrite the request method or path (see `c.RewriteMethod` and
+// `c.RewritePath`) to influence routing. Typical uses are method override, path
+// normalization, and stripping tenant or version prefixes.
+//
+// Unlike middleware registered with `Use`, pre-routing hooks run on EVERY request,
+// including those that end in 404 Not Found or 405 Method Not Allowed. They are on the
+// hot path and must be fast. Panics in hooks are recovered like panics in handlers.
+// Hooks cannot abort the request; use middleware for that.
+//
+// Example:
+//
+//	app.UsePreRouting(xylium.MethodOverride(xylium.MethodOverrideConfig{}))
+//	app.UsePreRouting(func(c *xylium.Context) {
+//		if p := c.Path(); strings.HasPrefix(p, "/tenant-a/") {
+//			c.Set("tenant", "tenant-a")
+//			c.RewritePath(strings.TrimPrefix(p, "/tenant-a"))
+//		}
+//	})
+func (r *Router) UsePreRouting(hooks ...PreRoutingHook) {
+	r.preRoutingHooks = append(r.preRoutingHooks, hooks...)
+}
+
+// RegisterErrorMapper adds one or more `ErrorMapper` functions that translate errors
+// which are not `*HTTPError` (e.g., `sql.ErrNoRows` or domain errors returned from a
+// service layer) into an `*HTTPError`, so handlers can return them unwrapped.
+//
+// The default `GlobalErrorHandler` consults the mappers, in the order they were
+// registered, for errors that do not contain an `*HTTPError`; the first mapper that
+// returns true wins, and errors no mapper handles still result in a 500 response.
+// Mappers only run when an error response is actually sent, i.e. after the check that
+// the response has not been committed yet. If the mapped `*HTTPError` has no `Internal`
+// error, the original error is set as its `Internal` error for logging. Custom error
+// handlers can apply the same mapping with `MapError`.
+//
+// Mappers should be registered before the server starts. It panics if a mapper is nil.
+//
+// Example:
+//
+//	app.RegisterErrorMapper(xylium.MapErrorIs(sql.ErrNoRows, xylium.StatusNotFound))
+//	app.RegisterErrorMapper(func(err error) (*xylium.HTTPError, bool) {
+//		var verr *service.ValidationError
+//		if errors.As(err, &verr) {
+//			return xylium.NewHTTPError(xylium.StatusBadRequest, verr.Fields), true
+//		}
+//		return nil, false
+//	})
+func (r *Router) RegisterErrorMapper(mappers ...ErrorMapper) {
+	for _, m := range mappers {
+		if m == nil {
+			panic("xylium: RegisterErrorMapper called with a nil mapper")
+		}
+	}
+	r.errorMappers = append(r.errorMappers, mappers...)
+}
+
+// MapError translates `err` into an `*HTTPError` using the mappers registered with
+// `RegisterErrorMapper`. It returns false if no mapper handles `err`. Errors that already
+// contain an `*HTTPError` are not passed to the mappers by the default error handler.
+func (r *Router) MapError(err error) (*HTTPError, bool) {
+	return mapErrorWith(r.errorMappers, err)
+}
+
+// mapErrorWith translates `err` with `mappers`, as described for `MapError`.
+func mapErrorWith(mappers []ErrorMapper, err error) (*HTTPError, bool) {
+	for _, mapper := range mappers {
+		httpErr, ok := mapper(err)
+		if !ok || httpErr == nil {
+			continue
+		}
+		if httpErr.Internal == nil {
+			mapped := *httpErr // Do not modify an *HTTPError the mapper may share between calls.
+			mapped.Internal = err
+			httpErr = &mapped
+		}
+		return httpErr, true
+	}
+	return nil, false
+}
+
+// mapError translates `err` for the default error handler: the error mappers of a
+// mounted sub-router (see `Mount`) apply to its routes before the router's own.
+func (c *Context) mapError(err error) (*HTTPError, bool) {
+	for g := c.group; g != nil; g = g.parent {
+		if httpErr, ok := mapErrorWith(g.errorMappers, err); ok {
+			return httpErr, true
+		}
+	}
+	if c.router == nil {
+		return nil, false
+	}
+	return c.router.MapError(err)
+}
+
+// ErrorStatus returns the HTTP status the default error handler answers `err` with:
+// the `Code` of an `*HTTPError` in its chain, the code of the `*HTTPError` an error
+// mapper (see `RegisterErrorMapper` and `Mount`) translates it to, or 500 otherwise.
+// For a nil `err`, it returns the status of the response written so far.
+// Middleware that inspect the error returned by `next` (e.g., `AccessLog` and the
+// default `CircuitBreakerConfig.IsFailure`) use it to see the status the client receives.
+func (c *Context) ErrorStatus(err error) int {
+	if err == nil {
+		return c.Ctx.Response.StatusCode()
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	if mapped, ok := c.mapError(err); ok {
+		return mapped.Code
+	}
+	return StatusInternalServerError
+}
+
 // AppSet stores a key-value pair in the application-level store (`r.appStore`).
 // This store is managed by the `Router` instance and is shared across all requests
 // handled by it. It's suitable for storing global resources like database connection
@@ -284,7 +475,7 @@ func (r *Router) Use(middlewares ...Middleware) {
 // If the provided `value` implements the `io.Closer` interface, it is automatically
 // registered with the router (via `r.RegisterCloser`) to be closed during the
 // application's graceful shutdown sequence. This helps manage the lifecycle of
-// shared resources.
+// shared resources. Use `AppDelete` to remove a value together with its registration.
 //
 // This method is thread-safe.
 func (r *Router) AppSet(key string, value interface{}) {
@@ -318,6 +509,120 @@ func (r *Router) AppGet(key string) (interface{}, bool) {
 	return val, ok
 }
 
+// AppGet retrieves a value from the application-level store of `r` (see `Router.AppGet`)
+// and asserts it as type `T`, sparing call sites the type assertion.
+// Returns the value and true if the key exists and the value is a `T`.
+// Otherwise, it returns the zero value of `T` and false.
+// This function is thread-safe.
+//
+// Example:
+//
+//	db, ok := xylium.AppGet[*sql.DB](app, "db")
+func AppGet[T any](r *Router, key string) (value T, exists bool) {
+	val, ok := r.AppGet(key)
+	if !ok {
+		return value, false
+	}
+	value, exists = val.(T)
+	return
+}
+
+// MustAppGet retrieves a value from the application-level store of `r` and asserts it
+// as type `T`. It panics if the key does not exist or the value is not a `T`, so use it
+// for resources registered with `AppSet` during application setup.
+// This function is thread-safe.
+//
+// Example:
+//
+//	db := xylium.MustAppGet[*sql.DB](app, "db")
+func MustAppGet[T any](r *Router, key string) T {
+	val, ok := r.AppGet(key)
+	if !ok {
+		panic(fmt.Sprintf("xylium: key '%s' does not exist in application store", key))
+	}
+	value, ok := val.(T)
+	if !ok {
+		panic(fmt.Sprintf("xylium: value for key '%s' in application store has type %T, not %s", key, val, reflect.TypeFor[T]()))
+	}
+	return value
+}
+
+// AppDelete removes the value stored under `key` from the application-level store.
+// It does nothing if the key does not exist.
+//
+// If the value implements `io.Closer`, it was registered for graceful shutdown by
+// `AppSet`; AppDelete unregisters it and closes it right away, returning the error from
+// `Close`, so that no closer is left behind for a value the application no longer holds.
+// Make sure no in-flight request still uses the value. A value that is also stored
+// under another key is not closed, and stays registered for the remaining key. Closers registered directly
+// with `RegisterCloser` are not affected unless they were also stored with `AppSet`.
+//
+// This method is thread-safe.
+func (r *Router) AppDelete(key string) error {
+	r.appStoreMux.Lock()
+	val, ok := r.appStore[key]
+	if !ok {
+		r.appStoreMux.Unlock()
+		return nil
+	}
+	delete(r.appStore, key)
+	closer, isCloser := val.(io.Closer)
+	stillStored := false
+	if isCloser {
+		for _, other := range r.appStore {
+			if otherCloser, ok := other.(io.Closer); ok && sameCloser(closer, otherCloser) {
+				stillStored = true
+				break
+			}
+		}
+	}
+	r.appStoreMux.Unlock()
+
+	// Each AppSet of a closer registers it once, so drop the registration made for this key.
+	if !isCloser || !r.unregisterCloser(closer) || stillStored {
+		return nil
+	}
+	r.Logger().Debugf("Resource (type %T) under app store key '%s' unregistered and closed.", closer, key)
+	return closer.Close()
+}
+
+// AppKeys returns the keys of the application-level store, sorted alphabetically.
+// It is useful for debugging and for dynamic reconfiguration alongside `AppDelete`.
+// This method is thread-safe.
+func (r *Router) AppKeys() []string {
+	r.appStoreMux.RLock()
+	defer r.appStoreMux.RUnlock()
+	keys := make([]string, 0, len(r.appStore))
+	for key := range r.appStore {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unregisterCloser removes the most recent registration of `closer` from the closers
+// closed on shutdown. It reports whether `closer` was registered.
+func (r *Router) unregisterCloser(closer io.Closer) bool {
+	r.closersMux.Lock()
+	defer r.closersMux.Unlock()
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if sameCloser(r.closers[i], closer) {
+			r.closers = append(r.closers[:i], r.closers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// sameCloser reports whether `a` and `b` are the same closer. Closers of non-comparable
+// types (which cannot be compared with ==) are never considered the same.
+func sameCloser(a, b io.Closer) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
 // RegisterCloser explicitly registers an instance that implements `io.Closer`
 // to be closed during the router's graceful shutdown sequence (`closeApplicationResources`).
 // This is useful for managing the lifecycle of resources that might not be stored
@@ -374,7 +679,20 @@ func (r *Router) addInternalStore(store LimiterStore) {
 //   - `middlewares` (...Middleware): Optional route-specific middleware.
 //
 // Panics if `path` does not start with "/" or if `handler` is nil.
-func (r *Router) addRoute(method, path string, handler HandlerFunc, middlewares ...Middleware) {
+func (r *Router) addRoute(method, path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRouteTarget(method, path, routeTarget{handler: handler, middleware: middlewares})
+}
+
+// addRouteTarget registers a fully populated `routeTarget` (e.g., one carrying its
+// `RouteGroup`). It implements `addRoute` and has the same path handling and panics.
+func (r *Router) addRouteTarget(method, path string, target routeTarget) *Route {
+	return r.addRouteTargets([]string{method}, path, target)
+}
+
+// addRouteTargets registers `target` for each of `methods` at once (see `Tree.addTargets`),
+// with the same path handling and panics as `addRoute`. It returns the route's handle,
+// creating one if `target` does not carry one yet.
+func (r *Router) addRouteTargets(methods []string, path string, target routeTarget) *Route {
 	if path == "" {
 		path = "/" // Default to root path if an empty path string is provided.
 	}
@@ -384,44 +702,71 @@ func (r *Router) addRoute(method, path string, handler HandlerFunc, middlewares
 	}
 	// `r.tree.Add` will handle further normalization (like trailing slashes) and
 	// will panic if the handler is nil or if the route is a duplicate.
-	r.tree.Add(method, path, handler, middlewares...)
+	if target.route == nil {
+		target.route = &Route{}
+	}
+	r.tree.addTargets(methods, path, target)
+	return target.route
 }
 
 // GET registers a new route for GET requests to the given `path`.
 // The `handler` will be executed when a GET request matches this path.
 // Optional route-specific `middlewares` can also be provided.
-func (r *Router) GET(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodGet, path, handler, middlewares...)
+func (r *Router) GET(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodGet, path, handler, middlewares...)
 }
 
 // POST registers a new route for POST requests to the given `path`.
-func (r *Router) POST(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodPost, path, handler, middlewares...)
+func (r *Router) POST(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodPost, path, handler, middlewares...)
 }
 
 // PUT registers a new route for PUT requests to the given `path`.
-func (r *Router) PUT(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodPut, path, handler, middlewares...)
+func (r *Router) PUT(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodPut, path, handler, middlewares...)
 }
 
 // DELETE registers a new route for DELETE requests to the given `path`.
-func (r *Router) DELETE(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodDelete, path, handler, middlewares...)
+func (r *Router) DELETE(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodDelete, path, handler, middlewares...)
 }
 
 // PATCH registers a new route for PATCH requests to the given `path`.
-func (r *Router) PATCH(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodPatch, path, handler, middlewares...)
+func (r *Router) PATCH(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodPatch, path, handler, middlewares...)
 }
 
 // HEAD registers a new route for HEAD requests to the given `path`.
-func (r *Router) HEAD(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodHead, path, handler, middlewares...)
+func (r *Router) HEAD(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodHead, path, handler, middlewares...)
 }
 
 // OPTIONS registers a new route for OPTIONS requests to the given `path`.
-func (r *Router) OPTIONS(path string, handler HandlerFunc, middlewares ...Middleware) {
-	r.addRoute(MethodOptions, path, handler, middlewares...)
+func (r *Router) OPTIONS(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRoute(MethodOptions, path, handler, middlewares...)
+}
+
+// anyMethods lists the HTTP methods registered by `Any`.
+var anyMethods = []string{
+	MethodGet, MethodPost, MethodPut, MethodDelete, MethodPatch, MethodHead, MethodOptions,
+}
+
+// Any registers the route for GET, POST, PUT, DELETE, PATCH, HEAD, and OPTIONS requests
+// to the given `path`, e.g., for a proxy or a catch-all handler.
+// If a handler is already registered for any of these methods and `path`, it panics,
+// naming every conflicting method, and registers none of them.
+func (r *Router) Any(path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.Match(anyMethods, path, handler, middlewares...)
+}
+
+// Match registers the route for each of the given HTTP `methods` and `path`.
+// Duplicate registrations are reported like in `Any`.
+//
+// Example:
+//
+//	app.Match([]string{xylium.MethodGet, xylium.MethodPost}, "/search", searchHandler)
+func (r *Router) Match(methods []string, path string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return r.addRouteTargets(methods, path, routeTarget{handler: handler, middleware: middlewares})
 }
 
 // Handler is the core request handler function that Xylium provides to the
@@ -433,20 +778,28 @@ func (r *Router) OPTIONS(path string, handler HandlerFunc, middlewares ...Middle
 //  3. Ensuring the `xylium.Context` is released back to the pool after request processing.
 //  4. Implementing panic recovery:
 //     - If a panic occurs in any handler or middleware, it recovers the panic.
-//     - Logs the panic details (including stack trace).
+//     - Logs the panic details (including stack trace) with the request method, path,
+//     query, headers and body, redacted outside `DebugMode` (see `panicReportFields`).
 //     - Invokes the router's configured `PanicHandler` (or `defaultPanicHandler`).
-//  5. Finding the appropriate route in the radix tree based on the request method and path.
+//  5. Running pre-routing hooks (see `UsePreRouting`), then finding the appropriate route
+//     in the radix tree based on the (possibly rewritten) request method and path.
 //  6. Constructing the full middleware chain (global, group-level, route-specific).
 //  7. Executing the handler chain via `c.Next()`.
 //  8. Handling errors returned from the handler chain:
 //     - If an error is returned, it is passed to the router's `GlobalErrorHandler`
 //     (or `defaultGlobalErrorHandler`) for centralized processing and response generation.
 //  9. Handling special cases:
-//     - If no route matches the path, `NotFoundHandler` is invoked.
+//     - If no route matches the path, the `Fallback` with the longest matching prefix
+//     is invoked, or `NotFoundHandler` if none covers the path.
 //     - If a path matches but not the HTTP method, `MethodNotAllowedHandler` is invoked
 //     (after setting the "Allow" header).
+//     - With `ServerConfig.RunMiddlewareOnNoRoute`, global middleware wraps these 404/405
+//     handlers too; otherwise they are invoked directly. AutoOPTIONS responses are
+//     always wrapped in global middleware.
 //  10. Ensuring a response is sent or logging a warning if a handler completes
 //     without committing a response (in DebugMode, for non-HEAD requests without No Content status).
+//     If a middleware returned without calling `next`, the warning names it and its
+//     position in the chain. Both warnings are only logged in DebugMode.
 func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 	// Acquire a Xylium Context from the pool and initialize it.
 	c := acquireCtx(originalFasthttpCtx)
@@ -455,18 +808,22 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 	defer releaseCtx(c)
 
 	var errHandler error              // To store any error from the handler chain or panic handler.
+	var trace *middlewareTrace        // Records how far the middleware chain ran (DebugMode only).
 	requestScopedLogger := c.Logger() // Get the request-scoped logger early.
 
 	// Centralized panic and error handling for the entire request lifecycle.
 	defer func() {
 		if rec := recover(); rec != nil {
-			// A panic occurred. Log it with stack trace.
-			requestScopedLogger.Errorf("PANIC RECOVERED: %v\nStack Trace:\n%s", rec, string(debug.Stack()))
+			// A panic occurred. Log it with stack trace and the details of the request, using
+			// a fresh request logger so a request ID set by middleware is included.
+			stack := debug.Stack()
+			c.Logger().WithFields(r.panicReportFields(c)).Errorf("PANIC RECOVERED: %v\nStack Trace:\n%s", rec, stack)
 			// If a PanicHandler is configured, invoke it.
 			if r.PanicHandler != nil {
-				// Store panic info in context for the PanicHandler to access.
+				// Store panic info and stack trace in context for the PanicHandler to access.
 				c.Set(ContextKeyPanicInfo, rec) // Use defined constant for context key.
-				errHandler = r.PanicHandler(c)  // PanicHandler might return an error itself.
+				c.Set(ContextKeyPanicStack, stack)
+				errHandler = r.PanicHandler(c) // PanicHandler might return an error itself.
 			} else {
 				// This branch should ideally not be reached if defaultPanicHandler is always set.
 				// Fallback to a generic HTTPError if PanicHandler is somehow nil.
@@ -474,20 +831,30 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 			}
 		}
 
+		// Trailers declared for a response that cannot carry them are reported, not dropped silently.
+		if errHandler == nil {
+			errHandler = c.trailerModeError()
+		}
+
 		// After panic recovery (if any) and normal handler execution, process any `errHandler`.
-		if errHandler != nil {
+		// Errors wrapping ErrResponseWritten have already been answered (e.g., by
+		// `c.MustBindAndValidate`), so they are not handled again.
+		if errHandler != nil && errors.Is(errHandler, ErrResponseWritten) {
+			requestScopedLogger.Debugf("Error for %s %s was already answered by the handler: %v", c.Method(), c.Path(), errHandler)
+		} else if errHandler != nil {
 			// If a response hasn't already been committed by a handler/middleware,
 			// let the GlobalErrorHandler process `errHandler` and send a response.
 			if !c.ResponseCommitted() {
-				if r.GlobalErrorHandler != nil {
-					// Store the error cause in context for GlobalErrorHandler.
+				// Routes in a group with its own error handler use it instead of GlobalErrorHandler.
+				if errorHandler := r.errorHandlerFor(c.group); errorHandler != nil {
+					// Store the error cause in context for the error handler.
 					c.Set(ContextKeyErrorCause, errHandler) // Use defined constant.
-					// Invoke the GlobalErrorHandler.
-					if globalErrHandlingErr := r.GlobalErrorHandler(c); globalErrHandlingErr != nil {
+					// Invoke the error handler.
+					if globalErrHandlingErr := errorHandler(c); globalErrHandlingErr != nil {
 						// Critical: The GlobalErrorHandler itself failed.
 						// Send a minimal, hardcoded error response directly.
 						requestScopedLogger.Errorf(
-							"CRITICAL: Error occurred within GlobalErrorHandler: %v (while handling original error: %v). Request: %s %s",
+							"CRITICAL: Error occurred within error handler: %v (while handling original error: %v). Request: %s %s",
 							globalErrHandlingErr, errHandler, c.Method(), c.Path(),
 						)
 						c.Ctx.Response.SetStatusCode(StatusInternalServerError)
@@ -515,7 +882,16 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 					c.Method(), c.Path(), errHandler,
 				)
 			}
-		} else if !c.ResponseCommitted() && c.Method() != MethodHead {
+		}
+
+		// For HEAD requests, discard any body written by the handler (e.g., a GET handler
+		// invoked via AutoHEAD) while preserving headers and the Content-Length it implies.
+		if c.Method() == MethodHead {
+			discardHEADResponseBody(c)
+			return
+		}
+
+		if errHandler == nil && !c.ResponseCommitted() {
 			// No error occurred, but the response was not committed by any handler.
 			// This might be intentional for HEAD requests or if `c.NoContent()` was used for
 			// statuses like 204 or 304.
@@ -532,7 +908,14 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 			// Log a debug message if a non-error, non-HEAD, non-"No Content" status request
 			// completes without writing a response body. This can help catch unintentional omissions.
 			if !isNoContentStatus && isResponseEffectivelyEmpty && statusCode < StatusBadRequest {
-				if r.CurrentMode() == DebugMode {
+				if position, name, ok := trace.shortCircuit(); ok {
+					requestScopedLogger.Warnf(
+						"Request %s %s (Status: %d) ended without a response: middleware #%d in the chain (%s) "+
+							"returned without calling next(c) or writing a response. "+
+							"Ensure it calls next(c) to continue the chain, or sends a response when it stops it.",
+						c.Method(), c.Path(), statusCode, position+1, name,
+					)
+				} else if r.CurrentMode() == DebugMode {
 					requestScopedLogger.Debugf(
 						"Handler for %s %s (Status: %d) completed without writing a response body or calling c.NoContent(). "+
 							"Ensure handlers explicitly send a response or use c.NoContent() if no body is intended.",
@@ -546,55 +929,191 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 	}() // End of deferred error/panic handling logic.
 
 	// --- Main Request Processing Logic ---
+	// Run pre-routing hooks, which may rewrite the method or path used for route lookup.
+	for _, hook := range r.preRoutingHooks {
+		hook(c)
+	}
+
 	method := c.Method() // Get request method.
 	path := c.Path()     // Get request path.
 
 	// Find the route in the radix tree.
-	nodeHandler, routeMiddleware, params, allowedMethods := r.tree.Find(method, path)
+	target, found, params, paramNames, allowedMethods := r.tree.find(method, path)
+
+	// With AutoHEAD, a HEAD request without an explicit HEAD route falls back to the GET route.
+	// The body produced by the GET handler is discarded by the deferred completion logic.
+	if r.serverConfig.AutoHEAD {
+		if !found && method == MethodHead {
+			if getTarget, getFound, getParams, getParamNames, _ := r.tree.find(MethodGet, path); getFound {
+				target, found, params, paramNames = getTarget, getFound, getParams, getParamNames
+			}
+		}
+		allowedMethods = withAutoHEAD(allowedMethods)
+	}
 
-	if nodeHandler != nil {
+	if found {
 		// Route found for the method and path.
-		c.Params = params // Set extracted path parameters on the context.
+		c.Params = params         // Set extracted path parameters on the context.
+		c.paramNames = paramNames // Their names in path order, for c.ParamNames.
+		c.group = target.group    // Nil for routes registered directly on the router.
+		c.route = target.route    // Carries the route's metadata for c.RouteMeta.
+		c.routePattern = target.pattern
+		nodeHandler, routeMiddleware := target.handler, target.middleware
 
 		// Construct the full handler chain: global -> group (if any, handled by tree) -> route-specific -> main handler.
 		// `routeMiddleware` from tree.Find already includes group middleware in the correct order.
-		finalChain := nodeHandler // Start with the main route handler.
+		trace = r.newMiddlewareTrace(r.globalMiddleware, routeMiddleware)
 		// Apply route-specific middleware (in reverse order to build the chain).
-		for i := len(routeMiddleware) - 1; i >= 0; i-- {
-			finalChain = routeMiddleware[i](finalChain)
+		finalChain := trace.apply(nodeHandler, routeMiddleware, len(r.globalMiddleware))
+		// A declarative timeout (route, group, or router-wide) wraps group and route middleware.
+		if timeoutMiddleware := r.routeTimeoutMiddleware(target); timeoutMiddleware != nil {
+			finalChain = timeoutMiddleware(finalChain)
 		}
+		// Group and route middleware of a group with SetStripPrefix see relative paths.
+		finalChain = withStrippedPrefix(target.group, finalChain)
 		// Apply global middleware (also in reverse order).
-		for i := len(r.globalMiddleware) - 1; i >= 0; i-- {
-			finalChain = r.globalMiddleware[i](finalChain)
-		}
+		finalChain = trace.apply(finalChain, r.globalMiddleware, 0)
 
 		c.handlers = []HandlerFunc{finalChain} // Set the fully constructed chain.
 		c.index = -1                           // Reset handler index for c.Next().
 		errHandler = c.Next()                  // Execute the handler chain.
 	} else {
 		// No direct handler found for the method and path.
+		var noRouteHandler HandlerFunc
+		runGlobalMiddleware := r.serverConfig.RunMiddlewareOnNoRoute
 		if len(allowedMethods) > 0 {
 			// Path matched, but not for this HTTP method (405 Method Not Allowed).
 			c.Params = params // Path parameters might still be relevant for the 405 handler.
-			if r.MethodNotAllowedHandler != nil {
+			c.paramNames = paramNames
+			if method == MethodOptions && r.serverConfig.AutoOPTIONS {
+				// AutoOPTIONS: answer with the methods defined on this path instead of a 405.
+				// Global middleware always wraps it, so e.g. CORS answers preflights.
+				allow := strings.Join(withAllowedMethod(allowedMethods, MethodOptions), ", ")
+				noRouteHandler = func(c *Context) error {
+					c.SetHeader("Allow", allow)
+					return c.NoContent(StatusNoContent)
+				}
+				runGlobalMiddleware = true
+			} else if r.MethodNotAllowedHandler != nil {
 				// Set "Allow" header with the list of methods that *are* allowed for this path.
-				c.SetHeader("Allow", strings.Join(allowedMethods, ", "))
-				errHandler = r.MethodNotAllowedHandler(c)
+				allow := strings.Join(allowedMethods, ", ")
+				methodNotAllowedHandler := r.MethodNotAllowedHandler
+				noRouteHandler = func(c *Context) error {
+					c.SetHeader("Allow", allow)
+					return methodNotAllowedHandler(c)
+				}
 			} else { // Fallback if MethodNotAllowedHandler is somehow nil.
-				errHandler = NewHTTPError(StatusMethodNotAllowed, StatusText(StatusMethodNotAllowed))
+				noRouteHandler = func(c *Context) error {
+					return NewHTTPError(StatusMethodNotAllowed, StatusText(StatusMethodNotAllowed))
+				}
 			}
 		} else {
 			// No route matched the path at all (404 Not Found).
-			if r.NotFoundHandler != nil {
-				errHandler = r.NotFoundHandler(c)
+			if fallback := r.findFallback(path); fallback != nil {
+				// A fallback covers this path: run it like a route of its group.
+				c.group = fallback.group
+				trace = r.newMiddlewareTrace(r.globalMiddleware, fallback.middleware)
+				finalChain := trace.apply(fallback.handler, fallback.middleware, len(r.globalMiddleware))
+				finalChain = withStrippedPrefix(fallback.group, finalChain)
+				finalChain = trace.apply(finalChain, r.globalMiddleware, 0)
+				c.handlers = []HandlerFunc{finalChain}
+				c.index = -1
+				errHandler = c.Next()
+			} else if r.NotFoundHandler != nil {
+				noRouteHandler = r.NotFoundHandler
 			} else { // Fallback if NotFoundHandler is somehow nil.
-				errHandler = NewHTTPError(StatusNotFound, StatusText(StatusNotFound))
+				noRouteHandler = func(c *Context) error {
+					return NewHTTPError(StatusNotFound, StatusText(StatusNotFound))
+				}
+			}
+		}
+
+		if noRouteHandler != nil {
+			if runGlobalMiddleware {
+				// Wrap the 404/405 (or AutoOPTIONS) handler in global middleware, like a matched route.
+				trace = r.newMiddlewareTrace(r.globalMiddleware, nil)
+				finalChain := trace.apply(noRouteHandler, r.globalMiddleware, 0)
+				c.handlers = []HandlerFunc{finalChain}
+				c.index = -1
+				errHandler = c.Next()
+			} else {
+				errHandler = noRouteHandler(c)
 			}
 		}
 	}
 	// The deferred function will handle `errHandler`.
 }
 
+// withAutoHEAD adds HEAD to a sorted list of allowed methods if GET is present.
+// Used when `ServerConfig.AutoHEAD` is enabled.
+func withAutoHEAD(allowedMethods []string) []string {
+	for _, m := range allowedMethods {
+		if m == MethodGet {
+			return withAllowedMethod(allowedMethods, MethodHead)
+		}
+	}
+	return allowedMethods
+}
+
+// withAllowedMethod returns a sorted list of allowed methods that includes `method`.
+// The input slice is returned unchanged if it already contains `method`.
+func withAllowedMethod(allowedMethods []string, method string) []string {
+	for _, m := range allowedMethods {
+		if m == method {
+			return allowedMethods
+		}
+	}
+	result := make([]string, 0, len(allowedMethods)+1)
+	result = append(result, allowedMethods...)
+	result = append(result, method)
+	sort.Strings(result)
+	return result
+}
+
+// discardHEADResponseBody drops the buffered response body for a HEAD request,
+// setting `Content-Length` to the length the body would have had.
+// Streamed bodies are left to fasthttp, which never sends a body for HEAD.
+func discardHEADResponseBody(c *Context) {
+	resp := &c.Ctx.Response
+	if resp.IsBodyStream() {
+		return
+	}
+	if bodyLen := len(resp.Body()); bodyLen > 0 {
+		resp.ResetBody()
+		resp.Header.SetContentLength(bodyLen)
+		resp.SkipBody = true
+	}
+}
+
+// ServeFilesConfig defines caching options for `ServeFilesWithConfig` and
+// `ServeFilesFSWithConfig`. The zero value sends no `Cache-Control` or `ETag` headers,
+// like `ServeFiles`.
+type ServeFilesConfig struct {
+	// MaxAge, if positive, sets "Cache-Control: public, max-age=<seconds>" on successful
+	// file responses (200, 206, and 304).
+	MaxAge time.Duration
+
+	// Immutable, if true (and `MaxAge` is positive), appends "immutable" to Cache-Control,
+	// so browsers do not revalidate the file until it expires. Use it for hashed bundles
+	// like "app.3f9a1c.js", whose content never changes under the same name.
+	Immutable bool
+
+	// NoCacheSuffixes lists file name suffixes (e.g., ".html" or "index.html") excluded
+	// from `MaxAge` and `Immutable`. Matching files get "Cache-Control: no-cache", so
+	// browsers always revalidate them. Directory requests match against the name of the
+	// index file served (e.g., "docs/index.html").
+	NoCacheSuffixes []string
+
+	// ETag, if true, sets a weak ETag derived from the file's size and modification time,
+	// and answers requests with a matching "If-None-Match" header with 304 Not Modified.
+	// No ETag is sent for files without a modification time (e.g., from an `embed.FS`).
+	ETag bool
+
+	// DisableLastModified, if true, removes the "Last-Modified" header that is otherwise
+	// set on file responses.
+	DisableLastModified bool
+}
+
 // ServeFiles serves static files from a given filesystem root directory (`fileSystemRoot`)
 // under a specified URL path prefix (`urlPathPrefix`).
 //
@@ -626,7 +1145,24 @@ func (r *Router) Handler(originalFasthttpCtx *fasthttp.RequestCtx) {
 //
 // A warning is logged if `fileSystemRoot` does not exist at the time of configuration,
 // though the route will still be registered.
+//
+// No caching headers other than `Last-Modified` are sent; use `ServeFilesWithConfig`
+// to set `Cache-Control` and `ETag` headers.
 func (r *Router) ServeFiles(urlPathPrefix string, fileSystemRoot string) {
+	r.ServeFilesWithConfig(urlPathPrefix, fileSystemRoot, ServeFilesConfig{})
+}
+
+// ServeFilesWithConfig is like `ServeFiles`, with caching headers configured by `config`.
+//
+// Example:
+//
+//	app.ServeFilesWithConfig("/assets", "./dist", xylium.ServeFilesConfig{
+//		MaxAge:          365 * 24 * time.Hour,
+//		Immutable:       true,
+//		NoCacheSuffixes: []string{".html"},
+//		ETag:            true,
+//	})
+func (r *Router) ServeFilesWithConfig(urlPathPrefix string, fileSystemRoot string, config ServeFilesConfig) {
 	if strings.Contains(urlPathPrefix, ":") || strings.Contains(urlPathPrefix, "*") {
 		panic("xylium: urlPathPrefix for ServeFiles cannot contain route parameters ':' or '*'")
 	}
@@ -642,6 +1178,62 @@ func (r *Router) ServeFiles(urlPathPrefix string, fileSystemRoot string) {
 			fileSystemRoot, cleanedFileSystemRoot, urlPathPrefix)
 	}
 
+	statFile := func(name string) (fs.FileInfo, error) {
+		return os.Stat(filepath.Join(cleanedFileSystemRoot, filepath.FromSlash(name)))
+	}
+	r.serveStaticFiles(urlPathPrefix, &fasthttp.FS{Root: cleanedFileSystemRoot}, "filesystem root '"+cleanedFileSystemRoot+"'", config, statFile)
+}
+
+// ServeFilesFS serves static files from `fsys` under `urlPathPrefix`, like `ServeFiles`,
+// but reading from an `fs.FS` instead of a directory on disk. This allows serving assets
+// embedded with `//go:embed`, so the application ships as a single binary.
+//
+// The behavior matches `ServeFiles`: `index.html` is served for directory requests,
+// `Content-Type` is derived from the file extension, byte range requests are supported,
+// eligible files are compressed (in memory), and missing files get a JSON 404 response.
+//
+// Paths are resolved relative to the root of `fsys`. For an `embed.FS` whose files live
+// in a subdirectory, use `fs.Sub` to serve that subdirectory.
+//
+// Example:
+//
+//	//go:embed dist
+//	var assets embed.FS
+//
+//	dist, _ := fs.Sub(assets, "dist")
+//	app.ServeFilesFS("/", dist)
+//
+// Panics if `fsys` is nil or if `urlPathPrefix` contains route parameters (':' or '*').
+// Use `ServeFilesFSWithConfig` to set caching headers.
+func (r *Router) ServeFilesFS(urlPathPrefix string, fsys fs.FS) {
+	r.ServeFilesFSWithConfig(urlPathPrefix, fsys, ServeFilesConfig{})
+}
+
+// ServeFilesFSWithConfig is like `ServeFilesFS`, with caching headers configured by `config`
+// (see `ServeFilesWithConfig`).
+func (r *Router) ServeFilesFSWithConfig(urlPathPrefix string, fsys fs.FS, config ServeFilesConfig) {
+	if strings.Contains(urlPathPrefix, ":") || strings.Contains(urlPathPrefix, "*") {
+		panic("xylium: urlPathPrefix for ServeFilesFS cannot contain route parameters ':' or '*'")
+	}
+	if fsys == nil {
+		panic("xylium: ServeFilesFS requires a non-nil fs.FS")
+	}
+	statFile := func(name string) (fs.FileInfo, error) {
+		name = strings.TrimPrefix(name, "/")
+		if name == "" {
+			name = "."
+		}
+		return fs.Stat(fsys, name)
+	}
+	r.serveStaticFiles(urlPathPrefix, &fasthttp.FS{FS: fsys}, "fs.FS", config, statFile)
+}
+
+// serveStaticFiles completes the configuration of `fileServer` (whose source, `Root` or
+// `FS`, is already set) and registers the `GET urlPathPrefix/*filepath` route serving it.
+// `source` describes the file source for log messages, and `statFile` stats a slash-separated
+// path (relative to the source) for the caching headers of `config`. It implements
+// `ServeFilesWithConfig` and `ServeFilesFSWithConfig`.
+func (r *Router) serveStaticFiles(urlPathPrefix string, fileServer *fasthttp.FS, source string, config ServeFilesConfig, statFile func(name string) (fs.FileInfo, error)) {
 	// Normalize the URL path prefix.
 	// Ensures it starts with "/" and does not have a trailing "/" unless it's the root.
 	normalizedUrlPathPrefix := "/" + strings.Trim(urlPathPrefix, "/")
@@ -665,43 +1257,40 @@ func (r *Router) ServeFiles(urlPathPrefix string, fileSystemRoot string) {
 	routerBaseLogger := r.Logger()
 
 	// Configure fasthttp.FS for serving files.
-	fs := &fasthttp.FS{
-		Root:               cleanedFileSystemRoot,  // Serve files from this directory.
-		IndexNames:         []string{"index.html"}, // Serve "index.html" for directory requests.
-		GenerateIndexPages: false,                  // Do not auto-generate directory listings.
-		AcceptByteRange:    true,                   // Support byte range requests.
-		Compress:           true,                   // Enable Gzip compression for eligible files.
-		PathNotFound: func(originalFasthttpCtx *fasthttp.RequestCtx) {
-			// Custom handler for when a file is not found by fasthttp.FS.
-			// This provides a Xylium-style JSON error response.
-			errorMsg := M{"error": "The requested static asset was not found."}
-			// Get the path fasthttp attempted to serve, for logging.
-			assetPath := string(originalFasthttpCtx.Path()) // Path relative to FS.Root.
-
-			// Use a logger derived from the router's base logger for this callback,
-			// as it doesn't have a full Xylium Context.
-			fsLogger := routerBaseLogger // routerBaseLogger is already non-nil.
-			fsLogger.Warnf(
-				"ServeFiles: Static asset not found by fasthttp.FS. Request URI: %s, FS Attempted Path (relative to root): %s, FS Root: %s",
-				string(originalFasthttpCtx.RequestURI()), assetPath, cleanedFileSystemRoot,
-			)
+	fileServer.IndexNames = []string{"index.html"} // Serve "index.html" for directory requests.
+	fileServer.GenerateIndexPages = false          // Do not auto-generate directory listings.
+	fileServer.AcceptByteRange = true              // Support byte range requests.
+	fileServer.Compress = true                     // Enable Gzip compression for eligible files.
+	fileServer.PathNotFound = func(originalFasthttpCtx *fasthttp.RequestCtx) {
+		// Custom handler for when a file is not found by fasthttp.FS.
+		// This provides a Xylium-style JSON error response.
+		errorMsg := M{"error": "The requested static asset was not found."}
+		// Get the path fasthttp attempted to serve, for logging.
+		assetPath := string(originalFasthttpCtx.Path()) // Path relative to FS.Root.
+
+		// Use a logger derived from the router's base logger for this callback,
+		// as it doesn't have a full Xylium Context.
+		fsLogger := routerBaseLogger // routerBaseLogger is already non-nil.
+		fsLogger.Warnf(
+			"ServeFiles: Static asset not found by fasthttp.FS. Request URI: %s, FS Attempted Path (relative to root): %s, Source: %s",
+			string(originalFasthttpCtx.RequestURI()), assetPath, source,
+		)
 
-			// Send a 404 Not Found response with a JSON body.
-			originalFasthttpCtx.SetStatusCode(StatusNotFound)
-			originalFasthttpCtx.SetContentType("application/json; charset=utf-8")
-			if err := json.NewEncoder(originalFasthttpCtx.Response.BodyWriter()).Encode(errorMsg); err != nil {
-				// Critical error: if JSON encoding itself fails. Log to primary logger.
-				fsLogger.Errorf(
-					"ServeFiles: CRITICAL - Error encoding JSON for PathNotFound response (asset path: %s): %v.",
-					assetPath, err,
-				)
-				// Fallback to plain text if JSON fails.
-				originalFasthttpCtx.SetBodyString(`{"error":"Static asset not found, and error occurred generating JSON response."}`)
-			}
-		},
+		// Send a 404 Not Found response with a JSON body.
+		originalFasthttpCtx.SetStatusCode(StatusNotFound)
+		originalFasthttpCtx.SetContentType("application/json; charset=utf-8")
+		if err := json.NewEncoder(originalFasthttpCtx.Response.BodyWriter()).Encode(errorMsg); err != nil {
+			// Critical error: if JSON encoding itself fails. Log to primary logger.
+			fsLogger.Errorf(
+				"ServeFiles: CRITICAL - Error encoding JSON for PathNotFound response (asset path: %s): %v.",
+				assetPath, err,
+			)
+			// Fallback to plain text if JSON fails.
+			originalFasthttpCtx.SetBodyString(`{"error":"Static asset not found, and error occurred generating JSON response."}`)
+		}
 	}
 	// Get the fasthttp request handler from the configured fasthttp.FS.
-	fileServerHandler := fs.NewRequestHandler()
+	fileServerHandler := fileServer.NewRequestHandler()
 
 	// Register a GET route with the catch-all pattern to handle static file requests.
 	r.GET(routePath, func(c *Context) error {
@@ -712,28 +1301,130 @@ func (r *Router) ServeFiles(urlPathPrefix string, fileSystemRoot string) {
 		// We need to adjust the context's RequestURI for fasthttp.FS to work correctly,
 		// then restore it afterwards so Xylium's logging/other features see the original URI.
 		// Path must start with '/' for fasthttp.FS. Clean it to prevent traversal issues.
-		pathForFasthttpFS := "/" + filepath.Clean("/"+requestedFileSubPath)
-
-		originalURI := c.Ctx.Request.RequestURI()      // Save original URI.
-		c.Ctx.Request.SetRequestURI(pathForFasthttpFS) // Set URI for fasthttp.FS.
+		// Slash-separated on all platforms, as required when serving from an fs.FS.
+		pathForFasthttpFS := filepath.ToSlash(filepath.Clean("/" + requestedFileSubPath))
+
+		// Copy the original URI: the returned slice is reused by SetRequestURI.
+		originalURI := append([]byte(nil), c.Ctx.Request.RequestURI()...) // Save original URI.
+		c.Ctx.Request.SetRequestURI(pathForFasthttpFS)                    // Set URI for fasthttp.FS.
+
+		// With ETag enabled, answer a matching conditional request without reading the file.
+		if config.ETag {
+			if etag := staticFileETag(statFile, pathForFasthttpFS); etag != "" {
+				c.Ctx.Response.Header.Set("ETag", etag)
+				if etagMatches(c.Ctx.Request.Header.Peek("If-None-Match"), etag) {
+					c.Ctx.NotModified()
+					setStaticCacheHeaders(c, config, statFile, pathForFasthttpFS)
+					c.Ctx.Request.SetRequestURIBytes(originalURI)
+					return nil
+				}
+			}
+		}
 
 		fileServerHandler(c.Ctx) // Let fasthttp.FS handle the request.
 
+		// fasthttp.FS redirects directory requests without a trailing slash to add one,
+		// but the router strips trailing slashes, so the redirect would loop.
+		// Serve the directory's index file directly instead.
+		if c.Ctx.Response.StatusCode() == StatusFound && pathForFasthttpFS != "/" {
+			c.Ctx.Response.Header.Del("Location")
+			c.Ctx.Response.ResetBody()
+			c.Ctx.Request.SetRequestURI(pathForFasthttpFS + "/")
+			fileServerHandler(c.Ctx)
+		}
+		setStaticCacheHeaders(c, config, statFile, pathForFasthttpFS)
+
 		c.Ctx.Request.SetRequestURIBytes(originalURI) // Restore original URI.
 		return nil                                    // Indicate request handled; fasthttp.FS sent the response.
 	})
 
-	r.Logger().Debugf("Static file serving configured for URL prefix '%s' from filesystem root '%s' via route '%s'",
-		normalizedUrlPathPrefix, cleanedFileSystemRoot, routePath)
+	r.Logger().Debugf("Static file serving configured for URL prefix '%s' from %s via route '%s'",
+		normalizedUrlPathPrefix, source, routePath)
+}
+
+// setStaticCacheHeaders sets the caching headers configured in `config` on a successful
+// static file response for `name` (the path passed to fasthttp.FS).
+func setStaticCacheHeaders(c *Context, config ServeFilesConfig, statFile func(string) (fs.FileInfo, error), name string) {
+	switch c.Ctx.Response.StatusCode() {
+	case StatusOK, StatusPartialContent, StatusNotModified:
+	default:
+		return // Do not cache error responses.
+	}
+	if config.DisableLastModified {
+		c.Ctx.Response.Header.Del("Last-Modified")
+	}
+	if len(config.NoCacheSuffixes) > 0 {
+		servedName := staticServedFileName(statFile, name)
+		for _, suffix := range config.NoCacheSuffixes {
+			if strings.HasSuffix(servedName, suffix) {
+				c.Ctx.Response.Header.Set("Cache-Control", "no-cache")
+				return
+			}
+		}
+	}
+	if config.MaxAge > 0 {
+		cacheControl := fmt.Sprintf("public, max-age=%d", int64(config.MaxAge/time.Second))
+		if config.Immutable {
+			cacheControl += ", immutable"
+		}
+		c.Ctx.Response.Header.Set("Cache-Control", cacheControl)
+	}
+}
+
+// staticServedFileName returns the name of the file served for `name`: the index file
+// for a directory, otherwise `name` itself.
+func staticServedFileName(statFile func(string) (fs.FileInfo, error), name string) string {
+	if info, err := statFile(name); err == nil && info.IsDir() {
+		return strings.TrimSuffix(name, "/") + "/index.html"
+	}
+	return name
+}
+
+// staticFileETag returns a weak ETag for the file served for `name`, derived from its
+// size and modification time, or "" if the file cannot be stat'ed or has no modification
+// time. File systems such as `embed.FS` report a zero time, with which an edited file of
+// the same size would keep its ETag and clients would get stale 304 responses.
+func staticFileETag(statFile func(string) (fs.FileInfo, error), name string) string {
+	info, err := statFile(staticServedFileName(statFile, name))
+	if err != nil || info.IsDir() || info.ModTime().IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(`W/"%x-%x"`, info.Size(), info.ModTime().UnixNano())
+}
+
+// etagMatches reports whether an "If-None-Match" header value matches `etag`,
+// using the weak comparison required for If-None-Match.
+func etagMatches(ifNoneMatch []byte, etag string) bool {
+	header := strings.TrimSpace(string(ifNoneMatch))
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == target {
+			return true
+		}
+	}
+	return false
 }
 
 // RouteGroup provides a way to organize routes under a common URL path prefix
 // and/or apply a shared set of `Middleware` to all routes within that group.
 // Groups can be nested to create more complex routing structures.
 type RouteGroup struct {
-	router     *Router      // Reference to the parent Router instance.
-	prefix     string       // The URL path prefix for this group.
-	middleware []Middleware // Middleware specific to this group.
+	router       *Router       // Reference to the parent Router instance.
+	parent       *RouteGroup   // The group this group was created from (nil for top-level groups).
+	prefix       string        // The URL path prefix for this group.
+	middleware   []Middleware  // Middleware specific to this group.
+	errorHandler HandlerFunc   // Error handler for this group's routes (nil to inherit).
+	errorMappers []ErrorMapper // Error mappers of a mounted sub-router (see Mount), tried before the router's.
+
+	timeout           time.Duration // Timeout for this group's routes (0 to inherit); see SetTimeout.
+	timeoutMiddleware Middleware    // Timeout middleware built for `timeout`, nil if unset.
+	stripPrefix       bool          // Whether handlers see paths relative to `prefix`; see SetStripPrefix.
 }
 
 // Group creates a new `RouteGroup` with the given `urlPrefix`.
@@ -775,11 +1466,156 @@ func (rg *RouteGroup) Use(middlewares ...Middleware) {
 	rg.middleware = append(rg.middleware, middlewares...)
 }
 
+// SetErrorHandler sets an error handler for the routes of this group and of its
+// sub-groups, replacing the router's `GlobalErrorHandler` for them. It is invoked the same
+// way as `GlobalErrorHandler`: the error is available via `c.Get(ContextKeyErrorCause)`.
+// For example, an "/api" group can render errors as JSON while "/admin" renders HTML.
+//
+// Precedence: for a route, the error handler of the innermost group that has one wins,
+// then its enclosing groups outward, and finally `Router.GlobalErrorHandler`. Sub-groups
+// inherit dynamically, so a handler set on a parent after a sub-group was created still
+// applies to it. Pass nil to remove this group's handler (and inherit again).
+// Errors on unmatched requests (404/405) are handled by the router's handlers, since no
+// group applies, except for requests served by the group's `Fallback`.
+// Call SetErrorHandler during setup, before the server starts.
+func (rg *RouteGroup) SetErrorHandler(handler HandlerFunc) {
+	rg.errorHandler = handler
+}
+
+// SetTimeout sets a timeout for the routes of this group and of its sub-groups, as if
+// the `Timeout` middleware with duration `d` wrapped their group and route middleware
+// and handlers. A route's own `Route.Timeout` takes precedence; otherwise the innermost
+// group with a timeout wins, then the router-wide `ServerConfig.RouteTimeout`. Like
+// `SetErrorHandler`, sub-groups inherit dynamically. Pass 0 to remove this group's
+// timeout (and inherit again). It panics if `d` is negative.
+// Call SetTimeout during setup, before the server starts.
+func (rg *RouteGroup) SetTimeout(d time.Duration) {
+	rg.timeout, rg.timeoutMiddleware = d, newRouteTimeoutMiddleware(d, "RouteGroup.SetTimeout")
+}
+
+// SetStripPrefix sets whether the group and route middleware and handlers of this group
+// and of its sub-groups see request paths relative to the group's prefix, as if the group
+// were mounted at the root. It is meant for mounted modules (see `Mount`, `MountHTTP`)
+// and for `net/http` handlers that assume they serve "/":
+//
+//	api := app.Group("/billing")
+//	api.SetStripPrefix(true)
+//	api.GET("/invoices/:id", handler) // For "/billing/invoices/7": c.Path() is "/invoices/7"
+//
+// What changes for those middleware and handlers:
+//   - `c.Path()` and `c.RoutePattern()` lack the prefix ("/" for the prefix itself), and
+//     `c.StrippedPrefix()` returns it. `http.Handler`s adapted with `WrapHTTPHandler`
+//     receive the relative path in `URL.Path`, like with `http.StripPrefix`.
+//   - `c.Param` is unaffected: parameters keep their names and values, including those
+//     matched within the prefix (e.g., ":tenant" of a group "/t/:tenant", whose segments
+//     are stripped from the path).
+//   - The request URI itself (`c.URI()`, `c.Ctx`) is not rewritten, and global middleware
+//     and error handlers still see full paths, so logs and metrics stay unambiguous.
+//   - Route listings (`Router.Routes`, the routes printed in `DebugMode`) and thus any
+//     links built from them keep the full patterns; prepend `c.StrippedPrefix()` to a
+//     relative path to link back into the group.
+//
+// The prefix stripped is that of the innermost group with stripping enabled, from this
+// group outward; like `SetErrorHandler`, sub-groups inherit dynamically. Pass false to
+// disable stripping for this group (sub-groups then inherit from its parents again).
+// Call SetStripPrefix during setup, before the server starts.
+func (rg *RouteGroup) SetStripPrefix(strip bool) {
+	rg.stripPrefix = strip
+}
+
+// resolveStripPrefix returns the prefix of the innermost group in the chain from `rg`
+// outward with `SetStripPrefix` enabled, or "" if there is none (or it is the root).
+func (rg *RouteGroup) resolveStripPrefix() string {
+	for g := rg; g != nil; g = g.parent {
+		if g.stripPrefix {
+			if g.prefix == "/" {
+				return ""
+			}
+			return g.prefix
+		}
+	}
+	return ""
+}
+
+// withStrippedPrefix wraps `next` so it runs with `c.strippedPrefix` set to the prefix
+// stripped for `group` (see `RouteGroup.SetStripPrefix`). It returns `next` unchanged if
+// no prefix is stripped.
+func withStrippedPrefix(group *RouteGroup, next HandlerFunc) HandlerFunc {
+	prefix := group.resolveStripPrefix()
+	if prefix == "" {
+		return next
+	}
+	return func(c *Context) error {
+		previous := c.strippedPrefix
+		c.strippedPrefix = prefix
+		defer func() { c.strippedPrefix = previous }()
+		return next(c)
+	}
+}
+
+// stripPathPrefix removes `prefix` from `path` if `path` is `prefix` or lies below it.
+// A prefix with parameters (e.g., "/t/:tenant") removes as many leading segments from
+// a request path as it has, unless `path` is the pattern itself.
+func stripPathPrefix(path, prefix string) string {
+	if prefix == "" {
+		return path
+	}
+	if !strings.HasPrefix(path, prefix) {
+		if !strings.ContainsAny(prefix, ":*") {
+			return path
+		}
+		end := 0
+		for segments := strings.Count(prefix, "/"); segments > 0; segments-- {
+			if end >= len(path) || path[end] != '/' {
+				return path
+			}
+			next := strings.IndexByte(path[end+1:], '/')
+			if next < 0 {
+				return "/"
+			}
+			end += next + 1
+		}
+		return path[end:]
+	}
+	if len(path) == len(prefix) {
+		return "/"
+	}
+	if path[len(prefix)] != '/' {
+		return path // e.g., "/billingx" is not below "/billing".
+	}
+	return path[len(prefix):]
+}
+
+// resolveErrorHandler returns the error handler of the innermost group in the chain
+// from `rg` outward that has one, or nil if none of them does.
+func (rg *RouteGroup) resolveErrorHandler() HandlerFunc {
+	for g := rg; g != nil; g = g.parent {
+		if g.errorHandler != nil {
+			return g.errorHandler
+		}
+	}
+	return nil
+}
+
+// errorHandlerFor returns the error handler for routes of `group`: the closest
+// group-level error handler, or the router's `GlobalErrorHandler`.
+func (r *Router) errorHandlerFor(group *RouteGroup) HandlerFunc {
+	if groupErrorHandler := group.resolveErrorHandler(); groupErrorHandler != nil {
+		return groupErrorHandler
+	}
+	return r.GlobalErrorHandler
+}
+
 // addRoute is an internal helper for `RouteGroup` to register a route.
 // It constructs the full path by prepending the group's prefix to the `relativePath`
 // and combines the group's middleware with any route-specific `middlewares`
 // before adding the route to the main router's tree.
-func (rg *RouteGroup) addRoute(method, relativePath string, handler HandlerFunc, middlewares ...Middleware) {
+func (rg *RouteGroup) addRoute(method, relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoutes([]string{method}, relativePath, handler, middlewares...)
+}
+
+// addRoutes is like `addRoute`, but registers the route for each of `methods` at once.
+func (rg *RouteGroup) addRoutes(methods []string, relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
 	// Normalize the relative path for the route within the group.
 	normalizedRelativePath := "/" + strings.Trim(relativePath, "/")
 	if relativePath == "/" || relativePath == "" { // Handler for the group's root.
@@ -811,44 +1647,54 @@ func (rg *RouteGroup) addRoute(method, relativePath string, handler HandlerFunc,
 	allApplicableMiddleware = append(allApplicableMiddleware, middlewares...)
 
 	// Add the route to the main router's tree with the full path and combined middleware.
-	rg.router.addRoute(method, fullPath, handler, allApplicableMiddleware...)
+	return rg.router.addRouteTargets(methods, fullPath, routeTarget{handler: handler, middleware: allApplicableMiddleware, group: rg})
 }
 
 // GET registers a new GET request handler within this `RouteGroup`.
 // The `relativePath` is appended to the group's prefix to form the full route path.
 // Group middleware and any provided route-specific `middlewares` are applied.
-func (rg *RouteGroup) GET(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodGet, relativePath, handler, middlewares...)
+func (rg *RouteGroup) GET(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodGet, relativePath, handler, middlewares...)
 }
 
 // POST registers a new POST request handler within this `RouteGroup`.
-func (rg *RouteGroup) POST(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodPost, relativePath, handler, middlewares...)
+func (rg *RouteGroup) POST(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodPost, relativePath, handler, middlewares...)
 }
 
 // PUT registers a new PUT request handler within this `RouteGroup`.
-func (rg *RouteGroup) PUT(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodPut, relativePath, handler, middlewares...)
+func (rg *RouteGroup) PUT(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodPut, relativePath, handler, middlewares...)
 }
 
 // DELETE registers a new DELETE request handler within this `RouteGroup`.
-func (rg *RouteGroup) DELETE(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodDelete, relativePath, handler, middlewares...)
+func (rg *RouteGroup) DELETE(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodDelete, relativePath, handler, middlewares...)
 }
 
 // PATCH registers a new PATCH request handler within this `RouteGroup`.
-func (rg *RouteGroup) PATCH(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodPatch, relativePath, handler, middlewares...)
+func (rg *RouteGroup) PATCH(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodPatch, relativePath, handler, middlewares...)
 }
 
 // HEAD registers a new HEAD request handler within this `RouteGroup`.
-func (rg *RouteGroup) HEAD(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodHead, relativePath, handler, middlewares...)
+func (rg *RouteGroup) HEAD(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodHead, relativePath, handler, middlewares...)
 }
 
 // OPTIONS registers a new OPTIONS request handler within this `RouteGroup`.
-func (rg *RouteGroup) OPTIONS(relativePath string, handler HandlerFunc, middlewares ...Middleware) {
-	rg.addRoute(MethodOptions, relativePath, handler, middlewares...)
+func (rg *RouteGroup) OPTIONS(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoute(MethodOptions, relativePath, handler, middlewares...)
+}
+
+// Any registers the handler for all methods listed in `Router.Any` within this `RouteGroup`.
+func (rg *RouteGroup) Any(relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoutes(anyMethods, relativePath, handler, middlewares...)
+}
+
+// Match registers the handler for each of the given HTTP `methods` within this `RouteGroup`.
+func (rg *RouteGroup) Match(methods []string, relativePath string, handler HandlerFunc, middlewares ...Middleware) *Route {
+	return rg.addRoutes(methods, relativePath, handler, middlewares...)
 }
 
 // Group creates a new sub-`RouteGroup` nested within the current `RouteGroup`.
@@ -893,6 +1739,7 @@ func (rg *RouteGroup) Group(relativePathPrefix string, middlewares ...Middleware
 
 	return &RouteGroup{
 		router:     rg.router,          // Link back to the main router.
+		parent:     rg,                 // Link to the parent group (for inherited settings).
 		prefix:     newFullPrefix,      // Set the full prefix for the new sub-group.
 		middleware: combinedMiddleware, // Set the combined middleware.
 	}
@@ -13,12 +13,13 @@ import (
 // Key Responsibilities:
 //   - Retrieves the original error cause from the context (using ContextKeyErrorCause).
 //   - Uses `c.Logger()` for contextualized logging.
-//   - Differentiates between `xylium.HTTPError` and generic Go errors.
+//   - Differentiates between `xylium.HTTPError` and generic Go errors. Generic errors are
+//     first passed to the mappers registered with `Router.RegisterErrorMapper`.
 //   - For `xylium.HTTPError`:
 //   - Uses the error's specified HTTP status code and message for the client response.
 //   - Logs the error details, including any internal error (`httpErr.Internal`).
 //   - In `DebugMode`, includes `httpErr.Internal.Error()` in the client JSON response under `_debug_info`.
-//   - For generic Go errors:
+//   - For generic Go errors that no mapper handles:
 //   - Responds with HTTP 500 Internal Server Error.
 //   - In `DebugMode`, includes the `originalErr.Error()` in the client JSON response under `_debug_info`.
 //   - In `ReleaseMode`, provides a generic "Internal Server Error" message to the client.
@@ -41,7 +42,12 @@ func defaultGlobalErrorHandler(c *Context) error {
 		responseMessage = M{"error": "An unexpected error occurred internally; cause not specified."}
 	} else {
 		var httpErr *HTTPError
-		if errors.As(originalErr, &httpErr) {
+		isHTTPError := errors.As(originalErr, &httpErr)
+		if !isHTTPError {
+			// Errors registered with Router.RegisterErrorMapper (e.g., sql.ErrNoRows -> 404).
+			httpErr, isHTTPError = c.mapError(originalErr)
+		}
+		if isHTTPError {
 			httpStatusCode = httpErr.Code
 			if httpErr.Message != nil {
 				responseMessage = httpErr.Message
@@ -106,7 +112,8 @@ func defaultGlobalErrorHandler(c *Context) error {
 // It is invoked by the router's main Handler when `recover()` captures a panic.
 //
 // Key Responsibilities:
-//   - Retrieves panic information from the context (using ContextKeyPanicInfo).
+//   - Retrieves panic information from the context (using ContextKeyPanicInfo; the stack
+//     trace is available under ContextKeyPanicStack, see `c.PanicInfo()`).
 //   - Logs the panic event using `c.Logger()`. (Stack trace logged by Router.Handler).
 //   - Constructs and returns an `xylium.HTTPError` with status 500.
 //   - This `HTTPError` is then processed by the `GlobalErrorHandler`.
@@ -129,16 +136,17 @@ func defaultPanicHandler(c *Context) error {
 // defaultNotFoundHandler is Xylium's default handler for requests where no route
 // matches the requested path (HTTP 404 Not Found).
 // It returns an `xylium.HTTPError`, which is then processed by the `GlobalErrorHandler`.
+// The body is built by `noRouteErrorBody`.
 func defaultNotFoundHandler(c *Context) error {
-	return NewHTTPError(StatusNotFound,
-		M{"error": fmt.Sprintf("The requested resource at '%s' could not be found on this server.", c.Path())},
-	)
+	message := fmt.Sprintf("The requested resource at '%s' could not be found on this server.", c.Path())
+	return NewHTTPError(StatusNotFound, noRouteErrorBody(c, StatusNotFound, message, nil))
 }
 
 // defaultMethodNotAllowedHandler is Xylium's default handler for requests where a route
 // path exists, but not for the requested HTTP method (HTTP 405 Method Not Allowed).
 // The "Allow" header is expected to be set by Router.Handler before this is called.
 // It returns an `xylium.HTTPError`, processed by the `GlobalErrorHandler`.
+// The body is built by `noRouteErrorBody`.
 func defaultMethodNotAllowedHandler(c *Context) error {
 	allowHeader := string(c.Ctx.Response.Header.Peek("Allow"))
 	var allowedMethods []string
@@ -151,10 +159,24 @@ func defaultMethodNotAllowedHandler(c *Context) error {
 			}
 		}
 	}
-	return NewHTTPError(StatusMethodNotAllowed,
-		M{
-			"error":           fmt.Sprintf("The method '%s' is not supported for the resource at '%s'.", c.Method(), c.Path()),
-			"allowed_methods": allowedMethods,
-		},
-	)
+	message := fmt.Sprintf("The method '%s' is not supported for the resource at '%s'.", c.Method(), c.Path())
+	return NewHTTPError(StatusMethodNotAllowed, noRouteErrorBody(c, StatusMethodNotAllowed, message, allowedMethods))
+}
+
+// noRouteErrorBody returns the response body of the default 404 and 405 handlers. If
+// `ServerConfig.NoRouteErrorBody` is set, its result is used. Otherwise the body is
+// `{"error": message, "status": status, "path": path}`, plus "allowed_methods" for a 405.
+func noRouteErrorBody(c *Context, status int, message string, allowedMethods []string) interface{} {
+	if c.router != nil && c.router.serverConfig.NoRouteErrorBody != nil {
+		return c.router.serverConfig.NoRouteErrorBody(c, status, message)
+	}
+	body := M{
+		"error":  message,
+		"status": status,
+		"path":   c.Path(),
+	}
+	if status == StatusMethodNotAllowed {
+		body["allowed_methods"] = allowedMethods
+	}
+	return body
 }
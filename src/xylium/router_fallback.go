@@ -0,0 +1,91 @@
+// src/xylium/router_fallback.go
+package xylium
+
+import (
+	"strings" // For normalizing and matching fallback prefixes.
+)
+
+// routeFallback is a handler for requests under `prefix` that match no route,
+// registered via `Router.Fallback` or `RouteGroup.Fallback`.
+type routeFallback struct {
+	prefix     string       // Normalized path prefix ("/" covers every path).
+	handler    HandlerFunc  // Handler invoked for unmatched paths under the prefix.
+	middleware []Middleware // Group middleware to run before the handler (nil for router fallbacks).
+	group      *RouteGroup  // Group the fallback was registered on (nil for router fallbacks).
+}
+
+// covers reports whether `path` lies under the fallback's prefix. Prefixes match whole
+// path segments, so "/app" covers "/app" and "/app/settings" but not "/application".
+func (f *routeFallback) covers(path string) bool {
+	if f.prefix == "/" || path == f.prefix {
+		return true
+	}
+	return strings.HasPrefix(path, f.prefix+"/")
+}
+
+// Fallback registers `handler` for requests under `prefix` that match no route, in place
+// of `NotFoundHandler`. This is typically used to serve the index page of a single-page
+// application for client-side routes, while unknown paths elsewhere still get a 404.
+// When several fallbacks cover a path, the one with the longest prefix wins.
+// Requests that match a path but not its method still get a 405.
+//
+// The fallback runs after global middleware, like a route. Registering a second fallback
+// for the same prefix panics.
+//
+// Example:
+//
+//	app.GET("/api/status", statusHandler)
+//	app.Fallback("/", func(c *xylium.Context) error {
+//		return c.File("./web/dist/index.html") // "/dashboard", "/users/42", ...
+//	})
+func (r *Router) Fallback(prefix string, handler HandlerFunc) {
+	r.addFallback(routeFallback{prefix: prefix, handler: handler})
+}
+
+// Fallback registers `handler` for requests under this group's prefix that match no
+// route, like `Router.Fallback`. The group's middleware runs before it, and errors it
+// returns are handled by the group's error handler (see `SetErrorHandler`).
+//
+// Example:
+//
+//	app.Group("/api").Fallback(func(c *xylium.Context) error {
+//		return xylium.NewHTTPError(xylium.StatusNotFound, "unknown API endpoint")
+//	})
+func (rg *RouteGroup) Fallback(handler HandlerFunc) {
+	// Copy the group middleware, as for routes registered on the group.
+	groupMiddleware := make([]Middleware, len(rg.middleware))
+	copy(groupMiddleware, rg.middleware)
+	rg.router.addFallback(routeFallback{prefix: rg.prefix, handler: handler, middleware: groupMiddleware, group: rg})
+}
+
+// addFallback normalizes the prefix of `fallback` and registers it.
+// It panics if the handler is nil or a fallback for the prefix already exists.
+func (r *Router) addFallback(fallback routeFallback) {
+	if fallback.handler == nil {
+		panic("xylium: fallback handler cannot be nil")
+	}
+	normalizedPrefix := "/" + strings.Trim(fallback.prefix, "/")
+	if fallback.prefix == "/" || fallback.prefix == "" {
+		normalizedPrefix = "/"
+	}
+	fallback.prefix = normalizedPrefix
+	for _, existing := range r.fallbacks {
+		if existing.prefix == fallback.prefix {
+			panic("xylium: a fallback is already registered for prefix '" + fallback.prefix + "'")
+		}
+	}
+	r.fallbacks = append(r.fallbacks, fallback)
+}
+
+// findFallback returns the fallback with the longest prefix covering `path`,
+// or nil if none does.
+func (r *Router) findFallback(path string) *routeFallback {
+	var best *routeFallback
+	for i := range r.fallbacks {
+		f := &r.fallbacks[i]
+		if f.covers(path) && (best == nil || len(f.prefix) > len(best.prefix)) {
+			best = f
+		}
+	}
+	return best
+}
@@ -0,0 +1,235 @@
+// src/xylium/router_health.go
+package xylium
+
+import (
+	"context" // For health check contexts and timeouts.
+	"fmt"     // For panic messages on invalid health checks.
+	"sync"    // For caching health check results.
+	"time"    // For HealthConfig timeouts and cache TTL.
+)
+
+// SetReady marks the router as ready (true) or not ready (false) to receive traffic,
+// as reported by `ReadyHandler`. A router is ready when created.
+//
+// The graceful shutdown methods (e.g., `Start`, `ListenAndServeGracefully`) mark the
+// router not ready as soon as a shutdown signal is received, before waiting for
+// `ServerConfig.PreShutdownDelay`. Applications can also mark the router not ready
+// during startup (e.g., while warming caches) and ready once they can serve requests.
+// It is safe for concurrent use.
+func (r *Router) SetReady(ready bool) {
+	r.notReady.Store(!ready)
+}
+
+// IsReady reports whether the router is ready to receive traffic (see `SetReady`).
+func (r *Router) IsReady() bool {
+	if r.mountedOn != nil && !r.mountedOn.IsReady() {
+		return false // A mounted router follows the router it is mounted on.
+	}
+	return !r.notReady.Load()
+}
+
+// ReadyHandler returns a handler for a readiness probe endpoint, e.g., for a load
+// balancer or a Kubernetes `readinessProbe`. It responds with 200 and
+// `{"status":"ready"}` while the router is ready, and with 503 and
+// `{"status":"not ready"}` otherwise, including during graceful shutdown.
+//
+// Example:
+//
+//	app.GET("/ready", app.ReadyHandler())
+func (r *Router) ReadyHandler() HandlerFunc {
+	return func(c *Context) error {
+		if !r.IsReady() {
+			return c.JSON(StatusServiceUnavailable, M{"status": "not ready"})
+		}
+		return c.JSON(StatusOK, M{"status": "ready"})
+	}
+}
+
+// HealthCheck is a named dependency check run by the endpoint registered with `Health`
+// (e.g., pinging a database). `Check` returns nil if the dependency is healthy.
+type HealthCheck struct {
+	// Name identifies the check in the JSON report (e.g., "database"). It must be unique
+	// within an endpoint and not empty.
+	Name string
+	// Check reports the health of the dependency. It must return before `ctx` is done;
+	// `ctx` carries the `HealthConfig.Timeout` deadline. A panic is recovered and reported
+	// as a failed check.
+	Check func(ctx context.Context) error
+}
+
+// HealthConfig defines the configuration for the endpoint registered with `HealthWithConfig`.
+type HealthConfig struct {
+	// Checks are the dependency checks to run. They run concurrently.
+	Checks []HealthCheck
+
+	// Timeout limits how long each check may run. A check still running after
+	// `Timeout` is reported as down.
+	// Default: 5 seconds (also used if zero or negative).
+	Timeout time.Duration
+
+	// CacheTTL is how long check results are reused for subsequent requests, so frequent
+	// probes (or several load balancers) do not hammer the dependencies. Concurrent
+	// requests while checks are running wait for and share the same results.
+	// A value of 0 runs the checks on every request.
+	// Default: 1 second (from `DefaultHealthConfig`).
+	CacheTTL time.Duration
+}
+
+// DefaultHealthConfig provides the default configuration used by `Health`.
+var DefaultHealthConfig = HealthConfig{
+	Timeout:  5 * time.Second,
+	CacheTTL: 1 * time.Second,
+}
+
+// healthCheckResult is the outcome of one `HealthCheck` in a health report.
+type healthCheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// healthReport is the JSON body of the health endpoints.
+type healthReport struct {
+	Status string                       `json:"status"`
+	Reason string                       `json:"reason,omitempty"`
+	Checks map[string]healthCheckResult `json:"checks,omitempty"`
+}
+
+// Liveness registers a GET liveness endpoint at `path`, e.g., for a Kubernetes
+// `livenessProbe`. It always responds with 200 and `{"status":"up"}` while the process
+// can serve requests, and runs no dependency checks: a failing database should make the
+// instance unready (see `Health`), not get it restarted.
+func (r *Router) Liveness(path string) {
+	r.addHealthPath(path)
+	r.GET(path, func(c *Context) error {
+		return c.JSON(StatusOK, healthReport{Status: "up"})
+	})
+}
+
+// Health registers a GET readiness endpoint at `path` that runs `checks` with
+// `DefaultHealthConfig`. See `HealthWithConfig`.
+//
+// Example:
+//
+//	app.Liveness("/livez")
+//	app.Health("/readyz", xylium.HealthCheck{Name: "database", Check: db.PingContext})
+func (r *Router) Health(path string, checks ...HealthCheck) {
+	config := DefaultHealthConfig
+	config.Checks = checks
+	r.HealthWithConfig(path, config)
+}
+
+// HealthWithConfig registers a GET readiness endpoint at `path`, e.g., for a load
+// balancer or a Kubernetes `readinessProbe`. It runs `config.Checks` and responds with
+// a JSON report of each check:
+//
+//	{"status":"down","checks":{"database":{"status":"up"},"cache":{"status":"down","error":"..."}}}
+//
+// The status is 200 if all checks pass and 503 otherwise. While the router is not ready
+// (see `SetReady`), including during graceful shutdown, it responds with 503 and
+// `{"status":"down","reason":"not ready"}` without running the checks.
+// Check errors are included in the report except in `ReleaseMode`, so internal details
+// (e.g., database addresses) are not exposed in production.
+//
+// It panics if a check has an empty or duplicate name or a nil `Check` function.
+func (r *Router) HealthWithConfig(path string, config HealthConfig) {
+	names := make(map[string]struct{}, len(config.Checks))
+	for _, check := range config.Checks {
+		if check.Name == "" || check.Check == nil {
+			panic(fmt.Sprintf("xylium: health check for '%s' must have a name and a Check function", path))
+		}
+		if _, exists := names[check.Name]; exists {
+			panic(fmt.Sprintf("xylium: duplicate health check name '%s' for '%s'", check.Name, path))
+		}
+		names[check.Name] = struct{}{}
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultHealthConfig.Timeout
+	}
+
+	var (
+		mu        sync.Mutex
+		cached    healthReport
+		checkedAt time.Time
+	)
+	r.addHealthPath(path)
+	r.GET(path, func(c *Context) error {
+		if !r.IsReady() {
+			return c.JSON(StatusServiceUnavailable, healthReport{Status: "down", Reason: "not ready"})
+		}
+
+		mu.Lock()
+		if checkedAt.IsZero() || time.Since(checkedAt) >= config.CacheTTL {
+			// The checks must not be canceled with this request, as their results are shared.
+			cached = runHealthChecks(context.WithoutCancel(c.GoContext()), config, r.CurrentMode() != ReleaseMode)
+			checkedAt = time.Now()
+		}
+		report := cached
+		mu.Unlock()
+
+		if report.Status != "up" {
+			return c.JSON(StatusServiceUnavailable, report)
+		}
+		return c.JSON(StatusOK, report)
+	})
+}
+
+// addHealthPath records `path` as a health endpoint (see `isHealthPath`).
+func (r *Router) addHealthPath(path string) {
+	if r.healthPaths == nil {
+		r.healthPaths = make(map[string]struct{})
+	}
+	r.healthPaths[path] = struct{}{}
+}
+
+// isHealthPath reports whether `path` is a health endpoint registered with `Liveness`,
+// `Health` or `HealthWithConfig`.
+func (r *Router) isHealthPath(path string) bool {
+	_, ok := r.healthPaths[path]
+	return ok
+}
+
+// runHealthChecks runs the checks of `config` concurrently, each with `config.Timeout`,
+// and returns the aggregated report. Errors are included if `includeErrors` is true.
+func runHealthChecks(ctx context.Context, config HealthConfig, includeErrors bool) healthReport {
+	report := healthReport{Status: "up", Checks: make(map[string]healthCheckResult, len(config.Checks))}
+	errs := make([]error, len(config.Checks))
+	var wg sync.WaitGroup
+	for i, check := range config.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+			defer cancel()
+			done := make(chan error, 1)
+			go func() {
+				// A panicking check is reported as down instead of crashing the process.
+				defer func() {
+					if p := recover(); p != nil {
+						done <- fmt.Errorf("health check %q panicked: %v", check.Name, p)
+					}
+				}()
+				done <- check.Check(checkCtx)
+			}()
+			select {
+			case errs[i] = <-done:
+			case <-checkCtx.Done():
+				errs[i] = fmt.Errorf("health check timed out after %s", config.Timeout)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, check := range config.Checks {
+		if errs[i] == nil {
+			report.Checks[check.Name] = healthCheckResult{Status: "up"}
+			continue
+		}
+		report.Status = "down"
+		result := healthCheckResult{Status: "down"}
+		if includeErrors {
+			result.Error = errs[i].Error()
+		}
+		report.Checks[check.Name] = result
+	}
+	return report
+}
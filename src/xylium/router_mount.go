@@ -0,0 +1,236 @@
+// src/xylium/router_mount.go
+package xylium
+
+import (
+	"fmt"      // For app store merge conflict messages.
+	"io"       // For the closers of mounted routers.
+	"net/http" // For mounting standard library http.Handler instances.
+	"reflect"  // For detecting whether a sub-router's handlers were customized.
+	"strings"  // For normalizing mount prefixes.
+)
+
+// Mount registers all routes of the sub-router `sub` under `prefix`, so a self-contained
+// module can expose its own `*Router` and be attached to an application without
+// re-registering its routes. A route "/users/:id" of `sub` mounted at "/module" is served
+// at "/module/users/:id". Fallbacks registered on `sub` (see `Fallback`) are mounted too.
+//
+// Composition across the boundary:
+//   - Middleware runs in the order: global middleware of this router, global middleware
+//     of `sub`, then the group and route middleware of the mounted route.
+//   - Errors are handled by the error handlers of the route's groups in `sub` (see
+//     `RouteGroup.SetErrorHandler`), then by the `GlobalErrorHandler` of `sub` if it was
+//     customized, and finally by the `GlobalErrorHandler` of this router. The default
+//     error handler applies the error mappers of `sub` (see `RegisterErrorMapper`) to
+//     mounted routes before those of this router.
+//   - The application store of `sub` (see `AppSet`) is merged into this router's, so
+//     `c.AppGet` works in mounted handlers, and the closers of `sub` are closed on this
+//     router's shutdown. Mount panics if a key is set on both routers to different values.
+//   - A customized `NotFoundHandler` of `sub` answers unmatched paths under `prefix`, as
+//     a fallback, unless `sub` registered a root fallback itself.
+//   - Health endpoints of `sub` (see `Health`) report not ready while this router is not
+//     ready, and are recognized as health endpoints by middleware such as `HTTPSRedirect`.
+//   - A customized `PanicHandler` or `MethodNotAllowedHandler` of `sub` cannot apply to
+//     only part of this router, so Mount panics; set them on this router instead.
+//   - Pre-routing hooks, renderers and the server configuration of `sub` are not used;
+//     this router's apply.
+//
+// Mount copies the routes at call time, so register all routes on `sub` (and set its
+// handlers) before mounting it. Mounting panics if a route collides with an existing route.
+//
+// Mount returns the group that represents the mount point. Call `SetStripPrefix(true)`
+// on it if the handlers of `sub` expect `c.Path()` and `c.RoutePattern()` relative to
+// `prefix`, as when `sub` is served on its own; global middleware of `sub` then sees
+// relative paths too. `SetErrorHandler` and `SetTimeout` apply to all mounted routes.
+//
+// Example:
+//
+//	billing := xylium.New()
+//	billing.GET("/invoices", listInvoices)
+//	app.Mount("/billing", billing) // GET /billing/invoices
+func (r *Router) Mount(prefix string, sub *Router) *RouteGroup {
+	if sub == nil {
+		panic("xylium: Mount requires a non-nil sub-router")
+	}
+	if sub == r {
+		panic("xylium: a router cannot be mounted on itself")
+	}
+
+	if !isDefaultHandler(sub.PanicHandler, defaultPanicHandler) {
+		panic("xylium: Mount cannot apply the PanicHandler of the sub-router; set it on the parent router")
+	}
+	if !isDefaultHandler(sub.MethodNotAllowedHandler, defaultMethodNotAllowedHandler) {
+		panic("xylium: Mount cannot apply the MethodNotAllowedHandler of the sub-router; set it on the parent router")
+	}
+	r.mergeAppStore(sub)
+
+	mountGroup := &RouteGroup{router: r, prefix: normalizeMountPrefix(prefix), errorMappers: sub.errorMappers}
+	if !isDefaultHandler(sub.GlobalErrorHandler, defaultGlobalErrorHandler) {
+		mountGroup.errorHandler = sub.GlobalErrorHandler
+	}
+	// Groups of `sub` are cloned so their error handlers chain up to `mountGroup`
+	// without modifying `sub`.
+	clonedGroups := make(map[*RouteGroup]*RouteGroup)
+	var cloneGroup func(g *RouteGroup) *RouteGroup
+	cloneGroup = func(g *RouteGroup) *RouteGroup {
+		if g == nil {
+			return mountGroup
+		}
+		if clone, ok := clonedGroups[g]; ok {
+			return clone
+		}
+		clone := &RouteGroup{
+			router:       r,
+			parent:       cloneGroup(g.parent),
+			prefix:       joinRoutePath(mountGroup.prefix, g.prefix),
+			middleware:   g.middleware,
+			errorHandler: g.errorHandler,
+			errorMappers: g.errorMappers,
+
+			timeout:           g.timeout,
+			timeoutMiddleware: g.timeoutMiddleware,
+			stripPrefix:       g.stripPrefix,
+		}
+		clonedGroups[g] = clone
+		return clone
+	}
+
+	sub.tree.walkRoutes(func(method, path string, target routeTarget) {
+		r.addRouteTarget(method, joinRoutePath(mountGroup.prefix, path), routeTarget{
+			handler:    target.handler,
+			middleware: withLeadingMiddleware(sub.globalMiddleware, target.middleware),
+			group:      cloneGroup(target.group),
+			route:      target.route,
+		})
+	})
+	hasRootFallback := false
+	for _, fallback := range sub.fallbacks {
+		hasRootFallback = hasRootFallback || fallback.prefix == "/"
+		r.addFallback(routeFallback{
+			prefix:     joinRoutePath(mountGroup.prefix, fallback.prefix),
+			handler:    fallback.handler,
+			middleware: withLeadingMiddleware(sub.globalMiddleware, fallback.middleware),
+			group:      cloneGroup(fallback.group),
+		})
+	}
+	if !hasRootFallback && !isDefaultHandler(sub.NotFoundHandler, defaultNotFoundHandler) {
+		r.addFallback(routeFallback{
+			prefix:     mountGroup.prefix,
+			handler:    sub.NotFoundHandler,
+			middleware: sub.globalMiddleware,
+			group:      mountGroup,
+		})
+	}
+
+	for path := range sub.healthPaths {
+		r.addHealthPath(joinRoutePath(mountGroup.prefix, path))
+	}
+	sub.mountedOn = r
+	return mountGroup
+}
+
+// mergeAppStore copies the application store and closers of the mounted router `sub`
+// into this router. It panics if a key is set on both routers to different values.
+func (r *Router) mergeAppStore(sub *Router) {
+	sub.appStoreMux.RLock()
+	entries := make(map[string]interface{}, len(sub.appStore))
+	for key, value := range sub.appStore {
+		entries[key] = value
+	}
+	sub.appStoreMux.RUnlock()
+
+	r.appStoreMux.Lock()
+	for key, value := range entries {
+		if existing, ok := r.appStore[key]; ok && !sameAppValue(existing, value) {
+			r.appStoreMux.Unlock()
+			panic(fmt.Sprintf("xylium: Mount cannot merge app store key '%s': it is set to a different value on the parent router", key))
+		}
+	}
+	for key, value := range entries {
+		r.appStore[key] = value
+	}
+	r.appStoreMux.Unlock()
+
+	sub.closersMux.Lock()
+	closers := append([]io.Closer(nil), sub.closers...)
+	sub.closersMux.Unlock()
+	for _, closer := range closers {
+		r.RegisterCloser(closer)
+	}
+	sub.internalRateLimitStoresMux.Lock()
+	stores := append([]LimiterStore(nil), sub.internalRateLimitStores...)
+	sub.internalRateLimitStoresMux.Unlock()
+	for _, store := range stores {
+		r.addInternalStore(store)
+	}
+}
+
+// sameAppValue reports whether two app store values are the same comparable value,
+// e.g., the same `*sql.DB` set on both routers.
+func sameAppValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// MountHTTP serves the standard library `handler` for every path under `prefix` (including
+// `prefix` itself) and for all methods registered by `Any`, for interop with existing
+// `net/http` code. The handler is adapted with `WrapHTTPHandler`, so its performance cost
+// and limits (no streaming or hijacking) apply.
+//
+// The handler sees the full request path. If it expects paths relative to `prefix`, call
+// `SetStripPrefix(true)` on the returned group (the group the routes are registered on),
+// which has the effect of `http.StripPrefix`. Global middleware and the optional
+// `middlewares` run before it; the handler itself never returns an error to Xylium.
+//
+// Example:
+//
+//	app.MountHTTP("/debug/pprof", http.DefaultServeMux)
+//	app.MountHTTP("/legacy", legacyMux).SetStripPrefix(true) // legacyMux sees "/..."
+func (r *Router) MountHTTP(prefix string, handler http.Handler, middlewares ...Middleware) *RouteGroup {
+	if handler == nil {
+		panic("xylium: MountHTTP requires a non-nil http.Handler")
+	}
+	xyliumHandler := WrapHTTPHandler(handler)
+
+	group := r.Group(prefix)
+	if group.prefix != "/" {
+		group.Any("/", xyliumHandler, middlewares...)
+	}
+	group.Any("/*path", xyliumHandler, middlewares...)
+	return group
+}
+
+// normalizeMountPrefix normalizes a prefix the same way as `Router.Group`.
+func normalizeMountPrefix(prefix string) string {
+	if prefix == "/" || prefix == "" {
+		return "/"
+	}
+	return "/" + strings.Trim(prefix, "/")
+}
+
+// joinRoutePath joins a normalized `prefix` and an absolute `path`, as for group routes.
+func joinRoutePath(prefix, path string) string {
+	if prefix == "/" {
+		return path
+	}
+	if path == "/" {
+		return prefix
+	}
+	return prefix + path
+}
+
+// withLeadingMiddleware returns a new slice with `leading` followed by `mw`.
+func withLeadingMiddleware(leading, mw []Middleware) []Middleware {
+	combined := make([]Middleware, 0, len(leading)+len(mw))
+	combined = append(combined, leading...)
+	return append(combined, mw...)
+}
+
+// isDefaultHandler reports whether `handler` is unset or Xylium's `defaultHandler`.
+func isDefaultHandler(handler, defaultHandler HandlerFunc) bool {
+	return handler == nil || reflect.ValueOf(handler).Pointer() == reflect.ValueOf(defaultHandler).Pointer()
+}
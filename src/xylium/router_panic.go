@@ -0,0 +1,89 @@
+// src/xylium/router_panic.go
+package xylium
+
+import (
+	"fmt"      // For describing redacted bodies.
+	"net/http" // For canonicalizing header names.
+	"strings"  // For building the redacted query string.
+)
+
+// panicReportMaxBody caps the request body snapshot logged with a recovered panic.
+const panicReportMaxBody = 2048
+
+// panicReportRedacted replaces sensitive values in panic reports outside `DebugMode`.
+const panicReportRedacted = "[REDACTED]"
+
+// panicReportSensitiveHeaders lists the headers whose values are always redacted in
+// panic reports outside `DebugMode`.
+var panicReportSensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+	"X-Csrf-Token":        true,
+}
+
+// panicReportFields returns the structured log fields describing the request that
+// caused a recovered panic, so the crash can be reproduced: "method", "path", "query",
+// "client_ip", "headers" and a "body" snapshot of at most `panicReportMaxBody` bytes.
+// The request ID is added by `c.Logger()` itself.
+//
+// In `DebugMode`, everything is logged as received. In other modes, the values of
+// sensitive headers (e.g., "Authorization", "Cookie") and of query parameters are
+// redacted, and only the size of the body is logged, so credentials and personal data
+// do not end up in production logs.
+func (r *Router) panicReportFields(c *Context) M {
+	debugMode := r.CurrentMode() == DebugMode
+	req := &c.Ctx.Request
+
+	headers := make(map[string]string)
+	req.Header.VisitAll(func(key, value []byte) {
+		name := http.CanonicalHeaderKey(string(key))
+		if !debugMode && panicReportSensitiveHeaders[name] {
+			headers[name] = panicReportRedacted
+			return
+		}
+		if previous, ok := headers[name]; ok {
+			headers[name] = previous + ", " + string(value)
+			return
+		}
+		headers[name] = string(value)
+	})
+
+	query := string(c.Ctx.URI().QueryString())
+	if !debugMode && query != "" {
+		var redacted []string
+		c.Ctx.QueryArgs().VisitAll(func(key, _ []byte) {
+			redacted = append(redacted, string(key)+"="+panicReportRedacted)
+		})
+		query = strings.Join(redacted, "&")
+	}
+
+	var body string
+	switch {
+	case req.IsBodyStream():
+		body = "[streamed body not captured]"
+	case !debugMode:
+		if size := len(req.Body()); size > 0 {
+			body = fmt.Sprintf("[REDACTED %d bytes]", size)
+		}
+	default:
+		raw := req.Body()
+		if len(raw) > panicReportMaxBody {
+			body = string(raw[:panicReportMaxBody]) + fmt.Sprintf("... [truncated, %d bytes total]", len(raw))
+		} else {
+			body = string(raw)
+		}
+	}
+
+	return M{
+		"method":    string(c.Ctx.Method()),
+		"path":      string(c.Ctx.Path()),
+		"query":     query,
+		"client_ip": c.RealIP(),
+		"headers":   headers,
+		"body":      body,
+	}
+}
@@ -1,13 +1,18 @@
 package xylium
 
 import (
-	"io"        // For io.Closer, used in closeApplicationResources.
-	"log"       // Used by fasthttp as a fallback if its logger is nil, and for emergency logs.
-	"net"       // For net.Conn, fasthttp.ConnState.
-	"os"        // For os.Signal.
-	"os/signal" // For graceful shutdown signal handling.
-	"syscall"   // For syscall.SIGINT, syscall.SIGTERM.
-	"time"      // For timeouts.
+	"context"    // For shutting down the net/http server used with EnableHTTP2.
+	"crypto/tls" // For embedded certificates with EnableHTTP2.
+	"errors"     // For recognizing http.ErrServerClosed.
+	"io"         // For io.Closer, used in closeApplicationResources.
+	"log"        // Used by fasthttp as a fallback if its logger is nil, and for emergency logs.
+	"net"        // For net.Conn, fasthttp.ConnState.
+	"net/http"   // For the net/http server used with EnableHTTP2.
+	"os"         // For os.Signal.
+	"os/signal"  // For graceful shutdown signal handling.
+	"strings"    // For trimming net/http log lines.
+	"syscall"    // For syscall.SIGINT, syscall.SIGTERM.
+	"time"       // For timeouts.
 
 	"github.com/valyala/fasthttp" // The underlying HTTP server.
 )
@@ -48,6 +53,16 @@ type ServerConfig struct {
 	// Default: 4MB (4 * 1024 * 1024) (from `DefaultServerConfig()`).
 	MaxRequestBodySize int
 
+	// MaxRequestHeaderSize defines the maximum size, in bytes, of the request line and
+	// headers of an incoming request, limiting header-bomb attacks (huge or numerous
+	// headers and cookies). A request whose header exceeds it is answered with HTTP 431
+	// "Request Header Fields Too Large" and its connection is closed, before any handler
+	// runs. With fasthttp, this sets the per-connection read buffer (`ReadBufferSize`), so
+	// large values also raise the memory used by every open connection. With
+	// `EnableHTTP2`, it sets `http.Server.MaxHeaderBytes`.
+	// Default: 0 (the server's default: 4KB for fasthttp, 1MB for `net/http`).
+	MaxRequestHeaderSize int
+
 	// ReduceMemoryUsage, if true, enables `fasthttp`'s memory reduction mode.
 	// This can decrease memory allocations at the cost of potentially higher CPU usage.
 	// Test with your specific workload to determine the impact.
@@ -135,11 +150,110 @@ type ServerConfig struct {
 
 	// StreamRequestBody, if true, enables streaming of request bodies. This can be
 	// beneficial for handling very large uploads, as it avoids buffering the entire
-	// request body in memory before processing. When enabled, `c.Body()` might behave
-	// differently, and the body should be consumed as a stream.
+	// request body in memory before processing. When enabled, consume the body as a
+	// stream via `c.BodyReader()`; `c.Body()` and the binder still work, but buffer the
+	// whole body on first use.
 	// Default: false (request bodies are typically buffered by `fasthttp`).
 	StreamRequestBody bool
 
+	// MultipartMaxMemory, if positive, is the number of bytes of uploaded file contents that
+	// `c.MultipartForm()` (and `c.FormFile()`, form binding) keeps in memory per request;
+	// files beyond it are written to temporary files in `os.TempDir()`, which are removed
+	// when the Context is released. This keeps large uploads from exhausting memory,
+	// especially together with `StreamRequestBody`. Non-file values may use up to
+	// 10MB in addition, as with `mime/multipart`.
+	// Default: 0 (forms are parsed by `fasthttp`, which keeps buffered files in memory).
+	MultipartMaxMemory int64
+
+	// DisallowUnknownFields, if true, makes JSON binding (`c.Bind`, `c.BindJSON`,
+	// `c.BindAndValidate`, ...) reject request bodies with object keys that match no
+	// field of the target struct, including in nested structs, to catch client typos in
+	// strict public APIs. Binding then fails with a 400 `*HTTPError` whose "details"
+	// name the unknown field, e.g., `"emial": "unknown field"`. A map target accepts any key.
+	// Default: false (unknown fields are ignored, as with `encoding/json`).
+	DisallowUnknownFields bool
+
+	// AutoHEAD, if true, makes every GET route also answer HEAD requests for the same
+	// path, unless an explicit HEAD route is registered for it. The GET handler chain
+	// (including its middleware) is invoked, and the response body is discarded while
+	// headers and `Content-Length` are preserved, as required for HEAD by RFC 9110.
+	// It also causes HEAD to be listed in the "Allow" header of 405 responses for paths
+	// that have a GET route.
+	// Default: false (HEAD requests without an explicit HEAD route receive 405).
+	AutoHEAD bool
+
+	// AutoOPTIONS, if true, makes the router answer OPTIONS requests for paths that have
+	// routes but no explicit OPTIONS route. The response is `204 No Content` with an "Allow"
+	// header listing the methods registered for that path (plus OPTIONS itself).
+	// This runs instead of `MethodNotAllowedHandler`. A user-registered OPTIONS route for
+	// the path always takes precedence. Global middleware (registered with `Use`) wraps the
+	// response even without `RunMiddlewareOnNoRoute`, so a global `CORS` middleware answers
+	// browser preflights for every routed path.
+	// Default: false (OPTIONS requests without an explicit OPTIONS route receive 405).
+	AutoOPTIONS bool
+
+	// JSONStreamThreshold, if positive, makes `c.JSON` and `c.XML` stream encoded bodies of
+	// at least this many bytes to the client instead of copying them into the response
+	// buffer. This keeps large payloads out of the pooled response buffers, and the stream
+	// observes the request context deadline (see `c.Deadline()`, e.g., set by the `Timeout`
+	// middleware): if it passes while a slow client is still receiving the body, the write
+	// is aborted and the connection closed, so the client sees an incomplete response
+	// rather than a silently truncated one. Smaller bodies use the buffered path.
+	// Only the write is streamed: the payload is still encoded into one in-memory buffer
+	// before the handler returns (its size selects the path), and encoding does not observe
+	// the deadline. Use `c.SetBodyStreamWriter` to encode very large payloads incrementally.
+	// Streamed bodies are treated like other streams by middleware that inspect the
+	// response body (e.g., `Gzip`, `BodyDump`, `Idempotency`).
+	// Default: 0 (always buffered).
+	JSONStreamThreshold int
+
+	// TLSConfig is the base TLS configuration of the `ListenAndServeTLS*` methods (it is
+	// cloned, never modified), e.g., to set `MinVersion`, cipher suites, or certificates for
+	// several domains. A `GetCertificate` callback (e.g., from an ACME client) is consulted
+	// first for every handshake. If `Certificates` or `GetCertificate` is set, the
+	// certificate file arguments of `ListenAndServeTLS*` may be empty. The certificate
+	// loaded from those arguments can be replaced at runtime with `Router.ReloadCertificate`.
+	// Default: nil (Go's default TLS settings).
+	TLSConfig *tls.Config
+
+	// EnableHTTP2, if true, serves the router with the standard library's `net/http`
+	// server (through `Router.HTTPHandler`) instead of fasthttp, which does not implement
+	// HTTP/2. The `ListenAndServe*` methods (and `Start`) then accept HTTP/1.1 and HTTP/2:
+	// over TLS, "h2" is negotiated with ALPN; on plain listeners, HTTP/2 is accepted as
+	// h2c with prior knowledge (clients that send HTTP/2 directly, as gRPC and internal
+	// service clients do; the HTTP/1.1 "Upgrade: h2c" dance is not supported).
+	// Only `ReadTimeout`, `WriteTimeout`, `IdleTimeout`, `MaxRequestBodySize`,
+	// `MaxRequestHeaderSize`, `StreamRequestBody`, `Name`, `NoDefaultServerHeader` and
+	// `ConnState` apply in this mode, and the limits described on `Router.HTTPHandler` (no
+	// WebSocket upgrades, no trailers) hold. The bridge costs throughput, so enable it only where HTTP/2 is needed.
+	// Default: false (fasthttp serves HTTP/1.x only, and TLS negotiates "http/1.1").
+	EnableHTTP2 bool
+
+	// RunMiddlewareOnNoRoute, if true, runs global middleware (registered with `Use`) for
+	// requests that match no route, wrapping `NotFoundHandler` and `MethodNotAllowedHandler`
+	// the same way a route handler is wrapped (AutoOPTIONS responses are always wrapped). This ensures
+	// that access logging, CORS, request ID and similar middleware also apply to 404 and
+	// 405 responses. Group and route middleware never run for such requests.
+	// Default: false (404/405 handlers are invoked directly, bypassing global middleware).
+	RunMiddlewareOnNoRoute bool
+
+	// NoRouteErrorBody, if set, builds the response body of the default `NotFoundHandler`
+	// and `MethodNotAllowedHandler`, so 404 and 405 responses share the envelope of the
+	// application's other errors (e.g., RFC 7807 Problem Details) without replacing the
+	// handlers. It receives the status (404 or 405) and the default message; for a 405,
+	// the "Allow" response header is already set. The result becomes the `Message` of the
+	// returned `*HTTPError`, so it is sent by the `GlobalErrorHandler` like any other error.
+	// Default: nil (`{"error": ..., "status": ..., "path": ...}`, plus "allowed_methods"
+	// for a 405).
+	NoRouteErrorBody func(c *Context, status int, message string) interface{}
+
+	// RouteTimeout, if positive, is the default timeout for requests to every route, applied
+	// like the `Timeout` middleware around the route's group and route middleware and its
+	// handler. It is overridden by a group's `RouteGroup.SetTimeout` and a route's
+	// `Route.Timeout`. Requests that match no route are not affected.
+	// Default: 0 (no router-wide route timeout).
+	RouteTimeout time.Duration
+
 	// Logger is the `xylium.Logger` instance to be used by the Xylium server and router
 	// for all logging purposes.
 	// If this field is `nil` when `xylium.NewWithConfig()` is called, a `DefaultLogger`
@@ -171,18 +285,40 @@ type ServerConfig struct {
 	ConnState func(conn net.Conn, state fasthttp.ConnState)
 
 	// ShutdownTimeout is Xylium's application-level timeout for the entire graceful
-	// shutdown process. This duration begins when a shutdown signal (SIGINT, SIGTERM)
+	// shutdown process. This duration begins when a shutdown signal (see `ShutdownSignals`)
 	// is received. It encompasses the time taken for the underlying `fasthttp.Server`
 	// to shut down (which respects `fasthttp.Server.IdleTimeout` and `CloseOnShutdown`)
 	// AND the time taken for Xylium to close all registered application resources
 	// (via `router.closeApplicationResources()`, which includes `io.Closer` instances
 	// from `AppSet` or `RegisterCloser`).
 	// If the entire shutdown process exceeds this `ShutdownTimeout`, the Xylium
-	// application will forcefully exit.
+	// application will forcefully exit. It starts after `PreShutdownDelay`.
 	// Default: 15 seconds (from `DefaultServerConfig()`).
 	ShutdownTimeout time.Duration
+
+	// ShutdownSignals lists the OS signals that start the graceful shutdown of the
+	// `ListenAndServe*Gracefully` methods (and `Start`). Set it to, e.g.,
+	// `[]os.Signal{syscall.SIGTERM}` to leave SIGINT to a container runtime or debugger,
+	// or add `syscall.SIGHUP` where a hang-up should stop the server. Signals not listed
+	// keep their default behavior (or that of other `signal.Notify` handlers, e.g., a
+	// SIGHUP handler calling `Router.ReloadCertificate`).
+	// Default: nil (`DefaultShutdownSignals`: SIGINT and SIGTERM).
+	ShutdownSignals []os.Signal
+
+	// PreShutdownDelay is how long the server keeps serving requests after a shutdown
+	// signal is received, before it starts draining connections. The router is marked
+	// not ready (see `Router.SetReady`) as soon as the signal arrives, so `ReadyHandler`
+	// answers 503 during the delay. This gives a load balancer time to notice the failing
+	// readiness probe and stop routing new requests before the server stops accepting them,
+	// for zero-downtime rolling deploys. A second signal during the delay skips the rest of it.
+	// Default: 0 (draining starts immediately).
+	PreShutdownDelay time.Duration
 }
 
+// DefaultShutdownSignals are the OS signals that start a graceful shutdown when
+// `ServerConfig.ShutdownSignals` is empty.
+var DefaultShutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+
 // DefaultServerConfig returns a `ServerConfig` struct populated with sensible default values.
 // These defaults are intended to provide a good starting point for most Xylium applications,
 // balancing performance, security, and resource management.
@@ -266,8 +402,9 @@ func (r *Router) buildFasthttpServer() *fasthttp.Server {
 		cfgLog := r.Logger().WithFields(M{"component": "xylium-server-builder", "operation": "buildFasthttpServer"})
 		cfgLog.Debugf("Building fasthttp.Server with Name: '%s'", r.serverConfig.Name)
 		cfgLog.Debugf("Timeouts (Read/Write/Idle): %v / %v / %v", r.serverConfig.ReadTimeout, r.serverConfig.WriteTimeout, r.serverConfig.IdleTimeout)
-		cfgLog.Debugf("Limits (MaxBodySize Bytes: %d, Concurrency: %d, MaxConnsPerIP: %d, MaxReqsPerConn: %d)",
-			r.serverConfig.MaxRequestBodySize, r.serverConfig.Concurrency, r.serverConfig.MaxConnsPerIP, r.serverConfig.MaxRequestsPerConn)
+		cfgLog.Debugf("Limits (MaxBodySize Bytes: %d, MaxHeaderSize Bytes: %d, Concurrency: %d, MaxConnsPerIP: %d, MaxReqsPerConn: %d)",
+			r.serverConfig.MaxRequestBodySize, r.serverConfig.MaxRequestHeaderSize, r.serverConfig.Concurrency,
+			r.serverConfig.MaxConnsPerIP, r.serverConfig.MaxRequestsPerConn)
 		cfgLog.Debugf("KeepAlive (DisableKeepalive: %t, TCPKeepalive: %t, TCPKeepalivePeriod: %v)",
 			r.serverConfig.DisableKeepalive, r.serverConfig.TCPKeepalive, r.serverConfig.TCPKeepalivePeriod)
 		cfgLog.Debugf("Fasthttp.CloseOnShutdown: %t, XyliumApp.ShutdownTimeout: %v", r.serverConfig.CloseOnShutdown, r.serverConfig.ShutdownTimeout)
@@ -288,6 +425,7 @@ func (r *Router) buildFasthttpServer() *fasthttp.Server {
 		WriteTimeout:                  r.serverConfig.WriteTimeout,
 		IdleTimeout:                   r.serverConfig.IdleTimeout,
 		MaxRequestBodySize:            r.serverConfig.MaxRequestBodySize,
+		ReadBufferSize:                r.serverConfig.MaxRequestHeaderSize, // Also caps the header size; fasthttp answers 431 beyond it.
 		ReduceMemoryUsage:             r.serverConfig.ReduceMemoryUsage,
 		Concurrency:                   r.serverConfig.Concurrency,
 		DisableKeepalive:              r.serverConfig.DisableKeepalive,
@@ -305,12 +443,118 @@ func (r *Router) buildFasthttpServer() *fasthttp.Server {
 		StreamRequestBody:             r.serverConfig.StreamRequestBody,
 		Logger:                        fasthttpCompatibleLogger, // Use the adapted Xylium logger.
 		ConnState:                     r.serverConfig.ConnState,
-		// Other fasthttp.Server fields like TLSConfig, MaxHeaderBytes, etc.,
+		// Other fasthttp.Server fields like WriteBufferSize, etc.,
 		// are not directly exposed via Xylium's ServerConfig but could be added if needed.
 		// For TLS, specific ListenAndServeTLS* methods handle TLS configuration.
 	}
 }
 
+// buildHTTP2Server constructs the `net/http` server used when `ServerConfig.EnableHTTP2`
+// is set. It serves HTTP/1.1 and HTTP/2, including h2c on plain listeners.
+// `tlsConfig` (see `serverTLSConfig`) is nil for plain listeners.
+func (r *Router) buildHTTP2Server(addr string, tlsConfig *tls.Config) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true) // h2c with prior knowledge.
+
+	server := &http.Server{
+		Addr:           addr,
+		Handler:        r.HTTPHandler(),
+		ReadTimeout:    r.serverConfig.ReadTimeout,
+		WriteTimeout:   r.serverConfig.WriteTimeout,
+		IdleTimeout:    r.serverConfig.IdleTimeout,
+		MaxHeaderBytes: r.serverConfig.MaxRequestHeaderSize, // net/http answers 431 beyond it.
+		Protocols:      protocols,
+		ErrorLog:       log.New(&loggerAdapterWriter{adapter: &loggerAdapter{internalLogger: r.serverConfig.Logger}}, "", 0),
+	}
+	if connState := r.serverConfig.ConnState; connState != nil {
+		// net/http and fasthttp define the connection states in the same order.
+		server.ConnState = func(conn net.Conn, state http.ConnState) { connState(conn, fasthttp.ConnState(state)) }
+	}
+	server.TLSConfig = tlsConfig
+	return server
+}
+
+// serveHTTP2 runs `server` (see `buildHTTP2Server`) until it is shut down, with TLS if
+// `server.TLSConfig` is set.
+func serveHTTP2(server *http.Server) error {
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "") // The certificate comes from TLSConfig.
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil // Shut down as requested, like fasthttp's ListenAndServe.
+	}
+	return err
+}
+
+// listenAndServeHTTP2 implements the `ListenAndServe*` methods when
+// `ServerConfig.EnableHTTP2` is set. TLS is used if `tlsConfig` is non-nil.
+func (r *Router) listenAndServeHTTP2(addr string, graceful bool, tlsConfig *tls.Config) error {
+	currentLogger := r.Logger()
+	server := r.buildHTTP2Server(addr, tlsConfig)
+
+	scheme := "HTTP"
+	if tlsConfig != nil {
+		scheme = "HTTPS"
+	}
+	if !graceful {
+		currentLogger.Infof("Xylium %s server (HTTP/2 enabled) listening on %s (Mode: %s, Graceful Shutdown: No)", scheme, addr, r.CurrentMode())
+		err := serveHTTP2(server)
+		r.closeApplicationResources()
+		return err
+	}
+	startFn := func() error {
+		currentLogger.Infof("Xylium %s server (HTTP/2 enabled) listening gracefully on %s (Mode: %s)", scheme, addr, r.CurrentMode())
+		return serveHTTP2(server)
+	}
+	return r.commonGracefulShutdownLogic(http2Shutdowner{server: server}, startFn)
+}
+
+// loadServerTLSConfig returns the TLS configuration for a `ListenAndServeTLS*` method
+// (see `serverTLSConfig`). On failure, it logs the error and closes the application
+// resources, as a failed server start does.
+func (r *Router) loadServerTLSConfig(certFile, keyFile string, certData, keyData []byte) (*tls.Config, error) {
+	tlsConfig, err := r.serverTLSConfig(certFile, keyFile, certData, keyData)
+	if err != nil {
+		r.Logger().Errorf("Xylium HTTPS server cannot start: %v", err)
+		r.closeApplicationResources()
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
+// loggerAdapterWriter lets the `net/http` server log through a `loggerAdapter`.
+type loggerAdapterWriter struct {
+	adapter *loggerAdapter
+}
+
+// Write implements `io.Writer`, logging `p` as one message.
+func (w *loggerAdapterWriter) Write(p []byte) (int, error) {
+	w.adapter.Printf("%s", strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// serverShutdowner is the part of a server that the graceful shutdown sequence needs.
+// `*fasthttp.Server` implements it; `http2Shutdowner` adapts the `net/http` server.
+type serverShutdowner interface {
+	Shutdown() error
+}
+
+// http2Shutdowner adapts the `net/http` server used with `ServerConfig.EnableHTTP2`
+// to `serverShutdowner`. The overall deadline is enforced by `gracefulShutdown`.
+type http2Shutdowner struct {
+	server *http.Server
+}
+
+// Shutdown implements `serverShutdowner`.
+func (s http2Shutdowner) Shutdown() error {
+	return s.server.Shutdown(context.Background())
+}
+
 // ListenAndServe starts an HTTP server on the given network address `addr`.
 // This method is a blocking call. It does *not* implement Xylium's graceful shutdown
 // mechanism (handling OS signals for termination). For production environments,
@@ -320,13 +564,17 @@ func (r *Router) buildFasthttpServer() *fasthttp.Server {
 // will return an error. In such cases, it also attempts to close any application
 // resources that were registered with the Xylium router (via `AppSet` or `RegisterCloser`).
 //
-// In `DebugMode`, registered routes are printed to the logger before starting.
+// In `DebugMode`, registered routes and their middleware chains are printed to the logger before starting.
 func (r *Router) ListenAndServe(addr string) error {
 	currentLogger := r.Logger()
 	// Print routes if in DebugMode and the route tree exists.
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServe on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, false, nil)
 	}
 
 	server := r.buildFasthttpServer() // Construct the fasthttp server.
@@ -344,21 +592,32 @@ func (r *Router) ListenAndServe(addr string) error {
 
 // ListenAndServeTLS starts an HTTPS server on the given network address `addr`,
 // using the certificate from `certFile` and the private key from `keyFile`.
+// The certificate can be replaced while serving with `ReloadCertificate`, and further
+// TLS settings are taken from `ServerConfig.TLSConfig`.
 // This method is a blocking call and does *not* implement Xylium's graceful shutdown.
 // For production HTTPS servers, `ListenAndServeTLSGracefully` is recommended.
 //
 // If the server fails to start, it returns an error and attempts to close registered
 // application resources.
-// In `DebugMode`, registered routes are printed before starting.
+// In `DebugMode`, registered routes and their middleware chains are printed before starting.
 func (r *Router) ListenAndServeTLS(addr, certFile, keyFile string) error {
 	currentLogger := r.Logger()
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServeTLS on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	tlsConfig, err := r.loadServerTLSConfig(certFile, keyFile, nil, nil)
+	if err != nil {
+		return err
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, false, tlsConfig)
 	}
 	server := r.buildFasthttpServer()
+	server.TLSConfig = tlsConfig
 	currentLogger.Infof("Xylium HTTPS server listening on %s (Mode: %s, Graceful Shutdown: No, CertFile: %s, KeyFile: %s)", addr, r.CurrentMode(), certFile, keyFile)
-	err := server.ListenAndServeTLS(addr, certFile, keyFile)
+	err = server.ListenAndServeTLS(addr, "", "") // The certificate comes from server.TLSConfig.
 	r.closeApplicationResources()
 	return err
 }
@@ -374,16 +633,25 @@ func (r *Router) ListenAndServeTLS(addr, certFile, keyFile string) error {
 //
 // If the server fails to start, it returns an error and attempts to close registered
 // application resources.
-// In `DebugMode`, registered routes are printed before starting.
+// In `DebugMode`, registered routes and their middleware chains are printed before starting.
 func (r *Router) ListenAndServeTLSEmbed(addr string, certData, keyData []byte) error {
 	currentLogger := r.Logger()
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServeTLSEmbed on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	tlsConfig, err := r.loadServerTLSConfig("", "", certData, keyData)
+	if err != nil {
+		return err
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, false, tlsConfig)
 	}
 	server := r.buildFasthttpServer()
+	server.TLSConfig = tlsConfig
 	currentLogger.Infof("Xylium HTTPS server (with embedded certs) listening on %s (Mode: %s, Graceful Shutdown: No)", addr, r.CurrentMode())
-	err := server.ListenAndServeTLSEmbed(addr, certData, keyData)
+	err = server.ListenAndServeTLS(addr, "", "") // The certificate comes from server.TLSConfig.
 	r.closeApplicationResources()
 	return err
 }
@@ -450,16 +718,16 @@ func (r *Router) closeApplicationResources() {
 
 // commonGracefulShutdownLogic encapsulates the shared operational logic for initiating
 // and managing a graceful shutdown of the `fasthttp.Server` and Xylium application resources.
-// It listens for OS interrupt signals (SIGINT, SIGTERM), triggers the server shutdown,
-// waits for it to complete (or times out according to `r.serverConfig.ShutdownTimeout`),
-// and then closes all registered Xylium application resources.
+// It listens for the OS signals in `ServerConfig.ShutdownSignals` (SIGINT and SIGTERM by
+// default) and then runs `gracefulShutdown`.
 //
 // This function is used by all `ListenAndServe*Gracefully` methods.
 //
 // Parameters:
-//   - `server` (*fasthttp.Server): The configured `fasthttp.Server` instance to manage.
+//   - `server` (serverShutdowner): The configured `fasthttp.Server` instance to manage
+//     (or the `net/http` server used with `ServerConfig.EnableHTTP2`).
 //   - `startServerFunc` (func() error): A function that, when called, starts the
-//     `fasthttp.Server`'s listening loop (e.g., `server.ListenAndServe(addr)`).
+//     server's listening loop (e.g., `server.ListenAndServe(addr)`).
 //     This function should be blocking and return an error if server startup or
 //     operation fails (other than errors related to normal shutdown).
 //
@@ -468,7 +736,7 @@ func (r *Router) closeApplicationResources() {
 //     error occurred during the shutdown process itself.
 //   - `nil`: If the shutdown sequence was initiated successfully (either completed
 //     gracefully or timed out as per configuration). The server will no longer be listening.
-func (r *Router) commonGracefulShutdownLogic(server *fasthttp.Server, startServerFunc func() error) error {
+func (r *Router) commonGracefulShutdownLogic(server serverShutdowner, startServerFunc func() error) error {
 	currentLogger := r.Logger()
 	// Note: Route printing and "listening gracefully on ADDR (Mode: X)" messages
 	// are handled by the specific ListenAndServe*Gracefully methods before calling this.
@@ -491,11 +759,32 @@ func (r *Router) commonGracefulShutdownLogic(server *fasthttp.Server, startServe
 		}
 	}()
 
-	// Channel to listen for OS shutdown signals (SIGINT for Ctrl+C, SIGTERM for termination).
+	// Channel to listen for OS shutdown signals (by default, SIGINT for Ctrl+C and SIGTERM
+	// for termination; see ServerConfig.ShutdownSignals).
+	shutdownSignals := r.serverConfig.ShutdownSignals
+	if len(shutdownSignals) == 0 {
+		shutdownSignals = DefaultShutdownSignals // Never call signal.Notify without signals: it would relay all of them.
+	}
 	shutdownChan := make(chan os.Signal, 1)
-	signal.Notify(shutdownChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(shutdownChan, shutdownSignals...)
+	defer signal.Stop(shutdownChan) // Restore the default behavior of the signals once the server is down.
 
-	// Main select loop: waits for either a server error or a shutdown signal.
+	// Channel for programmatic shutdown requests (see Router.Shutdown).
+	shutdownRequests := make(chan shutdownRequest)
+	stopped := make(chan struct{})
+	r.shutdownMux.Lock()
+	r.shutdownRequests, r.shutdownStopped = shutdownRequests, stopped
+	r.shutdownMux.Unlock()
+	defer func() {
+		r.shutdownMux.Lock()
+		if r.shutdownRequests == shutdownRequests {
+			r.shutdownRequests, r.shutdownStopped = nil, nil
+		}
+		r.shutdownMux.Unlock()
+		close(stopped) // Unblocks Shutdown calls that raced with the end of the server.
+	}()
+
+	// Main select loop: waits for a server error, a shutdown signal or a Shutdown call.
 	select {
 	case err, ok := <-serverErrors:
 		// The server's listening loop (startServerFunc) exited.
@@ -518,57 +807,156 @@ func (r *Router) commonGracefulShutdownLogic(server *fasthttp.Server, startServe
 	case sig := <-shutdownChan:
 		// An OS shutdown signal was received.
 		currentLogger.Infof("Shutdown signal '%s' received. Initiating graceful shutdown of Xylium application...", sig.String())
+		r.gracefulShutdown(context.Background(), server, shutdownChan)
+		return nil // Indicates a shutdown (graceful or timed out) was successfully initiated and processed.
 
-		// Determine the application-level shutdown timeout from ServerConfig.
-		shutdownTimeout := r.serverConfig.ShutdownTimeout
-		if shutdownTimeout <= 0 {
-			// Ensure a positive timeout. Fallback to a sensible default if misconfigured.
-			shutdownTimeout = 15 * time.Second
-			currentLogger.Warnf("ServerConfig.ShutdownTimeout is not configured or is invalid (<=0). Using default: %s for overall application shutdown.", shutdownTimeout.String())
-		}
-		currentLogger.Debugf("Application graceful shutdown timeout is %s.", shutdownTimeout.String())
-
-		// Perform fasthttp server shutdown. This call is blocking, so run it in a goroutine
-		// to allow Xylium's application-level `shutdownTimeout` to manage the overall process.
-		shutdownComplete := make(chan struct{})
-		go func() {
-			defer close(shutdownComplete) // Signal that fasthttp.Shutdown attempt has finished.
-			currentLogger.Debugf("Attempting to gracefully shut down the underlying fasthttp server...")
-			if err := server.Shutdown(); err != nil {
-				// `fasthttp.Server.Shutdown()` can return errors (e.g., if called multiple times,
-				// or if context used for shutdown is canceled, though Xylium doesn't pass a context here).
-				// `fasthttp.ErrServerClosed` is not an error in this context for `ListenAndServe` which returns nil on successful shutdown.
-				// Xylium's logger (via loggerAdapter) inside fasthttp should log more details if fasthttp logs anything.
-				currentLogger.Errorf("Error reported by fasthttp server.Shutdown() call: %v. This may or may not be critical depending on the error.", err)
-			}
-		}()
+	case req := <-shutdownRequests:
+		// Router.Shutdown was called.
+		currentLogger.Info("Shutdown requested programmatically. Initiating graceful shutdown of Xylium application...")
+		req.done <- r.gracefulShutdown(req.ctx, server, shutdownChan)
+		return nil
+	}
+}
 
-		// Wait for fasthttp.Server.Shutdown() to complete or for Xylium's app-level timeout.
+// shutdownRequest is a call of `Router.Shutdown`, handled by `commonGracefulShutdownLogic`.
+type shutdownRequest struct {
+	ctx  context.Context // Bounds the shutdown, in addition to ServerConfig.ShutdownTimeout.
+	done chan error      // Receives the result of gracefulShutdown (buffered).
+}
+
+// Shutdown gracefully stops the server started by `ListenAndServeGracefully`, `Start` or
+// another `ListenAndServe*Gracefully` method, as a shutdown signal would, for
+// applications whose lifecycle is managed in code (e.g., by a supervisor or in tests)
+// rather than by OS signals. The blocked `ListenAndServe*Gracefully` call then returns nil.
+//
+// The usual sequence runs: the router is marked not ready, `PreShutdownDelay` is waited,
+// connections are drained within `ShutdownTimeout`, and registered resources are closed.
+// Shutdown returns when it has completed, or with `ctx.Err()` as soon as `ctx` is done,
+// e.g., at its deadline; the sequence then ends the delay and stops waiting for the
+// server, and resources are still closed in the background.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := app.Shutdown(ctx); err != nil {
+//		log.Printf("shutdown did not complete: %v", err)
+//	}
+//
+// Returns an error if no server started by a graceful method is running. If the server
+// stops on its own while Shutdown is waiting to reach it, Shutdown returns nil.
+func (r *Router) Shutdown(ctx context.Context) error {
+	r.shutdownMux.Lock()
+	requests, stopped := r.shutdownRequests, r.shutdownStopped
+	r.shutdownMux.Unlock()
+	if requests == nil {
+		return errors.New("xylium: Shutdown called, but no server started with a graceful ListenAndServe method is running")
+	}
+
+	req := shutdownRequest{ctx: ctx, done: make(chan error, 1)}
+	select {
+	case requests <- req:
+	case <-stopped:
+		return nil // The server stopped in the meantime (e.g., on a signal).
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// gracefulShutdown runs the shutdown sequence once a shutdown signal has been received
+// (or `Shutdown` was called):
+//  1. The router is marked not ready, so `ReadyHandler` answers 503.
+//  2. Requests are still served for `ServerConfig.PreShutdownDelay`, unless another signal
+//     arrives on `interrupt` (which may be nil) or `ctx` is done.
+//  3. The `fasthttp.Server` is shut down, waiting at most `ServerConfig.ShutdownTimeout`
+//     or until `ctx` is done.
+//  4. All registered Xylium application resources are closed.
+//
+// It returns `ctx.Err()` if `ctx` was done before the sequence completed; resource
+// closure then continues in the background.
+func (r *Router) gracefulShutdown(ctx context.Context, server serverShutdowner, interrupt <-chan os.Signal) error {
+	currentLogger := r.Logger()
+	r.SetReady(false)
+
+	if delay := r.serverConfig.PreShutdownDelay; delay > 0 {
+		currentLogger.Infof("Router marked not ready. Waiting %s (PreShutdownDelay) before draining connections...", delay.String())
 		select {
-		case <-shutdownComplete:
-			currentLogger.Info("Underlying fasthttp server has been instructed to stop and has completed its shutdown routine.")
-		case <-time.After(shutdownTimeout):
-			// This timeout is for the entire shutdown process, including fasthttp's part.
-			// If fasthttp.Shutdown() itself takes longer than this, this case will be hit.
-			currentLogger.Warnf("Graceful shutdown of fasthttp server timed out after %s (application-level timeout). The server might not have fully released all its internal resources or connections.", shutdownTimeout.String())
+		case <-time.After(delay):
+		case sig := <-interrupt:
+			currentLogger.Warnf("Second shutdown signal '%s' received. Skipping the rest of PreShutdownDelay.", sig.String())
+		case <-ctx.Done():
+			currentLogger.Warnf("Shutdown context done (%v). Skipping the rest of PreShutdownDelay.", ctx.Err())
+		}
+	}
+
+	// Determine the application-level shutdown timeout from ServerConfig.
+	shutdownTimeout := r.serverConfig.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		// Ensure a positive timeout. Fallback to a sensible default if misconfigured.
+		shutdownTimeout = 15 * time.Second
+		currentLogger.Warnf("ServerConfig.ShutdownTimeout is not configured or is invalid (<=0). Using default: %s for overall application shutdown.", shutdownTimeout.String())
+	}
+	currentLogger.Debugf("Application graceful shutdown timeout is %s.", shutdownTimeout.String())
+
+	// Perform fasthttp server shutdown. This call is blocking, so run it in a goroutine
+	// to allow Xylium's application-level `shutdownTimeout` to manage the overall process.
+	shutdownComplete := make(chan struct{})
+	go func() {
+		defer close(shutdownComplete) // Signal that fasthttp.Shutdown attempt has finished.
+		currentLogger.Debugf("Attempting to gracefully shut down the underlying fasthttp server...")
+		if err := server.Shutdown(); err != nil {
+			// `fasthttp.Server.Shutdown()` can return errors (e.g., if called multiple times,
+			// or if context used for shutdown is canceled, though Xylium doesn't pass a context here).
+			// `fasthttp.ErrServerClosed` is not an error in this context for `ListenAndServe` which returns nil on successful shutdown.
+			// Xylium's logger (via loggerAdapter) inside fasthttp should log more details if fasthttp logs anything.
+			currentLogger.Errorf("Error reported by fasthttp server.Shutdown() call: %v. This may or may not be critical depending on the error.", err)
 		}
+	}()
+
+	// Wait for fasthttp.Server.Shutdown() to complete or for Xylium's app-level timeout.
+	select {
+	case <-shutdownComplete:
+		currentLogger.Info("Underlying fasthttp server has been instructed to stop and has completed its shutdown routine.")
+	case <-time.After(shutdownTimeout):
+		// This timeout is for the entire shutdown process, including fasthttp's part.
+		// If fasthttp.Shutdown() itself takes longer than this, this case will be hit.
+		currentLogger.Warnf("Graceful shutdown of fasthttp server timed out after %s (application-level timeout). The server might not have fully released all its internal resources or connections.", shutdownTimeout.String())
+	case <-ctx.Done():
+		currentLogger.Warnf("Shutdown context done (%v) before the fasthttp server finished shutting down. The server might not have fully released all its internal resources or connections.", ctx.Err())
+	}
 
-		// After fasthttp server shutdown (or timeout), close Xylium's application resources.
+	// After fasthttp server shutdown (or timeout), close Xylium's application resources.
+	resourcesClosed := make(chan struct{})
+	go func() {
+		defer close(resourcesClosed)
 		r.closeApplicationResources()
-		currentLogger.Info("Xylium application graceful shutdown process is complete.")
-		return nil // Indicates a shutdown (graceful or timed out) was successfully initiated and processed.
+	}()
+	select {
+	case <-resourcesClosed:
+	case <-ctx.Done():
+		currentLogger.Warnf("Shutdown context done (%v) while closing application resources. Closing continues in the background.", ctx.Err())
+		return ctx.Err()
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	currentLogger.Info("Xylium application graceful shutdown process is complete.")
+	return nil
 }
 
 // ListenAndServeGracefully starts an HTTP server on the given network address `addr`
-// with integrated graceful shutdown capabilities. It monitors OS signals (SIGINT, SIGTERM)
+// with integrated graceful shutdown capabilities. It monitors OS signals (`ServerConfig.ShutdownSignals`)
 // to initiate a controlled shutdown, allowing active requests to complete and ensuring
 // registered Xylium application resources (see `AppSet`, `RegisterCloser`) are properly closed.
 //
 // This is the recommended method for starting a Xylium HTTP server in production environments
 // to ensure data integrity and prevent abrupt disconnections.
 //
-// In `DebugMode`, registered routes are printed to the logger before the server starts.
+// In `DebugMode`, registered routes and their middleware chains are printed to the logger before the server starts.
 // The overall shutdown process is governed by `ServerConfig.ShutdownTimeout`.
 func (r *Router) ListenAndServeGracefully(addr string) error {
 	currentLogger := r.Logger()
@@ -576,6 +964,10 @@ func (r *Router) ListenAndServeGracefully(addr string) error {
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServeGracefully on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, true, nil)
 	}
 	server := r.buildFasthttpServer() // Construct the fasthttp.Server instance.
 
@@ -593,19 +985,29 @@ func (r *Router) ListenAndServeGracefully(addr string) error {
 // graceful shutdown capabilities. It handles OS signals for termination and resource cleanup.
 //
 // This is the recommended method for starting a Xylium HTTPS server with file-based
-// certificates in production.
+// certificates in production. Renewed certificates (e.g., from Let's Encrypt) can be
+// picked up without a restart with `ReloadCertificate`; see also `ServerConfig.TLSConfig`.
 // The overall shutdown process is governed by `ServerConfig.ShutdownTimeout`.
 func (r *Router) ListenAndServeTLSGracefully(addr, certFile, keyFile string) error {
 	currentLogger := r.Logger()
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServeTLSGracefully on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	tlsConfig, err := r.loadServerTLSConfig(certFile, keyFile, nil, nil)
+	if err != nil {
+		return err
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, true, tlsConfig)
 	}
 	server := r.buildFasthttpServer()
+	server.TLSConfig = tlsConfig
 
 	startFn := func() error {
 		currentLogger.Infof("Xylium HTTPS server listening gracefully on %s (Mode: %s, CertFile: %s, KeyFile: %s)", addr, r.CurrentMode(), certFile, keyFile)
-		return server.ListenAndServeTLS(addr, certFile, keyFile)
+		return server.ListenAndServeTLS(addr, "", "") // The certificate comes from server.TLSConfig.
 	}
 	return r.commonGracefulShutdownLogic(server, startFn)
 }
@@ -622,19 +1024,28 @@ func (r *Router) ListenAndServeTLSEmbedGracefully(addr string, certData, keyData
 	if r.CurrentMode() == DebugMode && r.tree != nil {
 		currentLogger.Debugf("Printing registered routes for ListenAndServeTLSEmbedGracefully on %s:", addr)
 		r.tree.PrintRoutes(currentLogger)
+		r.logMiddlewareChains(currentLogger)
+	}
+	tlsConfig, err := r.loadServerTLSConfig("", "", certData, keyData)
+	if err != nil {
+		return err
+	}
+	if r.serverConfig.EnableHTTP2 {
+		return r.listenAndServeHTTP2(addr, true, tlsConfig)
 	}
 	server := r.buildFasthttpServer()
+	server.TLSConfig = tlsConfig
 
 	startFn := func() error {
 		currentLogger.Infof("Xylium HTTPS server (with embedded certs) listening gracefully on %s (Mode: %s)", addr, r.CurrentMode())
-		return server.ListenAndServeTLSEmbed(addr, certData, keyData)
+		return server.ListenAndServeTLS(addr, "", "") // The certificate comes from server.TLSConfig.
 	}
 	return r.commonGracefulShutdownLogic(server, startFn)
 }
 
 // Start is a convenience alias for `ListenAndServeGracefully(addr)`.
 // It starts an HTTP server on the given network address `addr` and includes
-// Xylium's full graceful shutdown mechanism, handling OS signals (SIGINT, SIGTERM)
+// Xylium's full graceful shutdown mechanism, handling OS signals (`ServerConfig.ShutdownSignals`)
 // to allow active requests to complete and to close registered application resources.
 //
 // This is the most commonly recommended method for starting a Xylium server.
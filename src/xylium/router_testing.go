@@ -0,0 +1,213 @@
+// src/xylium/router_testing.go
+package xylium
+
+import (
+	"encoding/json" // For JSON request bodies and DecodeJSON.
+	"fmt"           // For wrapping request errors.
+	"io"            // For reading the request body.
+	"net"           // For the in-memory dialer.
+	"net/http"      // For http.Header in TestResponse.
+	"strings"       // For validating the request path.
+
+	"github.com/valyala/fasthttp"              // For the client and server used by TestRequest.
+	"github.com/valyala/fasthttp/fasthttputil" // For the in-memory listener.
+)
+
+// testRequestHost is the Host header of requests made with `Router.TestRequest`.
+const testRequestHost = "xylium.test"
+
+// TestResponse is the response to a request made with `Router.TestRequest`.
+type TestResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (tr *TestResponse) StatusCode() int { return tr.statusCode }
+
+// Header returns the response headers, with canonical header keys.
+func (tr *TestResponse) Header() http.Header { return tr.header }
+
+// Body returns the response body.
+func (tr *TestResponse) Body() []byte { return tr.body }
+
+// String returns the response body as a string.
+func (tr *TestResponse) String() string { return string(tr.body) }
+
+// DecodeJSON unmarshals the JSON response body into `out`.
+func (tr *TestResponse) DecodeJSON(out interface{}) error {
+	if err := json.Unmarshal(tr.body, out); err != nil {
+		return fmt.Errorf("xylium: decoding test response body as JSON: %w", err)
+	}
+	return nil
+}
+
+// TestRequestBuilder builds a request for `Router.TestRequest`-style integration tests
+// with headers, cookies, and a body. Create one with `NewTestRequest`, chain the setters,
+// and send it with `Do`. A builder can be sent more than once.
+//
+// Example:
+//
+//	resp, err := xylium.NewTestRequest().
+//		Method(xylium.MethodPost).
+//		Path("/tasks").
+//		JSON(xylium.M{"title": "Write docs"}).
+//		Header("X-API-Key", "k").
+//		Cookie("session", "v").
+//		Do(app)
+type TestRequestBuilder struct {
+	method      string
+	path        string
+	headers     [][2]string
+	cookies     [][2]string
+	body        []byte
+	contentType string
+	err         error // First error from a setter, returned by Do.
+}
+
+// NewTestRequest returns a builder for a GET request to "/".
+func NewTestRequest() *TestRequestBuilder {
+	return &TestRequestBuilder{method: MethodGet, path: "/"}
+}
+
+// Method sets the HTTP method.
+func (b *TestRequestBuilder) Method(method string) *TestRequestBuilder {
+	b.method = method
+	return b
+}
+
+// Path sets the request path, which may include a query string.
+func (b *TestRequestBuilder) Path(path string) *TestRequestBuilder {
+	b.path = path
+	return b
+}
+
+// Header adds a request header. Calling it again with the same key adds another value.
+func (b *TestRequestBuilder) Header(key, value string) *TestRequestBuilder {
+	b.headers = append(b.headers, [2]string{key, value})
+	return b
+}
+
+// Cookie adds a request cookie.
+func (b *TestRequestBuilder) Cookie(name, value string) *TestRequestBuilder {
+	b.cookies = append(b.cookies, [2]string{name, value})
+	return b
+}
+
+// Body sets the raw request body and its Content-Type (omitted if empty).
+func (b *TestRequestBuilder) Body(contentType string, body []byte) *TestRequestBuilder {
+	b.contentType = contentType
+	b.body = body
+	return b
+}
+
+// JSON marshals `v` as the request body and sets the Content-Type to
+// "application/json; charset=utf-8". A marshalling error is returned by `Do`.
+func (b *TestRequestBuilder) JSON(v interface{}) *TestRequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("xylium: marshalling test request body as JSON: %w", err)
+		}
+		return b
+	}
+	return b.Body("application/json; charset=utf-8", body)
+}
+
+// Do sends the request to `router` through an in-memory server, as `Router.TestRequest`
+// does, and returns the response.
+func (b *TestRequestBuilder) Do(router *Router) (*TestResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.SetMethod(b.method)
+	for _, header := range b.headers {
+		req.Header.Add(header[0], header[1])
+	}
+	for _, cookie := range b.cookies {
+		req.Header.SetCookie(cookie[0], cookie[1])
+	}
+	if b.contentType != "" {
+		req.Header.SetContentType(b.contentType)
+	}
+	if b.body != nil {
+		req.SetBody(b.body)
+	}
+	return router.doTestRequest(req, b.path)
+}
+
+// TestRequest sends a request with `method`, `path` (which may include a query string)
+// and an optional `body` to the router, and returns the response. It is meant for
+// integration tests of handlers and middleware without binding a network port.
+//
+// The request goes through a real `fasthttp.Server` built from the router's
+// `ServerConfig`, served over an in-memory listener, so the full request lifecycle runs:
+// pre-routing hooks, global, group and route middleware, error and panic handlers, and
+// server limits such as `MaxRequestBodySize`. The server is started for this request
+// only and shut down before TestRequest returns. Streamed responses are read to the end.
+//
+// An error is returned only if the request could not be sent or the response could
+// not be read; error responses from the router (e.g., 404, 500) are returned as a
+// `*TestResponse` with the corresponding status code.
+//
+// Example:
+//
+//	resp, err := app.TestRequest(xylium.MethodGet, "/users/42?fields=name", nil)
+//	if err != nil || resp.StatusCode() != xylium.StatusOK {
+//		t.Fatalf("unexpected response: %v, %v", resp, err)
+//	}
+func (r *Router) TestRequest(method, path string, body io.Reader) (*TestResponse, error) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.SetMethod(method)
+	if body != nil {
+		bodyBytes, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("xylium: reading test request body: %w", err)
+		}
+		req.SetBody(bodyBytes)
+	}
+	return r.doTestRequest(req, path)
+}
+
+// doTestRequest sends `req` for `path` through an in-memory server running the router.
+// `req` must not have its URI set; its method, headers and body are sent as they are.
+func (r *Router) doTestRequest(req *fasthttp.Request, path string) (*TestResponse, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("xylium: test request path must start with '/', got '%s'", path)
+	}
+	req.SetRequestURI("http://" + testRequestHost + path)
+	req.SetConnectionClose() // The server closes the connection, so it can shut down cleanly.
+
+	ln := fasthttputil.NewInmemoryListener()
+	server := r.buildFasthttpServer()
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(ln) }()
+
+	client := &fasthttp.HostClient{
+		Addr: testRequestHost,
+		Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+	}
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	doErr := client.Do(req, resp)
+
+	_ = ln.Close()
+	<-serveErr // Serve returns once the listener is closed.
+	if doErr != nil {
+		return nil, fmt.Errorf("xylium: test request %s %s failed: %w", req.Header.Method(), path, doErr)
+	}
+
+	testResp := &TestResponse{
+		statusCode: resp.StatusCode(),
+		header:     make(http.Header),
+		body:       append([]byte(nil), resp.Body()...),
+	}
+	resp.Header.VisitAll(func(key, value []byte) {
+		testResp.header.Add(string(key), string(value))
+	})
+	return testResp, nil
+}
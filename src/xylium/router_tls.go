@@ -0,0 +1,101 @@
+// src/xylium/router_tls.go
+package xylium
+
+import (
+	"crypto/tls" // For certificates and the TLS configuration of the server.
+	"errors"     // For the error on missing certificates.
+	"fmt"        // For wrapping certificate loading errors.
+)
+
+// ReloadCertificate loads the certificate from `certFile` and the private key from
+// `keyFile` and makes the running HTTPS server use them for new TLS handshakes, e.g.,
+// after a Let's Encrypt renewal, without a restart. Established connections keep the
+// certificate they were opened with, so no connection is dropped.
+//
+// It applies to servers started with any `ListenAndServeTLS*` method (with or without
+// graceful shutdown, and with `ServerConfig.EnableHTTP2`). If loading fails, an error is
+// returned and the current certificate stays in use. It is safe to call concurrently
+// with request handling, e.g., from a SIGHUP handler or a periodic file check:
+//
+//	go func() {
+//		for range hup { // signal.Notify(hup, syscall.SIGHUP)
+//			if err := app.ReloadCertificate(certFile, keyFile); err != nil {
+//				app.Logger().Errorf("Certificate reload failed: %v", err)
+//			}
+//		}
+//	}()
+//
+// A `GetCertificate` callback in `ServerConfig.TLSConfig` takes precedence; the reloaded
+// certificate is used when it returns neither a certificate nor an error.
+func (r *Router) ReloadCertificate(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("xylium: cannot load TLS key pair from certFile=%q and keyFile=%q: %w", certFile, keyFile, err)
+	}
+	r.tlsCertificate.Store(&cert)
+	r.Logger().Infof("TLS certificate reloaded from %s.", certFile)
+	return nil
+}
+
+// serverTLSConfig returns the TLS configuration for the `ListenAndServeTLS*` methods:
+// a clone of `ServerConfig.TLSConfig` (or a new one) whose `GetCertificate` serves the
+// certificate held by the router, so `ReloadCertificate` can replace it. The initial
+// certificate is loaded from the files, or from `certData`/`keyData` if given. No
+// certificate is required if `ServerConfig.TLSConfig` provides its own.
+func (r *Router) serverTLSConfig(certFile, keyFile string, certData, keyData []byte) (*tls.Config, error) {
+	var config *tls.Config
+	if r.serverConfig.TLSConfig != nil {
+		config = r.serverConfig.TLSConfig.Clone()
+	} else {
+		config = &tls.Config{}
+	}
+
+	switch {
+	case len(certData) > 0 || len(keyData) > 0:
+		cert, err := tls.X509KeyPair(certData, keyData)
+		if err != nil {
+			return nil, fmt.Errorf("xylium: cannot load embedded TLS key pair: %w", err)
+		}
+		r.tlsCertificate.Store(&cert)
+	case certFile != "" || keyFile != "":
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("xylium: cannot load TLS key pair from certFile=%q and keyFile=%q: %w", certFile, keyFile, err)
+		}
+		r.tlsCertificate.Store(&cert)
+	case len(config.Certificates) == 0 && config.GetCertificate == nil && r.tlsCertificate.Load() == nil:
+		return nil, errors.New("xylium: no TLS certificate provided (set the certificate files, or Certificates or GetCertificate in ServerConfig.TLSConfig)")
+	}
+
+	userGetCertificate := config.GetCertificate
+	staticCertificates := config.Certificates
+	config.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if userGetCertificate != nil {
+			if cert, err := userGetCertificate(hello); cert != nil || err != nil {
+				return cert, err
+			}
+		}
+		// Like crypto/tls, prefer a certificate matching the client (e.g., by SNI), else
+		// fall back to the first one.
+		routerCert := r.tlsCertificate.Load()
+		if routerCert != nil && hello.SupportsCertificate(routerCert) == nil {
+			return routerCert, nil
+		}
+		for i := range staticCertificates {
+			if hello.SupportsCertificate(&staticCertificates[i]) == nil {
+				return &staticCertificates[i], nil
+			}
+		}
+		if routerCert != nil {
+			return routerCert, nil
+		}
+		if len(staticCertificates) > 0 {
+			return &staticCertificates[0], nil
+		}
+		return nil, errors.New("xylium: no TLS certificate available")
+	}
+	// All certificates are served through GetCertificate, so it is also used for clients
+	// without SNI (crypto/tls would otherwise pick Certificates[0] for them).
+	config.Certificates = nil
+	return config, nil
+}
@@ -0,0 +1,221 @@
+// src/xylium/template_renderer.go
+package xylium
+
+import (
+	"bytes"         // For buffering rendered output, so failed renders write nothing.
+	"fmt"           // For error messages.
+	"html/template" // The template engine wrapped by TemplateRenderer.
+	"io"            // For the Render writer.
+	"os"            // For reading and checking template files.
+	"path/filepath" // For glob loading and deriving template names.
+	"strings"       // For locating the static prefix of glob patterns.
+)
+
+// TemplateRenderer is an `HTMLRenderer` (and `Renderer`) backed by Go's `html/template`.
+// Create it with `NewTemplateRenderer`.
+//
+// Every file matching the renderer's pattern is a page, rendered by its name relative to
+// the pattern's static directory prefix, with forward slashes: for the pattern
+// "views/*/*.html", the file "views/users/show.html" is rendered as "users/show.html".
+// Each page is parsed together with the shared templates (see `WithTemplatePartials`),
+// so pages can include partials with `{{template "name" .}}` and fill a layout's blocks
+// (see `WithTemplateLayout`) without their `{{define}}`s colliding with other pages.
+//
+// Parsed templates are cached. In `DebugMode` (of the rendering router), templates are
+// instead parsed again on every render, so edits show up without a restart; see
+// `WithTemplateReload`. Output is buffered, so a template that fails midway writes nothing.
+type TemplateRenderer struct {
+	pattern         string           // Glob pattern of the page files.
+	partialsPattern string           // Glob pattern of the shared layout/partial files ("" if none).
+	layout          string           // Name of the template executed for every page ("" for the page itself).
+	funcs           template.FuncMap // Functions available to all templates.
+	reload          *bool            // Whether to re-parse on every render; nil to follow DebugMode.
+
+	pages map[string]*template.Template // Pages parsed by NewTemplateRenderer, by name.
+}
+
+// TemplateRendererOption configures a `TemplateRenderer` created with `NewTemplateRenderer`.
+type TemplateRendererOption func(*TemplateRenderer)
+
+// WithTemplateFuncs is a `TemplateRendererOption` that makes `funcs` available to all
+// templates. It can be given several times; later functions replace earlier ones with
+// the same name.
+func WithTemplateFuncs(funcs template.FuncMap) TemplateRendererOption {
+	return func(tr *TemplateRenderer) {
+		for name, fn := range funcs {
+			tr.funcs[name] = fn
+		}
+	}
+}
+
+// WithTemplatePartials is a `TemplateRendererOption` that parses the files matching the
+// glob `pattern` (layouts and partials) into every page. They are referenced by file
+// base name (e.g., `{{template "header.html" .}}`) or by the names they `{{define}}`.
+// Files matching both this pattern and the page pattern are not rendered as pages.
+func WithTemplatePartials(pattern string) TemplateRendererOption {
+	return func(tr *TemplateRenderer) {
+		tr.partialsPattern = pattern
+	}
+}
+
+// WithTemplateLayout is a `TemplateRendererOption` that renders every page through the
+// template `name` (typically a layout file from `WithTemplatePartials`, e.g.,
+// "layout.html"). The layout includes the page's content with `{{template "content" .}}`
+// or `{{block "content" .}}...{{end}}`, which each page provides with
+// `{{define "content"}}...{{end}}`.
+func WithTemplateLayout(name string) TemplateRendererOption {
+	return func(tr *TemplateRenderer) {
+		tr.layout = name
+	}
+}
+
+// WithTemplateReload is a `TemplateRendererOption` that forces re-parsing templates on
+// every render on (true) or off (false), regardless of the operating mode.
+func WithTemplateReload(enabled bool) TemplateRendererOption {
+	return func(tr *TemplateRenderer) {
+		tr.reload = &enabled
+	}
+}
+
+// NewTemplateRenderer creates a `TemplateRenderer` for the page files matching the glob
+// `pattern` (see `filepath.Glob`), configured by `options`. All templates are parsed
+// immediately, so errors (an invalid pattern, no matching files, or template syntax
+// errors) are reported here rather than on the first request.
+//
+// Example:
+//
+//	renderer, err := xylium.NewTemplateRenderer("views/pages/*.html",
+//		xylium.WithTemplatePartials("views/layouts/*.html"),
+//		xylium.WithTemplateLayout("base.html"),
+//		xylium.WithTemplateFuncs(template.FuncMap{"upper": strings.ToUpper}),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	app.HTMLRenderer = renderer
+//	// In a handler:
+//	return c.HTML(xylium.StatusOK, "home.html", data)
+func NewTemplateRenderer(pattern string, options ...TemplateRendererOption) (*TemplateRenderer, error) {
+	tr := &TemplateRenderer{pattern: pattern, funcs: template.FuncMap{}}
+	for _, option := range options {
+		option(tr)
+	}
+	pages, err := tr.parse()
+	if err != nil {
+		return nil, err
+	}
+	tr.pages = pages
+	return tr, nil
+}
+
+// Render renders the page `name` with `data` and writes the output to `w`, implementing
+// `HTMLRenderer` and `Renderer`. It returns an error if the page does not exist or
+// rendering fails; nothing is written to `w` in that case.
+func (tr *TemplateRenderer) Render(w io.Writer, name string, data interface{}, c *Context) error {
+	pages := tr.pages
+	if tr.reloadEnabled(c) {
+		var err error
+		if pages, err = tr.parse(); err != nil {
+			return err
+		}
+	}
+	page, ok := pages[name]
+	if !ok {
+		return fmt.Errorf("xylium: template '%s' not found", name)
+	}
+	execName := name
+	if tr.layout != "" {
+		execName = tr.layout
+	}
+	var buf bytes.Buffer
+	if err := page.ExecuteTemplate(&buf, execName, data); err != nil {
+		return fmt.Errorf("xylium: failed to render template '%s': %w", name, err)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// reloadEnabled reports whether templates are re-parsed for a render on `c`: as set with
+// `WithTemplateReload`, else if the rendering router (or, without one, the global mode)
+// is in `DebugMode`.
+func (tr *TemplateRenderer) reloadEnabled(c *Context) bool {
+	if tr.reload != nil {
+		return *tr.reload
+	}
+	mode := Mode()
+	if c != nil && c.router != nil {
+		mode = c.router.CurrentMode()
+	}
+	return mode == DebugMode
+}
+
+// parse loads and parses all pages, each together with the shared partials.
+func (tr *TemplateRenderer) parse() (map[string]*template.Template, error) {
+	files, err := globTemplateFiles(tr.pattern)
+	if err != nil {
+		return nil, err
+	}
+	var partials []string
+	if tr.partialsPattern != "" {
+		if partials, err = globTemplateFiles(tr.partialsPattern); err != nil {
+			return nil, err
+		}
+	}
+
+	root := globStaticDir(tr.pattern)
+	pages := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		if containsString(partials, file) {
+			continue
+		}
+		name, err := filepath.Rel(root, file)
+		if err != nil {
+			name = filepath.Base(file)
+		}
+		name = filepath.ToSlash(name)
+
+		page := template.New(name).Funcs(tr.funcs)
+		if len(partials) > 0 {
+			if _, err := page.ParseFiles(partials...); err != nil {
+				return nil, fmt.Errorf("xylium: failed to parse template partials for '%s': %w", name, err)
+			}
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("xylium: failed to read template '%s': %w", file, err)
+		}
+		if _, err := page.Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("xylium: failed to parse template '%s': %w", file, err)
+		}
+		pages[name] = page
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("xylium: no page templates match pattern '%s'", tr.pattern)
+	}
+	return pages, nil
+}
+
+// globTemplateFiles returns the regular files matching the glob `pattern`.
+func globTemplateFiles(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("xylium: invalid template pattern '%s': %w", pattern, err)
+	}
+	files := matches[:0]
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() {
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
+
+// globStaticDir returns the directory part of `pattern` before its first glob
+// metacharacter, e.g., "views" for "views/*/*.html" ("." if there is none).
+func globStaticDir(pattern string) string {
+	static := pattern
+	if idx := strings.IndexAny(pattern, `*?[\`); idx != -1 {
+		static = pattern[:idx]
+	}
+	return filepath.Clean(filepath.Dir(static + "x")) // "x" keeps a trailing separator's directory.
+}
@@ -6,8 +6,9 @@ package xylium
 // DO NOT USE these helpers outside the context of xylium package's own tests.
 
 import (
-	"io"  // For io.Discard
-	"log" // Standard Go logger for silencing during test setup
+	"context" // For the background context of GracefulShutdownForTesting
+	"io"      // For io.Discard
+	"log"     // Standard Go logger for silencing during test setup
 	"sync"
 
 	"github.com/valyala/fasthttp" // For fasthttp.RequestCtx
@@ -214,3 +215,22 @@ func (c *Context) GetParamsForTesting() map[string]string {
 func (c *Context) GetContextResponseOnceForTesting() *sync.Once {
 	return &c.responseOnce
 }
+
+// GracefulShutdownForTesting runs the shutdown sequence of the `ListenAndServe*Gracefully`
+// methods for `server`, as if a shutdown signal had been received, so it can be tested
+// without sending OS signals to the test process.
+//
+// WARNING: This function is intended for internal testing of the xylium package only.
+func (r *Router) GracefulShutdownForTesting(server *fasthttp.Server) {
+	_ = r.gracefulShutdown(context.Background(), server, nil)
+}
+
+// ResetContextReleaseHooksForTesting removes all hooks registered with `OnContextRelease`,
+// so tests registering hooks do not affect each other.
+//
+// WARNING: This function is intended for internal testing of the xylium package only.
+func ResetContextReleaseHooksForTesting() {
+	contextReleaseHooksMu.Lock()
+	defer contextReleaseHooksMu.Unlock()
+	contextReleaseHooks.Store(nil)
+}
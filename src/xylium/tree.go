@@ -28,6 +28,17 @@ type routeTarget struct {
 	// middleware is a slice of `Middleware` functions that are specific to this
 	// particular route and HTTP method. They are executed before the `handler`.
 	middleware []Middleware
+	// group is the `RouteGroup` the route was registered on, or nil for routes
+	// registered directly on the `Router`. Used to resolve group-level settings
+	// such as the group's error handler.
+	group *RouteGroup
+	// route is the handle returned when the route was registered, carrying the
+	// settings made through it (e.g., `Route.Timeout`). Nil for targets added
+	// directly to a `Tree`.
+	route *Route
+	// pattern is the full path pattern the target is registered under (e.g., "/users/:id"),
+	// set by `Tree.addTargets`.
+	pattern string
 }
 
 // node represents a node in the Xylium radix tree. Each `node` corresponds to a
@@ -101,13 +112,36 @@ func NewTree() *Tree {
 //   - If a catch-all segment (e.g., `*filepath`) is not the last segment in the `path`.
 //   - If a parameter or catch-all segment is malformed (e.g., ":" or "*" without a name).
 func (t *Tree) Add(method, path string, handler HandlerFunc, middlewares ...Middleware) {
+	t.addTarget(method, path, routeTarget{handler: handler, middleware: middlewares})
+}
+
+// addTarget registers `target` for `method` and `path`. It implements `Add` (see there for
+// the panics), allowing internal callers to attach additional route data to the target.
+func (t *Tree) addTarget(method, path string, target routeTarget) {
+	t.addTargets([]string{method}, path, target)
+}
+
+// addTargets registers `target` for each of `methods` and `path`. All methods are checked
+// for duplicate registrations before any is registered, so a panic reports every
+// conflicting method and registers none of them.
+func (t *Tree) addTargets(methods []string, path string, target routeTarget) {
 	if path == "" || path[0] != '/' {
 		panic("xylium: path must begin with '/' (e.g., \"/users\", \"/\")")
 	}
-	if handler == nil {
+	if target.handler == nil {
 		panic("xylium: handler cannot be nil for Add operation")
 	}
-	method = strings.ToUpper(method) // Normalize HTTP method to uppercase for consistent map keys.
+	if len(methods) == 0 {
+		panic("xylium: at least one HTTP method is required to register a route")
+	}
+	// Normalize HTTP methods to uppercase for consistent map keys, dropping repeats.
+	normalizedMethods := make([]string, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(method)
+		if !containsString(normalizedMethods, method) {
+			normalizedMethods = append(normalizedMethods, method)
+		}
+	}
 
 	currentNode := t.root // Start traversal from the root node.
 
@@ -135,15 +169,36 @@ func (t *Tree) Add(method, path string, handler HandlerFunc, middlewares ...Midd
 	}
 
 	// At the target node (which represents the end of the full path),
-	// register the handler and middleware for the given HTTP method.
+	// register the handler and middleware for the given HTTP methods.
 	if currentNode.handlers == nil {
 		currentNode.handlers = make(map[string]routeTarget)
 	}
-	// Check for duplicate registration: if a handler already exists for this method and path.
-	if _, exists := currentNode.handlers[method]; exists {
-		panic(fmt.Sprintf("xylium: handler already registered for method %s and path %s", method, path))
+	// Check for duplicate registration: if a handler already exists for a method and path.
+	var duplicates []string
+	for _, method := range normalizedMethods {
+		if _, exists := currentNode.handlers[method]; exists {
+			duplicates = append(duplicates, method)
+		}
+	}
+	if len(duplicates) == 1 {
+		panic(fmt.Sprintf("xylium: handler already registered for method %s and path %s", duplicates[0], path))
+	} else if len(duplicates) > 1 {
+		panic(fmt.Sprintf("xylium: handlers already registered for methods %s and path %s", strings.Join(duplicates, ", "), path))
+	}
+	target.pattern = path // The normalized path, as matched by `find`.
+	for _, method := range normalizedMethods {
+		currentNode.handlers[method] = target
 	}
-	currentNode.handlers[method] = routeTarget{handler: handler, middleware: middlewares}
+}
+
+// containsString reports whether `list` contains `s`.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
 // findOrAddChild is an internal helper method for a `node`. It attempts to find a
@@ -222,8 +277,21 @@ func (n *node) findOrAddChild(segment string) *node {
 //   - If no path structure in the tree matches the `requestPath`: all return values are nil/empty.
 //     This signals a 404 Not Found situation from the tree's perspective.
 func (t *Tree) Find(method, requestPath string) (handler HandlerFunc, routeMw []Middleware, params map[string]string, allowedMethods []string) {
+	target, found, params, _, allowedMethods := t.find(method, requestPath)
+	if !found {
+		return nil, nil, params, allowedMethods
+	}
+	return target.handler, target.middleware, params, allowedMethods
+}
+
+// find implements `Find`, returning the full `routeTarget` of the matched route.
+// `found` is false if no route matches both the path and the method; `params` and
+// `allowedMethods` are populated as described for `Find`. `paramNames` lists the keys
+// of `params` in the order their segments appear in the path (nil if there are none).
+func (t *Tree) find(method, requestPath string) (target routeTarget, found bool, params map[string]string, paramNames []string, allowedMethods []string) {
 	currentNode := t.root                  // Start search from the root of the tree.
 	foundParams := make(map[string]string) // Initialize map to store extracted path parameters.
+	var foundParamNames []string           // Names of the captured parameters, in path order.
 	method = strings.ToUpper(method)       // Normalize the request method to uppercase.
 
 	// Normalize the requestPath: remove trailing slash if it's not the root path.
@@ -235,12 +303,12 @@ func (t *Tree) Find(method, requestPath string) (handler HandlerFunc, routeMw []
 
 	var matchedNode *node // Pointer to store the tree node that matches the full path.
 	// Recursively search the tree. `matchedNode` will be updated if a path match is found.
-	searchPathRecursive(currentNode, segments, 0, foundParams, &matchedNode)
+	searchPathRecursive(currentNode, segments, 0, foundParams, &foundParamNames, &matchedNode)
 
 	// If no node in the tree matched the full request path, or if the matched node
 	// has no handlers defined for any method (which shouldn't happen for a valid terminal node).
 	if matchedNode == nil || matchedNode.handlers == nil {
-		return nil, nil, nil, nil // Signals a 404 Not Found from the tree's perspective.
+		return routeTarget{}, false, nil, nil, nil // Signals a 404 Not Found from the tree's perspective.
 	}
 
 	// A node matching the path structure was found (`matchedNode`).
@@ -255,14 +323,14 @@ func (t *Tree) Find(method, requestPath string) (handler HandlerFunc, routeMw []
 	// Check if a handler exists for the specific requested HTTP method on the matched node.
 	if target, ok := matchedNode.handlers[method]; ok {
 		// Handler found for the requested method and path.
-		return target.handler, target.middleware, foundParams, definedMethodsOnNode
+		return target, true, foundParams, foundParamNames, definedMethodsOnNode
 	}
 
 	// Path structure matched, but no handler for the specific requested `method`.
 	// This is a 405 Method Not Allowed situation.
 	// Return the extracted params (if any) and the list of allowed methods for this path.
-	// Handler and route-specific middleware are nil.
-	return nil, nil, foundParams, definedMethodsOnNode
+	// No route target is returned.
+	return routeTarget{}, false, foundParams, foundParamNames, definedMethodsOnNode
 }
 
 // searchPathRecursive is the core recursive search function used by `Tree.Find`.
@@ -275,6 +343,8 @@ func (t *Tree) Find(method, requestPath string) (handler HandlerFunc, routeMw []
 //   - `segIdx` (int): The index of the current segment in `segments` being matched.
 //   - `params` (map[string]string): The map where extracted path parameter values are stored.
 //     This map is passed by reference and modified during traversal.
+//   - `paramNames` (*[]string): The names of the parameters in `params`, appended in
+//     traversal (path) order and backtracked together with `params`.
 //   - `matchedNode` (**node): A pointer to a `*node` variable in the caller (`Tree.Find`).
 //     If a full path match is found, this variable will be updated to point to the
 //     terminal node of that path.
@@ -283,7 +353,7 @@ func (t *Tree) Find(method, requestPath string) (handler HandlerFunc, routeMw []
 // static nodes first, then parameter nodes, then catch-all nodes.
 // If a match is found along a branch, it continues recursively. If a branch does not
 // lead to a full match, parameter values captured along that branch are backtracked (removed).
-func searchPathRecursive(current *node, segments []string, segIdx int, params map[string]string, matchedNode **node) {
+func searchPathRecursive(current *node, segments []string, segIdx int, params map[string]string, paramNames *[]string, matchedNode **node) {
 	// Base case for recursion: all segments of the request path have been processed.
 	if segIdx == len(segments) {
 		// If the current node has handlers defined (i.e., it's a terminal node for some routes),
@@ -306,7 +376,7 @@ func searchPathRecursive(current *node, segments []string, segIdx int, params ma
 			// For a static child node, the request segment must exactly match the child's path.
 			if child.path == currentSegment {
 				// Match found. Recurse deeper with the next segment.
-				searchPathRecursive(child, segments, segIdx+1, params, matchedNode)
+				searchPathRecursive(child, segments, segIdx+1, params, paramNames, matchedNode)
 				if *matchedNode != nil {
 					// If a full match was found in the deeper recursion (e.g., a handler was set on a descendant),
 					// propagate this result up and stop further searching on this level for this branch.
@@ -317,8 +387,9 @@ func searchPathRecursive(current *node, segments []string, segIdx int, params ma
 			}
 		case paramNode:
 			// For a parameter child node, it captures the current request segment as a parameter value.
-			params[child.paramName] = currentSegment                            // Store the captured parameter value.
-			searchPathRecursive(child, segments, segIdx+1, params, matchedNode) // Recurse deeper.
+			params[child.paramName] = currentSegment // Store the captured parameter value.
+			*paramNames = append(*paramNames, child.paramName)
+			searchPathRecursive(child, segments, segIdx+1, params, paramNames, matchedNode) // Recurse deeper.
 			if *matchedNode != nil {
 				// Full match found in this parameter branch. Propagate up.
 				return
@@ -328,11 +399,13 @@ func searchPathRecursive(current *node, segments []string, segIdx int, params ma
 			// allowing other sibling branches (e.g., another static path at the same level)
 			// to be tried correctly without this param polluting their state.
 			delete(params, child.paramName)
+			*paramNames = (*paramNames)[:len(*paramNames)-1]
 		case catchAllNode:
 			// For a catch-all child node, it captures the current segment and all
 			// remaining segments of the request path.
 			// A catch-all node must be the terminal part of a registered route pattern.
 			params[child.paramName] = strings.Join(segments[segIdx:], "/") // Join remaining segments.
+			*paramNames = append(*paramNames, child.paramName)
 			// If this catch-all node itself has handlers, it's a match.
 			if child.handlers != nil {
 				*matchedNode = child
@@ -505,3 +578,41 @@ func (t *Tree) printNodeRoutesRecursive(logger Logger, n *node, basePath string)
 		t.printNodeRoutesRecursive(logger, child, currentFullPath)
 	}
 }
+
+// walkRoutes calls `fn` for every registered route, in the same order as `PrintRoutes`
+// (depth-first by node priority, methods sorted alphabetically), with the route's full
+// path pattern.
+func (t *Tree) walkRoutes(fn func(method, path string, target routeTarget)) {
+	t.walkNodeRoutesRecursive(t.root, "", fn)
+}
+
+// walkNodeRoutesRecursive is the recursive helper of `walkRoutes`. Paths are built
+// the same way as in `printNodeRoutesRecursive`.
+func (t *Tree) walkNodeRoutesRecursive(n *node, basePath string, fn func(method, path string, target routeTarget)) {
+	var currentFullPath string
+	if n.path == "" {
+		currentFullPath = "/"
+		if basePath != "" {
+			currentFullPath = basePath
+		}
+	} else if basePath == "/" || basePath == "" {
+		currentFullPath = "/" + n.path
+	} else {
+		currentFullPath = basePath + "/" + n.path
+	}
+
+	if len(n.handlers) > 0 {
+		methods := make([]string, 0, len(n.handlers))
+		for method := range n.handlers {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			fn(method, currentFullPath, n.handlers[method])
+		}
+	}
+
+	for _, child := range n.children {
+		t.walkNodeRoutesRecursive(child, currentFullPath, fn)
+	}
+}
@@ -29,21 +29,36 @@ type HandlerFunc func(*Context) error
 // returning an error or sending a response.
 type Middleware func(next HandlerFunc) HandlerFunc
 
+// PreRoutingHook defines the function signature for hooks that run before route lookup.
+// Unlike `Middleware`, which only runs after a route has been matched, a `PreRoutingHook`
+// runs for every request and may modify the request method or URI (via `c.Ctx.Request`)
+// to influence which route is matched. See `Router.UsePreRouting`.
+type PreRoutingHook func(c *Context)
+
+// ErrorMapper defines the function signature for translating errors that are not
+// `*HTTPError` (e.g., domain errors such as `sql.ErrNoRows`) into an `*HTTPError`.
+// It returns the `*HTTPError` to respond with and true if it handles `err`, or false to
+// let the next mapper (and finally the 500 fallback) handle it. See `Router.RegisterErrorMapper`.
+type ErrorMapper func(err error) (*HTTPError, bool)
+
 // --- Logger Definitions ---
 
 // LogLevel defines the severity level of a log message. It is used by Xylium's
 // logging system (e.g., `DefaultLogger`) to control which messages are outputted.
-// Log levels are ordered from most verbose (Debug) to most critical (Panic).
+// Log levels are ordered from most verbose (Trace) to most critical (Panic).
 type LogLevel int
 
 // Log level constants define the standard severity levels for logging.
+//
+// `LevelTrace` is -1 so that `LevelDebug` remains the zero value of `LogLevel`.
 const (
-	LevelDebug LogLevel = iota // DebugLevel logs are typically verbose messages useful for development and detailed tracing.
-	LevelInfo                  // InfoLevel logs are informational messages about the normal operation of the application.
-	LevelWarn                  // WarnLevel logs indicate potential issues or unusual situations that are not necessarily errors.
-	LevelError                 // ErrorLevel logs report errors that occurred during request processing or application operation but may not be fatal.
-	LevelFatal                 // FatalLevel logs report critical errors. After logging a Fatal message, the `DefaultLogger` will call `os.Exit(1)`.
-	LevelPanic                 // PanicLevel logs report critical errors. After logging a Panic message, the `DefaultLogger` will call `panic()`.
+	LevelTrace LogLevel = iota - 1 // TraceLevel logs are extremely verbose messages (e.g., wire-level dumps), finer than Debug.
+	LevelDebug                     // DebugLevel logs are typically verbose messages useful for development and detailed tracing.
+	LevelInfo                      // InfoLevel logs are informational messages about the normal operation of the application.
+	LevelWarn                      // WarnLevel logs indicate potential issues or unusual situations that are not necessarily errors.
+	LevelError                     // ErrorLevel logs report errors that occurred during request processing or application operation but may not be fatal.
+	LevelFatal                     // FatalLevel logs report critical errors. After logging a Fatal message, the `DefaultLogger` will call `os.Exit(1)`.
+	LevelPanic                     // PanicLevel logs report critical errors. After logging a Panic message, the `DefaultLogger` will call `panic()`.
 )
 
 // String returns the uppercase string representation of the `LogLevel`.
@@ -51,6 +66,8 @@ const (
 // If the log level is unknown, it returns "UNKNOWN_LEVEL(value)".
 func (l LogLevel) String() string {
 	switch l {
+	case LevelTrace:
+		return "TRACE"
 	case LevelDebug:
 		return "DEBUG"
 	case LevelInfo:
@@ -71,7 +88,7 @@ func (l LogLevel) String() string {
 
 // Logger defines the interface for Xylium's logging system.
 // Implementations of this interface (like `DefaultLogger`) provide methods for
-// leveled logging (Debug, Info, Warn, Error, Fatal, Panic) and structured logging
+// leveled logging (Trace, Debug, Info, Warn, Error, Fatal, Panic) and structured logging
 // capabilities via `WithFields`.
 //
 // The interface allows for replacing Xylium's `DefaultLogger` with a custom
@@ -81,6 +98,8 @@ type Logger interface {
 	// This is similar to `log.Printf` but at a fixed Info level.
 	Printf(format string, args ...interface{})
 
+	// Trace logs a message at LevelTrace. Arguments are handled by `fmt.Sprint`.
+	Trace(args ...interface{})
 	// Debug logs a message at LevelDebug. Arguments are handled by `fmt.Sprint`.
 	Debug(args ...interface{})
 	// Info logs a message at LevelInfo. Arguments are handled by `fmt.Sprint`.
@@ -98,6 +117,8 @@ type Logger interface {
 	// Arguments are handled by `fmt.Sprint`.
 	Panic(args ...interface{})
 
+	// Tracef logs a formatted message at LevelTrace using `fmt.Sprintf` style.
+	Tracef(format string, args ...interface{})
 	// Debugf logs a formatted message at LevelDebug using `fmt.Sprintf` style.
 	Debugf(format string, args ...interface{})
 	// Infof logs a formatted message at LevelInfo using `fmt.Sprintf` style.
@@ -118,6 +139,10 @@ type Logger interface {
 	// This is used for structured logging, allowing context-specific key-value
 	// data to be consistently logged. The original logger is not modified.
 	WithFields(fields M) Logger
+	// WithError returns a new `Logger` instance that includes `err` as structured
+	// fields (at least "error") in all subsequent log entries, e.g.,
+	// `c.Logger().WithError(err).Error("Payment failed.")`. A nil `err` adds nothing.
+	WithError(err error) Logger
 
 	// SetOutput sets the output destination `io.Writer` for the logger.
 	SetOutput(w io.Writer)
@@ -164,12 +189,24 @@ const ContextKeyOtelSpanID string = "otel_span_id"
 // panic handler to access details about the panic.
 const ContextKeyPanicInfo string = "xylium_panic_info"
 
+// ContextKeyPanicStack is the key used in `c.store` to store the stack trace (`[]byte`,
+// as captured by `debug.Stack()` or `runtime.Stack()`) of a recovered panic. Like
+// `ContextKeyPanicInfo`, it is set *before* the `Router.PanicHandler` (or the `Recover`
+// middleware's `OnPanic`) is invoked, so custom handlers can forward it to crash
+// reporting services. See also `c.PanicInfo()`.
+const ContextKeyPanicStack string = "xylium_panic_stack"
+
 // ContextKeyErrorCause is the key used in `c.store` to store the original `error`
 // that caused the `Router.GlobalErrorHandler` to be invoked. This could be an error
 // returned by a route handler, a middleware, or the `Router.PanicHandler`.
 // This value is set *before* the `GlobalErrorHandler` is called.
 const ContextKeyErrorCause string = "xylium_error_cause"
 
+// ContextKeyLogFields is the key used in `c.store` to hold the log fields (`M`) added
+// with `c.AddLogFields`, which `c.Logger()` includes in every logger it returns.
+// Use `c.AddLogFields` rather than setting this key directly.
+const ContextKeyLogFields string = "xylium_log_fields"
+
 // ContextKeyCSRFToken is the default key used by the `xylium.CSRF` middleware to
 // store the generated CSRF token (intended for the *next* request's validation)
 // in the *current* request's `xylium.Context` store (`c.store`).
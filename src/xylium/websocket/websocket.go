@@ -0,0 +1,488 @@
+// src/xylium/websocket/websocket.go
+
+// Package websocket upgrades Xylium requests to WebSocket connections (RFC 6455).
+// It is kept out of the core package so applications that do not need WebSockets do
+// not carry the protocol implementation:
+//
+//	import "github.com/arwahdevops/xylium-core/src/xylium/websocket"
+//
+//	app.GET("/ws", func(c *xylium.Context) error {
+//		return websocket.Upgrade(c, func(conn *websocket.Conn) {
+//			for {
+//				mt, msg, err := conn.ReadMessage()
+//				if err != nil {
+//					return
+//				}
+//				if err := conn.WriteMessage(mt, msg); err != nil {
+//					return
+//				}
+//			}
+//		})
+//	})
+package websocket
+
+import (
+	"bufio"           // For buffered reading of frames from the hijacked connection.
+	"crypto/sha1"     // For computing Sec-WebSocket-Accept (RFC 6455, section 4.2.2).
+	"encoding/base64" // For encoding and validating handshake keys.
+	"encoding/binary" // For frame payload lengths and close codes.
+	"errors"          // For sentinel errors.
+	"fmt"             // For error messages.
+	"io"              // For io.ReadFull.
+	"net"             // For the hijacked net.Conn.
+	"net/url"         // For parsing the Origin header.
+	"strings"         // For header token matching.
+	"sync"            // For serializing concurrent writes.
+	"time"            // For connection deadlines.
+	"unicode/utf8"    // For validating text messages and close reasons.
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// Message types, as used by `Conn.ReadMessage` and `Conn.WriteMessage`.
+// The values are the frame opcodes defined by RFC 6455.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// Close status codes (RFC 6455, section 7.4.1).
+const (
+	CloseNormalClosure           = 1000
+	CloseGoingAway               = 1001
+	CloseProtocolError           = 1002
+	CloseUnsupportedData         = 1003
+	CloseNoStatusReceived        = 1005 // Reported locally only; never sent on the wire.
+	CloseInvalidFramePayloadData = 1007
+	CloseMessageTooBig           = 1009
+)
+
+// DefaultReadLimit is the default maximum size in bytes of a single message read by
+// `Conn.ReadMessage`.
+const DefaultReadLimit = 1 << 20 // 1 MB
+
+// acceptGUID is the fixed GUID appended to Sec-WebSocket-Key (RFC 6455, section 1.3).
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// ErrClosed is returned by `Conn` write methods after the connection has been closed
+// (by either side).
+var ErrClosed = errors.New("xylium: websocket connection closed")
+
+// CloseError is returned by `Conn.ReadMessage` when the peer sends a close frame. The
+// close frame is answered automatically. A close frame without a status code is reported
+// with `CloseNoStatusReceived`.
+type CloseError struct {
+	Code int
+	Text string
+}
+
+// Error implements the `error` interface.
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("xylium: websocket closed by peer (code %d): %s", e.Code, e.Text)
+}
+
+// Config defines the configuration for `UpgradeWithConfig`.
+type Config struct {
+	// CheckOrigin decides whether the handshake is accepted for the request's "Origin" header.
+	// Default: requests without an "Origin" header (non-browser clients) are accepted, and
+	// browser requests are accepted only if the Origin host equals the request's Host.
+	CheckOrigin func(c *xylium.Context) bool
+
+	// Subprotocols lists the server's supported subprotocols, in order of preference.
+	// The first one also requested by the client (via "Sec-WebSocket-Protocol") is selected.
+	Subprotocols []string
+
+	// ReadLimit is the maximum size in bytes of a single message. Larger messages cause the
+	// connection to be closed with code 1009 (message too big).
+	// Default: `DefaultReadLimit` (1 MB).
+	ReadLimit int64
+}
+
+// Upgrade performs a WebSocket handshake using the default `Config` and, once the 101
+// response has been sent, calls `handler` with the upgraded connection.
+// See `UpgradeWithConfig` for details.
+func Upgrade(c *xylium.Context, handler func(conn *Conn)) error {
+	return UpgradeWithConfig(c, Config{}, handler)
+}
+
+// UpgradeWithConfig validates the WebSocket handshake request (GET method, "Upgrade" and
+// "Connection" headers, "Sec-WebSocket-Version: 13", a valid "Sec-WebSocket-Key", and the
+// Origin via `config.CheckOrigin`), prepares the "101 Switching Protocols" response, and
+// hijacks the connection with `c.Hijack`. The route handler should return its error.
+//
+// If the handshake is invalid, an `*xylium.HTTPError` is returned (400, 403 for a
+// rejected Origin, or 426 for an unsupported version) and nothing is hijacked.
+//
+// `handler` runs after the route handler (and all middleware) have returned, on the
+// connection's goroutine, so it must not use the `*xylium.Context`; copy any request
+// data it needs (params, user info) before calling UpgradeWithConfig. Server limits such
+// as `ReadTimeout`/`WriteTimeout` do not apply to the upgraded connection; use
+// `Conn.SetReadDeadline`/`SetWriteDeadline` instead.
+//
+// Connection lifetime follows `ServerConfig.KeepHijackedConns`: if false (default), the
+// connection is closed as soon as `handler` returns, so `handler` must block for the
+// lifetime of the WebSocket. If true, the connection stays open after `handler` returns
+// and must be closed with `Conn.Close`; such connections are not tracked by graceful
+// shutdown.
+func UpgradeWithConfig(c *xylium.Context, config Config, handler func(conn *Conn)) error {
+	if handler == nil {
+		panic("xylium: websocket.Upgrade requires a non-nil handler")
+	}
+	if config.ReadLimit <= 0 {
+		config.ReadLimit = DefaultReadLimit
+	}
+	if config.CheckOrigin == nil {
+		config.CheckOrigin = sameOrigin
+	}
+
+	if c.Method() != xylium.MethodGet {
+		return xylium.NewHTTPError(xylium.StatusMethodNotAllowed, "WebSocket handshake requires the GET method.")
+	}
+	if !headerContainsToken(c.Header("Connection"), "upgrade") || !headerContainsToken(c.Header("Upgrade"), "websocket") {
+		return xylium.NewHTTPError(xylium.StatusBadRequest, "Invalid WebSocket handshake: missing 'Upgrade: websocket' or 'Connection: Upgrade' header.")
+	}
+	if c.Header("Sec-WebSocket-Version") != "13" {
+		c.SetHeader("Sec-WebSocket-Version", "13")
+		return xylium.NewHTTPError(xylium.StatusUpgradeRequired, "Unsupported WebSocket version; only version 13 is supported.")
+	}
+	key := strings.TrimSpace(c.Header("Sec-WebSocket-Key"))
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return xylium.NewHTTPError(xylium.StatusBadRequest, "Invalid WebSocket handshake: malformed 'Sec-WebSocket-Key' header.")
+	}
+	if !config.CheckOrigin(c) {
+		return xylium.NewHTTPError(xylium.StatusForbidden, "WebSocket handshake rejected: origin not allowed.")
+	}
+
+	subprotocol := selectSubprotocol(c.Header("Sec-WebSocket-Protocol"), config.Subprotocols)
+	readLimit := config.ReadLimit
+
+	c.Ctx.SetStatusCode(xylium.StatusSwitchingProtocols)
+	c.Ctx.Response.Header.Set("Upgrade", "websocket")
+	c.Ctx.Response.Header.Set("Connection", "Upgrade")
+	c.Ctx.Response.Header.Set("Sec-WebSocket-Accept", acceptKey(key))
+	if subprotocol != "" {
+		c.Ctx.Response.Header.Set("Sec-WebSocket-Protocol", subprotocol)
+	}
+
+	c.Hijack(func(netConn net.Conn, keepOpen bool) {
+		conn := &Conn{
+			conn:        netConn,
+			reader:      bufio.NewReader(netConn),
+			readLimit:   readLimit,
+			subprotocol: subprotocol,
+		}
+		handler(conn)
+		if !keepOpen {
+			conn.markClosed() // fasthttp closes the underlying connection after we return.
+		}
+	})
+	return nil
+}
+
+// sameOrigin is the default `Config.CheckOrigin`.
+func sameOrigin(c *xylium.Context) bool {
+	origin := c.Header("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, string(c.Ctx.Host()))
+}
+
+// acceptKey computes the Sec-WebSocket-Accept value for a client key.
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// selectSubprotocol returns the first server-supported subprotocol that the client
+// requested, or "" if there is none.
+func selectSubprotocol(requested string, supported []string) string {
+	if requested == "" || len(supported) == 0 {
+		return ""
+	}
+	for _, s := range supported {
+		if headerContainsToken(requested, s) {
+			return s
+		}
+	}
+	return ""
+}
+
+// headerContainsToken reports whether a comma-separated header value contains `token`
+// (case-insensitive).
+func headerContainsToken(headerValue, token string) bool {
+	for _, part := range strings.Split(headerValue, ",") {
+		if strings.EqualFold(strings.TrimSpace(part), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// validCloseCode reports whether `code` may appear in a close frame on the wire
+// (RFC 6455, section 7.4). 1005, 1006 and 1015 are reserved for local use.
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1014:
+		return true
+	default:
+		return code >= 3000 && code <= 4999
+	}
+}
+
+// Conn is an upgraded WebSocket connection created by `Upgrade`.
+// It implements the RFC 6455 framing without extensions (no compression).
+//
+// `ReadMessage` must be called from a single goroutine. Write methods are safe for
+// concurrent use. Ping frames are answered automatically while reading.
+type Conn struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	readLimit   int64
+	subprotocol string
+
+	writeMu sync.Mutex
+	closed  bool // Guarded by writeMu.
+}
+
+// Subprotocol returns the negotiated subprotocol, or "" if none was selected.
+func (ws *Conn) Subprotocol() string { return ws.subprotocol }
+
+// RemoteAddr returns the remote network address.
+func (ws *Conn) RemoteAddr() net.Addr { return ws.conn.RemoteAddr() }
+
+// SetReadDeadline sets the deadline for future reads. A zero value disables the deadline.
+func (ws *Conn) SetReadDeadline(t time.Time) error { return ws.conn.SetReadDeadline(t) }
+
+// SetWriteDeadline sets the deadline for future writes. A zero value disables the deadline.
+func (ws *Conn) SetWriteDeadline(t time.Time) error { return ws.conn.SetWriteDeadline(t) }
+
+// ReadMessage reads the next complete (possibly fragmented) text or binary message.
+// Ping frames are answered with pongs and pong frames are ignored. If the peer sends a
+// close frame, it is answered and a `*CloseError` is returned. Protocol violations
+// (including invalid close codes) close the connection with 1002, text messages or close
+// reasons that are not valid UTF-8 with 1007, and oversized messages with 1009.
+func (ws *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := ws.writeFrame(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			return 0, nil, ws.answerClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, ws.failProtocol("new data frame before previous message completed")
+			}
+			messageType = opcode
+		case 0: // Continuation frame.
+			if messageType == 0 {
+				return 0, nil, ws.failProtocol("continuation frame without a started message")
+			}
+		default:
+			return 0, nil, ws.failProtocol(fmt.Sprintf("unknown opcode %d", opcode))
+		}
+
+		if int64(len(message)+len(payload)) > ws.readLimit {
+			_ = ws.WriteClose(CloseMessageTooBig, "message too big")
+			return 0, nil, fmt.Errorf("xylium: websocket message exceeds read limit of %d bytes", ws.readLimit)
+		}
+		message = append(message, payload...)
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, ws.failInvalidData("text message is not valid UTF-8")
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+// answerClose answers a close frame received from the peer and returns the
+// `*CloseError` describing it, or the protocol error if the frame is invalid.
+func (ws *Conn) answerClose(payload []byte) error {
+	switch {
+	case len(payload) == 0:
+		// No status code: answer with an empty close frame, as 1005 must not be sent.
+		_ = ws.writeClose(nil)
+		return &CloseError{Code: CloseNoStatusReceived}
+	case len(payload) == 1:
+		return ws.failProtocol("close frame payload too short")
+	}
+	code := int(binary.BigEndian.Uint16(payload))
+	if !validCloseCode(code) {
+		return ws.failProtocol(fmt.Sprintf("invalid close code %d", code))
+	}
+	if !utf8.Valid(payload[2:]) {
+		return ws.failInvalidData("close reason is not valid UTF-8")
+	}
+	_ = ws.writeClose(payload[:2]) // Echo the status code.
+	return &CloseError{Code: code, Text: string(payload[2:])}
+}
+
+// WriteMessage sends `data` as a single text or binary message
+// (`TextMessage` or `BinaryMessage`).
+func (ws *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("xylium: invalid websocket message type %d for WriteMessage", messageType)
+	}
+	return ws.writeFrame(messageType, data)
+}
+
+// WritePing sends a ping control frame with an optional payload (at most 125 bytes).
+func (ws *Conn) WritePing(data []byte) error {
+	return ws.writeFrame(PingMessage, data)
+}
+
+// WriteClose sends a close frame with the given status code and reason. The code must
+// be valid on the wire (e.g., not `CloseNoStatusReceived`) and the reason valid UTF-8.
+// After WriteClose, no further messages can be written; the underlying connection is left
+// open so the peer's close frame can still be read. Use `Close` to close the connection.
+func (ws *Conn) WriteClose(code int, text string) error {
+	if !validCloseCode(code) {
+		return fmt.Errorf("xylium: websocket close code %d cannot be sent", code)
+	}
+	if !utf8.ValidString(text) {
+		return errors.New("xylium: websocket close reason is not valid UTF-8")
+	}
+	payload := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	return ws.writeClose(append(payload, text...))
+}
+
+// writeClose sends a close frame with `payload` (empty or a status code and reason) and
+// prevents any further writes.
+func (ws *Conn) writeClose(payload []byte) error {
+	if err := ws.writeFrame(CloseMessage, payload); err != nil {
+		return err
+	}
+	ws.markClosed()
+	return nil
+}
+
+// Close closes the underlying network connection without sending a close frame.
+// Use `WriteClose` first for a clean closing handshake.
+func (ws *Conn) Close() error {
+	ws.markClosed()
+	return ws.conn.Close()
+}
+
+// markClosed prevents any further writes.
+func (ws *Conn) markClosed() {
+	ws.writeMu.Lock()
+	ws.closed = true
+	ws.writeMu.Unlock()
+}
+
+// failProtocol closes the connection with a protocol error and returns a descriptive error.
+func (ws *Conn) failProtocol(reason string) error {
+	_ = ws.WriteClose(CloseProtocolError, reason)
+	return fmt.Errorf("xylium: websocket protocol error: %s", reason)
+}
+
+// failInvalidData closes the connection with 1007 (invalid frame payload data) and
+// returns a descriptive error.
+func (ws *Conn) failInvalidData(reason string) error {
+	_ = ws.WriteClose(CloseInvalidFramePayloadData, reason)
+	return fmt.Errorf("xylium: websocket invalid payload: %s", reason)
+}
+
+// readFrame reads and unmasks a single frame.
+func (ws *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(ws.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, ws.failProtocol("reserved bits set without a negotiated extension")
+	}
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	isControl := opcode >= CloseMessage
+	if isControl && (!fin || length > 125) {
+		return false, 0, nil, ws.failProtocol("invalid control frame")
+	}
+	if !masked {
+		return false, 0, nil, ws.failProtocol("client frames must be masked")
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(ws.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(ws.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+	if length < 0 || length > ws.readLimit {
+		_ = ws.WriteClose(CloseMessageTooBig, "message too big")
+		return false, 0, nil, fmt.Errorf("xylium: websocket frame exceeds read limit of %d bytes", ws.readLimit)
+	}
+
+	var maskKey [4]byte
+	if _, err = io.ReadFull(ws.reader, maskKey[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(ws.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= maskKey[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes a single unmasked (server-to-client) frame with FIN set.
+func (ws *Conn) writeFrame(opcode int, payload []byte) error {
+	if opcode >= CloseMessage && len(payload) > 125 {
+		return errors.New("xylium: websocket control frame payload exceeds 125 bytes")
+	}
+
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 126, byte(n>>8), byte(n))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	frame = append(frame, payload...)
+
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if ws.closed {
+		return ErrClosed
+	}
+	_, err := ws.conn.Write(frame)
+	return err
+}
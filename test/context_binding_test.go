@@ -2,21 +2,28 @@
 package xylium_test
 
 import (
-	// "bytes" // Tidak digunakan secara langsung saat ini
+	"bytes"
 	// "encoding/json" // Tidak digunakan secara langsung saat ini, xylium.Bind menangani
 	// "encoding/xml"  // Akan dibutuhkan untuk tes XML binding
 	"errors"
-	// "mime/multipart" // Akan dibutuhkan untuk tes multipart form
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url" // Digunakan untuk query/form values
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
 	"github.com/valyala/fasthttp"
 	// Ganti path ini sesuai dengan module path Anda
 	"github.com/arwahdevops/xylium-core/src/xylium"
-	// "github.com/go-playground/validator/v10" // Akan dibutuhkan untuk tes custom validator
 )
 
 // --- Helper Structs untuk Binding ---
@@ -245,6 +252,48 @@ func TestContext_Bind_XBind(t *testing.T) {
 	}
 }
 
+// hookedSignupStruct mencatat urutan pemanggilan hook binding.
+type hookedSignupStruct struct {
+	Email string   `json:"email" validate:"required,email"`
+	Calls []string `json:"-"`
+}
+
+func (h *hookedSignupStruct) BeforeBind(c *xylium.Context) error {
+	h.Calls = append(h.Calls, "before")
+	return nil
+}
+
+func (h *hookedSignupStruct) AfterBind(c *xylium.Context) error {
+	h.Calls = append(h.Calls, "after:"+h.Email)
+	h.Email = strings.ToLower(strings.TrimSpace(h.Email))
+	return nil
+}
+
+func TestContext_Bind_PreAndPostBinder(t *testing.T) {
+	// Email dinormalisasi oleh AfterBind sebelum validasi, sehingga lolos validasi email.
+	ctx := newTestContextWithBody("POST", "/signup", "application/json", []byte(`{"email":"  Ana@Example.COM "}`))
+	var data hookedSignupStruct
+	if err := ctx.BindAndValidate(&data); err != nil {
+		t.Fatalf("BindAndValidate() returned an error: %v", err)
+	}
+	if data.Email != "ana@example.com" {
+		t.Errorf("Expected normalized email, got %q", data.Email)
+	}
+	if got := strings.Join(data.Calls, ","); got != "before,after:  Ana@Example.COM " {
+		t.Errorf("Unexpected hook order: %q", got)
+	}
+
+	// AfterBind tidak dipanggil jika binding gagal.
+	ctx = newTestContextWithBody("POST", "/signup", "application/json", []byte(`{"email":`))
+	var broken hookedSignupStruct
+	if err := ctx.BindJSON(&broken); err == nil {
+		t.Fatal("Expected an error for malformed JSON")
+	}
+	if got := strings.Join(broken.Calls, ","); got != "before" {
+		t.Errorf("Expected only BeforeBind to run, got %q", got)
+	}
+}
+
 func TestContext_Bind_Query(t *testing.T) {
 	t.Run("ValidQueryData", func(t *testing.T) {
 		q := url.Values{}
@@ -357,6 +406,82 @@ func TestContext_Bind_Query(t *testing.T) {
 			t.Errorf("PtrTime with empty input: expected nil, got value %v", *dataEmpty.PtrTime)
 		}
 	})
+
+	t.Run("BracketNotation", func(t *testing.T) {
+		type PageFilter struct {
+			Status string `query:"status"`
+			MinAge *int   `query:"min_age"`
+		}
+		type Paging struct {
+			Page int `query:"page"`
+		}
+		type BracketQuery struct {
+			Paging                     // Disematkan tanpa tag: field-nya dipetakan ke tingkat atas.
+			Tags    []string           `query:"tags"`
+			IDs     []int              `query:"ids"`
+			Filter  PageFilter         `query:"filter"`
+			Sort    *PageFilter        `query:"sort"`
+			Labels  map[string]string  `query:"labels"`
+			Weights map[string]float64 `query:"weights"`
+		}
+
+		q := url.Values{}
+		q.Add("tags[]", "a")
+		q.Add("tags[]", "b")
+		q.Add("ids", "1")
+		q.Add("ids[]", "2")
+		q.Set("filter[status]", "open")
+		q.Set("filter[min_age]", "18")
+		q.Set("labels[env]", "prod")
+		q.Set("labels[team]", "core")
+		q.Set("labels[a][b]", "ignored")
+		q.Set("weights[x]", "0.5")
+		q.Set("page", "3")
+		ctx := newTestContextWithQueryForm("GET", "/test", q, nil)
+		var data BracketQuery
+		if err := ctx.Bind(&data); err != nil {
+			t.Fatalf("Bind() with bracket notation returned an unexpected error: %v", err)
+		}
+		if strings.Join(data.Tags, ",") != "a,b" {
+			t.Errorf("Tags: expected [a b], got %v", data.Tags)
+		}
+		if len(data.IDs) != 2 || data.IDs[0] != 1 || data.IDs[1] != 2 {
+			t.Errorf("IDs: expected [1 2] from repeated and bracketed keys, got %v", data.IDs)
+		}
+		if data.Filter.Status != "open" || data.Filter.MinAge == nil || *data.Filter.MinAge != 18 {
+			t.Errorf("Filter: expected {open 18}, got %+v", data.Filter)
+		}
+		if data.Sort != nil {
+			t.Errorf("Sort: expected nil pointer without sort[...] parameters, got %+v", data.Sort)
+		}
+		if len(data.Labels) != 2 || data.Labels["env"] != "prod" || data.Labels["team"] != "core" {
+			t.Errorf("Labels: expected map[env:prod team:core], got %v", data.Labels)
+		}
+		if data.Weights["x"] != 0.5 {
+			t.Errorf("Weights: expected map[x:0.5], got %v", data.Weights)
+		}
+		if data.Page != 3 {
+			t.Errorf("Page: expected 3 from the embedded struct, got %d", data.Page)
+		}
+
+		// Kesalahan konversi dilaporkan dengan nama parameter bertanda kurung.
+		qBad := url.Values{}
+		qBad.Set("filter[min_age]", "abc")
+		qBad.Set("weights[y]", "heavy")
+		var bad BracketQuery
+		err := newTestContextWithQueryForm("GET", "/test", qBad, nil).Bind(&bad)
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != xylium.StatusBadRequest {
+			t.Fatalf("Expected a 400 HTTPError for invalid nested values, got %v", err)
+		}
+		details, _ := httpErr.Message.(xylium.M)["details"].(map[string]string)
+		if _, ok := details["filter[min_age]"]; !ok {
+			t.Errorf("Expected an error for 'filter[min_age]', got %v", details)
+		}
+		if _, ok := details["weights"]; !ok {
+			t.Errorf("Expected an error for 'weights', got %v", details)
+		}
+	})
 }
 
 func TestContext_Bind_Form(t *testing.T) {
@@ -383,3 +508,632 @@ func derefString(s *string) string {
 	}
 	return *s
 }
+
+type PathParamBindingStruct struct {
+	ID     int    `param:"id" json:"id" validate:"gte=1"`
+	Slug   string `param:"slug"`
+	Name   string `json:"name" query:"name" validate:"required"`
+	Ignore string `json:"ignore"`
+}
+
+func TestContext_Bind_PathParams(t *testing.T) {
+	newCtx := func(method, uri, contentType, body string, params map[string]string) *xylium.Context {
+		var bodyBytes []byte
+		if body != "" {
+			bodyBytes = []byte(body)
+		}
+		ctx := newTestContextWithBody(method, uri, contentType, bodyBytes)
+		ctx.Params = params
+		return ctx
+	}
+
+	t.Run("WithJSONBody", func(t *testing.T) {
+		ctx := newCtx("PUT", "/users/42/post", "application/json", `{"id": 7, "name": "Alice"}`,
+			map[string]string{"id": "42", "slug": "post", "ignore": "x"})
+		var data PathParamBindingStruct
+		if err := ctx.BindAndValidate(&data); err != nil {
+			t.Fatalf("BindAndValidate() returned an unexpected error: %v", err)
+		}
+		if data.ID != 42 {
+			t.Errorf("ID: expected route parameter 42 to win over body, got %d", data.ID)
+		}
+		if data.Slug != "post" || data.Name != "Alice" {
+			t.Errorf("Expected Slug 'post' and Name 'Alice', got %+v", data)
+		}
+		if data.Ignore != "" {
+			t.Errorf("Ignore: expected untagged field not to be bound from params, got '%s'", data.Ignore)
+		}
+	})
+
+	t.Run("WithQueryAndEmptyBody", func(t *testing.T) {
+		for _, method := range []string{"GET", "DELETE"} {
+			ctx := newCtx(method, "/users/5?name=Bob", "", "", map[string]string{"id": "5"})
+			var data PathParamBindingStruct
+			if err := ctx.BindAndValidate(&data); err != nil {
+				t.Fatalf("%s: BindAndValidate() returned an unexpected error: %v", method, err)
+			}
+			if data.ID != 5 || data.Name != "Bob" {
+				t.Errorf("%s: expected {ID:5 Name:Bob}, got %+v", method, data)
+			}
+		}
+		ctx := newCtx("POST", "/users/9", "", "", map[string]string{"id": "9"})
+		var data PathParamBindingStruct
+		if err := ctx.Bind(&data); err != nil {
+			t.Fatalf("POST without body: Bind() returned an unexpected error: %v", err)
+		}
+		if data.ID != 9 {
+			t.Errorf("POST without body: expected ID 9, got %d", data.ID)
+		}
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		ctx := newCtx("GET", "/users/abc?name=Bob", "", "", map[string]string{"id": "abc"})
+		var data PathParamBindingStruct
+		err := ctx.Bind(&data)
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected HTTPError 400 for a non-numeric id, got %v", err)
+		}
+		if !strings.Contains(httpErr.Error(), "route parameter 'id'") {
+			t.Errorf("Expected error to mention route parameter 'id', got '%s'", httpErr.Error())
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		ctx := newCtx("GET", "/users/0?name=Bob", "", "", map[string]string{"id": "0"})
+		var data PathParamBindingStruct
+		err := ctx.BindAndValidate(&data)
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected validation HTTPError 400 for id 0, got %v", err)
+		}
+	})
+}
+
+func TestContext_Bind_ExplicitSources(t *testing.T) {
+	t.Run("BindJSONOnGET", func(t *testing.T) {
+		ctx := newTestContextWithBody("GET", "/test?name=Query", "", []byte(`{"name":"JSONUser","age":30}`))
+		var data BasicBindingStruct
+		if err := ctx.BindJSON(&data); err != nil {
+			t.Fatalf("BindJSON() returned an unexpected error: %v", err)
+		}
+		if data.Name != "JSONUser" || data.Age != 30 {
+			t.Errorf("Expected {JSONUser 30} from body, got %+v", data)
+		}
+	})
+
+	t.Run("BindJSONMalformed", func(t *testing.T) {
+		ctx := newTestContextWithBody("POST", "/test", "text/plain", []byte(`{"name":`))
+		var data BasicBindingStruct
+		var httpErr *xylium.HTTPError
+		if err := ctx.BindJSON(&data); !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Errorf("Expected HTTPError 400 for malformed JSON, got %v", err)
+		}
+	})
+
+	t.Run("BindXML", func(t *testing.T) {
+		ctx := newTestContextWithBody("PUT", "/test", "application/octet-stream", []byte(`<BasicBindingStruct><Name>XMLUser</Name><Age>41</Age></BasicBindingStruct>`))
+		var data BasicBindingStruct
+		if err := ctx.BindXML(&data); err != nil {
+			t.Fatalf("BindXML() returned an unexpected error: %v", err)
+		}
+		if data.Name != "XMLUser" || data.Age != 41 {
+			t.Errorf("Expected {XMLUser 41}, got %+v", data)
+		}
+	})
+
+	t.Run("BindQueryIgnoresBody", func(t *testing.T) {
+		ctx := newTestContextWithBody("POST", "/test?name=QueryUser&age=7", "application/json", []byte(`{"name":"JSONUser"}`))
+		var data BasicBindingStruct
+		if err := ctx.BindQuery(&data); err != nil {
+			t.Fatalf("BindQuery() returned an unexpected error: %v", err)
+		}
+		if data.Name != "QueryUser" || data.Age != 7 {
+			t.Errorf("Expected {QueryUser 7} from query, got %+v", data)
+		}
+	})
+
+	t.Run("BindBodyOnGET", func(t *testing.T) {
+		ctx := newTestContextWithBody("GET", "/test?name=Query", "application/x-www-form-urlencoded", []byte("name=FormUser&age=19"))
+		var data BasicBindingStruct
+		if err := ctx.BindBody(&data); err != nil {
+			t.Fatalf("BindBody() returned an unexpected error: %v", err)
+		}
+		if data.Name != "FormUser" || data.Age != 19 {
+			t.Errorf("Expected {FormUser 19} from body, got %+v", data)
+		}
+	})
+
+	t.Run("BindBodyUnsupportedContentType", func(t *testing.T) {
+		ctx := newTestContextWithBody("POST", "/test", "application/octet-stream", []byte("raw"))
+		var data BasicBindingStruct
+		var httpErr *xylium.HTTPError
+		if err := ctx.BindBody(&data); !errors.As(err, &httpErr) || httpErr.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected HTTPError 415, got %v", err)
+		}
+	})
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		ctx := newTestContextWithBody("GET", "/test", "", nil)
+		var data BasicBindingStruct
+		var httpErr *xylium.HTTPError
+		if err := ctx.BindQuery(data); !errors.As(err, &httpErr) || httpErr.Code != http.StatusInternalServerError {
+			t.Errorf("Expected HTTPError 500 for a non-pointer target, got %v", err)
+		}
+	})
+}
+
+type MultiErrorBindingStruct struct {
+	Page    int    `query:"page" form:"page" json:"page"`
+	Active  bool   `query:"active" form:"active" json:"active"`
+	Name    string `query:"name" form:"name" json:"name"`
+	Address struct {
+		Zip int `json:"zip"`
+	} `json:"address"`
+}
+
+func TestContext_Bind_AllFieldErrors(t *testing.T) {
+	bindingDetails := func(t *testing.T, err error, wantMessage string) map[string]string {
+		t.Helper()
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected HTTPError 400, got %v", err)
+		}
+		msg, ok := httpErr.Message.(xylium.M)
+		if !ok {
+			t.Fatalf("Expected structured message, got %#v", httpErr.Message)
+		}
+		if msg["message"] != wantMessage {
+			t.Errorf("Expected message '%s', got '%v'", wantMessage, msg["message"])
+		}
+		details, ok := msg["details"].(map[string]string)
+		if !ok {
+			t.Fatalf("Expected details map, got %#v", msg["details"])
+		}
+		return details
+	}
+
+	t.Run("Query", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("page", "abc")
+		q.Set("active", "maybe")
+		q.Set("name", "ok")
+		ctx := newTestContextWithQueryForm("GET", "/test", q, nil)
+		var data MultiErrorBindingStruct
+		details := bindingDetails(t, ctx.Bind(&data), "Invalid values in URL query parameters.")
+		if len(details) != 2 || details["page"] == "" || details["active"] == "" {
+			t.Errorf("Expected errors for 'page' and 'active', got %v", details)
+		}
+		if data.Name != "ok" {
+			t.Errorf("Expected valid fields to still be bound, got Name '%s'", data.Name)
+		}
+	})
+
+	t.Run("Form", func(t *testing.T) {
+		f := url.Values{}
+		f.Set("page", "1.5")
+		f.Set("active", "nope")
+		ctx := newTestContextWithQueryForm("POST", "/test", nil, f)
+		var data MultiErrorBindingStruct
+		details := bindingDetails(t, ctx.Bind(&data), "Invalid values in form data from request body.")
+		if len(details) != 2 {
+			t.Errorf("Expected 2 field errors, got %v", details)
+		}
+	})
+
+	t.Run("JSONTypeMismatches", func(t *testing.T) {
+		body := []byte(`{"page": "one", "active": 1, "name": "ok", "address": {"zip": "x"}}`)
+		ctx := newTestContextWithBody("POST", "/test", "application/json", body)
+		var data MultiErrorBindingStruct
+		details := bindingDetails(t, ctx.Bind(&data), "Invalid values in JSON request body.")
+		for _, key := range []string{"page", "active", "address.zip"} {
+			if details[key] == "" {
+				t.Errorf("Expected an error for '%s', got %v", key, details)
+			}
+		}
+		if len(details) != 3 {
+			t.Errorf("Expected exactly 3 field errors, got %v", details)
+		}
+	})
+
+	t.Run("JSONSyntaxErrorStaysSeparate", func(t *testing.T) {
+		ctx := newTestContextWithBody("POST", "/test", "application/json", []byte(`{"page": "one",`))
+		var data MultiErrorBindingStruct
+		var httpErr *xylium.HTTPError
+		if err := ctx.Bind(&data); !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected HTTPError 400, got %v", err)
+		}
+		if httpErr.Message != "Invalid JSON data provided in request body." {
+			t.Errorf("Expected plain syntax error message, got %#v", httpErr.Message)
+		}
+	})
+}
+
+func TestContext_Bind_FormBodies(t *testing.T) {
+	t.Run("URLEncoded", func(t *testing.T) {
+		f := url.Values{}
+		f.Set("name", "FormUser")
+		f.Set("age", "33")
+		q := url.Values{}
+		q.Set("name", "QueryUser")
+		ctx := newTestContextWithQueryForm("POST", "/test", q, f)
+		var data BasicBindingStruct
+		if err := ctx.Bind(&data); err != nil {
+			t.Fatalf("Bind() returned an unexpected error: %v", err)
+		}
+		if data.Name != "FormUser" || data.Age != 33 {
+			t.Errorf("Expected {FormUser 33} from the body, got %+v", data)
+		}
+		if got := ctx.PostForm("name"); got != "FormUser" {
+			t.Errorf("PostForm: expected 'FormUser', got '%s'", got)
+		}
+		if got := ctx.FormValue("name"); got != "QueryUser" {
+			t.Errorf("FormValue: expected the query value 'QueryUser' first, got '%s'", got)
+		}
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		_ = mw.WriteField("name", "MultipartUser")
+		_ = mw.WriteField("age", "27")
+		fw, _ := mw.CreateFormFile("avatar", "a.png")
+		_, _ = fw.Write([]byte("png"))
+		_ = mw.Close()
+
+		ctx := newTestContextWithBody("PUT", "/test", mw.FormDataContentType(), body.Bytes())
+		var data BasicBindingStruct
+		if err := ctx.Bind(&data); err != nil {
+			t.Fatalf("Bind() returned an unexpected error: %v", err)
+		}
+		if data.Name != "MultipartUser" || data.Age != 27 {
+			t.Errorf("Expected {MultipartUser 27} from the multipart body, got %+v", data)
+		}
+		if got := ctx.PostForm("age"); got != "27" {
+			t.Errorf("PostForm: expected '27', got '%s'", got)
+		}
+		if got := ctx.PostFormParams(); len(got) != 2 {
+			t.Errorf("PostFormParams: expected 2 non-file fields, got %v", got)
+		}
+		if _, err := ctx.FormFile("avatar"); err != nil {
+			t.Errorf("FormFile: expected the uploaded file to remain available, got %v", err)
+		}
+	})
+
+	t.Run("MalformedMultipart", func(t *testing.T) {
+		ctx := newTestContextWithBody("POST", "/test", "multipart/form-data; boundary=xyz", []byte("not multipart"))
+		var data BasicBindingStruct
+		var httpErr *xylium.HTTPError
+		if err := ctx.Bind(&data); !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Errorf("Expected HTTPError 400 for a malformed multipart body, got %v", err)
+		}
+	})
+}
+
+func TestContext_Bind_TimeFormat(t *testing.T) {
+	type filterRequest struct {
+		From    time.Time   `query:"from" time_format:"2006-01-02"`
+		To      *time.Time  `query:"to" time_format:"02/01/2006|2006-01-02"`
+		Default time.Time   `query:"default"`
+		Days    []time.Time `query:"day" time_format:"2006-01-02"`
+	}
+
+	t.Run("TaggedLayouts", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("from", "2024-03-01")
+		q.Set("to", "15/03/2024")
+		q.Set("default", "2024-03-01T10:00:00Z")
+		q.Add("day", "2024-03-02")
+		q.Add("day", "2024-03-03")
+		ctx := newTestContextWithQueryForm("GET", "/reports", q, nil)
+		var req filterRequest
+		if err := ctx.Bind(&req); err != nil {
+			t.Fatalf("Bind returned an error: %v", err)
+		}
+		if !req.From.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected From %v", req.From)
+		}
+		if req.To == nil || !req.To.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected To %v", req.To)
+		}
+		if !req.Default.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected Default %v", req.Default)
+		}
+		if len(req.Days) != 2 || req.Days[1].Day() != 3 {
+			t.Errorf("Unexpected Days %v", req.Days)
+		}
+	})
+
+	t.Run("MismatchIsFieldError", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("from", "2024-03-01T10:00:00Z") // RFC3339 tidak diterima karena tag time_format.
+		q.Set("to", "March 15")
+		ctx := newTestContextWithQueryForm("GET", "/reports", q, nil)
+		var req filterRequest
+		var httpErr *xylium.HTTPError
+		if err := ctx.Bind(&req); !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected HTTPError 400, got %v", err)
+		}
+		details := httpErr.Message.(xylium.M)["details"].(map[string]string)
+		if !strings.Contains(details["from"], "expected layout: '2006-01-02'") {
+			t.Errorf("Expected the 'from' error to name the layout, got '%s'", details["from"])
+		}
+		if !strings.Contains(details["to"], "'02/01/2006' or '2006-01-02'") {
+			t.Errorf("Expected the 'to' error to name both layouts, got '%s'", details["to"])
+		}
+	})
+
+	t.Run("DefaultTimeFormatsIsConfigurable", func(t *testing.T) {
+		original := xylium.DefaultTimeFormats
+		xylium.DefaultTimeFormats = []string{time.RFC1123}
+		defer func() { xylium.DefaultTimeFormats = original }()
+
+		q := url.Values{}
+		q.Set("default", "Fri, 01 Mar 2024 10:00:00 UTC")
+		ctx := newTestContextWithQueryForm("GET", "/reports", q, nil)
+		var req filterRequest
+		if err := ctx.Bind(&req); err != nil {
+			t.Fatalf("Bind returned an error: %v", err)
+		}
+		if req.Default.Hour() != 10 {
+			t.Errorf("Unexpected Default %v", req.Default)
+		}
+	})
+}
+
+func TestContext_MustBindAndValidate(t *testing.T) {
+	type signupInput struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	var (
+		handlerErr     error
+		committedAfter bool
+	)
+	router := newRouterWithConfigForTest(nil)
+	handler := func(c *xylium.Context) error {
+		var input signupInput
+		if err := c.MustBindAndValidate(&input); err != nil {
+			handlerErr = err
+			committedAfter = c.ResponseCommitted()
+			return err
+		}
+		return c.String(http.StatusOK, "welcome %s", input.Email)
+	}
+	router.POST("/signup", handler)
+	api := router.Group("/api")
+	api.SetErrorHandler(func(c *xylium.Context) error {
+		return c.String(http.StatusTeapot, "%s", "group error handler")
+	})
+	api.POST("/signup", handler)
+
+	send := func(path, body string) (*xylium.TestResponse, error) {
+		return xylium.NewTestRequest().Method(http.MethodPost).Path(path).
+			Body("application/json", []byte(body)).Do(router)
+	}
+
+	resp, err := send("/signup", `{"email":"not-an-email"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusBadRequest || !strings.Contains(resp.String(), "Validation failed.") {
+		t.Errorf("Expected the validation error response, got %d %s", resp.StatusCode(), resp.String())
+	}
+	if !errors.Is(handlerErr, xylium.ErrResponseWritten) {
+		t.Errorf("Expected the returned error to wrap ErrResponseWritten, got %v", handlerErr)
+	}
+	var httpErr *xylium.HTTPError
+	if !errors.As(handlerErr, &httpErr) || httpErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected the returned error to wrap the original *HTTPError, got %v", handlerErr)
+	}
+	if !committedAfter {
+		t.Error("Expected the response to be committed after MustBindAndValidate failed")
+	}
+
+	// Route dalam group memakai error handler milik group.
+	resp, _ = send("/api/signup", `{"email":""}`)
+	if resp.StatusCode() != http.StatusTeapot || resp.String() != "group error handler" {
+		t.Errorf("Expected the group error handler response, got %d %s", resp.StatusCode(), resp.String())
+	}
+
+	// Sukses: tidak ada response yang ditulis oleh MustBindAndValidate.
+	resp, _ = send("/signup", `{"email":"dev@example.com"}`)
+	if resp.StatusCode() != http.StatusOK || resp.String() != "welcome dev@example.com" {
+		t.Errorf("Expected success response, got %d %s", resp.StatusCode(), resp.String())
+	}
+
+	// ShouldBindAndValidate tidak menulis response.
+	ctx := newTestContextWithBody(http.MethodPost, "/signup", "application/json", []byte(`{"email":"x"}`))
+	var input signupInput
+	if err := ctx.ShouldBindAndValidate(&input); err == nil || errors.Is(err, xylium.ErrResponseWritten) {
+		t.Errorf("Expected a plain validation error, got %v", err)
+	}
+	if ctx.ResponseCommitted() {
+		t.Error("Expected ShouldBindAndValidate not to write a response")
+	}
+}
+
+func TestContext_Bind_OversizedBody(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.MaxRequestBodySize = 32
+	})
+	newStreamedContext := func(body io.Reader, contentLength int) *xylium.Context {
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.SetMethod(http.MethodPost)
+		fasthttpCtx.Request.SetRequestURI("/items")
+		fasthttpCtx.Request.Header.SetContentType("application/json")
+		fasthttpCtx.Request.SetBodyStream(body, contentLength)
+		ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+		ctx.SetRouterForTesting(router)
+		return ctx
+	}
+	bindStatus := func(ctx *xylium.Context) int {
+		var p payload
+		err := ctx.Bind(&p)
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) {
+			t.Fatalf("Expected *HTTPError, got %v", err)
+		}
+		return httpErr.Code
+	}
+
+	large := `{"name":"` + strings.Repeat("x", 64) + `"}`
+	// Body stream tanpa Content-Length (chunked) yang melebihi batas.
+	if code := bindStatus(newStreamedContext(strings.NewReader(large), -1)); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for an oversized streamed body, got %d", code)
+	}
+	// Content-Length yang dideklarasikan melebihi batas.
+	if code := bindStatus(newStreamedContext(strings.NewReader(large), len(large))); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for an oversized Content-Length, got %d", code)
+	}
+	// Body terpotong karena koneksi terputus bukan JSON yang salah format, tetapi tetap 400.
+	truncated := io.MultiReader(strings.NewReader(`{"name":`), iotest.ErrReader(io.ErrUnexpectedEOF))
+	ctx := newStreamedContext(truncated, -1)
+	var p payload
+	err := ctx.Bind(&p)
+	var httpErr *xylium.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected a 400 wrapping the read error, got %v", err)
+	}
+
+	// Body dalam batas tetap di-bind dan tersedia lewat c.Body().
+	small := `{"name":"ok"}`
+	ctx = newStreamedContext(strings.NewReader(small), -1)
+	if err := ctx.Bind(&p); err != nil || p.Name != "ok" {
+		t.Fatalf("Expected small streamed body to bind, got %+v, %v", p, err)
+	}
+	if string(ctx.Body()) != small {
+		t.Errorf("Expected c.Body() to return the buffered body, got %q", ctx.Body())
+	}
+
+	// JSON yang salah format tetap menghasilkan 400.
+	if code := bindStatus(newStreamedContext(strings.NewReader(`{"name":`), -1)); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed JSON, got %d", code)
+	}
+}
+
+func TestContext_Bind_DisallowUnknownFields(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type payload struct {
+		Name    string  `json:"name"`
+		Age     int     `json:"age"`
+		Address address `json:"address"`
+	}
+	newJSONContext := func(router *xylium.Router, body string) *xylium.Context {
+		ctx := newTestContextWithBody("POST", "/users", "application/json", []byte(body))
+		ctx.SetRouterForTesting(router)
+		return ctx
+	}
+	strict := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.DisallowUnknownFields = true })
+	// detailsOf mengembalikan detail error field dari HTTPError 400.
+	detailsOf := func(t *testing.T, err error) map[string]string {
+		t.Helper()
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected a 400 HTTPError, got %v", err)
+		}
+		msg, _ := httpErr.Message.(xylium.M)
+		details, _ := msg["details"].(map[string]string)
+		return details
+	}
+
+	// Default: field yang tidak dikenal diabaikan.
+	var p payload
+	lenient := newRouterWithConfigForTest(nil)
+	if err := newJSONContext(lenient, `{"name":"Ann","emial":"x"}`).Bind(&p); err != nil || p.Name != "Ann" {
+		t.Fatalf("Expected lenient binding to ignore unknown fields, got %+v, %v", p, err)
+	}
+
+	p = payload{}
+	if err := newJSONContext(strict, `{"name":"Ann","age":30,"address":{"city":"Oslo"}}`).Bind(&p); err != nil || p.Address.City != "Oslo" {
+		t.Fatalf("Expected strict binding of known fields, got %+v, %v", p, err)
+	}
+	if details := detailsOf(t, newJSONContext(strict, `{"name":"Ann","emial":"x"}`).Bind(&payload{})); details["emial"] != "unknown field" {
+		t.Errorf("Expected 'emial' reported as unknown field, got %v", details)
+	}
+	if details := detailsOf(t, newJSONContext(strict, `{"address":{"zip":"0150"}}`).Bind(&payload{})); details["zip"] != "unknown field" {
+		t.Errorf("Expected nested 'zip' reported as unknown field, got %v", details)
+	}
+	// Kesalahan tipe tetap dilaporkan per field, dan data setelah nilai JSON ditolak.
+	if details := detailsOf(t, newJSONContext(strict, `{"age":"old"}`).Bind(&payload{})); details["age"] == "" {
+		t.Errorf("Expected a type error for 'age', got %v", details)
+	}
+	detailsOf(t, newJSONContext(strict, `{"name":"Ann"} {"name":"Bob"}`).Bind(&payload{}))
+	// Target map menerima semua key.
+	var m map[string]interface{}
+	if err := newJSONContext(strict, `{"anything":1}`).Bind(&m); err != nil || m["anything"] != float64(1) {
+		t.Errorf("Expected a map target to accept any key, got %v, %v", m, err)
+	}
+}
+
+func TestContext_BindAndValidate_LocalizedMessages(t *testing.T) {
+	type signupInput struct {
+		Email string `json:"email" validate:"required"`
+	}
+
+	uni := ut.New(en.New(), en.New(), id.New())
+	enTrans, _ := uni.GetTranslator("en")
+	idTrans, _ := uni.GetTranslator("id")
+	v := validator.New()
+	if err := en_translations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		t.Fatal(err)
+	}
+	if err := id_translations.RegisterDefaultTranslations(v, idTrans); err != nil {
+		t.Fatal(err)
+	}
+	previous := xylium.GetValidator()
+	xylium.SetCustomValidator(v)
+	xylium.SetValidationTranslators(map[string]ut.Translator{"en": enTrans, "id": idTrans}, "en")
+	t.Cleanup(func() {
+		xylium.SetCustomValidator(previous)
+		xylium.SetValidationTranslators(nil, "")
+	})
+
+	detailFor := func(acceptLanguage string) string {
+		ctx := newTestContextWithBody(http.MethodPost, "/signup", "application/json", []byte(`{}`))
+		if acceptLanguage != "" {
+			ctx.Ctx.Request.Header.Set("Accept-Language", acceptLanguage)
+		}
+		var input signupInput
+		err := ctx.BindAndValidate(&input)
+		var httpErr *xylium.HTTPError
+		if !errors.As(err, &httpErr) {
+			t.Fatalf("Expected *HTTPError, got %v", err)
+		}
+		details := httpErr.Message.(xylium.M)["details"].(map[string]string)
+		return details["Email"]
+	}
+
+	tests := map[string]string{
+		"":                        "Email is a required field", // Tanpa header: locale default.
+		"id-ID,id;q=0.9,en;q=0.8": "Email wajib diisi",
+		"en-GB":                   "Email is a required field",
+		"ja":                      "Email is a required field", // Tidak cocok: locale default.
+	}
+	for header, want := range tests {
+		if got := detailFor(header); got != want {
+			t.Errorf("Accept-Language %q: expected %q, got %q", header, want, got)
+		}
+	}
+
+	// Tanpa translator, pesan bawaan dipakai kembali.
+	xylium.SetValidationTranslators(nil, "")
+	if got := detailFor("id"); got != "validation failed on tag 'required'" {
+		t.Errorf("Expected the default message without translators, got %q", got)
+	}
+}
+
+func TestSetValidationTranslators_PanicsWithoutDefault(t *testing.T) {
+	trans, _ := ut.New(en.New()).GetTranslator("en")
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when the default locale has no translator")
+		}
+	}()
+	xylium.SetValidationTranslators(map[string]ut.Translator{"en": trans}, "id")
+}
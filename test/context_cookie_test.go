@@ -0,0 +1,94 @@
+// File: /test/context_cookie_test.go
+package xylium_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+func newCookieTestContext(t *testing.T) (*xylium.Context, *fasthttp.RequestCtx) {
+	t.Helper()
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+	return ctx, &fasthttpCtx
+}
+
+func TestContext_Cookies(t *testing.T) {
+	ctx, fasthttpCtx := newCookieTestContext(t)
+	fasthttpCtx.Request.Header.Set("Cookie", "session=abc; theme=dark; session=shadowed")
+
+	cookies := ctx.Cookies()
+	if len(cookies) != 2 || cookies["theme"] != "dark" {
+		t.Errorf("Unexpected cookies: %v", cookies)
+	}
+	// Cookie dengan nama ganda: yang pertama dipakai, sama seperti c.Cookie.
+	if cookies["session"] != "abc" || ctx.Cookie("session") != "abc" {
+		t.Errorf("Expected first 'session' cookie 'abc', got %q (Cookie: %q)", cookies["session"], ctx.Cookie("session"))
+	}
+
+	empty, _ := newCookieTestContext(t)
+	if cookies := empty.Cookies(); cookies == nil || len(cookies) != 0 {
+		t.Errorf("Expected empty non-nil map without cookies, got %v", cookies)
+	}
+}
+
+func TestContext_ClearCookie(t *testing.T) {
+	const expired = "expires=Tue, 10 Nov 2009 23:00:00 GMT"
+
+	tests := []struct {
+		name    string
+		cookie  string
+		opts    []xylium.CookieOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "Defaults",
+			cookie:  "session",
+			want:    []string{"session=;", expired, "path=/", "HttpOnly"},
+			notWant: []string{"domain=", "secure", "SameSite"},
+		},
+		{
+			name:   "MatchingPathAndDomain",
+			cookie: "prefs",
+			opts:   []xylium.CookieOptions{{Path: "/app", Domain: "example.com", SameSite: fasthttp.CookieSameSiteLaxMode}},
+			want:   []string{"prefs=;", expired, "domain=example.com", "path=/app", "SameSite=Lax"},
+		},
+		{
+			name:   "SecurePrefixForcesSecure",
+			cookie: "__Host-token",
+			want:   []string{"__Host-token=;", expired, "path=/", "secure"},
+		},
+		{
+			name:   "ExplicitSecure",
+			cookie: "id",
+			opts:   []xylium.CookieOptions{{Secure: true}},
+			want:   []string{expired, "secure"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, fasthttpCtx := newCookieTestContext(t)
+			ctx.ClearCookie(tt.cookie, tt.opts...)
+
+			header := string(fasthttpCtx.Response.Header.PeekCookie(tt.cookie))
+			if header == "" {
+				t.Fatalf("Expected a Set-Cookie header for %q", tt.cookie)
+			}
+			for _, part := range tt.want {
+				if !strings.Contains(header, part) {
+					t.Errorf("Expected Set-Cookie %q to contain %q", header, part)
+				}
+			}
+			for _, part := range tt.notWant {
+				if strings.Contains(strings.ToLower(header), strings.ToLower(part)) {
+					t.Errorf("Expected Set-Cookie %q not to contain %q", header, part)
+				}
+			}
+		})
+	}
+}
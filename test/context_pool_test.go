@@ -0,0 +1,63 @@
+// File: /test/context_pool_test.go
+package xylium_test
+
+import (
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+func TestOnContextRelease(t *testing.T) {
+	defer xylium.ResetContextReleaseHooksForTesting()
+
+	var (
+		order        []string
+		seenInHook   interface{}
+		released     *xylium.Context
+		handlerError = xylium.NewHTTPError(xylium.StatusTeapot, "teapot")
+	)
+	xylium.OnContextRelease(func(c *xylium.Context) {
+		order = append(order, "first")
+		seenInHook, _ = c.Get("tenant")
+		released = c
+	})
+	xylium.OnContextRelease(func(c *xylium.Context) {
+		order = append(order, "second")
+		panic("hook failure") // Harus dipulihkan tanpa mengganggu request.
+	})
+	xylium.OnContextRelease(func(c *xylium.Context) {
+		order = append(order, "third")
+	})
+
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/tenant", func(c *xylium.Context) error {
+		c.Set("tenant", "acme")
+		return handlerError
+	})
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/tenant")
+	if ctx.Response.StatusCode() != xylium.StatusTeapot {
+		t.Errorf("Expected the error response to be sent before release, got %d", ctx.Response.StatusCode())
+	}
+	if len(order) != 3 || order[0] != "first" || order[1] != "second" || order[2] != "third" {
+		t.Errorf("Expected hooks to run in registration order despite a panic, got %v", order)
+	}
+	if seenInHook != "acme" {
+		t.Errorf("Expected hook to see the store value 'acme', got %v", seenInHook)
+	}
+	if released == nil {
+		t.Fatal("Expected the hook to receive the context")
+	}
+	if store := released.GetContextStoreForTesting(); len(store) != 0 {
+		t.Errorf("Expected the store to be cleared after release, got %v", store)
+	}
+}
+
+func TestOnContextRelease_NilHookPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected OnContextRelease(nil) to panic")
+		}
+	}()
+	xylium.OnContextRelease(nil)
+}
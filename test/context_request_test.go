@@ -2,10 +2,16 @@ package xylium_test
 
 import (
 	// "fmt" // Dihapus karena tidak ada penggunaan langsung fmt.xxx
+	"bytes"
+	"io"
+	"mime/multipart"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/valyala/fasthttp"
+	"golang.org/x/text/language"
 	// Ganti path ini sesuai dengan module path Anda
 	"github.com/arwahdevops/xylium-core/src/xylium"
 	// "github.com/stretchr/testify/assert" // Opsional
@@ -234,3 +240,240 @@ func TestContext_QueryParam(t *testing.T) {
 		})
 	}
 }
+
+func TestContext_TypedParams(t *testing.T) {
+	ctx := newTestContextWithParams(map[string]string{
+		"flag": "yes", "bad": "maybe", "price": "12.5", "day": "2024-03-01",
+	})
+	fallbackTime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if v, err := ctx.ParamBool("flag"); err != nil || !v {
+		t.Errorf("ParamBool(flag): expected true, got %v (err: %v)", v, err)
+	}
+	if _, err := ctx.ParamBool("bad"); err == nil || !strings.Contains(err.Error(), "not a valid boolean") {
+		t.Errorf("ParamBool(bad): expected a parse error, got %v", err)
+	}
+	if v := ctx.ParamBoolDefault("missing", true); !v {
+		t.Error("ParamBoolDefault(missing): expected the default true")
+	}
+	if v, err := ctx.ParamFloat("price"); err != nil || v != 12.5 {
+		t.Errorf("ParamFloat(price): expected 12.5, got %v (err: %v)", v, err)
+	}
+	if v := ctx.ParamFloatDefault("bad", 1.5); v != 1.5 {
+		t.Errorf("ParamFloatDefault(bad): expected the default 1.5, got %v", v)
+	}
+	if v, err := ctx.ParamTime("day", "2006-01-02"); err != nil || !v.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParamTime(day): expected 2024-03-01, got %v (err: %v)", v, err)
+	}
+	if _, err := ctx.ParamTime("missing", time.RFC3339); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("ParamTime(missing): expected a not found error, got %v", err)
+	}
+	if v := ctx.ParamTimeDefault("day", time.RFC3339, fallbackTime); !v.Equal(fallbackTime) {
+		t.Errorf("ParamTimeDefault(day) with a mismatched layout: expected the default, got %v", v)
+	}
+}
+
+func TestContext_TypedQueryParams(t *testing.T) {
+	ctx := newTestContextWithQuery(map[string]string{
+		"active": "ON", "off": "0", "bad": "abc", "ratio": "0.25", "empty": "", "since": "2024-03-01T10:00:00Z",
+	})
+	fallbackTime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"BoolAlternative", ctx.QueryParamBoolDefault("active", false), true},
+		{"BoolNumeric", ctx.QueryParamBoolDefault("off", true), false},
+		{"BoolInvalidUsesDefault", ctx.QueryParamBoolDefault("bad", true), true},
+		{"BoolEmptyUsesDefault", ctx.QueryParamBoolDefault("empty", true), true},
+		{"Float", ctx.QueryParamFloatDefault("ratio", 1), 0.25},
+		{"FloatMissingUsesDefault", ctx.QueryParamFloatDefault("missing", 1), 1.0},
+		{"FloatInvalidUsesDefault", ctx.QueryParamFloatDefault("bad", 2), 2.0},
+		{"Time", ctx.QueryParamTimeDefault("since", time.RFC3339, fallbackTime), time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"TimeInvalidUsesDefault", ctx.QueryParamTimeDefault("bad", time.RFC3339, fallbackTime), fallbackTime},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("Expected %v, got %v", tc.want, tc.got)
+			}
+		})
+	}
+
+	t.Run("StrictVariantsReturnErrors", func(t *testing.T) {
+		if _, err := ctx.QueryParamBool("bad"); err == nil || !strings.Contains(err.Error(), "not a valid boolean") {
+			t.Errorf("QueryParamBool(bad): expected a parse error, got %v", err)
+		}
+		if _, err := ctx.QueryParamFloat("empty"); err == nil || !strings.Contains(err.Error(), "not found or is empty") {
+			t.Errorf("QueryParamFloat(empty): expected a not found error, got %v", err)
+		}
+		if _, err := ctx.QueryParamTime("since", "2006-01-02"); err == nil || !strings.Contains(err.Error(), "layout '2006-01-02'") {
+			t.Errorf("QueryParamTime(since): expected a layout error, got %v", err)
+		}
+	})
+}
+
+func TestContext_QueryParamArray(t *testing.T) {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.SetRequestURI("/filter?tag=go&tag=web&status=active")
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+
+	if got := ctx.QueryParamArray("tag"); len(got) != 2 || got[0] != "go" || got[1] != "web" {
+		t.Errorf("QueryParamArray(tag): expected [go web], got %v", got)
+	}
+	if got := ctx.QueryParamArray("status"); len(got) != 1 || got[0] != "active" {
+		t.Errorf("QueryParamArray(status): expected [active], got %v", got)
+	}
+	if got := ctx.QueryParamArray("missing"); got != nil {
+		t.Errorf("QueryParamArray(missing): expected nil, got %v", got)
+	}
+}
+
+func TestContext_BodyReader(t *testing.T) {
+	payload := strings.Repeat("chunk-", 1000)
+
+	newCtx := func(streamed bool) *xylium.Context {
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.SetMethod("POST")
+		if streamed {
+			fasthttpCtx.Request.SetBodyStream(strings.NewReader(payload), -1)
+		} else {
+			fasthttpCtx.Request.SetBodyString(payload)
+		}
+		return xylium.NewContextForTest(nil, &fasthttpCtx)
+	}
+
+	for _, streamed := range []bool{false, true} {
+		ctx := newCtx(streamed)
+		data, err := io.ReadAll(ctx.BodyReader())
+		if err != nil || string(data) != payload {
+			t.Errorf("streamed=%v: BodyReader returned %d bytes (err: %v), want %d", streamed, len(data), err, len(payload))
+		}
+	}
+
+	// Body() terlebih dahulu membuffer stream; BodyReader lalu membaca salinan buffer.
+	ctx := newCtx(true)
+	if got := string(ctx.Body()); got != payload {
+		t.Fatalf("Body(): expected full payload, got %d bytes", len(got))
+	}
+	data, err := io.ReadAll(ctx.BodyReader())
+	if err != nil || string(data) != payload {
+		t.Errorf("BodyReader after Body(): expected full payload, got %d bytes (err: %v)", len(data), err)
+	}
+
+	// Membaca sebagian stream: Body() hanya melihat sisanya.
+	ctx = newCtx(true)
+	prefix := make([]byte, 6)
+	if _, err := io.ReadFull(ctx.BodyReader(), prefix); err != nil || string(prefix) != "chunk-" {
+		t.Fatalf("BodyReader partial read: got %q (err: %v)", prefix, err)
+	}
+	if got := string(ctx.Body()); got != payload[6:] {
+		t.Errorf("Body() after partial stream read: expected remaining %d bytes, got %d", len(payload)-6, len(got))
+	}
+}
+
+func newTestContextWithAcceptLanguage(header string) *xylium.Context {
+	var fasthttpCtx fasthttp.RequestCtx
+	if header != "" {
+		fasthttpCtx.Request.Header.Set("Accept-Language", header)
+	}
+	return xylium.NewContextForTest(nil, &fasthttpCtx)
+}
+
+func TestContext_AcceptsLanguages(t *testing.T) {
+	offers := []string{"en", "id", "fr-CA"}
+	tests := []struct {
+		header   string
+		offers   []string
+		expected string
+	}{
+		{"", offers, "en"}, // Tanpa header: tawaran pertama.
+		{"id-ID,id;q=0.9,en;q=0.8", offers, "id"},
+		{"fr;q=0.5, EN;q=0.4", offers, "fr-CA"}, // Range "fr" mencakup "fr-CA".
+		{"de, en-US;q=0.7", offers, "en"},       // "en-US" dipotong menjadi "en".
+		{"de, ja", offers, ""},
+		{"*, en;q=0", offers, "id"},
+		{"en;q=0.2, id;q=0.8", offers, "id"},
+		{"fr-CA;q=0.9, fr;q=0.9", offers, "fr-CA"},
+		{"en", nil, ""},
+	}
+	for _, tt := range tests {
+		c := newTestContextWithAcceptLanguage(tt.header)
+		if got := c.AcceptsLanguages(tt.offers...); got != tt.expected {
+			t.Errorf("AcceptsLanguages(%v) with %q: expected %q, got %q", tt.offers, tt.header, tt.expected, got)
+		}
+	}
+}
+
+func TestContext_PreferredLanguage(t *testing.T) {
+	supported := []language.Tag{language.English, language.Indonesian, language.BrazilianPortuguese}
+	tests := []struct {
+		header   string
+		expected language.Tag
+	}{
+		{"", language.English},
+		{"id-ID,id;q=0.9,en;q=0.8", language.Indonesian},
+		{"pt-BR;q=0.9, en;q=0.5", language.BrazilianPortuguese},
+		{"ja", language.English},
+		{"not a language tag;;", language.English},
+	}
+	for _, tt := range tests {
+		c := newTestContextWithAcceptLanguage(tt.header)
+		if got := c.PreferredLanguage(supported); got != tt.expected {
+			t.Errorf("PreferredLanguage with %q: expected %s, got %s", tt.header, tt.expected, got)
+		}
+	}
+	if got := newTestContextWithAcceptLanguage("en").PreferredLanguage(nil); got != language.Und {
+		t.Errorf("Expected language.Und without supported tags, got %s", got)
+	}
+}
+
+func TestContext_MultipartMaxMemory(t *testing.T) {
+	cfg := xylium.DefaultServerConfig()
+	cfg.MultipartMaxMemory = 1024
+	router := xylium.NewRouterForTesting(xylium.RouterTestOptions{Config: cfg})
+
+	var tempPath string
+	router.POST("/upload", func(c *xylium.Context) error {
+		if got := c.FormValue("title"); got != "report" {
+			t.Errorf("FormValue(title): expected 'report', got %q", got)
+		}
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return err
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		osFile, ok := f.(*os.File)
+		if !ok {
+			t.Errorf("expected a file larger than MultipartMaxMemory to be spilled to disk, got %T", f)
+			return c.NoContent(xylium.StatusOK)
+		}
+		tempPath = osFile.Name()
+		return c.NoContent(xylium.StatusOK)
+	})
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("title", "report")
+	fw, _ := mw.CreateFormFile("file", "big.bin")
+	_, _ = fw.Write(bytes.Repeat([]byte("x"), 8*1024))
+	_ = mw.Close()
+
+	resp, err := xylium.NewTestRequest().Method("POST").Path("/upload").
+		Body(mw.FormDataContentType(), body.Bytes()).Do(router)
+	if err != nil || resp.StatusCode() != xylium.StatusOK {
+		t.Fatalf("upload failed: status %v, err %v, body %s", resp.StatusCode(), err, resp.Body())
+	}
+	if tempPath == "" {
+		t.Fatal("expected a temporary file for the upload")
+	}
+	if _, err := os.Stat(tempPath); !os.IsNotExist(err) {
+		t.Errorf("expected temporary file %s to be removed on release, stat err: %v", tempPath, err)
+	}
+}
@@ -2,7 +2,9 @@
 package xylium_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -17,6 +19,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/arwahdevops/xylium-core/src/xylium"
 	"github.com/valyala/fasthttp"
@@ -382,6 +385,92 @@ func TestContext_HTML(t *testing.T) {
 	})
 }
 
+// mockTypedRenderer adalah renderer dengan Content-Type sendiri (misalnya, CSV).
+type mockTypedRenderer struct {
+	mockHTMLRenderer
+	contentType string
+}
+
+func (m *mockTypedRenderer) ContentType() string { return m.contentType }
+
+func TestContext_Render(t *testing.T) {
+	var fasthttpCtx fasthttp.RequestCtx
+	router := xylium.NewRouterForTesting()
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(router)
+
+	writeName := func(prefix string) func(w io.Writer, name string, data interface{}, c *xylium.Context) error {
+		return func(w io.Writer, name string, data interface{}, c *xylium.Context) error {
+			_, err := fmt.Fprintf(w, "%s:%s:%v", prefix, name, data)
+			return err
+		}
+	}
+	router.HTMLRenderer = &mockHTMLRenderer{RenderFunc: writeName("default-html")}
+	router.AddRenderer("csv", &mockTypedRenderer{
+		mockHTMLRenderer: mockHTMLRenderer{RenderFunc: writeName("csv")},
+		contentType:      "text/csv; charset=utf-8",
+	})
+	router.AddRenderer("plain", &mockHTMLRenderer{RenderFunc: func(w io.Writer, name string, data interface{}, c *xylium.Context) error {
+		c.SetContentType("text/x-custom")
+		_, err := io.WriteString(w, "plain")
+		return err
+	}})
+
+	tests := []struct {
+		renderer, wantType, wantBody string
+	}{
+		{"html", "text/html; charset=utf-8", "default-html:page:1"},
+		{"csv", "text/csv; charset=utf-8", "csv:page:1"},
+		{"plain", "text/x-custom", "plain"},
+	}
+	for _, tt := range tests {
+		fasthttpCtx.Response.Reset()
+		if err := ctx.Render(http.StatusCreated, tt.renderer, "page", 1); err != nil {
+			t.Fatalf("Render(%q) returned an unexpected error: %v", tt.renderer, err)
+		}
+		if fasthttpCtx.Response.StatusCode() != http.StatusCreated {
+			t.Errorf("Render(%q): expected status %d, got %d", tt.renderer, http.StatusCreated, fasthttpCtx.Response.StatusCode())
+		}
+		if ct := string(fasthttpCtx.Response.Header.ContentType()); ct != tt.wantType {
+			t.Errorf("Render(%q): expected Content-Type %q, got %q", tt.renderer, tt.wantType, ct)
+		}
+		if body := string(fasthttpCtx.Response.Body()); body != tt.wantBody {
+			t.Errorf("Render(%q): expected body %q, got %q", tt.renderer, tt.wantBody, body)
+		}
+	}
+
+	// Renderer "html" yang didaftarkan menggantikan HTMLRenderer, juga untuk c.HTML.
+	router.AddRenderer(xylium.RendererHTML, &mockHTMLRenderer{RenderFunc: writeName("named-html")})
+	fasthttpCtx.Response.Reset()
+	if err := ctx.HTML(http.StatusOK, "home", nil); err != nil {
+		t.Fatalf("HTML() returned an unexpected error: %v", err)
+	}
+	if body := string(fasthttpCtx.Response.Body()); body != "named-html:home:<nil>" {
+		t.Errorf("Expected c.HTML to use the registered 'html' renderer, got %q", body)
+	}
+
+	fasthttpCtx.Response.Reset()
+	err := ctx.Render(http.StatusOK, "pdf", "invoice", nil)
+	var httpErr *xylium.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected HTTPError 500 for an unknown renderer, got %v", err)
+	}
+
+	for name, fn := range map[string]func(){
+		"empty name":   func() { router.AddRenderer("", &mockHTMLRenderer{}) },
+		"nil renderer": func() { router.AddRenderer("pdf", nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("AddRenderer with %s: expected panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
 func TestContext_File(t *testing.T) {
 	ctx, fasthttpCtx, _ := getGlobalTestAssetsForResponse()
 	tempDir := t.TempDir()
@@ -732,3 +821,600 @@ func TestContext_ResponseCommitted(t *testing.T) {
 		checkCommitted("After Hijack (fasthttpCtx.Hijacked() is false)", false)
 	}
 }
+
+// closeTrackingReaderForTest mencatat apakah reader sudah ditutup.
+type closeTrackingReaderForTest struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeTrackingReaderForTest) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestContext_Stream(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	tracked := &closeTrackingReaderForTest{Reader: strings.NewReader("known")}
+	router.GET("/known", func(c *xylium.Context) error {
+		return c.Status(xylium.StatusAccepted).Stream(tracked, 5, "text/plain; charset=utf-8")
+	})
+	router.GET("/chunked", func(c *xylium.Context) error {
+		return c.Stream(strings.NewReader(strings.Repeat("chunk", 1000)), -1, "")
+	})
+	router.GET("/report", func(c *xylium.Context) error {
+		return c.AttachmentReader(strings.NewReader("%PDF-1.7"), "report.pdf")
+	})
+	router.GET("/nil", func(c *xylium.Context) error {
+		return c.Stream(nil, 0, "")
+	})
+
+	t.Run("KnownSize", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/known", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusAccepted || resp.String() != "known" {
+			t.Errorf("Expected 202 'known', got %d '%s'", resp.StatusCode(), resp.String())
+		}
+		if got := resp.Header().Get("Content-Length"); got != "5" {
+			t.Errorf("Expected Content-Length '5', got '%s'", got)
+		}
+		if !tracked.closed {
+			t.Error("Expected the reader to be closed after the response was sent")
+		}
+	})
+
+	t.Run("UnknownSizeIsChunked", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/chunked", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if len(resp.Body()) != 5000 {
+			t.Errorf("Expected a 5000 byte body, got %d", len(resp.Body()))
+		}
+		if got := resp.Header().Get("Content-Type"); got != "application/octet-stream" {
+			t.Errorf("Expected default Content-Type 'application/octet-stream', got '%s'", got)
+		}
+		if got := resp.Header().Get("Transfer-Encoding"); got != "chunked" {
+			t.Errorf("Expected Transfer-Encoding 'chunked', got '%s'", got)
+		}
+	})
+
+	t.Run("AttachmentReader", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/report", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if got := resp.Header().Get("Content-Disposition"); got != `attachment; filename="report.pdf"` {
+			t.Errorf("Unexpected Content-Disposition '%s'", got)
+		}
+		if got := resp.Header().Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("Expected Content-Type 'application/pdf', got '%s'", got)
+		}
+		if resp.String() != "%PDF-1.7" {
+			t.Errorf("Unexpected body '%s'", resp.String())
+		}
+	})
+
+	t.Run("NilReader", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/nil", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusInternalServerError {
+			t.Errorf("Expected status 500 for a nil reader, got %d", resp.StatusCode())
+		}
+	})
+}
+
+func TestContext_Range(t *testing.T) {
+	const content = "0123456789abcdefghij" // 20 byte.
+	filePath := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/file", func(c *xylium.Context) error {
+		return c.File(filePath)
+	})
+	router.GET("/stream", func(c *xylium.Context) error {
+		return c.Stream(strings.NewReader(content), int64(len(content)), "video/mp4")
+	})
+	router.GET("/download", func(c *xylium.Context) error {
+		return c.AttachmentReader(bytes.NewReader([]byte(content)), "video.mp4")
+	})
+
+	testCases := []struct {
+		name             string
+		byteRange        string
+		wantStatus       int
+		wantBody         string
+		wantContentRange string
+	}{
+		{"NoRange", "", xylium.StatusOK, content, ""},
+		{"Bounded", "bytes=2-5", xylium.StatusPartialContent, "2345", "bytes 2-5/20"},
+		{"OpenEnded", "bytes=15-", xylium.StatusPartialContent, "fghij", "bytes 15-19/20"},
+		{"Suffix", "bytes=-3", xylium.StatusPartialContent, "hij", "bytes 17-19/20"},
+		{"EndBeyondSize", "bytes=18-100", xylium.StatusPartialContent, "ij", "bytes 18-19/20"},
+		{"MultiRangeServesWholeBody", "bytes=0-1,4-5", xylium.StatusOK, content, ""},
+		{"Unsatisfiable", "bytes=50-", xylium.StatusRequestedRangeNotSatisfiable, "", ""},
+	}
+
+	for _, path := range []string{"/file", "/stream", "/download"} {
+		for _, tc := range testCases {
+			t.Run(strings.TrimPrefix(path, "/")+"/"+tc.name, func(t *testing.T) {
+				req := xylium.NewTestRequest().Path(path)
+				if tc.byteRange != "" {
+					req.Header("Range", tc.byteRange)
+				}
+				resp, err := req.Do(router)
+				if err != nil {
+					t.Fatalf("Do returned an error: %v", err)
+				}
+				if resp.StatusCode() != tc.wantStatus {
+					t.Fatalf("Expected status %d, got %d (body: %s)", tc.wantStatus, resp.StatusCode(), resp.Body())
+				}
+				if tc.wantBody != "" && resp.String() != tc.wantBody {
+					t.Errorf("Expected body '%s', got '%s'", tc.wantBody, resp.String())
+				}
+				if got := resp.Header().Get("Content-Range"); got != tc.wantContentRange && tc.wantStatus != xylium.StatusRequestedRangeNotSatisfiable {
+					t.Errorf("Expected Content-Range '%s', got '%s'", tc.wantContentRange, got)
+				}
+				if tc.wantStatus != xylium.StatusRequestedRangeNotSatisfiable && resp.Header().Get("Accept-Ranges") != "bytes" {
+					t.Errorf("Expected Accept-Ranges 'bytes', got '%s'", resp.Header().Get("Accept-Ranges"))
+				}
+			})
+		}
+	}
+
+	t.Run("MultiRangeHeaderRestoredForMiddleware", func(t *testing.T) {
+		var seenRange string
+		restoreRouter := newRouterWithConfigForTest(nil)
+		restoreRouter.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+			return func(c *xylium.Context) error {
+				err := next(c)
+				seenRange = c.Header("Range") // Dibaca setelah handler, seperti middleware logging.
+				return err
+			}
+		})
+		restoreRouter.GET("/file", func(c *xylium.Context) error {
+			return c.File(filePath)
+		})
+		resp, err := xylium.NewTestRequest().Path("/file").Header("Range", "bytes=0-1,4-5").Do(restoreRouter)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode())
+		}
+		if seenRange != "bytes=0-1,4-5" {
+			t.Errorf("Expected middleware to see the original Range header, got '%s'", seenRange)
+		}
+	})
+
+	t.Run("NonSeekableReaderIgnoresRange", func(t *testing.T) {
+		router.GET("/pipe", func(c *xylium.Context) error {
+			return c.Stream(io.MultiReader(strings.NewReader(content)), int64(len(content)), "")
+		})
+		resp, err := xylium.NewTestRequest().Path("/pipe").Header("Range", "bytes=2-5").Do(router)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusOK || resp.String() != content {
+			t.Errorf("Expected the whole body with 200, got %d '%s'", resp.StatusCode(), resp.String())
+		}
+		if got := resp.Header().Get("Accept-Ranges"); got != "" {
+			t.Errorf("Expected no Accept-Ranges for a non-seekable reader, got '%s'", got)
+		}
+	})
+}
+
+func TestContext_SetTrailer(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/grpc", func(c *xylium.Context) error {
+		if err := c.SetTrailer("Grpc-Status", "0"); err != nil {
+			return err
+		}
+		if err := c.Stream(strings.NewReader("payload"), -1, "application/grpc-web"); err != nil {
+			return err
+		}
+		// Trailer tetap bisa disetel setelah stream chunked dimulai.
+		if err := c.SetTrailer("Grpc-Message", "OK"); err != nil {
+			return err
+		}
+		return c.SetTrailer("grpc-status", "2") // Mengganti nilai trailer yang sudah dideklarasikan.
+	})
+	router.GET("/buffered-late", func(c *xylium.Context) error {
+		if err := c.String(xylium.StatusOK, "done"); err != nil {
+			return err
+		}
+		err := c.SetTrailer("Grpc-Status", "0")
+		c.Set("trailerErr", err)
+		return nil
+	})
+	router.GET("/buffered-early", func(c *xylium.Context) error {
+		if err := c.SetTrailer("Grpc-Status", "0"); err != nil {
+			return err
+		}
+		return c.String(xylium.StatusOK, "done")
+	})
+	router.GET("/no-body", func(c *xylium.Context) error {
+		return c.SetTrailer("Grpc-Status", "0")
+	})
+
+	t.Run("ChunkedStreamSendsTrailers", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/grpc", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.String() != "payload" {
+			t.Errorf("Expected body 'payload', got '%s'", resp.String())
+		}
+		if got := resp.Header().Get("Grpc-Status"); got != "2" {
+			t.Errorf("Expected trailer Grpc-Status '2', got '%s'", got)
+		}
+		if got := resp.Header().Get("Grpc-Message"); got != "OK" {
+			t.Errorf("Expected trailer Grpc-Message 'OK', got '%s'", got)
+		}
+	})
+
+	t.Run("BufferedResponseReturnsError", func(t *testing.T) {
+		var trailerErr error
+		router.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+			return func(c *xylium.Context) error {
+				err := next(c)
+				if v, ok := c.Get("trailerErr"); ok && v != nil {
+					trailerErr = v.(error)
+				}
+				return err
+			}
+		})
+		resp, err := router.TestRequest(xylium.MethodGet, "/buffered-late", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if !errors.Is(trailerErr, xylium.ErrTrailersNotSupported) {
+			t.Errorf("Expected ErrTrailersNotSupported, got %v", trailerErr)
+		}
+		if resp.String() != "done" || resp.Header().Get("Trailer") != "" {
+			t.Errorf("Expected plain 'done' response without Trailer header, got '%s' (Trailer '%s')", resp.String(), resp.Header().Get("Trailer"))
+		}
+	})
+
+	t.Run("TrailersDeclaredBeforeBufferedBodyAreDropped", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/buffered-early", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.String() != "done" {
+			t.Errorf("Expected body 'done', got '%s'", resp.String())
+		}
+		if got := resp.Header().Get("Trailer"); got != "" {
+			t.Errorf("Expected no Trailer header, got '%s'", got)
+		}
+	})
+
+	t.Run("NoBodyReportsError", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/no-body", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusInternalServerError {
+			t.Errorf("Expected status %d, got %d", xylium.StatusInternalServerError, resp.StatusCode())
+		}
+	})
+
+	t.Run("ForbiddenTrailerAndDeclaredTrailers", func(t *testing.T) {
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.SetMethod(xylium.MethodGet)
+		fasthttpCtx.Request.SetRequestURI("/")
+		ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+
+		if err := ctx.SetTrailer("Content-Length", "10"); !errors.Is(err, fasthttp.ErrBadTrailer) {
+			t.Errorf("Expected fasthttp.ErrBadTrailer for Content-Length, got %v", err)
+		}
+		if got := ctx.DeclaredTrailers(); got != nil {
+			t.Errorf("Expected no declared trailers, got %v", got)
+		}
+		if err := ctx.SetTrailer("x-checksum", "abc"); err != nil {
+			t.Fatalf("SetTrailer returned an error: %v", err)
+		}
+		if got := ctx.DeclaredTrailers(); len(got) != 1 || got[0] != "X-Checksum" {
+			t.Errorf("Expected declared trailers [X-Checksum], got %v", got)
+		}
+	})
+}
+
+func TestContext_JSONStreamThreshold(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.JSONStreamThreshold = 1024 })
+	large := xylium.M{"data": strings.Repeat("x", 4096)}
+	var streamed bool
+	router.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			err := next(c)
+			streamed = c.Ctx.Response.IsBodyStream()
+			return err
+		}
+	})
+	router.GET("/large", func(c *xylium.Context) error { return c.JSON(xylium.StatusOK, large) })
+	router.GET("/large-xml", func(c *xylium.Context) error {
+		return c.XML(xylium.StatusOK, struct {
+			XMLName xml.Name `xml:"doc"`
+			Data    string   `xml:"data"`
+		}{Data: strings.Repeat("y", 4096)})
+	})
+	router.GET("/small", func(c *xylium.Context) error { return c.JSON(xylium.StatusOK, xylium.M{"ok": true}) })
+	router.GET("/expired", func(c *xylium.Context) error {
+		ctx, cancel := context.WithTimeout(c.GoContext(), time.Millisecond)
+		defer cancel()
+		cWithDeadline := c.WithGoContext(ctx)
+		if _, ok := cWithDeadline.Deadline(); !ok {
+			t.Error("Expected c.Deadline() to report the context deadline")
+		}
+		if err := cWithDeadline.JSON(xylium.StatusOK, large); err != nil {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond) // Deadline lewat sebelum body dikirim.
+		return nil
+	})
+
+	t.Run("LargeJSONIsStreamed", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/large", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if !streamed {
+			t.Error("Expected a large JSON body to be streamed")
+		}
+		var got map[string]string
+		if err := resp.DecodeJSON(&got); err != nil || len(got["data"]) != 4096 {
+			t.Errorf("Expected the full JSON body, got %d bytes (err %v)", len(resp.Body()), err)
+		}
+		if cl := resp.Header().Get("Content-Length"); cl != fmt.Sprint(len(resp.Body())) {
+			t.Errorf("Expected Content-Length %d, got '%s'", len(resp.Body()), cl)
+		}
+	})
+
+	t.Run("LargeXMLIsStreamed", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/large-xml", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if !streamed || !strings.HasSuffix(resp.String(), "</data></doc>") {
+			t.Errorf("Expected a streamed, complete XML body (streamed %t), got %d bytes", streamed, len(resp.Body()))
+		}
+	})
+
+	t.Run("SmallJSONIsBuffered", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/small", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if streamed {
+			t.Error("Expected a small JSON body to be buffered")
+		}
+		if resp.String() != `{"ok":true}` {
+			t.Errorf("Expected body '{\"ok\":true}', got '%s'", resp.String())
+		}
+	})
+
+	t.Run("DeadlineAbortsStream", func(t *testing.T) {
+		if _, err := router.TestRequest(xylium.MethodGet, "/expired", nil); err == nil {
+			t.Error("Expected the response to be aborted after the deadline passed")
+		}
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		defaultRouter := newRouterWithConfigForTest(nil)
+		defaultRouter.GET("/large", func(c *xylium.Context) error {
+			err := c.JSON(xylium.StatusOK, large)
+			if c.Ctx.Response.IsBodyStream() {
+				t.Error("Expected JSON to be buffered without JSONStreamThreshold")
+			}
+			return err
+		})
+		if _, err := defaultRouter.TestRequest(xylium.MethodGet, "/large", nil); err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+	})
+}
+
+// deadlineRecordingConnForTest mencatat write deadline yang disetel pada koneksi.
+type deadlineRecordingConnForTest struct {
+	net.Conn
+	deadlines []time.Time
+}
+
+func (c *deadlineRecordingConnForTest) SetWriteDeadline(t time.Time) error {
+	c.deadlines = append(c.deadlines, t)
+	return nil
+}
+
+func TestContext_SetWriteDeadline(t *testing.T) {
+	newCtx := func() (*xylium.Context, *deadlineRecordingConnForTest) {
+		server, client := net.Pipe()
+		t.Cleanup(func() { server.Close(); client.Close() })
+		conn := &deadlineRecordingConnForTest{Conn: server}
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Init2(conn, nil, false)
+		return xylium.NewContextForTest(nil, &fasthttpCtx), conn
+	}
+	drain := func(t *testing.T, c *xylium.Context) string {
+		t.Helper()
+		body := c.Ctx.Response.BodyStream()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("Reading the body stream failed: %v", err)
+		}
+		if closer, ok := body.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		return string(data)
+	}
+
+	t.Run("AppliedWhenStreamIsRead", func(t *testing.T) {
+		c, conn := newCtx()
+		deadline := time.Now().Add(time.Hour)
+		c.SetWriteDeadline(deadline)
+		if err := c.Stream(strings.NewReader("event"), -1, "text/event-stream"); err != nil {
+			t.Fatalf("Stream returned an error: %v", err)
+		}
+		if len(conn.deadlines) != 0 {
+			t.Fatalf("Expected no deadline before the body is written, got %v", conn.deadlines)
+		}
+		if got := drain(t, c); got != "event" {
+			t.Errorf("Expected body 'event', got '%s'", got)
+		}
+		// Tanpa router (WriteTimeout 0), deadline dihapus lagi setelah stream selesai.
+		if len(conn.deadlines) != 2 || !conn.deadlines[0].Equal(deadline) || !conn.deadlines[1].IsZero() {
+			t.Errorf("Expected the deadline to be set on first read and cleared on close, got %v", conn.deadlines)
+		}
+	})
+
+	t.Run("DisableWriteTimeoutWithStreamWriter", func(t *testing.T) {
+		c, conn := newCtx()
+		c.DisableWriteTimeout()
+		c.SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("data: ping\n\n")
+		})
+		if got := drain(t, c); got != "data: ping\n\n" {
+			t.Errorf("Expected the streamed event, got '%s'", got)
+		}
+		if len(conn.deadlines) != 1 || !conn.deadlines[0].IsZero() {
+			t.Errorf("Expected the write deadline to be removed, got %v", conn.deadlines)
+		}
+	})
+
+	t.Run("NotSetLeavesStreamUntouched", func(t *testing.T) {
+		c, conn := newCtx()
+		reader := strings.NewReader("plain")
+		if err := c.Stream(reader, 5, ""); err != nil {
+			t.Fatalf("Stream returned an error: %v", err)
+		}
+		if c.Ctx.Response.BodyStream() != io.Reader(reader) {
+			t.Error("Expected the reader to be used as is without a write deadline")
+		}
+		drain(t, c)
+		if len(conn.deadlines) != 0 {
+			t.Errorf("Expected no write deadline changes, got %v", conn.deadlines)
+		}
+	})
+}
+
+func TestContext_File_IfModifiedSince(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "avatar.png")
+	if err := os.WriteFile(filePath, []byte("avatar-v1"), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set modification time: %v", err)
+	}
+
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/avatar", func(c *xylium.Context) error { return c.File(filePath) })
+	router.POST("/avatar", func(c *xylium.Context) error { return c.File(filePath) })
+
+	testCases := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{"NoCondition", xylium.MethodGet, nil, xylium.StatusOK},
+		{"SameTime", xylium.MethodGet, map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat)}, xylium.StatusNotModified},
+		{"NewerTime", xylium.MethodGet, map[string]string{"If-Modified-Since": modTime.Add(time.Hour).Format(http.TimeFormat)}, xylium.StatusNotModified},
+		{"OlderTime", xylium.MethodGet, map[string]string{"If-Modified-Since": modTime.Add(-time.Hour).Format(http.TimeFormat)}, xylium.StatusOK},
+		{"InvalidDate", xylium.MethodGet, map[string]string{"If-Modified-Since": "yesterday"}, xylium.StatusOK},
+		// If-None-Match didahulukan; If-Modified-Since diabaikan (RFC 9110).
+		{"IfNoneMatchPresent", xylium.MethodGet, map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat), "If-None-Match": `"other"`}, xylium.StatusOK},
+		{"NotGetOrHead", xylium.MethodPost, map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat)}, xylium.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := xylium.NewTestRequest().Method(tc.method).Path("/avatar")
+			for k, v := range tc.headers {
+				req.Header(k, v)
+			}
+			resp, err := req.Do(router)
+			if err != nil {
+				t.Fatalf("Do returned an error: %v", err)
+			}
+			if resp.StatusCode() != tc.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tc.wantStatus, resp.StatusCode())
+			}
+			if got := resp.Header().Get("Last-Modified"); got != modTime.Format(http.TimeFormat) {
+				t.Errorf("Expected Last-Modified %q, got %q", modTime.Format(http.TimeFormat), got)
+			}
+			wantBody := "avatar-v1"
+			if tc.wantStatus == xylium.StatusNotModified {
+				wantBody = ""
+			}
+			if resp.String() != wantBody {
+				t.Errorf("Expected body %q, got %q", wantBody, resp.String())
+			}
+		})
+	}
+}
+
+// Benchmark respons JSON kecil: bandingkan alokasi c.JSON dengan map, struct, dan c.JSONBytes.
+// Jalankan dengan: go test ./test -run '^$' -bench 'Context_JSON' -benchmem
+func BenchmarkContext_JSON(b *testing.B) {
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	statusOK := []byte(`{"status":"ok"}`)
+	type statusResponse struct {
+		Status string `json:"status"`
+	}
+
+	b.Run("Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fasthttpCtx.Response.ResetBody()
+			_ = ctx.JSON(xylium.StatusOK, xylium.M{"status": "ok"})
+		}
+	})
+	b.Run("Struct", func(b *testing.B) {
+		b.ReportAllocs()
+		resp := &statusResponse{Status: "ok"}
+		for i := 0; i < b.N; i++ {
+			fasthttpCtx.Response.ResetBody()
+			_ = ctx.JSON(xylium.StatusOK, resp)
+		}
+	})
+	b.Run("Bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fasthttpCtx.Response.ResetBody()
+			_ = ctx.JSON(xylium.StatusOK, statusOK)
+		}
+	})
+	b.Run("JSONBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fasthttpCtx.Response.ResetBody()
+			_ = ctx.JSONBytes(xylium.StatusOK, statusOK)
+		}
+	})
+}
+
+func TestContext_JSONBytes(t *testing.T) {
+	ctx, fasthttpCtx, _ := getGlobalTestAssetsForResponse()
+	fasthttpCtx.Response.Reset()
+	raw := []byte(`{"status":"ok"}`)
+	if err := ctx.JSONBytes(http.StatusAccepted, raw); err != nil {
+		t.Fatalf("JSONBytes() returned an unexpected error: %v", err)
+	}
+	copy(raw, "XXXXXXXXXX") // Body sudah disalin, jadi slice boleh dipakai ulang.
+	if fasthttpCtx.Response.StatusCode() != http.StatusAccepted {
+		t.Errorf("Expected status %d, got %d", http.StatusAccepted, fasthttpCtx.Response.StatusCode())
+	}
+	if ct := string(fasthttpCtx.Response.Header.ContentType()); ct != "application/json; charset=utf-8" {
+		t.Errorf("Expected JSON Content-Type, got '%s'", ct)
+	}
+	if body := string(fasthttpCtx.Response.Body()); body != `{"status":"ok"}` {
+		t.Errorf("Expected body '{\"status\":\"ok\"}', got '%s'", body)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	// Ganti path ini sesuai dengan module path Anda
 	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
 	// "github.com/stretchr/testify/assert" // Opsional
 )
 
@@ -220,3 +221,207 @@ func TestContext_Store_Concurrency(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestContext_Store_Generic(t *testing.T) {
+	type user struct{ Name string }
+	ctx := newTestContextForStore()
+	ctx.Set("user", &user{Name: "alice"})
+	ctx.Set("roles", []string{"admin"})
+
+	if u, ok := xylium.Get[*user](ctx, "user"); !ok || u.Name != "alice" {
+		t.Errorf("Get[*user]: expected alice and true, got %v and %t", u, ok)
+	}
+	if roles, ok := xylium.Get[[]string](ctx, "roles"); !ok || len(roles) != 1 {
+		t.Errorf("Get[[]string]: expected [admin] and true, got %v and %t", roles, ok)
+	}
+	if u, ok := xylium.Get[*user](ctx, "roles"); ok || u != nil {
+		t.Errorf("Get[*user] with wrong type: expected nil and false, got %v and %t", u, ok)
+	}
+	if _, ok := xylium.Get[fmt.Stringer](ctx, "missing"); ok {
+		t.Error("Get[fmt.Stringer] for a missing key: expected false")
+	}
+	if u := xylium.MustGet[*user](ctx, "user"); u.Name != "alice" {
+		t.Errorf("MustGet[*user]: expected alice, got %v", u)
+	}
+
+	t.Run("MustGetWrongTypePanics", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			want := "xylium: value for key 'roles' in context store has type []string, not *xylium_test.user"
+			if r != want {
+				t.Errorf("Expected panic '%s', got %v", want, r)
+			}
+		}()
+		_ = xylium.MustGet[*user](ctx, "roles")
+	})
+
+	t.Run("MustGetMissingKeyPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic for a missing key")
+			}
+		}()
+		_ = xylium.MustGet[int](ctx, "missing")
+	})
+}
+
+func TestAppGetGeneric(t *testing.T) {
+	type client struct{ Name string }
+	router := xylium.NewRouterForTesting()
+	router.AppSet("client", &client{Name: "billing"})
+	router.AppSet("retries", 3)
+
+	if cl, ok := xylium.AppGet[*client](router, "client"); !ok || cl.Name != "billing" {
+		t.Errorf("AppGet[*client]: expected billing and true, got %v and %t", cl, ok)
+	}
+	if n, ok := xylium.AppGet[string](router, "retries"); ok || n != "" {
+		t.Errorf("AppGet[string] with wrong type: expected empty and false, got %q and %t", n, ok)
+	}
+	if _, ok := xylium.AppGet[int](router, "missing"); ok {
+		t.Error("AppGet[int] for a missing key: expected false")
+	}
+	if n := xylium.MustAppGet[int](router, "retries"); n != 3 {
+		t.Errorf("MustAppGet[int]: expected 3, got %d", n)
+	}
+
+	t.Run("MustAppGetWrongTypePanics", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			want := "xylium: value for key 'retries' in application store has type int, not string"
+			if r != want {
+				t.Errorf("Expected panic '%s', got %v", want, r)
+			}
+		}()
+		_ = xylium.MustAppGet[string](router, "retries")
+	})
+
+	t.Run("MustAppGetMissingKeyPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic for a missing key")
+			}
+		}()
+		_ = xylium.MustAppGet[int](router, "missing")
+	})
+}
+
+// countingCloser mencatat berapa kali Close dipanggil.
+type countingCloser struct{ closed int }
+
+func (cc *countingCloser) Close() error {
+	cc.closed++
+	return nil
+}
+
+func TestRouter_AppDeleteAndAppKeys(t *testing.T) {
+	router := xylium.NewRouterForTesting()
+	pool := &countingCloser{}
+	shared := &countingCloser{}
+	router.AppSet("pool", pool)
+	router.AppSet("shared", shared)
+	router.AppSet("shared-alias", shared)
+	router.AppSet("name", "svc")
+
+	if keys := router.AppKeys(); fmt.Sprint(keys) != "[name pool shared shared-alias]" {
+		t.Errorf("AppKeys: expected sorted keys, got %v", keys)
+	}
+
+	if err := router.AppDelete("pool"); err != nil {
+		t.Fatalf("AppDelete(pool) returned an error: %v", err)
+	}
+	if _, ok := router.AppGet("pool"); ok {
+		t.Error("Expected 'pool' to be removed from the application store")
+	}
+	if pool.closed != 1 {
+		t.Errorf("Expected a deleted io.Closer to be closed once, got %d", pool.closed)
+	}
+
+	// Nilai yang masih tersimpan di kunci lain tidak boleh ditutup.
+	if err := router.AppDelete("shared-alias"); err != nil {
+		t.Fatalf("AppDelete(shared-alias) returned an error: %v", err)
+	}
+	if shared.closed != 0 {
+		t.Errorf("Expected a value still stored under another key not to be closed, got %d closes", shared.closed)
+	}
+	if err := router.AppDelete("missing"); err != nil {
+		t.Errorf("AppDelete for a missing key: expected nil, got %v", err)
+	}
+
+	// Saat shutdown, closer yang dihapus tidak ditutup lagi.
+	router.GracefulShutdownForTesting(&fasthttp.Server{Handler: router.Handler})
+	if pool.closed != 1 {
+		t.Errorf("Expected a deleted closer to be unregistered from shutdown, got %d closes", pool.closed)
+	}
+	if shared.closed != 1 {
+		t.Errorf("Expected the remaining closer to be closed on shutdown, got %d closes", shared.closed)
+	}
+}
+
+func TestContext_Store_DeleteKeysAndScoped(t *testing.T) {
+	ctx := newTestContextForStore()
+	if keys := ctx.Keys(); len(keys) != 0 {
+		t.Errorf("Keys: expected an empty store, got %v", keys)
+	}
+	ctx.Set("user", "alice")
+	ctx.Set("tenant", "acme")
+	if keys := ctx.Keys(); fmt.Sprint(keys) != "[tenant user]" {
+		t.Errorf("Keys: expected sorted keys, got %v", keys)
+	}
+	ctx.Delete("user")
+	if _, ok := ctx.Get("user"); ok {
+		t.Error("Delete: expected 'user' to be removed")
+	}
+
+	t.Run("SetScoped", func(t *testing.T) {
+		// Nilai scoped hanya terlihat selama sub-chain berjalan.
+		mw := func(next xylium.HandlerFunc) xylium.HandlerFunc {
+			return func(c *xylium.Context) error {
+				defer c.SetScoped("tx", "tx-1")()
+				defer c.SetScoped("tenant", "inner")()
+				return next(c)
+			}
+		}
+		var seenTx, seenTenant interface{}
+		err := mw(func(c *xylium.Context) error {
+			seenTx, _ = c.Get("tx")
+			seenTenant, _ = c.Get("tenant")
+			return nil
+		})(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if seenTx != "tx-1" || seenTenant != "inner" {
+			t.Errorf("Expected scoped values inside the sub-chain, got tx=%v tenant=%v", seenTx, seenTenant)
+		}
+		if _, ok := ctx.Get("tx"); ok {
+			t.Error("Expected 'tx' to be removed after the sub-chain")
+		}
+		if tenant, _ := ctx.Get("tenant"); tenant != "acme" {
+			t.Errorf("Expected 'tenant' to be restored to 'acme', got %v", tenant)
+		}
+	})
+}
+
+func TestContext_Store_NotLeakedAcrossPooledRequests(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/set", func(c *xylium.Context) error {
+		c.Set("user", "alice")
+		return c.NoContent(xylium.StatusNoContent)
+	})
+	var leaked []string
+	router.GET("/check", func(c *xylium.Context) error {
+		leaked = c.Keys()
+		return c.NoContent(xylium.StatusNoContent)
+	})
+
+	// Context dari pool digunakan ulang; store harus selalu kosong di awal request.
+	for i := 0; i < 20; i++ {
+		serveRequestForTest(router, xylium.MethodGet, "/set")
+		serveRequestForTest(router, xylium.MethodGet, "/check")
+		for _, k := range leaked {
+			if k == "user" {
+				t.Fatalf("Expected a fresh store for each request, got keys %v", leaked)
+			}
+		}
+	}
+}
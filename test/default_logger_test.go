@@ -0,0 +1,481 @@
+// File: /test/default_logger_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// newBufferLoggerForTest membuat DefaultLogger dengan output teks ke buffer.
+func newBufferLoggerForTest(buf *bytes.Buffer, sampling *xylium.SamplingConfig) *xylium.DefaultLogger {
+	return xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:     xylium.LevelDebug,
+		Formatter: xylium.TextFormatter,
+		Output:    buf,
+		Sampling:  sampling,
+	})
+}
+
+func countLogLines(buf *bytes.Buffer) int {
+	return strings.Count(buf.String(), "\n")
+}
+
+func TestDefaultLogger_Sampling(t *testing.T) {
+	t.Run("InitialThenEveryNth", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newBufferLoggerForTest(&buf, &xylium.SamplingConfig{Initial: 3, Thereafter: 5})
+		for i := 0; i < 23; i++ {
+			logger.Info("hello")
+		}
+		// 3 pertama, lalu entri ke-8, 13, 18, dan 23 (1 dari 5 setelahnya).
+		if got := countLogLines(&buf); got != 7 {
+			t.Errorf("Expected 7 sampled Info lines, got %d", got)
+		}
+	})
+
+	t.Run("PerLevelCounters", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newBufferLoggerForTest(&buf, &xylium.SamplingConfig{Initial: 2})
+		for i := 0; i < 5; i++ {
+			logger.Debug("debug")
+			logger.Info("info")
+		}
+		if got := countLogLines(&buf); got != 4 {
+			t.Errorf("Expected 2 Debug + 2 Info lines, got %d", got)
+		}
+	})
+
+	t.Run("NeverDropsErrors", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newBufferLoggerForTest(&buf, &xylium.SamplingConfig{Initial: 1})
+		for i := 0; i < 10; i++ {
+			logger.Error("boom")
+		}
+		if got := countLogLines(&buf); got != 10 {
+			t.Errorf("Expected all 10 Error lines, got %d", got)
+		}
+	})
+
+	t.Run("SharedWithDerivedLoggers", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newBufferLoggerForTest(&buf, &xylium.SamplingConfig{Initial: 2})
+		child := logger.WithFields(xylium.M{"component": "child"})
+		logger.Info("parent")
+		child.Info("child")
+		child.Info("child dropped")
+		if got := countLogLines(&buf); got != 2 {
+			t.Errorf("Expected 2 lines across parent and child, got %d", got)
+		}
+	})
+
+	t.Run("SetSamplingAtRuntime", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newBufferLoggerForTest(&buf, nil)
+		for i := 0; i < 5; i++ {
+			logger.Info("unsampled")
+		}
+		if got := countLogLines(&buf); got != 5 {
+			t.Fatalf("Expected 5 lines without sampling, got %d", got)
+		}
+
+		buf.Reset()
+		logger.SetSampling(&xylium.SamplingConfig{Initial: 1})
+		for i := 0; i < 5; i++ {
+			logger.Info("sampled")
+		}
+		if got := countLogLines(&buf); got != 1 {
+			t.Fatalf("Expected 1 line with sampling, got %d", got)
+		}
+
+		buf.Reset()
+		logger.SetSampling(nil)
+		for i := 0; i < 5; i++ {
+			logger.Info("disabled again")
+		}
+		if got := countLogLines(&buf); got != 5 {
+			t.Errorf("Expected 5 lines after disabling sampling, got %d", got)
+		}
+	})
+}
+
+// blockingWriterForTest menahan setiap Write sampai channel release ditutup.
+type blockingWriterForTest struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	release chan struct{}
+}
+
+func (w *blockingWriterForTest) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriterForTest) lines() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Count(w.buf.String(), "\n")
+}
+
+func TestDefaultLogger_Async(t *testing.T) {
+	t.Run("FlushWritesQueuedEntries", func(t *testing.T) {
+		w := &blockingWriterForTest{release: make(chan struct{})}
+		logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+			Level:  xylium.LevelDebug,
+			Output: w,
+			Async:  true,
+		})
+		defer logger.Close()
+
+		// Output yang lambat tidak boleh menahan pemanggil.
+		for i := 0; i < 10; i++ {
+			logger.Info("queued")
+		}
+		if got := w.lines(); got != 0 {
+			t.Fatalf("Expected no lines written while output is blocked, got %d", got)
+		}
+
+		close(w.release)
+		if err := logger.Flush(); err != nil {
+			t.Fatalf("Flush returned error: %v", err)
+		}
+		if got := w.lines(); got != 10 {
+			t.Errorf("Expected 10 lines after Flush, got %d", got)
+		}
+	})
+
+	t.Run("DropPolicyNeverBlocks", func(t *testing.T) {
+		w := &blockingWriterForTest{release: make(chan struct{})}
+		logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+			Level:          xylium.LevelDebug,
+			Output:         w,
+			Async:          true,
+			BufferSize:     2,
+			OverflowPolicy: xylium.AsyncOverflowDrop,
+		})
+
+		for i := 0; i < 50; i++ {
+			logger.Info("maybe dropped")
+		}
+		close(w.release)
+		logger.Close()
+
+		got := w.lines()
+		if got == 0 || got >= 50 {
+			t.Errorf("Expected some but not all entries to be written, got %d", got)
+		}
+	})
+
+	t.Run("CloseFallsBackToSyncWrites", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+			Level:  xylium.LevelDebug,
+			Output: &buf,
+			Async:  true,
+		})
+		child := logger.WithFields(xylium.M{"component": "child"})
+		logger.Info("before close")
+		child.Info("child before close")
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+		if err := logger.Close(); err != nil { // Close berulang aman.
+			t.Fatalf("second Close returned error: %v", err)
+		}
+		child.Info("after close")
+		if got := countLogLines(&buf); got != 3 {
+			t.Errorf("Expected 3 lines, got %d: %q", got, buf.String())
+		}
+	})
+}
+
+// recordingHookForTest mencatat entri yang diterima dan bisa mengembalikan error.
+type recordingHookForTest struct {
+	mu      sync.Mutex
+	levels  []xylium.LogLevel
+	entries []xylium.LogEntry
+	err     error
+}
+
+func (h *recordingHookForTest) Levels() []xylium.LogLevel { return h.levels }
+
+func (h *recordingHookForTest) Fire(entry xylium.LogEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return h.err
+}
+
+func TestDefaultLogger_Hooks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newBufferLoggerForTest(&buf, nil)
+	child := logger.WithFields(xylium.M{"component": "child"}) // Dibuat sebelum hook ditambahkan.
+
+	hook := &recordingHookForTest{
+		levels: []xylium.LogLevel{xylium.LevelError},
+		err:    errors.New("hook unavailable"),
+	}
+	logger.AddHook(hook)
+
+	logger.Info("not forwarded")
+	child.Errorf("forwarded", xylium.M{"order_id": 42})
+
+	if len(hook.entries) != 1 {
+		t.Fatalf("Expected hook to fire once, got %d", len(hook.entries))
+	}
+	entry := hook.entries[0]
+	if entry.Message != "forwarded" || entry.Level != "ERROR" {
+		t.Errorf("Unexpected entry passed to hook: %+v", entry)
+	}
+	if entry.Fields["component"] != "child" || entry.Fields["order_id"] != 42 {
+		t.Errorf("Expected entry fields to include base and call fields, got %v", entry.Fields)
+	}
+	// Error dari hook tidak boleh menghalangi penulisan log.
+	if got := countLogLines(&buf); got != 2 {
+		t.Errorf("Expected 2 lines written despite hook error, got %d", got)
+	}
+}
+
+func TestDefaultLogger_TimestampFormatAndFieldKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:           xylium.LevelInfo,
+		Formatter:       xylium.JSONFormatter,
+		Output:          &buf,
+		TimestampFormat: time.RFC3339Nano,
+		FieldKeys:       xylium.FieldKeys{Timestamp: "@timestamp", Message: "msg"},
+	})
+	logger.WithFields(xylium.M{"user": "alice"}).Info("hello")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON log line %q: %v", buf.String(), err)
+	}
+	if decoded["msg"] != "hello" || decoded["level"] != "INFO" {
+		t.Errorf("Expected remapped message key and default level key, got %v", decoded)
+	}
+	if _, ok := decoded["message"]; ok {
+		t.Errorf("Did not expect default 'message' key, got %v", decoded)
+	}
+	ts, _ := decoded["@timestamp"].(string)
+	if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+		t.Errorf("Expected RFC3339Nano timestamp under '@timestamp', got %q: %v", ts, err)
+	}
+	fields, _ := decoded["fields"].(map[string]interface{})
+	if fields["user"] != "alice" {
+		t.Errorf("Expected fields under default 'fields' key, got %v", decoded)
+	}
+}
+
+func TestDefaultLogger_FieldOrderIsDeterministic(t *testing.T) {
+	fields := xylium.M{"zeta": 1, "alpha": 2, "mid": xylium.M{"b": 1, "a": 2}, "beta": 3}
+
+	for _, formatter := range []xylium.FormatterType{xylium.JSONFormatter, xylium.TextFormatter} {
+		var first string
+		for i := 0; i < 20; i++ {
+			var buf bytes.Buffer
+			logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+				Level:     xylium.LevelInfo,
+				Formatter: formatter,
+				Output:    &buf,
+			})
+			logger.WithFields(fields).Info("ordered")
+			// Buang timestamp agar baris bisa dibandingkan.
+			line := buf.String()
+			line = line[strings.Index(line, "ordered"):]
+			if i == 0 {
+				first = line
+				if !strings.Contains(line, `{"alpha":2,"beta":3,"mid":{"a":2,"b":1},"zeta":1}`) {
+					t.Errorf("[%s] Expected fields sorted by key, got %q", formatter, line)
+				}
+				continue
+			}
+			if line != first {
+				t.Fatalf("[%s] Field order changed between runs:\n%q\n%q", formatter, first, line)
+			}
+		}
+	}
+}
+
+func TestDefaultLogger_TraceLevel(t *testing.T) {
+	if xylium.LevelTrace >= xylium.LevelDebug {
+		t.Fatalf("Expected LevelTrace to be below LevelDebug")
+	}
+	if got := xylium.LevelTrace.String(); got != "TRACE" {
+		t.Errorf("Expected LevelTrace.String() to be TRACE, got %q", got)
+	}
+	var zero xylium.LogLevel
+	if zero != xylium.LevelDebug {
+		t.Errorf("Expected LevelDebug to remain the zero value of LogLevel")
+	}
+
+	var buf bytes.Buffer
+	logger := newBufferLoggerForTest(&buf, nil) // Level: LevelDebug.
+	logger.Trace("suppressed")
+	logger.Tracef("suppressed %d", 1)
+	if buf.Len() != 0 {
+		t.Fatalf("Expected Trace to be suppressed at LevelDebug, got %q", buf.String())
+	}
+
+	logger.SetLevel(xylium.LevelTrace)
+	logger.Tracef("wire dump %d", 2)
+	if !strings.Contains(buf.String(), "[TRACE] wire dump 2") {
+		t.Errorf("Expected TRACE entry, got %q", buf.String())
+	}
+}
+
+func TestDefaultLogger_Sinks(t *testing.T) {
+	var text, jsonErrors bytes.Buffer
+	logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level: xylium.LevelDebug,
+		Sinks: []xylium.SinkConfig{
+			{Output: &text, Formatter: xylium.TextFormatter},
+			{Output: &jsonErrors, Formatter: xylium.JSONFormatter, Level: xylium.LevelError},
+		},
+	})
+
+	logger.Debug("starting")
+	logger.WithFields(xylium.M{"user": "ana"}).Errorf("failed %d", 1)
+
+	// Sink teks menerima semua entri, sink JSON hanya Error ke atas.
+	if got := countLogLines(&text); got != 2 {
+		t.Fatalf("Expected 2 lines in the text sink, got %d: %q", got, text.String())
+	}
+	if !strings.Contains(text.String(), `[ERROR] failed 1 {"user":"ana"}`) {
+		t.Errorf("Unexpected text sink output: %q", text.String())
+	}
+	if got := countLogLines(&jsonErrors); got != 1 {
+		t.Fatalf("Expected 1 line in the JSON sink, got %d: %q", got, jsonErrors.String())
+	}
+	var entry xylium.LogEntry
+	if err := json.Unmarshal(jsonErrors.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON sink output is not valid JSON: %v", err)
+	}
+	if entry.Level != "ERROR" || entry.Message != "failed 1" || entry.Fields["user"] != "ana" {
+		t.Errorf("Unexpected JSON sink entry: %+v", entry)
+	}
+
+	// Level logger tetap berlaku untuk semua sink.
+	logger.SetLevel(xylium.LevelWarn)
+	logger.Info("suppressed")
+	if got := countLogLines(&text); got != 2 {
+		t.Errorf("Expected the logger Level to suppress Info for all sinks, got %d lines", got)
+	}
+
+	// SetOutput mengganti semua sink dengan satu output.
+	var single bytes.Buffer
+	logger.SetOutput(&single)
+	logger.Error("only here")
+	if countLogLines(&single) != 1 || countLogLines(&text) != 2 || countLogLines(&jsonErrors) != 1 {
+		t.Errorf("Expected SetOutput to replace the sinks")
+	}
+}
+
+// stackErrorForTest adalah error yang membawa stack trace.
+type stackErrorForTest struct{ msg string }
+
+func (e stackErrorForTest) Error() string { return e.msg }
+func (e stackErrorForTest) Stack() []byte { return []byte("goroutine 1 [running]:") }
+
+func TestDefaultLogger_WithError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:     xylium.LevelDebug,
+		Formatter: xylium.JSONFormatter,
+		Output:    &buf,
+	})
+
+	lastEntry := func() xylium.LogEntry {
+		t.Helper()
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		var entry xylium.LogEntry
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &entry); err != nil {
+			t.Fatalf("Invalid JSON log line: %v", err)
+		}
+		return entry
+	}
+
+	logger.WithError(errors.New("boom")).Error("failed")
+	if entry := lastEntry(); entry.Fields["error"] != "boom" || len(entry.Fields) != 1 {
+		t.Errorf("Expected only an error field, got %+v", entry.Fields)
+	}
+
+	// HTTPError menampilkan status dan penyebab internalnya.
+	httpErr := xylium.NewHTTPError(xylium.StatusBadGateway, "Upstream unavailable.").
+		WithInternal(stackErrorForTest{msg: "dial tcp: connection refused"})
+	logger.WithFields(xylium.M{"user": "ana"}).WithError(fmt.Errorf("charge: %w", httpErr)).Error("failed")
+	entry := lastEntry()
+	if entry.Fields["error_status"] != float64(xylium.StatusBadGateway) ||
+		entry.Fields["error_cause"] != "dial tcp: connection refused" ||
+		entry.Fields["error_stack"] != "goroutine 1 [running]:" ||
+		entry.Fields["user"] != "ana" {
+		t.Errorf("Unexpected error fields: %+v", entry.Fields)
+	}
+
+	if logger.WithError(nil) != xylium.Logger(logger) {
+		t.Error("Expected WithError(nil) to return the logger unchanged")
+	}
+}
+
+func TestContext_AddLogFields(t *testing.T) {
+	var buf bytes.Buffer
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.Logger = xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+			Level:     xylium.LevelInfo,
+			Formatter: xylium.JSONFormatter,
+			Output:    &buf,
+		})
+	})
+	router.Use(xylium.RequestID())
+	// Middleware "auth" menambahkan field yang berlaku untuk semua c.Logger() berikutnya.
+	router.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			before := c.Logger()
+			c.AddLogFields(xylium.M{"user_id": "u-1", "tenant": "acme"})
+			c.AddLogFields(xylium.M{"tenant": "globex", xylium.ContextKeyRequestID: "spoofed"})
+			c.AddLogFields(nil)
+			before.Info("before")
+			return next(c)
+		}
+	})
+	router.GET("/", func(c *xylium.Context) error {
+		c.Logger().Info("handler")
+		return c.NoContent(xylium.StatusNoContent)
+	})
+
+	serveRequestForTest(router, xylium.MethodGet, "/")
+
+	lines := map[string]map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			continue // Log bootstrap router.
+		}
+		if msg, _ := decoded["message"].(string); msg == "before" || msg == "handler" {
+			fields, _ := decoded["fields"].(map[string]interface{})
+			lines[msg] = fields
+		}
+	}
+
+	handler := lines["handler"]
+	if handler["user_id"] != "u-1" || handler["tenant"] != "globex" {
+		t.Errorf("Expected accumulated fields in handler log, got %v", handler)
+	}
+	// Field standar (request ID) didahulukan atas field yang ditambahkan.
+	if id, _ := handler[xylium.ContextKeyRequestID].(string); id == "" || id == "spoofed" {
+		t.Errorf("Expected the real request ID to take precedence, got %v", handler[xylium.ContextKeyRequestID])
+	}
+	// Logger yang diambil sebelum AddLogFields tidak terpengaruh.
+	if _, ok := lines["before"]["user_id"]; ok {
+		t.Errorf("Expected logger obtained before AddLogFields to be unaffected, got %v", lines["before"])
+	}
+}
@@ -0,0 +1,218 @@
+// File: /test/http_adapter_test.go
+package xylium_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+type httpAdapterCtxKeyForTest struct{}
+
+func TestWrapHTTPHandler(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/std", xylium.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Query", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte("<html><body>hi</body></html>"))
+	})))
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/std?q=42")
+	if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Body()) != "<html><body>hi</body></html>" {
+		t.Errorf("Unexpected response: %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if got := string(ctx.Response.Header.Peek("X-Query")); got != "42" {
+		t.Errorf("Expected X-Query 42, got %q", got)
+	}
+	if ct := string(ctx.Response.Header.ContentType()); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Expected detected text/html Content-Type, got %q", ct)
+	}
+}
+
+func TestWrapHTTPMiddleware(t *testing.T) {
+	var recordedStatus int
+	stdMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				http.Error(w, "missing token", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-Vendor", "sdk")
+			r.Header.Set("X-User", "alice")
+			r = r.WithContext(context.WithValue(r.Context(), httpAdapterCtxKeyForTest{}, "from-std"))
+			rec := &statusRecorderForTest{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			recordedStatus = rec.status
+		})
+	}
+
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.WrapHTTPMiddleware(stdMiddleware))
+	router.GET("/me", func(c *xylium.Context) error {
+		value, _ := c.GoContext().Value(httpAdapterCtxKeyForTest{}).(string)
+		c.Ctx.Response.Header.Set("Set-Cookie", "session=abc; Path=/")
+		return c.String(xylium.StatusAccepted, "%s %s", c.Header("X-User"), value)
+	})
+	router.GET("/fail", func(c *xylium.Context) error {
+		return xylium.NewHTTPError(xylium.StatusTeapot, "failure")
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/me")
+		if ctx.Response.StatusCode() != xylium.StatusUnauthorized || !strings.Contains(string(ctx.Response.Body()), "missing token") {
+			t.Errorf("Expected 401 from net/http middleware, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	serveAuthorized := func(path string) *fasthttp.RequestCtx {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(xylium.MethodGet)
+		ctx.Request.SetRequestURI(path)
+		ctx.Request.Header.Set("Authorization", "Bearer token")
+		router.Handler(&ctx)
+		return &ctx
+	}
+
+	t.Run("PassThrough", func(t *testing.T) {
+		recordedStatus = 0
+		ctx := serveAuthorized("/me")
+		if ctx.Response.StatusCode() != xylium.StatusAccepted || string(ctx.Response.Body()) != "alice from-std" {
+			t.Errorf("Expected request changes to reach the handler, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+		if got := string(ctx.Response.Header.Peek("X-Vendor")); got != "sdk" {
+			t.Errorf("Expected header set by net/http middleware, got %q", got)
+		}
+		if got := string(ctx.Response.Header.PeekCookie("session")); !strings.Contains(got, "session=abc") {
+			t.Errorf("Expected cookie set by the handler to survive the replay, got %q", got)
+		}
+		if recordedStatus != xylium.StatusAccepted {
+			t.Errorf("Expected net/http middleware to observe status 202, got %d", recordedStatus)
+		}
+	})
+
+	t.Run("ErrorsReachErrorHandler", func(t *testing.T) {
+		ctx := serveAuthorized("/fail")
+		if ctx.Response.StatusCode() != xylium.StatusTeapot {
+			t.Errorf("Expected 418 from the error handler, got %d", ctx.Response.StatusCode())
+		}
+	})
+}
+
+// statusRecorderForTest mencatat status yang ditulis, seperti middleware logging net/http.
+type statusRecorderForTest struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorderForTest) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func TestRouter_HTTPHandler(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.POST("/echo/:name", func(c *xylium.Context) error {
+		return c.JSON(xylium.StatusCreated, xylium.M{"name": c.Param("name"), "body": string(c.Body()), "ip": c.RealIP()})
+	})
+	router.GET("/events", func(c *xylium.Context) error {
+		c.SetContentType("text/event-stream")
+		c.SetBodyStreamWriter(func(w *bufio.Writer) {
+			for i := 0; i < 2; i++ {
+				fmt.Fprintf(w, "data: %d\n\n", i)
+				_ = w.Flush()
+			}
+		})
+		return nil
+	})
+
+	large := func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s", strings.Repeat("x", 10000))
+	}
+	router.GET("/large", large)
+	router.HEAD("/large", large)
+
+	srv := httptest.NewServer(router.HTTPHandler())
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/echo/ana", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != xylium.StatusCreated || !strings.Contains(string(body), `"body":"hello"`) ||
+		!strings.Contains(string(body), `"name":"ana"`) || !strings.Contains(string(body), `"ip":"127.0.0.1"`) {
+		t.Errorf("Unexpected response: %d %s", resp.StatusCode, body)
+	}
+
+	resp, err = http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "data: 0\n\ndata: 1\n\n" {
+		t.Errorf("Unexpected streamed body: %q", body)
+	}
+
+	// Body yang di-buffer dikirim dengan Content-Length, juga untuk HEAD, bukan chunked.
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		req, _ := http.NewRequest(method, srv.URL+"/large", nil)
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s failed: %v", method, err)
+		}
+		body, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.ContentLength != 10000 || len(resp.TransferEncoding) != 0 {
+			t.Errorf("%s: expected Content-Length 10000 without chunking, got %d %v", method, resp.ContentLength, resp.TransferEncoding)
+		}
+		if wantLen := map[string]int{http.MethodGet: 10000, http.MethodHead: 0}[method]; len(body) != wantLen {
+			t.Errorf("%s: expected a body of %d bytes, got %d", method, wantLen, len(body))
+		}
+	}
+
+	resp, err = http.Get(srv.URL + "/missing")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != xylium.StatusNotFound {
+		t.Errorf("Expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_HTTPHandlerServesH2C(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/proto", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "ok")
+	})
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := httptest.NewUnstartedServer(router.HTTPHandler())
+	srv.Config.Protocols = protocols
+	srv.Start()
+	defer srv.Close()
+
+	clientProtocols := new(http.Protocols)
+	clientProtocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: clientProtocols}}
+	resp, err := client.Get(srv.URL + "/proto")
+	if err != nil {
+		t.Fatalf("h2c GET failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.ProtoMajor != 2 || string(body) != "ok" {
+		t.Errorf("Expected an HTTP/2 response 'ok', got %s %q", resp.Proto, body)
+	}
+}
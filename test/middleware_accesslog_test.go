@@ -0,0 +1,142 @@
+// File: /test/middleware_accesslog_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// newRouterWithLogBufferForTest membuat router yang logger-nya menulis JSON ke buffer.
+func newRouterWithLogBufferForTest(buf *bytes.Buffer) *xylium.Router {
+	logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:     xylium.LevelDebug,
+		Formatter: xylium.JSONFormatter,
+		Output:    buf,
+	})
+	return newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.Logger = logger })
+}
+
+// decodeLogLines mengurai setiap baris JSON di buffer menjadi map.
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to decode log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	router := newRouterWithLogBufferForTest(&buf)
+	router.Use(xylium.AccessLog(xylium.AccessLogConfig{
+		Skip: func(c *xylium.Context) bool { return c.Path() == "/health" },
+	}))
+	router.GET("/ok", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "hello") })
+	router.GET("/missing-item", func(c *xylium.Context) error {
+		return xylium.NewHTTPError(xylium.StatusNotFound, "no such item")
+	})
+	router.GET("/health", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "up") })
+
+	tests := []struct {
+		path          string
+		expectLogged  bool
+		expectedLevel string
+		expectedCode  float64
+		expectedBytes float64
+	}{
+		{"/ok", true, "INFO", 200, 5},
+		{"/missing-item", true, "WARN", 404, 0},
+		{"/health", false, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			buf.Reset()
+			var fasthttpCtx fasthttp.RequestCtx
+			fasthttpCtx.Request.Header.SetMethod(xylium.MethodGet)
+			fasthttpCtx.Request.SetRequestURI(tt.path)
+			fasthttpCtx.Request.Header.SetUserAgent("access-log-test")
+			router.Handler(&fasthttpCtx)
+
+			var accessEntry map[string]interface{}
+			for _, e := range decodeLogLines(t, &buf) {
+				if e["fields"] != nil {
+					if fields, ok := e["fields"].(map[string]interface{}); ok && fields["middleware"] == "AccessLog" {
+						accessEntry = e
+					}
+				}
+			}
+			if !tt.expectLogged {
+				if accessEntry != nil {
+					t.Errorf("Expected no access log entry, got %v", accessEntry)
+				}
+				return
+			}
+			if accessEntry == nil {
+				t.Fatalf("Expected an access log entry, got log output: %s", buf.String())
+			}
+			fields := accessEntry["fields"].(map[string]interface{})
+			if accessEntry["level"] != tt.expectedLevel {
+				t.Errorf("Expected level %s, got %v", tt.expectedLevel, accessEntry["level"])
+			}
+			if fields["status"] != tt.expectedCode {
+				t.Errorf("Expected status %v, got %v", tt.expectedCode, fields["status"])
+			}
+			if fields["bytes_out"] != tt.expectedBytes {
+				t.Errorf("Expected bytes_out %v, got %v", tt.expectedBytes, fields["bytes_out"])
+			}
+			if fields["user_agent"] != "access-log-test" || fields["path"] != tt.path || fields["method"] != "GET" {
+				t.Errorf("Unexpected request fields: %v", fields)
+			}
+		})
+	}
+}
+
+func TestAccessLogAndCircuitBreaker_MappedErrors(t *testing.T) {
+	errItemNotFound := errors.New("item not found")
+	var buf bytes.Buffer
+	router := newRouterWithLogBufferForTest(&buf)
+	router.RegisterErrorMapper(xylium.MapErrorIs(errItemNotFound, xylium.StatusNotFound))
+	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{FailureThreshold: 2})
+	router.Use(xylium.AccessLog(xylium.AccessLogConfig{}), breaker.Middleware())
+	router.GET("/items/:id", func(c *xylium.Context) error {
+		return errItemNotFound // Error domain yang dipetakan ke 404, bukan kegagalan server.
+	})
+
+	for i := 0; i < 3; i++ {
+		buf.Reset()
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/items/42")
+		if ctx.Response.StatusCode() != xylium.StatusNotFound {
+			t.Fatalf("Request %d: expected mapped status 404, got %d", i, ctx.Response.StatusCode())
+		}
+		var accessEntry map[string]interface{}
+		for _, e := range decodeLogLines(t, &buf) {
+			if fields, ok := e["fields"].(map[string]interface{}); ok && fields["middleware"] == "AccessLog" {
+				accessEntry = e
+			}
+		}
+		if accessEntry == nil {
+			t.Fatalf("Request %d: expected an access log entry, got log output: %s", i, buf.String())
+		}
+		if status := accessEntry["fields"].(map[string]interface{})["status"]; status != float64(404) || accessEntry["level"] != "WARN" {
+			t.Errorf("Request %d: expected a WARN entry with status 404, got %v with status %v", i, accessEntry["level"], status)
+		}
+	}
+	if got := breaker.State(""); got != xylium.CircuitClosed {
+		t.Errorf("Expected mapped 4xx errors not to open the circuit, got %s", got)
+	}
+}
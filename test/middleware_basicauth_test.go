@@ -10,6 +10,7 @@ import (
 
 	"github.com/arwahdevops/xylium-core/src/xylium"
 	"github.com/valyala/fasthttp"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Helper untuk menjalankan middleware BasicAuth
@@ -325,3 +326,70 @@ func TestBasicAuth_CustomErrorHandler(t *testing.T) {
 		t.Errorf("Expected no WWW-Authenticate header from custom error handler, got '%s'", result.wwwAuthHeader)
 	}
 }
+
+func TestBasicAuth_ConstantTimeValidator(t *testing.T) {
+	config := xylium.BasicAuthConfig{
+		Validator: xylium.ConstantTimeValidator(map[string]string{"alice": "s3cret"}),
+	}
+
+	testCases := []struct {
+		name      string
+		user      string
+		pass      string
+		wantValid bool
+	}{
+		{"Valid", "alice", "s3cret", true},
+		{"WrongPassword", "alice", "s3cre", false},
+		{"UnknownUser", "bob", "s3cret", false},
+		{"UnknownUserEmptyPassword", "bob", "", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authValue := "Basic " + base64.StdEncoding.EncodeToString([]byte(tc.user+":"+tc.pass))
+			result := runBasicAuthMiddleware(t, config, authValue, true)
+			if result.handlerCalled != tc.wantValid {
+				t.Errorf("Expected handlerCalled=%v, got %v", tc.wantValid, result.handlerCalled)
+			}
+			if tc.wantValid && result.contextUser != tc.user {
+				t.Errorf("Expected context user '%s', got '%v'", tc.user, result.contextUser)
+			}
+		})
+	}
+}
+
+func TestBasicAuth_BcryptValidator(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to generate bcrypt hash: %v", err)
+	}
+	config := xylium.BasicAuthConfig{
+		Validator: xylium.BcryptValidator(map[string]string{"alice": string(hash)}),
+	}
+
+	for _, tc := range []struct {
+		user, pass string
+		wantValid  bool
+	}{
+		{"alice", "s3cret", true},
+		{"alice", "wrong", false},
+		{"bob", "s3cret", false},
+	} {
+		authValue := "Basic " + base64.StdEncoding.EncodeToString([]byte(tc.user+":"+tc.pass))
+		result := runBasicAuthMiddleware(t, config, authValue, true)
+		if result.handlerCalled != tc.wantValid {
+			t.Errorf("%s:%s: expected handlerCalled=%v, got %v", tc.user, tc.pass, tc.wantValid, result.handlerCalled)
+		}
+		if !tc.wantValid && result.statusCode != http.StatusUnauthorized {
+			t.Errorf("%s:%s: expected status 401, got %d", tc.user, tc.pass, result.statusCode)
+		}
+	}
+
+	t.Run("InvalidHashPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for an invalid bcrypt hash")
+			}
+		}()
+		xylium.BcryptValidator(map[string]string{"alice": "plaintext"})
+	})
+}
@@ -0,0 +1,151 @@
+// File: /test/middleware_bodydump_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+type bodyDumpPayload struct {
+	Name string `json:"name"`
+}
+
+// runBodyDumpMiddleware menjalankan BodyDump dengan body tertentu; handler melakukan
+// binding JSON lalu menulis response, sehingga tes memastikan body masih bisa dibaca.
+func runBodyDumpMiddleware(t *testing.T, config xylium.BodyDumpConfig, body []byte, streamed bool, handlerErr error) (bound bodyDumpPayload, reqDump, resDump []byte, err error) {
+	t.Helper()
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(xylium.MethodPost)
+	fasthttpCtx.Request.Header.SetContentType("application/json")
+	fasthttpCtx.Request.SetRequestURI("/echo")
+	if streamed {
+		fasthttpCtx.Request.SetBodyStream(bytes.NewReader(body), -1)
+	} else {
+		fasthttpCtx.Request.SetBody(body)
+		fasthttpCtx.Request.Header.SetContentLength(len(body))
+	}
+
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+	config.Handler = func(c *xylium.Context, req, res []byte) {
+		reqDump, resDump = req, res
+	}
+	handler := func(c *xylium.Context) error {
+		if handlerErr != nil {
+			return handlerErr
+		}
+		if err := c.Bind(&bound); err != nil {
+			return err
+		}
+		return c.String(xylium.StatusOK, "hello %s", bound.Name)
+	}
+	err = xylium.BodyDump(config)(handler)(ctx)
+	return bound, reqDump, resDump, err
+}
+
+func TestBodyDump(t *testing.T) {
+	body := []byte(`{"name":"xylium"}`)
+
+	for _, streamed := range []bool{false, true} {
+		bound, reqDump, resDump, err := runBodyDumpMiddleware(t, xylium.BodyDumpConfig{}, body, streamed, nil)
+		if err != nil {
+			t.Fatalf("streamed=%v: unexpected error: %v", streamed, err)
+		}
+		if bound.Name != "xylium" {
+			t.Errorf("streamed=%v: expected binding to still see the body, got %+v", streamed, bound)
+		}
+		if string(reqDump) != string(body) {
+			t.Errorf("streamed=%v: expected request dump %q, got %q", streamed, body, reqDump)
+		}
+		if string(resDump) != "hello xylium" {
+			t.Errorf("streamed=%v: expected response dump 'hello xylium', got %q", streamed, resDump)
+		}
+	}
+
+	// Body dipotong sesuai MaxBodySize, tetapi handler tetap menerima body lengkap.
+	longName := strings.Repeat("x", 100)
+	longBody := []byte(`{"name":"` + longName + `"}`)
+	for _, streamed := range []bool{false, true} {
+		bound, reqDump, resDump, err := runBodyDumpMiddleware(t, xylium.BodyDumpConfig{MaxBodySize: 10}, longBody, streamed, nil)
+		if err != nil {
+			t.Fatalf("streamed=%v: unexpected error: %v", streamed, err)
+		}
+		if bound.Name != longName {
+			t.Errorf("streamed=%v: expected full body to be bound, got name of length %d", streamed, len(bound.Name))
+		}
+		if string(reqDump) != string(longBody[:10]) || len(resDump) != 10 {
+			t.Errorf("streamed=%v: expected dumps capped at 10 bytes, got %q and %q", streamed, reqDump, resDump)
+		}
+	}
+
+	// Error dari handler dikembalikan apa adanya; response error belum ditulis.
+	handlerErr := errors.New("boom")
+	_, reqDump, resDump, err := runBodyDumpMiddleware(t, xylium.BodyDumpConfig{}, body, false, handlerErr)
+	if err != handlerErr {
+		t.Errorf("Expected handler error to be returned, got %v", err)
+	}
+	if string(reqDump) != string(body) || len(resDump) != 0 {
+		t.Errorf("Expected request dump and empty response dump, got %q and %q", reqDump, resDump)
+	}
+
+	// Skip menonaktifkan dump.
+	called := false
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+	mw := xylium.BodyDump(xylium.BodyDumpConfig{
+		Handler: func(c *xylium.Context, req, res []byte) { called = true },
+		Skip:    func(c *xylium.Context) bool { return true },
+	})
+	if err := mw(func(c *xylium.Context) error { return nil })(ctx); err != nil || called {
+		t.Errorf("Expected Skip to bypass dumping, got err=%v called=%v", err, called)
+	}
+}
+
+func TestBodyDump_RequiresHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic when Handler is nil")
+		}
+	}()
+	xylium.BodyDump(xylium.BodyDumpConfig{})
+}
+
+func TestBodyDump_StreamRequestBodyServer(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.StreamRequestBody = true
+		cfg.MaxRequestBodySize = 1 << 20
+	})
+	var reqDump []byte
+	router.Use(xylium.BodyDump(xylium.BodyDumpConfig{
+		MaxBodySize: 16,
+		Handler:     func(c *xylium.Context, req, res []byte) { reqDump = req },
+	}))
+	router.POST("/echo", func(c *xylium.Context) error {
+		var payload bodyDumpPayload
+		if err := c.Bind(&payload); err != nil {
+			return err
+		}
+		return c.String(xylium.StatusOK, "%d", len(payload.Name))
+	})
+
+	// Body 100KB jauh melebihi MaxBodySize dan buffer baca server, sehingga benar-benar di-stream.
+	name := strings.Repeat("x", 100*1024)
+	resp, err := xylium.NewTestRequest().Method(xylium.MethodPost).Path("/echo").
+		JSON(bodyDumpPayload{Name: name}).Do(router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != xylium.StatusOK || resp.String() != "102400" {
+		t.Fatalf("Expected the handler to bind the whole streamed body, got %d %q", resp.StatusCode(), resp.String())
+	}
+	if want := `{"name":"xxxxxxx`; string(reqDump) != want {
+		t.Errorf("Expected request dump %q, got %q", want, reqDump)
+	}
+}
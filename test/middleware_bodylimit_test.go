@@ -0,0 +1,89 @@
+// File: /test/middleware_bodylimit_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// Helper untuk menjalankan middleware BodyLimit dengan body tertentu.
+// Jika streamed true, body dikirim sebagai stream (chunked, tanpa Content-Length).
+func runBodyLimitMiddleware(t *testing.T, limit int64, body []byte, streamed bool) (handlerBody []byte, handlerCalled bool, err error) {
+	t.Helper()
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(xylium.MethodPost)
+	fasthttpCtx.Request.SetRequestURI("/upload")
+	if streamed {
+		fasthttpCtx.Request.SetBodyStream(bytes.NewReader(body), -1)
+	} else {
+		fasthttpCtx.Request.SetBody(body)
+	}
+
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+	handler := func(c *xylium.Context) error {
+		handlerCalled = true
+		handlerBody = append([]byte(nil), c.Body()...)
+		return nil
+	}
+	err = xylium.BodyLimit(limit)(handler)(ctx)
+	return handlerBody, handlerCalled, err
+}
+
+func TestBodyLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		bodySize      int
+		streamed      bool
+		expectReject  bool
+		expectHandler bool
+	}{
+		{"WithinLimit_ContentLength", 10, false, false, true},
+		{"ExactLimit_ContentLength", 16, false, false, true},
+		{"ExceedsLimit_ContentLength", 17, false, true, false},
+		{"WithinLimit_Streamed", 16, true, false, true},
+		{"ExceedsLimit_Streamed", 1024, true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(strings.Repeat("a", tt.bodySize))
+			handlerBody, called, err := runBodyLimitMiddleware(t, 16, body, tt.streamed)
+
+			if called != tt.expectHandler {
+				t.Errorf("Expected handlerCalled=%v, got %v", tt.expectHandler, called)
+			}
+			if tt.expectReject {
+				var httpErr *xylium.HTTPError
+				if !errors.As(err, &httpErr) {
+					t.Fatalf("Expected *xylium.HTTPError, got %T (%v)", err, err)
+				}
+				if httpErr.Code != xylium.StatusRequestEntityTooLarge {
+					t.Errorf("Expected status %d, got %d", xylium.StatusRequestEntityTooLarge, httpErr.Code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !bytes.Equal(handlerBody, body) {
+				t.Errorf("Expected handler to read body of %d bytes via c.Body(), got %d bytes", len(body), len(handlerBody))
+			}
+		})
+	}
+
+	t.Run("InvalidConfig_ZeroLimit", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic for zero limit, but did not panic")
+			}
+		}()
+		_ = xylium.BodyLimit(0)
+	})
+}
@@ -0,0 +1,129 @@
+// File: /test/middleware_chain_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+func passThroughMiddlewareForTest(next xylium.HandlerFunc) xylium.HandlerFunc {
+	return func(c *xylium.Context) error { return next(c) }
+}
+
+func TestNamedMiddleware_AndMiddlewareChain(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.NamedMiddleware("global-a", passThroughMiddlewareForTest))
+	router.Use(xylium.NamedMiddleware("global-b", passThroughMiddlewareForTest))
+
+	api := router.Group("/api", xylium.NamedMiddleware("api-group", passThroughMiddlewareForTest))
+	users := api.Group("/users", xylium.NamedMiddleware("users-group", passThroughMiddlewareForTest))
+	users.GET("/:id", func(c *xylium.Context) error { return c.NoContent(xylium.StatusNoContent) },
+		xylium.NamedMiddleware("route", passThroughMiddlewareForTest),
+		passThroughMiddlewareForTest, // Tanpa nama: memakai nama fungsi.
+	)
+
+	got := router.MiddlewareChain("get", "/api/users/42")
+	want := []string{"global-a", "global-b", "api-group", "users-group", "route"}
+	if len(got) != len(want)+1 || !reflect.DeepEqual(got[:len(want)], want) ||
+		!strings.HasSuffix(got[len(want)], ".passThroughMiddlewareForTest") {
+		t.Errorf("MiddlewareChain mismatch:\n got: %v\nwant: %v", got, want)
+	}
+
+	if chain := router.MiddlewareChain(xylium.MethodPost, "/api/users/42"); chain != nil {
+		t.Errorf("Expected nil chain for unmatched method, got %v", chain)
+	}
+}
+
+func TestNamedMiddleware_NameKeptWithMiddleware(t *testing.T) {
+	// Nama disimpan pada middleware itu sendiri, sehingga setiap wrapper melaporkan namanya
+	// sendiri, juga setelah GC dan saat wrapper bersarang.
+	var named []xylium.Middleware
+	for i := 0; i < 100; i++ {
+		named = append(named, xylium.NamedMiddleware(fmt.Sprintf("mw-%d", i), passThroughMiddlewareForTest))
+	}
+	runtime.GC()
+	for i, mw := range named {
+		if got, want := xylium.MiddlewareName(mw), fmt.Sprintf("mw-%d", i); got != want {
+			t.Fatalf("Expected name %q, got %q", want, got)
+		}
+	}
+	if got := xylium.MiddlewareName(xylium.NamedMiddleware("outer", named[0])); got != "outer" {
+		t.Errorf("Expected the outermost name for nested NamedMiddleware, got %q", got)
+	}
+
+	// Wrapper bernama tetap menjalankan middleware aslinya.
+	called := false
+	mw := xylium.NamedMiddleware("marker", func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error { called = true; return next(c) }
+	})
+	if err := mw(func(c *xylium.Context) error { return nil })(nil); err != nil || !called {
+		t.Errorf("Expected the named middleware to run the wrapped one, got called=%v err=%v", called, err)
+	}
+}
+
+func TestMiddlewareName_FallbackForBuiltins(t *testing.T) {
+	name := xylium.MiddlewareName(xylium.Gzip())
+	if !strings.HasPrefix(name, "xylium.Gzip") {
+		t.Errorf("Expected fallback name to start with 'xylium.Gzip', got %q", name)
+	}
+	if xylium.MiddlewareName(nil) != "" {
+		t.Error("Expected empty name for nil middleware")
+	}
+}
+
+func TestRouter_WarnsOnMiddlewareShortCircuit(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := xylium.DefaultServerConfig()
+	cfg.Logger = xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:     xylium.LevelDebug,
+		Formatter: xylium.JSONFormatter,
+		Output:    &buf,
+	})
+	router := xylium.NewRouterForTesting(xylium.RouterTestOptions{Mode: xylium.DebugMode, SilenceLogs: true, Config: cfg})
+	router.Use(xylium.NamedMiddleware("global", passThroughMiddlewareForTest))
+	// Middleware yang lupa memanggil next(c) dan tidak menulis respons.
+	forgetful := func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error { return nil }
+	}
+	handlerCalled := false
+	api := router.Group("/api", xylium.NamedMiddleware("api-group", passThroughMiddlewareForTest))
+	api.GET("/broken", func(c *xylium.Context) error {
+		handlerCalled = true
+		return c.String(xylium.StatusOK, "ok")
+	}, xylium.NamedMiddleware("forgetful", forgetful))
+	api.GET("/empty", func(c *xylium.Context) error { return nil })
+
+	// findWarning mencari pesan log yang menyebut middleware yang berhenti.
+	findWarning := func() string {
+		for _, entry := range decodeLogLines(t, &buf) {
+			if msg, _ := entry["message"].(string); strings.Contains(msg, "without calling next(c)") {
+				return msg
+			}
+		}
+		return ""
+	}
+
+	serveRequestForTest(router, xylium.MethodGet, "/api/broken")
+	if handlerCalled {
+		t.Fatal("Expected the handler not to run")
+	}
+	if msg := findWarning(); !strings.Contains(msg, "middleware #3") || !strings.Contains(msg, "(forgetful)") {
+		t.Errorf("Expected a warning naming middleware #3 'forgetful', got %q", msg)
+	}
+
+	// Handler yang tidak menulis respons tetap mendapat pesan umum, bukan peringatan middleware.
+	buf.Reset()
+	serveRequestForTest(router, xylium.MethodGet, "/api/empty")
+	if msg := findWarning(); msg != "" {
+		t.Errorf("Expected no middleware warning when the whole chain ran, got %q", msg)
+	}
+	if !strings.Contains(buf.String(), "completed without writing a response body") {
+		t.Errorf("Expected the generic empty-response message, got %q", buf.String())
+	}
+}
@@ -0,0 +1,181 @@
+// File: /test/middleware_circuitbreaker_test.go
+package xylium_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// newCircuitBreakerRouterForTest membuat router dengan breaker dan handler yang
+// mengembalikan status dari query "status" (atau error biasa jika "fail=plain").
+func newCircuitBreakerRouterForTest(breaker *xylium.Breaker, calls *int) *xylium.Router {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(breaker.Middleware())
+	router.GET("/upstream", func(c *xylium.Context) error {
+		*calls++
+		switch c.QueryParam("result") {
+		case "5xx":
+			return xylium.NewHTTPError(xylium.StatusBadGateway, "upstream failed")
+		case "4xx":
+			return xylium.NewHTTPError(xylium.StatusNotFound, "not found")
+		case "plain":
+			return errors.New("boom")
+		case "status500":
+			return c.String(xylium.StatusInternalServerError, "%s", "failed")
+		}
+		return c.String(xylium.StatusOK, "%s", "ok")
+	})
+	return router
+}
+
+func TestCircuitBreaker_Lifecycle(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		transitions []string
+	)
+	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		OnStateChange: func(key string, from, to xylium.CircuitState) {
+			mu.Lock()
+			transitions = append(transitions, string(from)+"->"+string(to))
+			mu.Unlock()
+		},
+	})
+	calls := 0
+	router := newCircuitBreakerRouterForTest(breaker, &calls)
+
+	// Kesalahan klien (4xx) tidak dihitung sebagai kegagalan.
+	for i := 0; i < 5; i++ {
+		serveRequestForTest(router, xylium.MethodGet, "/upstream?result=4xx")
+	}
+	if got := breaker.State(""); got != xylium.CircuitClosed {
+		t.Fatalf("Expected the circuit to stay closed on 4xx errors, got %s", got)
+	}
+
+	for _, result := range []string{"5xx", "plain", "status500"} {
+		serveRequestForTest(router, xylium.MethodGet, "/upstream?result="+result)
+	}
+	if got := breaker.State(""); got != xylium.CircuitOpen {
+		t.Fatalf("Expected the circuit to open after 3 failures, got %s", got)
+	}
+
+	callsBefore := calls
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/upstream")
+	if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while open, got %d", ctx.Response.StatusCode())
+	}
+	if got := string(ctx.Response.Header.Peek("Retry-After")); got != "1" {
+		t.Errorf("Expected Retry-After '1', got '%s'", got)
+	}
+	if calls != callsBefore {
+		t.Error("Expected the handler not to be called while the circuit is open")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if got := breaker.State(""); got != xylium.CircuitHalfOpen {
+		t.Fatalf("Expected half-open after OpenTimeout, got %s", got)
+	}
+	// Percobaan gagal membuka sirkuit lagi.
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?result=5xx")
+	if got := breaker.State(""); got != xylium.CircuitOpen {
+		t.Fatalf("Expected a failed trial to reopen the circuit, got %s", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/upstream"); ctx.Response.StatusCode() != xylium.StatusOK {
+		t.Fatalf("Expected the trial request to succeed, got %d", ctx.Response.StatusCode())
+	}
+	if got := breaker.State(""); got != xylium.CircuitClosed {
+		t.Errorf("Expected a successful trial to close the circuit, got %s", got)
+	}
+	if states := breaker.States(); len(states) != 0 {
+		t.Errorf("Expected no tracked circuits after closing, got %v", states)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"closed->open", "open->half_open", "half_open->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Expected transitions %v, got %v", want, transitions)
+			break
+		}
+	}
+}
+
+func TestCircuitBreaker_KeyGeneratorAndWindow(t *testing.T) {
+	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Window:           40 * time.Millisecond,
+		KeyGenerator:     func(c *xylium.Context) string { return c.QueryParam("tenant") },
+	})
+	calls := 0
+	router := newCircuitBreakerRouterForTest(breaker, &calls)
+
+	// Kegagalan di luar Window tidak dihitung.
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=a&result=5xx")
+	time.Sleep(50 * time.Millisecond)
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=a&result=5xx")
+	if got := breaker.State("a"); got != xylium.CircuitClosed {
+		t.Fatalf("Expected failures outside the window not to open the circuit, got %s", got)
+	}
+
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=a&result=5xx")
+	if got := breaker.State("a"); got != xylium.CircuitOpen {
+		t.Fatalf("Expected tenant 'a' to be open, got %s", got)
+	}
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=b"); ctx.Response.StatusCode() != xylium.StatusOK {
+		t.Errorf("Expected tenant 'b' to be unaffected, got %d", ctx.Response.StatusCode())
+	}
+	if states := breaker.States(); len(states) != 1 || states["a"] != xylium.CircuitOpen {
+		t.Errorf("Expected only tenant 'a' to be tracked as open, got %v", states)
+	}
+}
+
+func TestCircuitBreaker_PanicCountsAsFailure(t *testing.T) {
+	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{FailureThreshold: 1})
+	router := newRouterWithConfigForTest(nil)
+	router.Use(breaker.Middleware())
+	router.GET("/panic", func(c *xylium.Context) error { panic("boom") })
+
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/panic"); ctx.Response.StatusCode() != xylium.StatusInternalServerError {
+		t.Errorf("Expected the panic to be recovered as 500, got %d", ctx.Response.StatusCode())
+	}
+	if got := breaker.State(""); got != xylium.CircuitOpen {
+		t.Errorf("Expected a panic to count as a failure, got %s", got)
+	}
+}
+
+func TestCircuitBreaker_PrunesStaleCircuits(t *testing.T) {
+	breaker := xylium.NewBreaker(xylium.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Window:           20 * time.Millisecond,
+		OpenTimeout:      20 * time.Millisecond,
+		KeyGenerator:     func(c *xylium.Context) string { return c.QueryParam("tenant") },
+	})
+	calls := 0
+	router := newCircuitBreakerRouterForTest(breaker, &calls)
+
+	// "a" gagal sekali dan "b" terbuka; keduanya tidak pernah terlihat lagi.
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=a&result=5xx")
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=b&result=5xx")
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=b&result=5xx")
+	if states := breaker.States(); len(states) != 2 {
+		t.Fatalf("Expected two tracked circuits, got %v", states)
+	}
+
+	// Permintaan untuk kunci lain memangkas sirkuit yang basi.
+	time.Sleep(60 * time.Millisecond)
+	serveRequestForTest(router, xylium.MethodGet, "/upstream?tenant=c")
+	if states := breaker.States(); len(states) != 0 {
+		t.Errorf("Expected stale circuits to be pruned, got %v", states)
+	}
+}
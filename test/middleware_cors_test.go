@@ -0,0 +1,163 @@
+// File: /test/middleware_cors_test.go
+package xylium_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// serveCORSRequestForTest menjalankan request dengan method dan header tertentu melalui router.
+func serveCORSRequestForTest(router *xylium.Router, method, uri string, headers ...string) *fasthttp.RequestCtx {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(method)
+	fasthttpCtx.Request.SetRequestURI(uri)
+	for i := 0; i+1 < len(headers); i += 2 {
+		fasthttpCtx.Request.Header.Set(headers[i], headers[i+1])
+	}
+	router.Handler(&fasthttpCtx)
+	return &fasthttpCtx
+}
+
+func TestCORS_AllowOriginFunc(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.CORSWithConfig(xylium.CORSConfig{
+		AllowOrigins: []string{"*"}, // Diabaikan karena AllowOriginFunc di-set.
+		AllowOriginFunc: func(origin string) bool {
+			return strings.HasSuffix(origin, ".example.com")
+		},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}))
+	router.Any("/resource", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "ok")
+	})
+
+	t.Run("AllowedOriginIsReflected", func(t *testing.T) {
+		ctx := serveCORSRequestForTest(router, xylium.MethodGet, "/resource", "Origin", "https://tenant.example.com")
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")); got != "https://tenant.example.com" {
+			t.Errorf("Expected ACAO to reflect the origin, got '%s'", got)
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Credentials")); got != "true" {
+			t.Errorf("Expected ACAC 'true', got '%s'", got)
+		}
+		if got := string(ctx.Response.Header.Peek("Vary")); got != "Origin" {
+			t.Errorf("Expected Vary 'Origin', got '%s'", got)
+		}
+	})
+
+	t.Run("RejectedOriginGetsNoACAO", func(t *testing.T) {
+		ctx := serveCORSRequestForTest(router, xylium.MethodGet, "/resource", "Origin", "https://evil.test")
+		if got := ctx.Response.Header.Peek("Access-Control-Allow-Origin"); got != nil {
+			t.Errorf("Expected no ACAO for a rejected origin (AllowOrigins '*' must be ignored), got '%s'", got)
+		}
+		if got := string(ctx.Response.Header.Peek("Vary")); got != "Origin" {
+			t.Errorf("Expected Vary 'Origin', got '%s'", got)
+		}
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Errorf("Expected the handler to still run, got status %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("Preflight", func(t *testing.T) {
+		ctx := serveCORSRequestForTest(router, xylium.MethodOptions, "/resource",
+			"Origin", "https://tenant.example.com",
+			"Access-Control-Request-Method", "PUT")
+		if ctx.Response.StatusCode() != xylium.StatusNoContent {
+			t.Fatalf("Expected preflight status 204, got %d", ctx.Response.StatusCode())
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")); got != "https://tenant.example.com" {
+			t.Errorf("Expected preflight ACAO to reflect the origin, got '%s'", got)
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Max-Age")); got != "600" {
+			t.Errorf("Expected Access-Control-Max-Age '600', got '%s'", got)
+		}
+	})
+}
+
+func TestCORS_WildcardWithCredentialsIsNotReflected(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.CORSWithConfig(xylium.CORSConfig{
+		AllowOrigins:     []string{"*"},
+		AllowCredentials: true,
+	}))
+	router.GET("/resource", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "ok")
+	})
+
+	ctx := serveCORSRequestForTest(router, xylium.MethodGet, "/resource", "Origin", "https://any.test")
+	if got := ctx.Response.Header.Peek("Access-Control-Allow-Origin"); got != nil {
+		t.Errorf("Expected no ACAO for '*' with credentials, got '%s'", got)
+	}
+}
+
+func TestCORS_PreflightShortCircuit(t *testing.T) {
+	newRouter := func(preflightContinue bool) (*xylium.Router, *int) {
+		authCalls := 0
+		auth := func(next xylium.HandlerFunc) xylium.HandlerFunc {
+			return func(c *xylium.Context) error {
+				authCalls++
+				return xylium.NewHTTPError(xylium.StatusUnauthorized, "unauthorized")
+			}
+		}
+		router := newRouterWithConfigForTest(nil)
+		router.Use(xylium.CORSWithConfig(xylium.CORSConfig{
+			AllowOrigins:      []string{"https://app.example.com"},
+			PreflightContinue: preflightContinue,
+		}))
+		router.Use(auth)
+		router.Any("/resource", func(c *xylium.Context) error {
+			return c.String(xylium.StatusOK, "ok")
+		})
+		return router, &authCalls
+	}
+
+	t.Run("AllowedOriginNeverReachesAuth", func(t *testing.T) {
+		router, authCalls := newRouter(false)
+		ctx := serveCORSRequestForTest(router, xylium.MethodOptions, "/resource",
+			"Origin", "https://app.example.com",
+			"Access-Control-Request-Method", "DELETE")
+		if ctx.Response.StatusCode() != xylium.StatusNoContent || *authCalls != 0 {
+			t.Errorf("Expected 204 without running auth, got %d (auth calls: %d)", ctx.Response.StatusCode(), *authCalls)
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")); got != "https://app.example.com" {
+			t.Errorf("Expected ACAO 'https://app.example.com', got '%s'", got)
+		}
+	})
+
+	t.Run("RejectedOriginNeverReachesAuth", func(t *testing.T) {
+		router, authCalls := newRouter(false)
+		ctx := serveCORSRequestForTest(router, xylium.MethodOptions, "/resource",
+			"Origin", "https://evil.test",
+			"Access-Control-Request-Method", "DELETE")
+		if ctx.Response.StatusCode() != xylium.StatusNoContent || *authCalls != 0 {
+			t.Errorf("Expected 204 without running auth, got %d (auth calls: %d)", ctx.Response.StatusCode(), *authCalls)
+		}
+		if got := ctx.Response.Header.Peek("Access-Control-Allow-Origin"); got != nil {
+			t.Errorf("Expected no ACAO for a rejected origin, got '%s'", got)
+		}
+	})
+
+	t.Run("OptionsWithoutRequestMethodIsNotPreflight", func(t *testing.T) {
+		router, authCalls := newRouter(false)
+		ctx := serveCORSRequestForTest(router, xylium.MethodOptions, "/resource", "Origin", "https://app.example.com")
+		if ctx.Response.StatusCode() != xylium.StatusUnauthorized || *authCalls != 1 {
+			t.Errorf("Expected a plain OPTIONS request to go through the chain, got %d (auth calls: %d)", ctx.Response.StatusCode(), *authCalls)
+		}
+	})
+
+	t.Run("PreflightContinue", func(t *testing.T) {
+		router, authCalls := newRouter(true)
+		ctx := serveCORSRequestForTest(router, xylium.MethodOptions, "/resource",
+			"Origin", "https://app.example.com",
+			"Access-Control-Request-Method", "DELETE")
+		if ctx.Response.StatusCode() != xylium.StatusUnauthorized || *authCalls != 1 {
+			t.Errorf("Expected preflight to continue down the chain, got %d (auth calls: %d)", ctx.Response.StatusCode(), *authCalls)
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Methods")); got == "" {
+			t.Error("Expected CORS preflight headers to be set before continuing")
+		}
+	})
+}
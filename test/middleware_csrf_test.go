@@ -0,0 +1,181 @@
+// File: /test/middleware_csrf_test.go
+package xylium_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// csrfTokenForTest adalah token valid (32 byte, Base64 raw URL) untuk cookie CSRF.
+var csrfTokenForTest = strings.Repeat("A", 43)
+
+// serveCSRFRequestForTest menjalankan request dengan cookie CSRF, header, dan body opsional.
+func serveCSRFRequestForTest(router *xylium.Router, method, uri, cookieToken, contentType, body string, headers ...string) *fasthttp.RequestCtx {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(method)
+	fasthttpCtx.Request.SetRequestURI(uri)
+	if cookieToken != "" {
+		fasthttpCtx.Request.Header.SetCookie("_csrf_token", cookieToken)
+	}
+	if contentType != "" {
+		fasthttpCtx.Request.Header.SetContentType(contentType)
+	}
+	fasthttpCtx.Request.SetBodyString(body)
+	for i := 0; i+1 < len(headers); i += 2 {
+		fasthttpCtx.Request.Header.Set(headers[i], headers[i+1])
+	}
+	router.Handler(&fasthttpCtx)
+	return &fasthttpCtx
+}
+
+// responseCSRFCookieForTest mengembalikan nilai cookie CSRF yang di-set pada response.
+func responseCSRFCookieForTest(ctx *fasthttp.RequestCtx) string {
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+	cookie.SetKey("_csrf_token")
+	if !ctx.Response.Header.Cookie(cookie) {
+		return ""
+	}
+	return string(cookie.Value())
+}
+
+func newCSRFRouterForTest(config xylium.CSRFConfig) *xylium.Router {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.CSRFWithConfig(config))
+	handler := func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "ok")
+	}
+	router.GET("/form", handler)
+	router.POST("/submit", handler)
+	return router
+}
+
+func TestCSRF_TokenLookup(t *testing.T) {
+	router := newCSRFRouterForTest(xylium.CSRFConfig{
+		TokenLookup: "header:X-CSRF-Token,json:_csrf,query:csrf",
+	})
+
+	testCases := []struct {
+		name        string
+		uri         string
+		contentType string
+		body        string
+		headers     []string
+		wantStatus  int
+	}{
+		{"Header", "/submit", "", "", []string{"X-CSRF-Token", csrfTokenForTest}, xylium.StatusOK},
+		{"JSONBodyField", "/submit", "application/json", `{"_csrf":"` + csrfTokenForTest + `","name":"x"}`, nil, xylium.StatusOK},
+		{"Query", "/submit?csrf=" + csrfTokenForTest, "", "", nil, xylium.StatusOK},
+		{"JSONFieldNotString", "/submit", "application/json", `{"_csrf":123}`, nil, xylium.StatusForbidden},
+		{"JSONFieldWithoutJSONContentType", "/submit", "text/plain", `{"_csrf":"` + csrfTokenForTest + `"}`, nil, xylium.StatusForbidden},
+		{"Mismatch", "/submit", "", "", []string{"X-CSRF-Token", strings.Repeat("B", 43)}, xylium.StatusForbidden},
+		{"Missing", "/submit", "", "", nil, xylium.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := serveCSRFRequestForTest(router, xylium.MethodPost, tc.uri, csrfTokenForTest, tc.contentType, tc.body, tc.headers...)
+			if ctx.Response.StatusCode() != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d (body: %s)", tc.wantStatus, ctx.Response.StatusCode(), ctx.Response.Body())
+			}
+		})
+	}
+}
+
+func TestCSRF_InvalidTokenLookupPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for an unsupported TokenLookup source")
+		}
+	}()
+	xylium.CSRFWithConfig(xylium.CSRFConfig{TokenLookup: "cookie:_csrf"})
+}
+
+func TestCSRF_ErrorHandler(t *testing.T) {
+	var gotErr error
+	router := newCSRFRouterForTest(xylium.CSRFConfig{
+		ErrorHandler: func(c *xylium.Context, err error) error {
+			gotErr = err
+			return c.JSON(xylium.StatusUnprocessableEntity, xylium.M{"error": "csrf"})
+		},
+	})
+
+	ctx := serveCSRFRequestForTest(router, xylium.MethodPost, "/submit", csrfTokenForTest, "", "")
+	if ctx.Response.StatusCode() != xylium.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d from the custom ErrorHandler, got %d", xylium.StatusUnprocessableEntity, ctx.Response.StatusCode())
+	}
+	if !errors.Is(gotErr, xylium.ErrorCSRFTokenInvalid) {
+		t.Errorf("Expected ErrorCSRFTokenInvalid, got %v", gotErr)
+	}
+}
+
+func TestCSRF_TokenRotation(t *testing.T) {
+	t.Run("RollingByDefault", func(t *testing.T) {
+		router := newCSRFRouterForTest(xylium.CSRFConfig{TokenLength: 16})
+		ctx := serveCSRFRequestForTest(router, xylium.MethodGet, "/form", csrfTokenForTest, "", "")
+		got := responseCSRFCookieForTest(ctx)
+		if got == "" || got == csrfTokenForTest {
+			t.Errorf("Expected a new token in the response cookie, got '%s'", got)
+		}
+		if len(got) != 22 { // 16 byte -> 22 karakter Base64 raw URL.
+			t.Errorf("Expected a token of 22 characters for TokenLength 16, got %d", len(got))
+		}
+	})
+
+	t.Run("PerSessionKeepsToken", func(t *testing.T) {
+		router := newCSRFRouterForTest(xylium.CSRFConfig{PerSessionToken: true})
+		ctx := serveCSRFRequestForTest(router, xylium.MethodPost, "/submit", csrfTokenForTest, "", "", "X-CSRF-Token", csrfTokenForTest)
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected status 200, got %d", ctx.Response.StatusCode())
+		}
+		if got := responseCSRFCookieForTest(ctx); got != csrfTokenForTest {
+			t.Errorf("Expected the session token to be kept, got '%s'", got)
+		}
+	})
+
+	t.Run("PerSessionReplacesMalformedToken", func(t *testing.T) {
+		router := newCSRFRouterForTest(xylium.CSRFConfig{PerSessionToken: true})
+		ctx := serveCSRFRequestForTest(router, xylium.MethodGet, "/form", "short", "", "")
+		if got := responseCSRFCookieForTest(ctx); got == "" || got == "short" {
+			t.Errorf("Expected a malformed token to be replaced, got '%s'", got)
+		}
+	})
+
+	t.Run("RotateCSRFToken", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.Use(xylium.CSRFWithConfig(xylium.CSRFConfig{PerSessionToken: true}))
+		var rotated string
+		router.POST("/login", func(c *xylium.Context) error {
+			var err error
+			if rotated, err = xylium.RotateCSRFToken(c); err != nil {
+				return err
+			}
+			if got := c.MustGet(xylium.ContextKeyCSRFToken); got != rotated {
+				t.Errorf("Expected the context token to be updated to '%s', got '%v'", rotated, got)
+			}
+			return c.NoContent(xylium.StatusNoContent)
+		})
+
+		ctx := serveCSRFRequestForTest(router, xylium.MethodPost, "/login", csrfTokenForTest, "", "", "X-CSRF-Token", csrfTokenForTest)
+		if ctx.Response.StatusCode() != xylium.StatusNoContent {
+			t.Fatalf("Expected status 204, got %d", ctx.Response.StatusCode())
+		}
+		if rotated == "" || rotated == csrfTokenForTest {
+			t.Fatalf("Expected a new token, got '%s'", rotated)
+		}
+		if got := responseCSRFCookieForTest(ctx); got != rotated {
+			t.Errorf("Expected the response cookie to hold the rotated token '%s', got '%s'", rotated, got)
+		}
+	})
+
+	t.Run("RotateWithoutMiddleware", func(t *testing.T) {
+		ctx := xylium.NewContextForTest(nil, nil)
+		if _, err := xylium.RotateCSRFToken(ctx); err == nil {
+			t.Error("Expected an error when the CSRF middleware did not run")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// File: /test/middleware_decompress_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// compressForTest mengompres data dengan encoding tertentu ("deflate-raw" untuk deflate tanpa zlib).
+func compressForTest(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "deflate-raw":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	default:
+		t.Fatalf("unknown encoding %s", encoding)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type decompressPayload struct {
+	Message string `json:"message"`
+}
+
+// runDecompressMiddleware menjalankan middleware Decompress dan melakukan binding JSON di handler.
+func runDecompressMiddleware(config xylium.DecompressConfig, contentEncoding string, body []byte) (bound decompressPayload, handlerCalled bool, err error) {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(xylium.MethodPost)
+	fasthttpCtx.Request.SetRequestURI("/ingest")
+	fasthttpCtx.Request.Header.SetContentType("application/json")
+	if contentEncoding != "" {
+		fasthttpCtx.Request.Header.Set("Content-Encoding", contentEncoding)
+	}
+	fasthttpCtx.Request.SetBody(body)
+	fasthttpCtx.Request.Header.SetContentLength(len(body))
+
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+	handler := func(c *xylium.Context) error {
+		handlerCalled = true
+		if ce := c.Header("Content-Encoding"); ce != "" && ce != "identity" {
+			return errors.New("Content-Encoding header should have been removed")
+		}
+		return c.Bind(&bound)
+	}
+	err = xylium.DecompressWithConfig(config)(handler)(ctx)
+	return bound, handlerCalled, err
+}
+
+func TestDecompress(t *testing.T) {
+	payload := []byte(`{"message":"hello compressed world"}`)
+
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+	}{
+		{"Gzip", "gzip", compressForTest(t, "gzip", payload)},
+		{"DeflateZlib", "deflate", compressForTest(t, "deflate", payload)},
+		{"DeflateRaw", "deflate", compressForTest(t, "deflate-raw", payload)},
+		{"Brotli", "br", compressForTest(t, "br", payload)},
+		{"GzipThenBrotli", "gzip, br", compressForTest(t, "br", compressForTest(t, "gzip", payload))},
+		{"UppercaseEncoding", "GZIP", compressForTest(t, "gzip", payload)},
+		{"Identity", "identity", payload},
+		{"NoEncoding", "", payload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bound, called, err := runDecompressMiddleware(xylium.DecompressConfig{}, tt.contentEncoding, tt.body)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !called || bound.Message != "hello compressed world" {
+				t.Errorf("Expected handler to bind decompressed body, got called=%v bound=%+v", called, bound)
+			}
+		})
+	}
+}
+
+func TestDecompress_Errors(t *testing.T) {
+	bomb := compressForTest(t, "gzip", []byte(strings.Repeat("0", 1<<20)))
+
+	tests := []struct {
+		name            string
+		config          xylium.DecompressConfig
+		contentEncoding string
+		body            []byte
+		expectedStatus  int
+	}{
+		{"ExceedsMaxSize", xylium.DecompressConfig{MaxDecompressedSize: 1024}, "gzip", bomb, xylium.StatusRequestEntityTooLarge},
+		{"Malformed", xylium.DecompressConfig{}, "gzip", []byte("not gzip"), xylium.StatusBadRequest},
+		{"UnsupportedEncoding", xylium.DecompressConfig{}, "compress", []byte("data"), xylium.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, called, err := runDecompressMiddleware(tt.config, tt.contentEncoding, tt.body)
+			if called {
+				t.Error("Expected handler not to be called")
+			}
+			var httpErr *xylium.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.Code != tt.expectedStatus {
+				t.Errorf("Expected HTTPError with status %d, got %v", tt.expectedStatus, err)
+			}
+		})
+	}
+
+	// Skip membiarkan body terkompresi apa adanya.
+	_, called, err := runDecompressMiddleware(xylium.DecompressConfig{
+		Skip: func(c *xylium.Context) bool { return true },
+	}, "gzip", bomb)
+	if !called || err == nil {
+		t.Errorf("Expected Skip to pass the compressed body through (binding fails), got called=%v err=%v", called, err)
+	}
+}
@@ -0,0 +1,68 @@
+// File: /test/middleware_defaultheaders_test.go
+package xylium_test
+
+import (
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// Helper untuk menjalankan middleware DefaultHeaders dengan handler tertentu.
+func runDefaultHeadersMiddleware(t *testing.T, headers map[string]string, handler xylium.HandlerFunc) *fasthttp.ResponseHeader {
+	t.Helper()
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	if err := xylium.DefaultHeaders(headers)(handler)(ctx); err != nil {
+		t.Fatalf("Middleware execution returned an error: %v", err)
+	}
+	return &fasthttpCtx.Response.Header
+}
+
+func TestDefaultHeaders_AppliesMissingHeaders(t *testing.T) {
+	headers := runDefaultHeadersMiddleware(t, map[string]string{
+		"x-app-version": "1.4.2",
+		"Cache-Control": "no-store",
+	}, func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "ok")
+	})
+
+	if got := string(headers.Peek("X-App-Version")); got != "1.4.2" {
+		t.Errorf("X-App-Version: expected '1.4.2', got '%s'", got)
+	}
+	if got := string(headers.Peek("Cache-Control")); got != "no-store" {
+		t.Errorf("Cache-Control: expected 'no-store', got '%s'", got)
+	}
+}
+
+func TestDefaultHeaders_KeepsExplicitValues(t *testing.T) {
+	// SecureHeaders berjalan di dalam rantai dan menetapkan X-Frame-Options lebih dulu.
+	secure := xylium.SecureHeaders(xylium.SecureHeadersConfig{FrameOptions: "DENY"})
+	headers := runDefaultHeadersMiddleware(t, map[string]string{
+		"Cache-Control":   "no-store",
+		"X-Frame-Options": "SAMEORIGIN",
+	}, secure(func(c *xylium.Context) error {
+		c.SetHeader("Cache-Control", "public, max-age=60")
+		return c.NoContent(xylium.StatusNotModified)
+	}))
+
+	if got := string(headers.Peek("Cache-Control")); got != "public, max-age=60" {
+		t.Errorf("Cache-Control: expected handler value to be kept, got '%s'", got)
+	}
+	if got := string(headers.Peek("X-Frame-Options")); got != "DENY" {
+		t.Errorf("X-Frame-Options: expected SecureHeaders value to be kept, got '%s'", got)
+	}
+}
+
+func TestDefaultHeaders_RejectsReservedHeaders(t *testing.T) {
+	for _, name := range []string{"", "content-length", "Content-Type"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected DefaultHeaders to panic for header name %q", name)
+				}
+			}()
+			xylium.DefaultHeaders(map[string]string{name: "x"})
+		}()
+	}
+}
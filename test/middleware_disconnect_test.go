@@ -0,0 +1,79 @@
+// File: /test/middleware_disconnect_test.go
+package xylium_test
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+func TestCancelOnDisconnect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("disconnect detection requires MSG_PEEK support")
+	}
+
+	type result struct {
+		cause        error
+		disconnected bool
+	}
+	results := make(chan result, 1)
+
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/slow", func(c *xylium.Context) error {
+		select {
+		case <-c.Context().Done():
+			results <- result{cause: context.Cause(c.Context()), disconnected: c.IsClientDisconnected()}
+		case <-time.After(5 * time.Second):
+			results <- result{}
+		}
+		return nil
+	}, xylium.CancelOnDisconnectWithConfig(xylium.CancelOnDisconnectConfig{PollInterval: 10 * time.Millisecond}))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	server := &fasthttp.Server{Handler: router.Handler}
+	go func() { _ = server.Serve(ln) }()
+	defer func() { _ = server.Shutdown() }()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	if _, err := conn.Write([]byte("GET /slow HTTP/1.1\r\nHost: example.com\r\n\r\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond) // Biarkan handler mulai berjalan.
+	conn.Close()
+
+	select {
+	case res := <-results:
+		if res.cause != xylium.ErrClientDisconnected {
+			t.Errorf("Expected context cause ErrClientDisconnected, got %v", res.cause)
+		}
+		if !res.disconnected {
+			t.Error("Expected IsClientDisconnected to report true after the client closed")
+		}
+	case <-time.After(6 * time.Second):
+		t.Fatal("Handler did not finish")
+	}
+}
+
+func TestContext_IsClientDisconnected_WithoutConnection(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/", func(c *xylium.Context) error {
+		if c.IsClientDisconnected() {
+			t.Error("Expected false for a context without a network connection")
+		}
+		return c.NoContent(xylium.StatusNoContent)
+	}, xylium.CancelOnDisconnect())
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/"); ctx.Response.StatusCode() != xylium.StatusNoContent {
+		t.Errorf("Expected 204, got %d", ctx.Response.StatusCode())
+	}
+}
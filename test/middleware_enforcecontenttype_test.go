@@ -0,0 +1,99 @@
+// File: /test/middleware_enforcecontenttype_test.go
+package xylium_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// Helper untuk menjalankan middleware EnforceContentType dengan method, Content-Type dan body tertentu.
+func runEnforceContentTypeMiddleware(mw xylium.Middleware, method, contentType, body string) (called bool, status int) {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(method)
+	fasthttpCtx.Request.SetRequestURI("/items")
+	if contentType != "" {
+		fasthttpCtx.Request.Header.SetContentType(contentType)
+	}
+	fasthttpCtx.Request.SetBodyString(body)
+	fasthttpCtx.Request.Header.SetContentLength(len(body))
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	err := mw(func(c *xylium.Context) error {
+		called = true
+		return nil
+	})(ctx)
+	var httpErr *xylium.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+	}
+	return called, status
+}
+
+func TestEnforceContentType(t *testing.T) {
+	mw := xylium.EnforceContentType("application/json", "text/*")
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		expectCall  bool
+	}{
+		{"AcceptedType", "POST", "application/json", `{"a":1}`, true},
+		{"AcceptedTypeWithParams", "PUT", "Application/JSON; charset=utf-8", `{"a":1}`, true},
+		{"WildcardSubtype", "PATCH", "text/csv", "a,b", true},
+		{"MissingContentType", "POST", "", `{"a":1}`, false},
+		{"UnsupportedType", "POST", "application/xml", "<a/>", false},
+		{"EmptyBodyAllowed", "POST", "", "", true},
+		{"UncheckedMethod", "DELETE", "application/xml", "<a/>", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, status := runEnforceContentTypeMiddleware(mw, tt.method, tt.contentType, tt.body)
+			if called != tt.expectCall {
+				t.Fatalf("Expected handler called=%v, got %v (status %d)", tt.expectCall, called, status)
+			}
+			if !tt.expectCall && status != xylium.StatusUnsupportedMediaType {
+				t.Errorf("Expected status 415, got %d", status)
+			}
+		})
+	}
+
+	t.Run("WithConfig", func(t *testing.T) {
+		strict := xylium.EnforceContentTypeWithConfig(xylium.EnforceContentTypeConfig{
+			Types:           []string{"application/json"},
+			Methods:         []string{"POST", "DELETE"},
+			RejectEmptyBody: true,
+			Skip:            func(c *xylium.Context) bool { return c.Header("X-Webhook") != "" },
+		})
+		if called, _ := runEnforceContentTypeMiddleware(strict, "POST", "", ""); called {
+			t.Error("Expected an empty body without Content-Type to be rejected with RejectEmptyBody")
+		}
+		if called, _ := runEnforceContentTypeMiddleware(strict, "DELETE", "text/plain", "x"); called {
+			t.Error("Expected DELETE to be checked when listed in Methods")
+		}
+		if called, _ := runEnforceContentTypeMiddleware(strict, "PUT", "text/plain", "x"); !called {
+			t.Error("Expected PUT to pass when not listed in Methods")
+		}
+
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.SetMethod("POST")
+		fasthttpCtx.Request.Header.Set("X-Webhook", "1")
+		fasthttpCtx.Request.SetBodyString("x")
+		called := false
+		if err := strict(func(c *xylium.Context) error { called = true; return nil })(xylium.NewContextForTest(nil, &fasthttpCtx)); err != nil || !called {
+			t.Errorf("Expected Skip to bypass the check, got called=%v err=%v", called, err)
+		}
+	})
+
+	t.Run("PanicsWithoutTypes", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected EnforceContentType() without types to panic")
+			}
+		}()
+		xylium.EnforceContentType()
+	})
+}
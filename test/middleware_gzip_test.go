@@ -2,8 +2,10 @@
 package xylium_test
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -274,3 +276,175 @@ func TestGzipMiddleware_WithCustomConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestGzipMiddleware_SkipAndNegotiation(t *testing.T) {
+	longBody := strings.Repeat("Xylium Framework is Fast! ", 100)
+
+	t.Run("DefaultSkipContentTypes_TakePrecedence", func(t *testing.T) {
+		config := xylium.GzipConfig{ContentTypes: []string{"image/png", "video/mp4", "text/plain"}}
+		for _, contentType := range []string{"image/png", "video/mp4"} {
+			result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longBody, contentType, "")
+			if result.contentEncoding == "gzip" || result.varyHeader != "" {
+				t.Errorf("%s: expected no compression and no Vary, got encoding '%s', Vary '%s'", contentType, result.contentEncoding, result.varyHeader)
+			}
+		}
+		result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longBody, "text/plain", "")
+		if result.contentEncoding != "gzip" {
+			t.Errorf("Expected text/plain to be compressed, got '%s'", result.contentEncoding)
+		}
+	})
+
+	t.Run("CustomSkipContentTypes_Wildcard", func(t *testing.T) {
+		config := xylium.GzipConfig{SkipContentTypes: []string{"text/*"}}
+		result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longBody, "text/html; charset=utf-8", "")
+		if result.contentEncoding == "gzip" {
+			t.Errorf("Expected text/html to be skipped by 'text/*', got '%s'", result.contentEncoding)
+		}
+	})
+
+	t.Run("EmptySkipContentTypes_DisablesDefaults", func(t *testing.T) {
+		config := xylium.GzipConfig{ContentTypes: []string{"image/png"}, SkipContentTypes: []string{}}
+		result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longBody, "image/png", "")
+		if result.contentEncoding != "gzip" {
+			t.Errorf("Expected image/png to be compressed with an empty skip list, got '%s'", result.contentEncoding)
+		}
+	})
+
+	t.Run("SkipFunc", func(t *testing.T) {
+		config := xylium.GzipConfig{Skip: func(c *xylium.Context) bool { return c.Path() == "/test-gzip" }}
+		result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longBody, "text/plain", "")
+		if !result.handlerCalled || result.contentEncoding == "gzip" || result.varyHeader != "" {
+			t.Errorf("Expected Skip to bypass compression, got called=%t encoding '%s' Vary '%s'", result.handlerCalled, result.contentEncoding, result.varyHeader)
+		}
+	})
+
+	t.Run("AcceptEncodingNegotiation", func(t *testing.T) {
+		tests := []struct {
+			acceptEncoding string
+			wantGzip       bool
+		}{
+			{"gzip;q=0", false},
+			{"GZIP; q=0.5", true},
+			{"br, x-gzip", true},
+			{"*", true},
+			{"*;q=0", false},
+			{"gzip;q=0, *", false},
+			{"identity", false},
+			{"", false},
+		}
+		for _, tt := range tests {
+			result := runGzipMiddleware(t, nil, tt.acceptEncoding, http.StatusOK, longBody, "text/plain", "")
+			if got := result.contentEncoding == "gzip"; got != tt.wantGzip {
+				t.Errorf("Accept-Encoding %q: expected gzip=%t, got encoding '%s'", tt.acceptEncoding, tt.wantGzip, result.contentEncoding)
+			}
+			// Respons yang memenuhi syarat selalu membawa Vary, dikompresi atau tidak.
+			if result.varyHeader != "Accept-Encoding" {
+				t.Errorf("Accept-Encoding %q: expected 'Vary: Accept-Encoding', got '%s'", tt.acceptEncoding, result.varyHeader)
+			}
+		}
+	})
+
+	t.Run("StreamedBody_NotCompressed", func(t *testing.T) {
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.Set("Accept-Encoding", "gzip")
+		fasthttpCtx.Request.SetRequestURI("/stream")
+		ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+		ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+		handler := xylium.Gzip()(func(c *xylium.Context) error {
+			c.SetContentType("text/plain")
+			c.Ctx.Response.SetBodyStream(strings.NewReader(longBody), len(longBody))
+			return nil
+		})
+		if err := handler(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !fasthttpCtx.Response.IsBodyStream() || len(fasthttpCtx.Response.Header.Peek("Content-Encoding")) > 0 {
+			t.Error("Expected streamed body to be left untouched")
+		}
+	})
+}
+
+func TestGzipMiddleware_ContextStreams(t *testing.T) {
+	// newStreamCtx menyiapkan context dengan Accept-Encoding gzip.
+	newStreamCtx := func() (*fasthttp.RequestCtx, *xylium.Context) {
+		fasthttpCtx := &fasthttp.RequestCtx{}
+		fasthttpCtx.Request.Header.Set("Accept-Encoding", "gzip")
+		fasthttpCtx.Request.SetRequestURI("/events")
+		ctx := xylium.NewContextForTest(nil, fasthttpCtx)
+		ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+		return fasthttpCtx, ctx
+	}
+
+	t.Run("SSE_CompressedIncrementally", func(t *testing.T) {
+		fasthttpCtx, ctx := newStreamCtx()
+		release := make(chan struct{})
+		handler := xylium.Gzip()(func(c *xylium.Context) error {
+			c.SetContentType("text/event-stream")
+			c.SetBodyStreamWriter(func(w *bufio.Writer) {
+				fmt.Fprint(w, "data: one\n\n")
+				_ = w.Flush()
+				<-release // Event kedua baru dikirim setelah event pertama diterima klien.
+				fmt.Fprint(w, "data: two\n\n")
+			})
+			return nil
+		})
+		if err := handler(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := string(fasthttpCtx.Response.Header.Peek("Content-Encoding")); got != "gzip" {
+			t.Fatalf("Expected Content-Encoding gzip for the stream, got '%s'", got)
+		}
+		if got := string(fasthttpCtx.Response.Header.Peek("Vary")); got != "Accept-Encoding" {
+			t.Errorf("Expected 'Vary: Accept-Encoding', got '%s'", got)
+		}
+
+		reader, err := gzip.NewReader(fasthttpCtx.Response.BodyStream())
+		if err != nil {
+			t.Fatalf("Failed to open gzip stream: %v", err)
+		}
+		first := make([]byte, len("data: one\n\n"))
+		if _, err := io.ReadFull(reader, first); err != nil || string(first) != "data: one\n\n" {
+			t.Fatalf("Expected the first event before the stream ends, got %q (err %v)", first, err)
+		}
+		close(release)
+		rest, err := io.ReadAll(reader)
+		if err != nil || string(rest) != "data: two\n\n" {
+			t.Errorf("Expected the second event, got %q (err %v)", rest, err)
+		}
+	})
+
+	t.Run("Stream_SmallerThanMinLength", func(t *testing.T) {
+		fasthttpCtx, ctx := newStreamCtx()
+		handler := xylium.GzipWithConfig(xylium.GzipConfig{MinLength: 1024})(func(c *xylium.Context) error {
+			return c.Stream(strings.NewReader("short"), 5, "text/plain")
+		})
+		if err := handler(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fasthttpCtx.Response.Header.Peek("Content-Encoding")) > 0 || string(fasthttpCtx.Response.Body()) != "short" {
+			t.Errorf("Expected a small stream to be sent uncompressed, got encoding '%s'", fasthttpCtx.Response.Header.Peek("Content-Encoding"))
+		}
+	})
+
+	t.Run("StreamSkipContentTypes", func(t *testing.T) {
+		config := xylium.GzipConfig{StreamSkipContentTypes: []string{"text/event-stream"}}
+		fasthttpCtx, ctx := newStreamCtx()
+		handler := xylium.GzipWithConfig(config)(func(c *xylium.Context) error {
+			return c.Stream(strings.NewReader("data: one\n\n"), -1, "text/event-stream")
+		})
+		if err := handler(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fasthttpCtx.Response.Header.Peek("Content-Encoding")) > 0 || string(fasthttpCtx.Response.Body()) != "data: one\n\n" {
+			t.Errorf("Expected the stream to be skipped, got encoding '%s'", fasthttpCtx.Response.Header.Peek("Content-Encoding"))
+		}
+
+		// Respons biasa dengan tipe yang sama tetap dikompresi.
+		longEvents := strings.Repeat("data: event\n\n", 100)
+		result := runGzipMiddleware(t, &config, "gzip", http.StatusOK, longEvents, "text/event-stream", "")
+		if result.contentEncoding != "gzip" {
+			t.Errorf("Expected a buffered text/event-stream response to be compressed, got '%s'", result.contentEncoding)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// File: /test/middleware_httpsredirect_test.go
+package xylium_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// serveFromAddrForTest menjalankan request dari alamat remote tertentu, dengan header opsional.
+func serveFromAddrForTest(router *xylium.Router, remoteIP, method, uri string, headers map[string]string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.Header.SetMethod(method)
+	req.SetRequestURI(uri)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Init(&req, &net.TCPAddr{IP: net.ParseIP(remoteIP), Port: 40000}, nil)
+	router.Handler(&fasthttpCtx)
+	return &fasthttpCtx
+}
+
+func TestHTTPSRedirect(t *testing.T) {
+	okHandler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") }
+	newRouter := func(config xylium.HTTPSRedirectConfig) *xylium.Router {
+		router := newRouterWithConfigForTest(nil)
+		router.Use(xylium.HTTPSRedirect(config))
+		router.GET("/orders", okHandler)
+		router.GET("/metrics", okHandler)
+		router.Liveness("/livez")
+		return router
+	}
+
+	t.Run("RedirectsPlainHTTP", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{})
+		ctx := serveFromAddrForTest(router, "203.0.113.7", xylium.MethodGet, "http://example.com:8080/orders?page=2", nil)
+		if ctx.Response.StatusCode() != xylium.StatusMovedPermanently {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusMovedPermanently, ctx.Response.StatusCode())
+		}
+		if loc := string(ctx.Response.Header.Peek("Location")); loc != "https://example.com/orders?page=2" {
+			t.Errorf("Expected Location 'https://example.com/orders?page=2', got '%s'", loc)
+		}
+	})
+
+	t.Run("PermanentRedirectWithHost", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{Code: xylium.StatusPermanentRedirect, Host: "secure.example.com:8443"})
+		ctx := serveFromAddrForTest(router, "203.0.113.7", xylium.MethodGet, "http://example.com/orders", nil)
+		if ctx.Response.StatusCode() != xylium.StatusPermanentRedirect {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusPermanentRedirect, ctx.Response.StatusCode())
+		}
+		if loc := string(ctx.Response.Header.Peek("Location")); loc != "https://secure.example.com:8443/orders" {
+			t.Errorf("Expected Location 'https://secure.example.com:8443/orders', got '%s'", loc)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{Reject: true})
+		ctx := serveFromAddrForTest(router, "203.0.113.7", xylium.MethodGet, "http://example.com/orders", nil)
+		if ctx.Response.StatusCode() != xylium.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", xylium.StatusBadRequest, ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("ForwardedProtoFromTrustedProxy", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1"}})
+		headers := map[string]string{"X-Forwarded-Proto": "https"}
+		for _, ip := range []string{"10.1.2.3", "192.0.2.1"} {
+			ctx := serveFromAddrForTest(router, ip, xylium.MethodGet, "http://example.com/orders", headers)
+			if ctx.Response.StatusCode() != xylium.StatusOK {
+				t.Errorf("Expected status %d for trusted proxy %s, got %d", xylium.StatusOK, ip, ctx.Response.StatusCode())
+			}
+		}
+		ctx := serveFromAddrForTest(router, "10.1.2.3", xylium.MethodGet, "http://example.com/orders", map[string]string{"X-Forwarded-Proto": "http"})
+		if ctx.Response.StatusCode() != xylium.StatusMovedPermanently {
+			t.Errorf("Expected status %d for forwarded http, got %d", xylium.StatusMovedPermanently, ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("ForwardedProtoUsesValueOfTrustedProxy", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+		// Proxy yang menambahkan nilai: nilai pertama berasal dari klien.
+		spoofed := map[string]string{"X-Forwarded-Proto": "https, http"}
+		ctx := serveFromAddrForTest(router, "10.1.2.3", xylium.MethodGet, "http://example.com/orders", spoofed)
+		if ctx.Response.StatusCode() != xylium.StatusMovedPermanently {
+			t.Errorf("Expected a client-sent https before the proxy's http to be ignored (status %d), got %d", xylium.StatusMovedPermanently, ctx.Response.StatusCode())
+		}
+		ctx = serveFromAddrForTest(router, "10.1.2.3", xylium.MethodGet, "http://example.com/orders", map[string]string{"X-Forwarded-Proto": "http, https"})
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Errorf("Expected the proxy's https to be honored, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("ForwardedProtoFromUntrustedClientIgnored", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+		ctx := serveFromAddrForTest(router, "203.0.113.7", xylium.MethodGet, "http://example.com/orders", map[string]string{"X-Forwarded-Proto": "https"})
+		if ctx.Response.StatusCode() != xylium.StatusMovedPermanently {
+			t.Errorf("Expected spoofed X-Forwarded-Proto to be ignored (status %d), got %d", xylium.StatusMovedPermanently, ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("SkipsHealthAndConfiguredPaths", func(t *testing.T) {
+		router := newRouter(xylium.HTTPSRedirectConfig{SkipPaths: []string{"/metrics"}})
+		for _, path := range []string{"/livez", "/metrics"} {
+			ctx := serveFromAddrForTest(router, "203.0.113.7", xylium.MethodGet, "http://example.com"+path, nil)
+			if ctx.Response.StatusCode() != xylium.StatusOK {
+				t.Errorf("Expected %s to be skipped (status %d), got %d", path, xylium.StatusOK, ctx.Response.StatusCode())
+			}
+		}
+	})
+
+	t.Run("InvalidConfigPanics", func(t *testing.T) {
+		for name, config := range map[string]xylium.HTTPSRedirectConfig{
+			"BadProxy": {TrustedProxies: []string{"not-an-ip"}},
+			"BadCode":  {Code: xylium.StatusOK},
+			"300":      {Code: xylium.StatusMultipleChoices},
+			"305":      {Code: xylium.StatusUseProxy},
+			"309":      {Code: 309},
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s: expected HTTPSRedirect to panic", name)
+					}
+				}()
+				xylium.HTTPSRedirect(config)
+			}()
+		}
+	})
+}
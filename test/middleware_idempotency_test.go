@@ -0,0 +1,147 @@
+// File: /test/middleware_idempotency_test.go
+package xylium_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// serveIdempotentRequestForTest mengirim request dengan body dan Idempotency-Key (opsional).
+func serveIdempotentRequestForTest(router *xylium.Router, method, uri, key, body string) *fasthttp.RequestCtx {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(method)
+	fasthttpCtx.Request.SetRequestURI(uri)
+	if key != "" {
+		fasthttpCtx.Request.Header.Set("Idempotency-Key", key)
+	}
+	fasthttpCtx.Request.SetBodyString(body)
+	router.Handler(&fasthttpCtx)
+	return &fasthttpCtx
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	var calls int32
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.Idempotency(xylium.IdempotencyConfig{}))
+	router.POST("/payments", func(c *xylium.Context) error {
+		n := atomic.AddInt32(&calls, 1)
+		c.SetHeader("X-Payment-Attempt", "first")
+		return c.JSON(xylium.StatusCreated, xylium.M{"payment": n})
+	})
+	router.GET("/payments", func(c *xylium.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return c.String(xylium.StatusOK, "%s", "list")
+	})
+
+	first := serveIdempotentRequestForTest(router, xylium.MethodPost, "/payments", "key-1", `{"amount":10}`)
+	second := serveIdempotentRequestForTest(router, xylium.MethodPost, "/payments", "key-1", `{"amount":10}`)
+
+	if calls != 1 {
+		t.Fatalf("Expected the handler to run once, ran %d times", calls)
+	}
+	if second.Response.StatusCode() != xylium.StatusCreated || string(second.Response.Body()) != string(first.Response.Body()) {
+		t.Errorf("Expected replayed 201 %q, got %d %q", first.Response.Body(), second.Response.StatusCode(), second.Response.Body())
+	}
+	if got := string(second.Response.Header.Peek("X-Payment-Attempt")); got != "first" {
+		t.Errorf("Expected replayed header 'first', got '%s'", got)
+	}
+	if got := string(second.Response.Header.ContentType()); got != "application/json; charset=utf-8" {
+		t.Errorf("Expected replayed Content-Type, got '%s'", got)
+	}
+	if got := string(second.Response.Header.Peek("Idempotent-Replayed")); got != "true" {
+		t.Errorf("Expected Idempotent-Replayed 'true', got '%s'", got)
+	}
+	if got := first.Response.Header.Peek("Idempotent-Replayed"); got != nil {
+		t.Errorf("Expected no Idempotent-Replayed header on the original response, got '%s'", got)
+	}
+
+	// Key yang sama dengan payload berbeda ditolak.
+	ctx := serveIdempotentRequestForTest(router, xylium.MethodPost, "/payments", "key-1", `{"amount":99}`)
+	if ctx.Response.StatusCode() != xylium.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a reused key with a different payload, got %d", ctx.Response.StatusCode())
+	}
+
+	// Tanpa key, atau dengan method lain, handler selalu dijalankan.
+	serveIdempotentRequestForTest(router, xylium.MethodPost, "/payments", "", `{"amount":10}`)
+	serveIdempotentRequestForTest(router, xylium.MethodGet, "/payments", "key-1", "")
+	if calls != 3 {
+		t.Errorf("Expected requests without key or with other methods to run the handler, calls=%d", calls)
+	}
+}
+
+func TestIdempotency_InFlightAndFailures(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	failNext := int32(1)
+
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.Idempotency(xylium.IdempotencyConfig{Required: true}))
+	router.POST("/slow", func(c *xylium.Context) error {
+		atomic.AddInt32(&calls, 1)
+		started <- struct{}{}
+		<-release
+		return c.String(xylium.StatusOK, "%s", "done")
+	})
+	router.POST("/flaky", func(c *xylium.Context) error {
+		atomic.AddInt32(&calls, 1)
+		if atomic.CompareAndSwapInt32(&failNext, 1, 0) {
+			return errors.New("temporary failure")
+		}
+		return c.String(xylium.StatusOK, "%s", "ok")
+	})
+
+	// Request kedua dengan key yang sama saat request pertama masih berjalan mendapat 409.
+	done := make(chan *fasthttp.RequestCtx)
+	go func() { done <- serveIdempotentRequestForTest(router, xylium.MethodPost, "/slow", "k", "") }()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Handler did not start")
+	}
+	if ctx := serveIdempotentRequestForTest(router, xylium.MethodPost, "/slow", "k", ""); ctx.Response.StatusCode() != xylium.StatusConflict {
+		t.Errorf("Expected 409 for an in-flight key, got %d", ctx.Response.StatusCode())
+	}
+	close(release)
+	if ctx := <-done; ctx.Response.StatusCode() != xylium.StatusOK {
+		t.Errorf("Expected the original request to succeed, got %d", ctx.Response.StatusCode())
+	}
+
+	// Handler yang gagal melepaskan key sehingga retry dieksekusi ulang.
+	callsBefore := atomic.LoadInt32(&calls)
+	if ctx := serveIdempotentRequestForTest(router, xylium.MethodPost, "/flaky", "k", ""); ctx.Response.StatusCode() != xylium.StatusInternalServerError {
+		t.Errorf("Expected the failing attempt to return 500, got %d", ctx.Response.StatusCode())
+	}
+	if ctx := serveIdempotentRequestForTest(router, xylium.MethodPost, "/flaky", "k", ""); ctx.Response.StatusCode() != xylium.StatusOK {
+		t.Errorf("Expected the retry to succeed, got %d", ctx.Response.StatusCode())
+	}
+	if got := atomic.LoadInt32(&calls) - callsBefore; got != 2 {
+		t.Errorf("Expected the retry after a failure to run the handler again, ran %d times", got)
+	}
+
+	// Required: request tanpa key ditolak.
+	if ctx := serveIdempotentRequestForTest(router, xylium.MethodPost, "/flaky", "", ""); ctx.Response.StatusCode() != xylium.StatusBadRequest {
+		t.Errorf("Expected 400 for a missing required key, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestInMemoryIdempotencyStore_Expiry(t *testing.T) {
+	store := xylium.NewInMemoryIdempotencyStore()
+	defer store.Close()
+
+	if existing, _ := store.Reserve("k", &xylium.IdempotencyRecord{Fingerprint: "a"}, 20*time.Millisecond); existing != nil {
+		t.Fatalf("Expected the first Reserve to claim the key, got %+v", existing)
+	}
+	if existing, _ := store.Reserve("k", &xylium.IdempotencyRecord{Fingerprint: "b"}, 20*time.Millisecond); existing == nil || existing.Fingerprint != "a" {
+		t.Fatalf("Expected the second Reserve to return the in-flight record, got %+v", existing)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if existing, _ := store.Reserve("k", &xylium.IdempotencyRecord{Fingerprint: "c"}, time.Minute); existing != nil {
+		t.Errorf("Expected an expired record to be replaced, got %+v", existing)
+	}
+}
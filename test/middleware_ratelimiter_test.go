@@ -0,0 +1,234 @@
+// File: /test/middleware_ratelimiter_test.go
+package xylium_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// allowOnlyStoreForTest adalah LimiterStore tanpa dukungan Reserve yang selalu menolak.
+type allowOnlyStoreForTest struct{}
+
+func (allowOnlyStoreForTest) Allow(key string, limit int, window time.Duration, cost int) (bool, int, int, time.Time) {
+	return false, limit + 1, limit, time.Now().Add(window)
+}
+
+func (allowOnlyStoreForTest) Close() error { return nil }
+
+// immediateReserveStoreForTest menolak di Allow, lalu Reserve memberi slot di jendela
+// saat ini tanpa jeda, seperti saat jendela bergulir di antara kedua panggilan.
+type immediateReserveStoreForTest struct{ allowOnlyStoreForTest }
+
+func (immediateReserveStoreForTest) Reserve(key string, limit int, window time.Duration, cost int, maxWait time.Duration) (time.Duration, bool) {
+	return 0, true
+}
+
+func (immediateReserveStoreForTest) CancelReservation(key string, window time.Duration, cost int) {}
+
+func newRateLimitedRouterForTest(config xylium.RateLimiterConfig) *xylium.Router {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.RateLimiter(config))
+	router.GET("/limited", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s", "ok")
+	})
+	return router
+}
+
+func TestRateLimiter_WaitMode(t *testing.T) {
+	t.Run("WaitsForNextWindow", func(t *testing.T) {
+		router := newRateLimitedRouterForTest(xylium.RateLimiterConfig{
+			MaxRequests:    1,
+			WindowDuration: 100 * time.Millisecond,
+			WaitMode:       true,
+			MaxWait:        time.Second,
+		})
+
+		if ctx := serveRequestForTest(router, xylium.MethodGet, "/limited"); ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected first request to pass, got %d", ctx.Response.StatusCode())
+		}
+		start := time.Now()
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/limited")
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected second request to wait and pass, got %d", ctx.Response.StatusCode())
+		}
+		if waited := time.Since(start); waited < 50*time.Millisecond {
+			t.Errorf("Expected second request to wait for the next window, waited %v", waited)
+		}
+		if got := string(ctx.Response.Header.Peek("X-RateLimit-Remaining")); got != "0" {
+			t.Errorf("Expected X-RateLimit-Remaining 0 after waiting, got %q", got)
+		}
+	})
+
+	t.Run("RejectsBeyondMaxWait", func(t *testing.T) {
+		router := newRateLimitedRouterForTest(xylium.RateLimiterConfig{
+			MaxRequests:    1,
+			WindowDuration: time.Minute,
+			WaitMode:       true,
+			MaxWait:        10 * time.Millisecond,
+		})
+		serveRequestForTest(router, xylium.MethodGet, "/limited")
+		start := time.Now()
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/limited")
+		if ctx.Response.StatusCode() != xylium.StatusTooManyRequests {
+			t.Errorf("Expected 429 when the wait exceeds MaxWait, got %d", ctx.Response.StatusCode())
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("Expected the request to be rejected without waiting")
+		}
+	})
+
+	t.Run("CanceledWaitGivesSlotBack", func(t *testing.T) {
+		store := xylium.NewInMemoryStore(xylium.WithCleanupInterval(0))
+		defer store.Close()
+		config := xylium.RateLimiterConfig{
+			MaxRequests:    1,
+			WindowDuration: 200 * time.Millisecond,
+			Store:          store,
+			WaitMode:       true,
+			MaxWait:        time.Second,
+		}
+		// Router dengan Timeout singkat: permintaan yang menunggu dibatalkan.
+		impatient := newRouterWithConfigForTest(nil)
+		impatient.Use(xylium.Timeout(10 * time.Millisecond))
+		impatient.Use(xylium.RateLimiter(config))
+		impatient.GET("/limited", func(c *xylium.Context) error {
+			return c.String(xylium.StatusOK, "%s", "ok")
+		})
+		router := newRateLimitedRouterForTest(config)
+
+		if ctx := serveRequestForTest(router, xylium.MethodGet, "/limited"); ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected first request to pass, got %d", ctx.Response.StatusCode())
+		}
+		if ctx := serveRequestForTest(impatient, xylium.MethodGet, "/limited"); ctx.Response.StatusCode() == xylium.StatusOK {
+			t.Fatal("Expected the canceled waiter to fail")
+		}
+		// Handler yang dibatalkan masih berjalan di goroutine Timeout; beri waktu untuk mengembalikan slot.
+		time.Sleep(20 * time.Millisecond)
+		// Slot jendela berikutnya harus tersedia kembali setelah pembatalan.
+		if ctx := serveRequestForTest(router, xylium.MethodGet, "/limited"); ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Errorf("Expected the next waiter to get the canceled slot, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("ImmediateSlotKeepsCurrentWindowReset", func(t *testing.T) {
+		router := newRateLimitedRouterForTest(xylium.RateLimiterConfig{
+			MaxRequests:    1,
+			WindowDuration: time.Minute,
+			Store:          immediateReserveStoreForTest{},
+			WaitMode:       true,
+		})
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/limited")
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected the request to get the immediate slot, got %d", ctx.Response.StatusCode())
+		}
+		if got := string(ctx.Response.Header.Peek("X-RateLimit-Reset")); got != "59" && got != "60" {
+			t.Errorf("Expected X-RateLimit-Reset of the current window (about 60s), got %q", got)
+		}
+	})
+
+	t.Run("StoreWithoutReserveRejects", func(t *testing.T) {
+		router := newRateLimitedRouterForTest(xylium.RateLimiterConfig{
+			MaxRequests:    1,
+			WindowDuration: time.Minute,
+			Store:          allowOnlyStoreForTest{},
+			WaitMode:       true,
+		})
+		if ctx := serveRequestForTest(router, xylium.MethodGet, "/limited"); ctx.Response.StatusCode() != xylium.StatusTooManyRequests {
+			t.Errorf("Expected 429 with a store that cannot reserve, got %d", ctx.Response.StatusCode())
+		}
+	})
+}
+
+func TestInMemoryStore_Reserve(t *testing.T) {
+	store := xylium.NewInMemoryStore(xylium.WithCleanupInterval(0))
+	defer store.Close()
+	window := 100 * time.Millisecond
+
+	// Slot di jendela saat ini dipakai langsung tanpa jeda.
+	if delay, ok := store.Reserve("k", 2, window, 1, time.Second); !ok || delay != 0 {
+		t.Fatalf("Expected an immediate slot, got %v, %v", delay, ok)
+	}
+	store.Allow("k", 2, window, 1)
+
+	// Jendela berikutnya menampung paling banyak `limit` reservasi.
+	for i := 0; i < 2; i++ {
+		if delay, ok := store.Reserve("k", 2, window, 1, time.Second); !ok || delay <= 0 || delay > window {
+			t.Fatalf("Reservation %d: expected a slot in the next window, got %v, %v", i, delay, ok)
+		}
+	}
+	if _, ok := store.Reserve("k", 2, window, 1, time.Second); ok {
+		t.Fatal("Expected no slot when the next window is fully reserved")
+	}
+	if _, ok := store.Reserve("other", 2, window, 1, 0); !ok {
+		t.Error("Expected keys to be limited independently")
+	}
+
+	// Reservasi yang dibatalkan membebaskan slotnya.
+	store.CancelReservation("k", window, 1)
+	if _, ok := store.Reserve("k", 2, window, 1, time.Second); !ok {
+		t.Fatal("Expected a canceled reservation to free its slot")
+	}
+
+	// Reservasi dihitung pada jendela berikutnya.
+	time.Sleep(window + 10*time.Millisecond)
+	if allowed, count, _, _ := store.Allow("k", 2, window, 1); allowed || count != 3 {
+		t.Errorf("Expected the next window to start with the reserved requests, got allowed=%v count=%d", allowed, count)
+	}
+}
+
+func TestRateLimiter_Cost(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	limiter := xylium.RateLimiter(xylium.RateLimiterConfig{
+		MaxRequests:    10,
+		WindowDuration: time.Minute,
+		Cost: func(c *xylium.Context) int {
+			if c.Path() == "/import" {
+				return 6
+			}
+			return 0 // Dianggap 1.
+		},
+	})
+	handler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "%s", "ok") }
+	router.GET("/item", handler, limiter)
+	router.GET("/import", handler, limiter)
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/import")
+	if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Header.Peek("X-RateLimit-Remaining")) != "4" {
+		t.Fatalf("Expected import to cost 6, got status %d remaining %q",
+			ctx.Response.StatusCode(), ctx.Response.Header.Peek("X-RateLimit-Remaining"))
+	}
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/item")
+	if got := string(ctx.Response.Header.Peek("X-RateLimit-Remaining")); got != "3" {
+		t.Errorf("Expected a cost below 1 to count as 1, remaining %q", got)
+	}
+	if ctx = serveRequestForTest(router, xylium.MethodGet, "/import"); ctx.Response.StatusCode() != xylium.StatusTooManyRequests {
+		t.Errorf("Expected a second import to exceed the budget, got %d", ctx.Response.StatusCode())
+	}
+	// Import yang ditolak tidak memakai sisa kuota: permintaan murah masih diizinkan.
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/item")
+	if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Header.Peek("X-RateLimit-Remaining")) != "2" {
+		t.Errorf("Expected a cheap request to fit after a rejected expensive one, got status %d remaining %q",
+			ctx.Response.StatusCode(), ctx.Response.Header.Peek("X-RateLimit-Remaining"))
+	}
+}
+
+func TestInMemoryStore_AllowDoesNotChargeRejectedCost(t *testing.T) {
+	store := xylium.NewInMemoryStore(xylium.WithCleanupInterval(0))
+	defer store.Close()
+
+	store.Allow("k", 100, time.Minute, 90)
+	if allowed, count, _, _ := store.Allow("k", 100, time.Minute, 50); allowed || count != 140 {
+		t.Fatalf("Expected a cost-50 request to be rejected at 140/100, got allowed=%v count=%d", allowed, count)
+	}
+	if allowed, count, _, _ := store.Allow("k", 100, time.Minute, 1); !allowed || count != 91 {
+		t.Errorf("Expected a cost-1 request to be allowed at 91/100, got allowed=%v count=%d", allowed, count)
+	}
+	if allowed, _, _, _ := store.Allow("new", 5, time.Minute, 6); allowed {
+		t.Error("Expected a request costing more than the limit to be rejected")
+	}
+	if allowed, count, _, _ := store.Allow("new", 5, time.Minute, 5); !allowed || count != 5 {
+		t.Errorf("Expected the full budget to remain after an oversized request, got allowed=%v count=%d", allowed, count)
+	}
+}
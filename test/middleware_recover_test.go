@@ -0,0 +1,127 @@
+// File: /test/middleware_recover_test.go
+package xylium_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRecover_Default(t *testing.T) {
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+	err := xylium.Recover(xylium.RecoverConfig{})(func(c *xylium.Context) error {
+		panic("boom")
+	})(ctx)
+
+	var httpErr *xylium.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *xylium.HTTPError, got %T (%v)", err, err)
+	}
+	if httpErr.Code != xylium.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", xylium.StatusInternalServerError, httpErr.Code)
+	}
+	if httpErr.Internal == nil || !strings.Contains(httpErr.Internal.Error(), "boom") {
+		t.Errorf("Expected internal error to contain panic value 'boom', got %v", httpErr.Internal)
+	}
+	if val, _ := ctx.Get(xylium.ContextKeyPanicInfo); val != "boom" {
+		t.Errorf("Expected panic info 'boom' in context, got %v", val)
+	}
+	if rec, stack, ok := ctx.PanicInfo(); !ok || rec != "boom" || len(stack) == 0 {
+		t.Errorf("Expected PanicInfo to return 'boom' with a stack trace, got %v (stack %d bytes, ok %t)", rec, len(stack), ok)
+	}
+}
+
+func TestRecover_OnPanic(t *testing.T) {
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(xylium.NewRouterForTesting())
+
+	var gotRecovered interface{}
+	var gotStack []byte
+	sentinel := errors.New("handled by OnPanic")
+	mw := xylium.Recover(xylium.RecoverConfig{
+		StackSize:       256,
+		DisableStackAll: true,
+		OnPanic: func(c *xylium.Context, recovered interface{}, stack []byte) error {
+			gotRecovered = recovered
+			gotStack = stack
+			return sentinel
+		},
+	})
+
+	err := mw(func(c *xylium.Context) error { panic("custom") })(ctx)
+
+	if !errors.Is(err, sentinel) {
+		t.Errorf("Expected error from OnPanic, got %v", err)
+	}
+	if gotRecovered != "custom" {
+		t.Errorf("Expected recovered value 'custom', got %v", gotRecovered)
+	}
+	if len(gotStack) == 0 || len(gotStack) > 256 {
+		t.Errorf("Expected non-empty stack of at most 256 bytes, got %d bytes", len(gotStack))
+	}
+}
+
+func TestRecover_NoDoubleHandlingInRouter(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	panicHandlerCalled := false
+	router.PanicHandler = func(c *xylium.Context) error {
+		panicHandlerCalled = true
+		return xylium.NewHTTPError(xylium.StatusInternalServerError, "router panic handler")
+	}
+	group := router.Group("/api", xylium.Recover(xylium.RecoverConfig{
+		OnPanic: func(c *xylium.Context, recovered interface{}, stack []byte) error {
+			return c.String(xylium.StatusServiceUnavailable, "group recovered")
+		},
+	}))
+	group.GET("/panic", func(c *xylium.Context) error { panic("group panic") })
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/api/panic")
+	if panicHandlerCalled {
+		t.Error("Expected router PanicHandler NOT to be called when Recover middleware handles the panic")
+	}
+	if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable || string(ctx.Response.Body()) != "group recovered" {
+		t.Errorf("Expected group recovery response, got %d '%s'", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+}
+
+func TestRouter_PanicHandler_ReceivesStack(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	var gotRecovered interface{}
+	var gotStack []byte
+	var gotOK bool
+	router.PanicHandler = func(c *xylium.Context) error {
+		gotRecovered, gotStack, gotOK = c.PanicInfo()
+		return xylium.NewHTTPError(xylium.StatusInternalServerError, "crashed")
+	}
+	router.GET("/crash", func(c *xylium.Context) error {
+		panic("kaboom")
+	})
+	router.GET("/ok", func(c *xylium.Context) error {
+		_, _, ok := c.PanicInfo()
+		return c.String(xylium.StatusOK, "%t", ok)
+	})
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/crash")
+	if ctx.Response.StatusCode() != xylium.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", xylium.StatusInternalServerError, ctx.Response.StatusCode())
+	}
+	if !gotOK || gotRecovered != "kaboom" {
+		t.Errorf("Expected PanicInfo to return 'kaboom', got %v (ok %t)", gotRecovered, gotOK)
+	}
+	// Stack trace harus menyertakan handler yang panic.
+	if !strings.Contains(string(gotStack), "TestRouter_PanicHandler_ReceivesStack") {
+		t.Errorf("Expected stack trace to include the panicking handler, got:\n%s", gotStack)
+	}
+
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/ok")
+	if body := string(ctx.Response.Body()); body != "false" {
+		t.Errorf("Expected PanicInfo ok=false without a panic, got '%s'", body)
+	}
+}
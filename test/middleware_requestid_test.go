@@ -199,3 +199,26 @@ func TestRequestID_WithCustomConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestRequestID_IncomingAndEchoOptions(t *testing.T) {
+	generator := func() string { return "generated-id" }
+
+	t.Run("IgnoreIncoming_AlwaysRegenerates", func(t *testing.T) {
+		mw := xylium.RequestIDWithConfig(xylium.RequestIDConfig{Generator: generator, IgnoreIncoming: true})
+		idInCtx, idInResp, _ := runRequestIDMiddleware(t, mw, xylium.DefaultRequestIDHeader, "spoofed-id", xylium.DefaultRequestIDHeader)
+		if idInCtx != "generated-id" || idInResp != "generated-id" {
+			t.Errorf("Expected incoming ID to be ignored, got context %v and response header %q", idInCtx, idInResp)
+		}
+	})
+
+	t.Run("DisableResponseHeader_DoesNotEcho", func(t *testing.T) {
+		mw := xylium.RequestIDWithConfig(xylium.RequestIDConfig{Generator: generator, DisableResponseHeader: true})
+		idInCtx, idInResp, _ := runRequestIDMiddleware(t, mw, xylium.DefaultRequestIDHeader, "upstream-id", xylium.DefaultRequestIDHeader)
+		if idInCtx != "upstream-id" {
+			t.Errorf("Expected upstream ID in context, got %v", idInCtx)
+		}
+		if idInResp != "" {
+			t.Errorf("Expected no response header, got %q", idInResp)
+		}
+	})
+}
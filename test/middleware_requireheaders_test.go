@@ -0,0 +1,75 @@
+// File: /test/middleware_requireheaders_test.go
+package xylium_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// Helper untuk menjalankan middleware RequireHeaders dengan header request tertentu.
+func runRequireHeadersMiddleware(spec, requestHeaders map[string]string) (called bool, err error) {
+	var fasthttpCtx fasthttp.RequestCtx
+	for name, value := range requestHeaders {
+		fasthttpCtx.Request.Header.Set(name, value)
+	}
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	err = xylium.RequireHeaders(spec)(func(c *xylium.Context) error {
+		called = true
+		return nil
+	})(ctx)
+	return called, err
+}
+
+func TestRequireHeaders(t *testing.T) {
+	spec := map[string]string{
+		"x-tenant-id": "uuid4",
+		"X-Client":    "oneof=web mobile",
+		"X-Trace":     "",
+	}
+
+	called, err := runRequireHeadersMiddleware(spec, map[string]string{
+		"X-Tenant-ID": "3f8e4a52-8d3c-4a8e-9c1d-2b7f6e5d4c3b",
+		"X-Client":    "web",
+		"X-Trace":     "abc",
+	})
+	if err != nil || !called {
+		t.Fatalf("Expected valid headers to pass, got called=%v err=%v", called, err)
+	}
+
+	called, err = runRequireHeadersMiddleware(spec, map[string]string{
+		"X-Tenant-ID": "not-a-uuid",
+		"X-Client":    "web",
+	})
+	if called {
+		t.Error("Expected the handler not to run for invalid headers")
+	}
+	var httpErr *xylium.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != xylium.StatusBadRequest {
+		t.Fatalf("Expected a 400 HTTPError, got %v", err)
+	}
+	details, ok := httpErr.Message.(xylium.M)["details"].(map[string]string)
+	if !ok {
+		t.Fatalf("Expected validation details, got %v", httpErr.Message)
+	}
+	if len(details) != 2 {
+		t.Errorf("Expected details for X-Tenant-Id and X-Trace only, got %v", details)
+	}
+	if got := details["X-Tenant-Id"]; got != "validation failed on tag 'uuid4'" {
+		t.Errorf("X-Tenant-Id: unexpected detail %q", got)
+	}
+	if got := details["X-Trace"]; got != "validation failed on tag 'required'" {
+		t.Errorf("X-Trace: unexpected detail %q", got)
+	}
+}
+
+func TestRequireHeaders_PanicsOnEmptySpec(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected RequireHeaders to panic for an empty spec")
+		}
+	}()
+	xylium.RequireHeaders(nil)
+}
@@ -0,0 +1,74 @@
+// File: /test/middleware_secureheaders_test.go
+package xylium_test
+
+import (
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// Helper untuk menjalankan middleware SecureHeaders dan mengembalikan header response.
+func runSecureHeadersMiddleware(t *testing.T, config xylium.SecureHeadersConfig, forwardedProto string) *fasthttp.ResponseHeader {
+	t.Helper()
+	var fasthttpCtx fasthttp.RequestCtx
+	if forwardedProto != "" {
+		fasthttpCtx.Request.Header.Set("X-Forwarded-Proto", forwardedProto)
+	}
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+
+	err := xylium.SecureHeaders(config)(func(c *xylium.Context) error { return nil })(ctx)
+	if err != nil {
+		t.Fatalf("Middleware execution returned an error: %v", err)
+	}
+	return &fasthttpCtx.Response.Header
+}
+
+func TestSecureHeaders_Defaults(t *testing.T) {
+	headers := runSecureHeadersMiddleware(t, xylium.SecureHeadersConfig{}, "https")
+
+	expected := map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "SAMEORIGIN",
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		"Content-Security-Policy":   "default-src 'self'",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+		"Permissions-Policy":        "geolocation=(), microphone=(), camera=()",
+	}
+	for name, want := range expected {
+		if got := string(headers.Peek(name)); got != want {
+			t.Errorf("Header %s: expected '%s', got '%s'", name, want, got)
+		}
+	}
+}
+
+func TestSecureHeaders_HSTSOnlyOnHTTPS(t *testing.T) {
+	headers := runSecureHeadersMiddleware(t, xylium.SecureHeadersConfig{}, "")
+	if got := string(headers.Peek("Strict-Transport-Security")); got != "" {
+		t.Errorf("Expected no HSTS header on plain HTTP, got '%s'", got)
+	}
+}
+
+func TestSecureHeaders_CustomAndDisabled(t *testing.T) {
+	config := xylium.SecureHeadersConfig{
+		FrameOptions:                 "DENY",
+		HSTSMaxAge:                   600,
+		HSTSExcludeSubdomains:        true,
+		HSTSPreload:                  true,
+		DisableContentSecurityPolicy: true,
+		DisablePermissionsPolicy:     true,
+	}
+	headers := runSecureHeadersMiddleware(t, config, "https")
+
+	if got := string(headers.Peek("X-Frame-Options")); got != "DENY" {
+		t.Errorf("Expected X-Frame-Options 'DENY', got '%s'", got)
+	}
+	if got := string(headers.Peek("Strict-Transport-Security")); got != "max-age=600; preload" {
+		t.Errorf("Expected HSTS 'max-age=600; preload', got '%s'", got)
+	}
+	for _, name := range []string{"Content-Security-Policy", "Permissions-Policy"} {
+		if got := string(headers.Peek(name)); got != "" {
+			t.Errorf("Expected header %s to be disabled, got '%s'", name, got)
+		}
+	}
+}
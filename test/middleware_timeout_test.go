@@ -2,11 +2,14 @@
 package xylium_test
 
 import (
+	"bufio"
 	"context"
 	"encoding/json" // Ditambahkan untuk helper simulasi GlobalErrorHandler
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -47,7 +50,6 @@ func runTimeoutMiddleware(
 	var wg sync.WaitGroup
 
 	dummyHandler := func(c *xylium.Context) error { // 'c' di sini adalah timedXyliumCtx
-		wg.Add(1)
 		defer wg.Done()
 
 		var earlyWriteSimWait time.Duration = 5 * time.Millisecond
@@ -140,6 +142,10 @@ func runTimeoutMiddleware(
 	mw := xylium.TimeoutWithConfig(config)
 	handlerWithMiddleware := mw(dummyHandler)
 
+	// wg.Add harus dipanggil sebelum middleware dijalankan: dummyHandler berjalan
+	// di goroutine milik middleware, sehingga Add di dalamnya berlomba dengan wg.Wait.
+	wg.Add(1)
+
 	// Jalankan handler yang sudah di-wrap middleware secara langsung
 	// dan tangkap panic jika ada.
 	func() {
@@ -492,3 +498,267 @@ func TestTimeoutMiddleware(t *testing.T) {
 		_ = xylium.TimeoutWithConfig(xylium.TimeoutConfig{Timeout: 0})
 	})
 }
+
+func TestTimeoutMiddleware_DeadlinePropagationAndLateWrite(t *testing.T) {
+	var fasthttpCtx fasthttp.RequestCtx
+	router := xylium.NewRouterForTesting()
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(router)
+
+	timeout := 15 * time.Millisecond
+	var hasDeadline bool
+	var lateWriteErr error
+	var committedAfterTimeout bool
+	handlerDone := make(chan struct{})
+
+	handler := func(c *xylium.Context) error {
+		defer close(handlerDone)
+		_, hasDeadline = c.Context().Deadline()
+		<-c.Context().Done()             // Tunggu sampai timeout terpicu.
+		time.Sleep(5 * time.Millisecond) // Beri waktu middleware mengambil alih response.
+		committedAfterTimeout = c.ResponseCommitted()
+		lateWriteErr = c.String(http.StatusOK, "late_response")
+		return nil
+	}
+
+	err := xylium.Timeout(timeout)(handler)(ctx)
+	<-handlerDone
+
+	if !hasDeadline {
+		t.Error("Expected c.Context() inside handler to carry a deadline")
+	}
+	var httpErr *xylium.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != xylium.StatusServiceUnavailable {
+		t.Fatalf("Expected HTTPError %d from middleware, got %v", xylium.StatusServiceUnavailable, err)
+	}
+	if !committedAfterTimeout {
+		t.Error("Expected handler's context to report response committed after timeout")
+	}
+	if lateWriteErr != nil {
+		t.Errorf("Expected late write to be discarded silently, got error %v", lateWriteErr)
+	}
+	if body := string(fasthttpCtx.Response.Body()); body != "" {
+		t.Errorf("Expected late write to be discarded, but response body is '%s'", body)
+	}
+}
+
+func TestTimeoutMiddleware_StackedPerRouteOverride(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.Timeout(20 * time.Millisecond))
+
+	// Timeout per-route yang lebih panjang harus menggantikan timeout global.
+	var deadlineRemaining time.Duration
+	router.GET("/report", func(c *xylium.Context) error {
+		if deadline, ok := c.Context().Deadline(); ok {
+			deadlineRemaining = time.Until(deadline)
+		}
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return c.String(http.StatusOK, "%s", "report_ready")
+		case <-c.Context().Done():
+			return c.Context().Err()
+		}
+	}, xylium.WithTimeout(500*time.Millisecond))
+
+	// Timeout per-route yang lebih pendek juga menggantikan global, beserta pesannya.
+	ctxErrChan := make(chan error, 1)
+	router.GET("/quick", func(c *xylium.Context) error {
+		<-c.Context().Done()
+		ctxErrChan <- c.Context().Err()
+		return c.Context().Err()
+	}, xylium.TimeoutWithConfig(xylium.TimeoutConfig{Timeout: 5 * time.Millisecond, Message: "quick_timeout"}))
+
+	ctx := serveRequestForTest(router, http.MethodGet, "/report")
+	if ctx.Response.StatusCode() != http.StatusOK || string(ctx.Response.Body()) != "report_ready" {
+		t.Errorf("Expected per-route timeout to supersede global one, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if deadlineRemaining <= 20*time.Millisecond {
+		t.Errorf("Expected handler deadline to reflect the per-route timeout, got %v remaining", deadlineRemaining)
+	}
+
+	start := time.Now()
+	ctx = serveRequestForTest(router, http.MethodGet, "/quick")
+	if elapsed := time.Since(start); elapsed >= 20*time.Millisecond {
+		t.Errorf("Expected the shorter per-route timeout to fire first, took %v", elapsed)
+	}
+	if ctx.Response.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", ctx.Response.StatusCode())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil || body["message"] != "quick_timeout" {
+		t.Errorf("Expected per-route timeout message, got %s", ctx.Response.Body())
+	}
+	if ctxErr := <-ctxErrChan; !errors.Is(ctxErr, context.DeadlineExceeded) {
+		t.Errorf("Expected handler to observe context.DeadlineExceeded, got %v", ctxErr)
+	}
+}
+
+func TestRouter_DeclarativeRouteTimeouts(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.RouteTimeout = 2 * time.Second
+	})
+
+	// Handler mencatat sisa waktu deadline yang terlihat.
+	remaining := map[string]time.Duration{}
+	var mu sync.Mutex
+	probe := func(c *xylium.Context) error {
+		deadline, ok := c.Context().Deadline()
+		mu.Lock()
+		if ok {
+			remaining[c.Path()] = time.Until(deadline)
+		} else {
+			remaining[c.Path()] = -1
+		}
+		mu.Unlock()
+		return c.String(http.StatusOK, "ok")
+	}
+
+	router.GET("/default", probe)
+	router.GET("/report", probe).Timeout(30 * time.Second)
+	router.Any("/any", probe).Timeout(10 * time.Second)
+	router.GET("/cleared", probe).Timeout(time.Minute).Timeout(0)
+
+	api := router.Group("/api")
+	api.SetTimeout(5 * time.Second)
+	api.GET("/group", probe)
+	api.GET("/route", probe).Timeout(time.Minute)
+	v1 := api.Group("/v1")
+	v1.GET("/inherited", probe) // Mewarisi timeout grup induk secara dinamis.
+
+	// Handler yang lambat harus tetap menerima 503 dari timeout per-route.
+	router.GET("/slow", func(c *xylium.Context) error {
+		<-c.Context().Done()
+		return c.Context().Err()
+	}).Timeout(10 * time.Millisecond)
+
+	tests := []struct {
+		path     string
+		min, max time.Duration
+	}{
+		{"/default", time.Second, 2 * time.Second},
+		{"/report", 29 * time.Second, 30 * time.Second},
+		{"/any", 9 * time.Second, 10 * time.Second},
+		{"/cleared", time.Second, 2 * time.Second},
+		{"/api/group", 4 * time.Second, 5 * time.Second},
+		{"/api/route", 59 * time.Second, time.Minute},
+		{"/api/v1/inherited", 4 * time.Second, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(router, http.MethodGet, tt.path)
+		if ctx.Response.StatusCode() != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", tt.path, ctx.Response.StatusCode())
+			continue
+		}
+		if got := remaining[tt.path]; got < tt.min || got > tt.max {
+			t.Errorf("%s: expected deadline in (%v, %v], got %v remaining", tt.path, tt.min, tt.max, got)
+		}
+	}
+
+	// Method lain pada route Any berbagi handle yang sama.
+	ctx := serveRequestForTest(router, http.MethodPost, "/any")
+	if got := remaining["/any"]; ctx.Response.StatusCode() != http.StatusOK || got < 9*time.Second {
+		t.Errorf("Expected POST /any to use the route timeout, got status %d and %v remaining", ctx.Response.StatusCode(), got)
+	}
+
+	ctx = serveRequestForTest(router, http.MethodGet, "/slow")
+	if ctx.Response.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 for /slow, got %d", ctx.Response.StatusCode())
+	}
+
+	// Menghapus timeout grup membuat route kembali ke default router.
+	api.SetTimeout(0)
+	serveRequestForTest(router, http.MethodGet, "/api/v1/inherited")
+	if got := remaining["/api/v1/inherited"]; got > 2*time.Second {
+		t.Errorf("Expected cleared group timeout to fall back to RouteTimeout, got %v remaining", got)
+	}
+
+	assertPanics := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic for negative duration", name)
+			}
+		}()
+		fn()
+	}
+	assertPanics("Route.Timeout", func() { router.GET("/neg", probe).Timeout(-time.Second) })
+	assertPanics("RouteGroup.SetTimeout", func() { api.SetTimeout(-time.Second) })
+}
+
+func TestTimeoutMiddleware_AbortsStartedStream(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.Use(xylium.Timeout(50 * time.Millisecond))
+
+	writerDone := make(chan error, 1)
+	errorHandlerCalled := make(chan struct{}, 1)
+	router.GET("/events", func(c *xylium.Context) error {
+		c.SetContentType("text/event-stream")
+		c.SetBodyStreamWriter(func(w *bufio.Writer) {
+			for i := 0; ; i++ {
+				fmt.Fprintf(w, "data: %d\n\n", i)
+				if err := w.Flush(); err != nil {
+					writerDone <- err
+					return
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+		})
+		// Handler terus bekerja setelah stream dimulai hingga melewati timeout.
+		<-c.Context().Done()
+		return c.Context().Err()
+	})
+	router.GET("/custom", func(c *xylium.Context) error {
+		c.SetBodyStreamWriter(func(w *bufio.Writer) { _, _ = w.WriteString("partial") })
+		<-c.Context().Done()
+		return nil
+	}, xylium.TimeoutWithConfig(xylium.TimeoutConfig{
+		Timeout: 20 * time.Millisecond,
+		ErrorHandler: func(c *xylium.Context, err error) error {
+			errorHandlerCalled <- struct{}{}
+			return c.JSON(xylium.StatusGatewayTimeout, xylium.M{"error": "timeout"})
+		},
+	}))
+	addr := startServerForTest(t, router)
+
+	// readStream mengembalikan body yang diterima dan error pembacaan, jika ada.
+	readStream := func(t *testing.T, path string) (int, string, error) {
+		t.Helper()
+		resp, err := http.Get("http://" + addr + path)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), err
+	}
+
+	t.Run("DefaultErrorHandler", func(t *testing.T) {
+		status, body, err := readStream(t, "/events")
+		if err == nil {
+			t.Fatalf("Expected the stream to be aborted, got a complete response %d %q", status, body)
+		}
+		if status == xylium.StatusServiceUnavailable || strings.Contains(body, "timed out") {
+			t.Errorf("Expected no timeout error body in the stream, got %d %q", status, body)
+		}
+		select {
+		case <-writerDone: // Flush gagal setelah stream dibatalkan.
+		case <-time.After(2 * time.Second):
+			t.Error("Expected the stream writer to stop after the stream was aborted")
+		}
+	})
+
+	t.Run("CustomErrorHandlerSkipped", func(t *testing.T) {
+		status, body, err := readStream(t, "/custom")
+		if err == nil {
+			t.Fatalf("Expected the stream to be aborted, got a complete response %d %q", status, body)
+		}
+		if status == xylium.StatusGatewayTimeout {
+			t.Errorf("Expected no 504 in place of the started stream, got %q", body)
+		}
+		select {
+		case <-errorHandlerCalled:
+			t.Error("Expected ErrorHandler not to be called for a started stream")
+		default:
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// File: /test/rotating_file_writer_test.go
+package xylium_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// listBackupsForTest mengembalikan nama file backup (selain file aktif) di dir.
+func listBackupsForTest(t *testing.T, dir, active string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Name() != active {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func TestRotatingFileWriter_RotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	w, err := xylium.NewRotatingFileWriter(xylium.RotatingFileWriterConfig{
+		Filename: filepath.Join(dir, "app.log"),
+		MaxSize:  10,
+	})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter failed: %v", err)
+	}
+
+	for _, line := range []string{"12345678\n", "abcdefgh\n", "ABCDEFGH\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	active, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(active) != "ABCDEFGH\n" {
+		t.Errorf("Expected active file to contain only the last write, got %q", active)
+	}
+	backups := listBackupsForTest(t, dir, "app.log")
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %v", backups)
+	}
+	for _, b := range backups {
+		if !strings.HasPrefix(b, "app-") || !strings.HasSuffix(b, ".log") {
+			t.Errorf("Unexpected backup name %q", b)
+		}
+	}
+}
+
+func TestRotatingFileWriter_MaxBackupsAndCompress(t *testing.T) {
+	dir := t.TempDir()
+	w, err := xylium.NewRotatingFileWriter(xylium.RotatingFileWriterConfig{
+		Filename:   filepath.Join(dir, "app.log"),
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter failed: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := w.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if err := w.Rotate(); err != nil {
+			t.Fatalf("Rotate failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil { // Close menunggu kompresi di background.
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	backups := listBackupsForTest(t, dir, "app.log")
+	if len(backups) != 2 {
+		t.Fatalf("Expected MaxBackups=2 backups to remain, got %v", backups)
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b, ".log.gz") {
+			t.Errorf("Expected compressed backup, got %q", b)
+		}
+	}
+}
+
+func TestRotatingFileWriter_AsLoggerOutput(t *testing.T) {
+	dir := t.TempDir()
+	w, err := xylium.NewRotatingFileWriter(xylium.RotatingFileWriterConfig{Filename: filepath.Join(dir, "app.log")})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter failed: %v", err)
+	}
+	logger := xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+		Level:    xylium.LevelInfo,
+		Output:   w,
+		UseColor: true, // Tidak boleh berlaku: writer bukan terminal.
+	})
+	logger.Info("to file")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(content), "to file") {
+		t.Errorf("Expected log entry in file, got %q", content)
+	}
+	if strings.Contains(string(content), "\x1b[") {
+		t.Errorf("Expected no ANSI color codes in file output, got %q", content)
+	}
+}
+
+func TestNewRotatingFileWriter_InvalidConfig(t *testing.T) {
+	if _, err := xylium.NewRotatingFileWriter(xylium.RotatingFileWriterConfig{}); err == nil {
+		t.Error("Expected error for empty Filename")
+	}
+	if _, err := xylium.NewRotatingFileWriter(xylium.RotatingFileWriterConfig{Filename: filepath.Join(t.TempDir(), "a.log"), MaxSize: -1}); err == nil {
+		t.Error("Expected error for negative MaxSize")
+	}
+}
@@ -0,0 +1,222 @@
+// File: /test/router_health_test.go
+package xylium_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestRouter_ReadyHandler(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/ready", router.ReadyHandler())
+
+	if !router.IsReady() {
+		t.Fatal("Expected a new router to be ready")
+	}
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/ready"); ctx.Response.StatusCode() != xylium.StatusOK {
+		t.Errorf("Expected status 200 while ready, got %d", ctx.Response.StatusCode())
+	}
+
+	router.SetReady(false)
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/ready")
+	if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 while not ready, got %d", ctx.Response.StatusCode())
+	}
+	if body := string(ctx.Response.Body()); body != `{"status":"not ready"}` {
+		t.Errorf("Unexpected body '%s'", body)
+	}
+}
+
+func TestRouter_GracefulShutdownPreShutdownDelay(t *testing.T) {
+	const delay = 300 * time.Millisecond
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.PreShutdownDelay = delay
+		cfg.ShutdownTimeout = 2 * time.Second
+	})
+	router.GET("/ready", router.ReadyHandler())
+	router.GET("/work", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "done")
+	})
+
+	// Server fasthttp biasa di atas listener in-memory, agar bisa di-shutdown oleh router.
+	ln := fasthttputil.NewInmemoryListener()
+	server := &fasthttp.Server{Handler: router.Handler}
+	serveDone := make(chan struct{})
+	go func() {
+		_ = server.Serve(ln)
+		close(serveDone)
+	}()
+	client := &fasthttp.HostClient{Addr: "xylium.test", Dial: func(string) (net.Conn, error) { return ln.Dial() }}
+	get := func(path string) (int, error) {
+		statusCode, _, err := client.Get(nil, "http://xylium.test"+path)
+		return statusCode, err
+	}
+
+	if status, err := get("/ready"); err != nil || status != xylium.StatusOK {
+		t.Fatalf("Expected status 200 before shutdown, got %d (err: %v)", status, err)
+	}
+
+	started := time.Now()
+	shutdownDone := make(chan struct{})
+	go func() {
+		router.GracefulShutdownForTesting(server)
+		close(shutdownDone)
+	}()
+
+	time.Sleep(delay / 3)
+	if status, err := get("/ready"); err != nil || status != xylium.StatusServiceUnavailable {
+		t.Errorf("Expected the readiness probe to fail during PreShutdownDelay, got %d (err: %v)", status, err)
+	}
+	if status, err := get("/work"); err != nil || status != xylium.StatusOK {
+		t.Errorf("Expected requests to be served during PreShutdownDelay, got %d (err: %v)", status, err)
+	}
+
+	select {
+	case <-shutdownDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Graceful shutdown did not finish")
+	}
+	if elapsed := time.Since(started); elapsed < delay {
+		t.Errorf("Expected shutdown to wait for PreShutdownDelay (%s), took %s", delay, elapsed)
+	}
+	select {
+	case <-serveDone:
+	case <-time.After(time.Second):
+		t.Error("Expected the server to stop serving after shutdown")
+	}
+}
+
+func TestRouter_Health(t *testing.T) {
+	var dbCalls atomic.Int32
+	dbErr := errors.New("connection refused")
+	router := newRouterWithConfigForTest(nil)
+	router.Liveness("/livez")
+	router.HealthWithConfig("/readyz", xylium.HealthConfig{
+		Checks: []xylium.HealthCheck{
+			{Name: "database", Check: func(ctx context.Context) error {
+				dbCalls.Add(1)
+				return nil
+			}},
+			{Name: "cache", Check: func(ctx context.Context) error { return nil }},
+		},
+		CacheTTL: time.Hour,
+	})
+	router.HealthWithConfig("/slow", xylium.HealthConfig{
+		Checks: []xylium.HealthCheck{
+			{Name: "slow", Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		},
+		Timeout: 20 * time.Millisecond,
+	})
+
+	decode := func(t *testing.T, ctx *fasthttp.RequestCtx) map[string]interface{} {
+		t.Helper()
+		var report map[string]interface{}
+		if err := json.Unmarshal(ctx.Response.Body(), &report); err != nil {
+			t.Fatalf("Failed to decode health report '%s': %v", ctx.Response.Body(), err)
+		}
+		return report
+	}
+
+	t.Run("Liveness", func(t *testing.T) {
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/livez")
+		if ctx.Response.StatusCode() != xylium.StatusOK || decode(t, ctx)["status"] != "up" {
+			t.Errorf("Expected 200 'up', got %d '%s'", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	t.Run("AllUpAndCached", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			ctx := serveRequestForTest(router, xylium.MethodGet, "/readyz")
+			if ctx.Response.StatusCode() != xylium.StatusOK {
+				t.Fatalf("Expected status 200, got %d (body: %s)", ctx.Response.StatusCode(), ctx.Response.Body())
+			}
+			checks := decode(t, ctx)["checks"].(map[string]interface{})
+			if len(checks) != 2 || checks["database"].(map[string]interface{})["status"] != "up" {
+				t.Errorf("Unexpected checks %v", checks)
+			}
+		}
+		if got := dbCalls.Load(); got != 1 {
+			t.Errorf("Expected the cached result to be reused, check ran %d times", got)
+		}
+	})
+
+	t.Run("TimedOutCheckIsDown", func(t *testing.T) {
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/slow")
+		if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+			t.Fatalf("Expected status 503, got %d", ctx.Response.StatusCode())
+		}
+		slow := decode(t, ctx)["checks"].(map[string]interface{})["slow"].(map[string]interface{})
+		if slow["status"] != "down" || !strings.Contains(slow["error"].(string), "timed out") {
+			t.Errorf("Expected a timed out check, got %v", slow)
+		}
+	})
+
+	t.Run("NotReadySkipsChecks", func(t *testing.T) {
+		router.SetReady(false)
+		defer router.SetReady(true)
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/slow")
+		if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+			t.Fatalf("Expected status 503, got %d", ctx.Response.StatusCode())
+		}
+		if report := decode(t, ctx); report["reason"] != "not ready" || report["checks"] != nil {
+			t.Errorf("Expected a not ready report without checks, got %v", report)
+		}
+		if ctx := serveRequestForTest(router, xylium.MethodGet, "/livez"); ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Errorf("Expected liveness to stay 200 while not ready, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("FailingCheckWithoutCache", func(t *testing.T) {
+		failing := newRouterWithConfigForTest(nil)
+		failing.Health("/readyz", xylium.HealthCheck{Name: "database", Check: func(ctx context.Context) error {
+			return dbErr
+		}})
+		ctx := serveRequestForTest(failing, xylium.MethodGet, "/readyz")
+		if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+			t.Fatalf("Expected status 503, got %d", ctx.Response.StatusCode())
+		}
+		report := decode(t, ctx)
+		database := report["checks"].(map[string]interface{})["database"].(map[string]interface{})
+		if report["status"] != "down" || database["error"] != dbErr.Error() {
+			t.Errorf("Unexpected report %v", report)
+		}
+	})
+
+	t.Run("PanickingCheckIsDown", func(t *testing.T) {
+		panicking := newRouterWithConfigForTest(nil)
+		panicking.Health("/readyz", xylium.HealthCheck{Name: "queue", Check: func(ctx context.Context) error {
+			panic("nil client")
+		}})
+		ctx := serveRequestForTest(panicking, xylium.MethodGet, "/readyz")
+		if ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+			t.Fatalf("Expected status 503, got %d", ctx.Response.StatusCode())
+		}
+		queue := decode(t, ctx)["checks"].(map[string]interface{})["queue"].(map[string]interface{})
+		if queue["status"] != "down" || !strings.Contains(queue["error"].(string), `health check "queue" panicked: nil client`) {
+			t.Errorf("Expected the panicking check to be reported as down, got %v", queue)
+		}
+	})
+
+	t.Run("InvalidChecksPanic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic for duplicate check names")
+			}
+		}()
+		check := xylium.HealthCheck{Name: "db", Check: func(context.Context) error { return nil }}
+		router.Health("/dup", check, check)
+	})
+}
@@ -0,0 +1,241 @@
+// File: /test/router_mount_test.go
+package xylium_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// headerMiddlewareForTest menambahkan nilai ke header X-Chain agar urutan middleware terlihat.
+func headerMiddlewareForTest(label string) xylium.Middleware {
+	return func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			c.Ctx.Response.Header.Add("X-Chain", label)
+			return next(c)
+		}
+	}
+}
+
+func TestRouter_Mount(t *testing.T) {
+	sub := newRouterWithConfigForTest(nil)
+	sub.Use(headerMiddlewareForTest("sub"))
+	sub.GET("/", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "sub root") })
+	sub.GET("/users/:id", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "user %s", c.Param("id")) })
+	sub.GET("/fail", func(c *xylium.Context) error { return xylium.NewHTTPError(xylium.StatusTeapot, "sub failure") })
+	admin := sub.Group("/admin", headerMiddlewareForTest("group"))
+	admin.SetErrorHandler(func(c *xylium.Context) error { return c.String(xylium.StatusTeapot, "admin error") })
+	admin.GET("/fail", func(c *xylium.Context) error { return xylium.NewHTTPError(xylium.StatusTeapot, "admin failure") })
+	sub.GlobalErrorHandler = func(c *xylium.Context) error { return c.String(xylium.StatusTeapot, "sub error") }
+
+	app := newRouterWithConfigForTest(nil)
+	app.Use(headerMiddlewareForTest("app"))
+	app.Mount("/module", sub)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+		wantChain  string
+	}{
+		{"/module", xylium.StatusOK, "sub root", "app,sub"},
+		{"/module/users/42", xylium.StatusOK, "user 42", "app,sub"},
+		{"/module/fail", xylium.StatusTeapot, "sub error", "app,sub"},
+		{"/module/admin/fail", xylium.StatusTeapot, "admin error", "app,sub,group"},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(app, xylium.MethodGet, tt.path)
+		if ctx.Response.StatusCode() != tt.wantStatus || string(ctx.Response.Body()) != tt.wantBody {
+			t.Errorf("%s: expected %d %q, got %d %q", tt.path, tt.wantStatus, tt.wantBody, ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+		var chain []string
+		ctx.Response.Header.VisitAll(func(key, value []byte) {
+			if string(key) == "X-Chain" {
+				chain = append(chain, string(value))
+			}
+		})
+		if got := strings.Join(chain, ","); got != tt.wantChain {
+			t.Errorf("%s: expected middleware chain %q, got %q", tt.path, tt.wantChain, got)
+		}
+	}
+
+	// Rute sub-router tidak boleh muncul tanpa prefix.
+	if ctx := serveRequestForTest(app, xylium.MethodGet, "/users/42"); ctx.Response.StatusCode() != xylium.StatusNotFound {
+		t.Errorf("Expected 404 for unprefixed path, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestRouter_Mount_DefaultSubErrorHandlerDefersToParent(t *testing.T) {
+	sub := newRouterWithConfigForTest(nil)
+	sub.GET("/fail", func(c *xylium.Context) error { return xylium.NewHTTPError(xylium.StatusTeapot, "sub failure") })
+
+	app := newRouterWithConfigForTest(nil)
+	app.GlobalErrorHandler = func(c *xylium.Context) error { return c.String(xylium.StatusTeapot, "app error") }
+	app.Mount("/module", sub)
+
+	ctx := serveRequestForTest(app, xylium.MethodGet, "/module/fail")
+	if string(ctx.Response.Body()) != "app error" {
+		t.Errorf("Expected parent GlobalErrorHandler, got %q", ctx.Response.Body())
+	}
+}
+
+func TestRouter_Mount_SubRouterSettings(t *testing.T) {
+	errNotFound := errors.New("record not found")
+	sub := newRouterWithConfigForTest(nil)
+	sub.AppSet("billing.currency", "EUR")
+	sub.AppSet("shared", 42)
+	sub.RegisterErrorMapper(xylium.MapErrorIs(errNotFound, xylium.StatusNotFound))
+	sub.NotFoundHandler = func(c *xylium.Context) error { return c.String(xylium.StatusNotFound, "billing route not found") }
+	sub.Health("/readyz")
+	sub.GET("/currency", func(c *xylium.Context) error {
+		currency, _ := c.AppGet("billing.currency")
+		return c.String(xylium.StatusOK, "%v", currency)
+	})
+	sub.GET("/missing", func(c *xylium.Context) error { return errNotFound })
+
+	app := newRouterWithConfigForTest(nil)
+	app.AppSet("shared", 42) // Nilai yang sama di kedua router tidak dianggap konflik.
+	app.GET("/missing", func(c *xylium.Context) error { return errNotFound })
+	app.Mount("/billing", sub)
+
+	t.Run("AppStoreMerged", func(t *testing.T) {
+		ctx := serveRequestForTest(app, xylium.MethodGet, "/billing/currency")
+		if string(ctx.Response.Body()) != "EUR" {
+			t.Errorf("Expected the sub-router's app store value, got %q", ctx.Response.Body())
+		}
+	})
+
+	t.Run("ErrorMappersScopedToMountedRoutes", func(t *testing.T) {
+		if ctx := serveRequestForTest(app, xylium.MethodGet, "/billing/missing"); ctx.Response.StatusCode() != xylium.StatusNotFound {
+			t.Errorf("Expected the sub-router's error mapper to apply, got %d", ctx.Response.StatusCode())
+		}
+		if ctx := serveRequestForTest(app, xylium.MethodGet, "/missing"); ctx.Response.StatusCode() != xylium.StatusInternalServerError {
+			t.Errorf("Expected the sub-router's error mapper not to apply to parent routes, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("NotFoundHandlerUnderPrefix", func(t *testing.T) {
+		ctx := serveRequestForTest(app, xylium.MethodGet, "/billing/nope")
+		if string(ctx.Response.Body()) != "billing route not found" {
+			t.Errorf("Expected the sub-router's NotFoundHandler, got %q", ctx.Response.Body())
+		}
+		if ctx := serveRequestForTest(app, xylium.MethodGet, "/nope"); string(ctx.Response.Body()) == "billing route not found" {
+			t.Error("Expected the sub-router's NotFoundHandler not to apply outside the prefix")
+		}
+	})
+
+	t.Run("HealthFollowsParentReadiness", func(t *testing.T) {
+		if ctx := serveRequestForTest(app, xylium.MethodGet, "/billing/readyz"); ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected 200 while ready, got %d", ctx.Response.StatusCode())
+		}
+		app.SetReady(false)
+		defer app.SetReady(true)
+		if ctx := serveRequestForTest(app, xylium.MethodGet, "/billing/readyz"); ctx.Response.StatusCode() != xylium.StatusServiceUnavailable {
+			t.Errorf("Expected the mounted health endpoint to report not ready, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		expectPanic := func(t *testing.T, name string, configure func(sub *xylium.Router)) {
+			t.Helper()
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected Mount to panic", name)
+				}
+			}()
+			conflicting := newRouterWithConfigForTest(nil)
+			configure(conflicting)
+			parent := newRouterWithConfigForTest(nil)
+			parent.AppSet("db", "primary")
+			parent.Mount("/mod", conflicting)
+		}
+		expectPanic(t, "AppStoreKey", func(sub *xylium.Router) { sub.AppSet("db", "replica") })
+		expectPanic(t, "PanicHandler", func(sub *xylium.Router) {
+			sub.PanicHandler = func(c *xylium.Context) error { return nil }
+		})
+		expectPanic(t, "MethodNotAllowedHandler", func(sub *xylium.Router) {
+			sub.MethodNotAllowedHandler = func(c *xylium.Context) error { return nil }
+		})
+	})
+}
+
+func TestRouter_MountHTTP(t *testing.T) {
+	app := newRouterWithConfigForTest(nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Std", "yes")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello from " + r.Method))
+	})
+	app.MountHTTP("/std", http.StripPrefix("/std", mux), headerMiddlewareForTest("route"))
+
+	ctx := serveRequestForTest(app, xylium.MethodPost, "/std/hello")
+	if ctx.Response.StatusCode() != xylium.StatusCreated || string(ctx.Response.Body()) != "hello from POST" {
+		t.Errorf("Expected 201 'hello from POST', got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if string(ctx.Response.Header.Peek("X-Std")) != "yes" || string(ctx.Response.Header.Peek("X-Chain")) != "route" {
+		t.Errorf("Expected headers from handler and middleware, got %q", ctx.Response.Header.String())
+	}
+
+	// Prefix tanpa sub-path juga diteruskan ke handler.
+	if ctx := serveRequestForTest(app, xylium.MethodGet, "/std"); string(ctx.Response.Header.Peek("X-Chain")) != "route" {
+		t.Errorf("Expected the bare prefix to be routed to the mounted handler, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestRouteGroup_SetStripPrefix(t *testing.T) {
+	sub := newRouterWithConfigForTest(nil)
+	sub.GET("/", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s %s %s", c.Path(), c.RoutePattern(), c.StrippedPrefix())
+	})
+	sub.GET("/users/:id", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s %s %s", c.Path(), c.RoutePattern(), c.Param("id"))
+	})
+
+	app := newRouterWithConfigForTest(nil)
+	var globalPath string
+	app.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			err := next(c)
+			globalPath = c.Path() + " " + c.RoutePattern()
+			return err
+		}
+	})
+	app.Mount("/module", sub).SetStripPrefix(true)
+	tenant := app.Group("/t/:tenant")
+	tenant.SetStripPrefix(true)
+	tenant.Group("/docs").GET("/:doc", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s %s %s/%s", c.Path(), c.RoutePattern(), c.Param("tenant"), c.Param("doc"))
+	})
+
+	tests := []struct {
+		path, wantBody, wantGlobal string
+	}{
+		{"/module", "/ / /module", "/module /module"},
+		{"/module/users/42", "/users/42 /users/:id 42", "/module/users/42 /module/users/:id"},
+		// Prefix dengan parameter: segmen prefix dihapus, parameter tetap tersedia.
+		{"/t/acme/docs/readme", "/docs/readme /docs/:doc acme/readme", "/t/acme/docs/readme /t/:tenant/docs/:doc"},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(app, xylium.MethodGet, tt.path)
+		if got := string(ctx.Response.Body()); got != tt.wantBody {
+			t.Errorf("%s: expected body %q, got %q", tt.path, tt.wantBody, got)
+		}
+		if globalPath != tt.wantGlobal {
+			t.Errorf("%s: expected global middleware to see %q, got %q", tt.path, tt.wantGlobal, globalPath)
+		}
+	}
+
+	// MountHTTP + SetStripPrefix berperilaku seperti http.StripPrefix.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("path " + r.URL.Path))
+	})
+	app.MountHTTP("/std", mux).SetStripPrefix(true)
+	if ctx := serveRequestForTest(app, xylium.MethodGet, "/std/hello"); string(ctx.Response.Body()) != "path /hello" {
+		t.Errorf("Expected the mounted handler to see /hello, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+}
@@ -0,0 +1,220 @@
+// File: /test/router_server_test.go
+package xylium_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// startServerForTest menjalankan router pada port bebas dan mengembalikan alamatnya.
+func startServerForTest(t *testing.T, router *xylium.Router) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	go func() { _ = router.ListenAndServe(addr) }()
+	for i := 0; i < 50; i++ { // Tunggu server siap.
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return addr
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("Server on %s did not start", addr)
+	return ""
+}
+
+// sendRawRequestForTest mengirim request GET dengan header tambahan dan mengembalikan status code-nya.
+func sendRawRequestForTest(t *testing.T, addr string, extraHeader string) int {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("GET /ok HTTP/1.1\r\nHost: test\r\n" + extraHeader + "\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServerConfig_MaxRequestHeaderSize(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.MaxRequestHeaderSize = 16 * 1024
+	})
+	router.GET("/ok", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") })
+	addr := startServerForTest(t, router)
+
+	// Header 8KB melebihi default fasthttp (4KB) tetapi masih di bawah batas yang dikonfigurasi.
+	if status := sendRawRequestForTest(t, addr, "X-Big: "+strings.Repeat("a", 8*1024)+"\r\n"); status != xylium.StatusOK {
+		t.Errorf("Expected status 200 for a header below the limit, got %d", status)
+	}
+	if status := sendRawRequestForTest(t, addr, "X-Big: "+strings.Repeat("a", 32*1024)+"\r\n"); status != xylium.StatusRequestHeaderFieldsTooLarge {
+		t.Errorf("Expected status 431 for a header above the limit, got %d", status)
+	}
+}
+
+func TestServerConfig_ShutdownSignals(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Sending signals to the own process is not supported on Windows")
+	}
+	// Tangkap SIGHUP juga di tes, agar proses tidak berhenti jika sinyal tiba sebelum server mendaftar.
+	guard := make(chan os.Signal, 8)
+	signal.Notify(guard, syscall.SIGHUP)
+	defer signal.Stop(guard)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.ShutdownSignals = []os.Signal{syscall.SIGHUP}
+		cfg.ShutdownTimeout = time.Second
+	})
+	closed := make(chan struct{})
+	router.RegisterCloser(closerFunc(func() error { close(closed); return nil }))
+	done := make(chan error, 1)
+	go func() { done <- router.ListenAndServeGracefully(addr) }()
+	for i := 0; i < 50; i++ { // Tunggu server siap.
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	self, _ := os.FindProcess(os.Getpid())
+	for i := 0; i < 50; i++ { // Kirim ulang sampai server menerima sinyal.
+		_ = self.Signal(syscall.SIGHUP)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Expected a clean graceful shutdown, got %v", err)
+			}
+			select {
+			case <-closed:
+			default:
+				t.Error("Expected registered closers to run on shutdown")
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	t.Fatal("Server did not shut down on SIGHUP")
+}
+
+// closerFunc mengadaptasi fungsi menjadi io.Closer.
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func TestRouter_Shutdown(t *testing.T) {
+	// startGracefulForTest menjalankan ListenAndServeGracefully dan menunggu server siap.
+	startGracefulForTest := func(t *testing.T, router *xylium.Router) <-chan error {
+		t.Helper()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr := ln.Addr().String()
+		ln.Close()
+		done := make(chan error, 1)
+		go func() { done <- router.ListenAndServeGracefully(addr) }()
+		for i := 0; i < 50; i++ {
+			if conn, err := net.Dial("tcp", addr); err == nil {
+				conn.Close()
+				return done
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("Server on %s did not start", addr)
+		return nil
+	}
+	// waitServerReturn menunggu ListenAndServeGracefully kembali tanpa error.
+	waitServerReturn := func(t *testing.T, done <-chan error) {
+		t.Helper()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Expected ListenAndServeGracefully to return nil, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("ListenAndServeGracefully did not return after Shutdown")
+		}
+	}
+
+	t.Run("NotRunning", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		if err := router.Shutdown(context.Background()); err == nil {
+			t.Error("Expected an error when no graceful server is running")
+		}
+	})
+
+	t.Run("Completes", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.ShutdownTimeout = time.Second })
+		closed := make(chan struct{})
+		router.RegisterCloser(closerFunc(func() error { close(closed); return nil }))
+		done := startGracefulForTest(t, router)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := router.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown returned an error: %v", err)
+		}
+		select {
+		case <-closed:
+		default:
+			t.Error("Expected registered closers to have run when Shutdown returns")
+		}
+		if router.IsReady() {
+			t.Error("Expected the router to be marked not ready after Shutdown")
+		}
+		waitServerReturn(t, done)
+		if err := router.Shutdown(ctx); err == nil {
+			t.Error("Expected an error for Shutdown after the server stopped")
+		}
+	})
+
+	t.Run("BoundedByContext", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+			cfg.PreShutdownDelay = time.Minute
+			cfg.ShutdownTimeout = time.Second
+		})
+		done := startGracefulForTest(t, router)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := router.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected Shutdown to return at the context deadline, took %v", elapsed)
+		}
+		// PreShutdownDelay dipotong oleh context, sehingga server tetap berhenti.
+		waitServerReturn(t, done)
+	})
+}
@@ -0,0 +1,165 @@
+// File: /test/router_static_test.go
+package xylium_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// staticFSForTest adalah fs.FS di memori yang meniru aset hasil //go:embed.
+func staticFSForTest() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":      {Data: []byte("<html>home</html>")},
+		"css/app.css":     {Data: []byte("body{color:red}")},
+		"docs/index.html": {Data: []byte("<html>docs</html>")},
+	}
+}
+
+// serveStaticRequestForTest seperti serveRequestForTest, tetapi menginisialisasi RequestCtx
+// (fasthttp.FS memakai ctx.Logger()) dan menerima pasangan header opsional (kunci, nilai, ...).
+func serveStaticRequestForTest(router *xylium.Router, uri string, headers ...string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.Header.SetMethod(xylium.MethodGet)
+	req.SetRequestURI(uri)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	var ctx fasthttp.RequestCtx
+	ctx.Init(&req, nil, nil)
+	router.Handler(&ctx)
+	return &ctx
+}
+
+func TestRouter_ServeFilesFS(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.ServeFilesFS("/static", staticFSForTest())
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantType   string
+	}{
+		{"File", "/static/css/app.css", xylium.StatusOK, "body{color:red}", "text/css"},
+		{"RootIndex", "/static/index.html", xylium.StatusOK, "<html>home</html>", "text/html"},
+		{"DirectoryIndex", "/static/docs", xylium.StatusOK, "<html>docs</html>", "text/html"},
+		{"Missing", "/static/missing.js", xylium.StatusNotFound, "", "application/json"},
+		{"Traversal", "/static/../../etc/passwd", xylium.StatusNotFound, "", "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := serveStaticRequestForTest(router, tt.path)
+			if ctx.Response.StatusCode() != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d (%q)", tt.wantStatus, ctx.Response.StatusCode(), ctx.Response.Body())
+			}
+			if tt.wantBody != "" && string(ctx.Response.Body()) != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, ctx.Response.Body())
+			}
+			if ct := string(ctx.Response.Header.ContentType()); !strings.HasPrefix(ct, tt.wantType) {
+				t.Errorf("Expected Content-Type %q, got %q", tt.wantType, ct)
+			}
+			// URI dengan ".." dinormalisasi oleh fasthttp, jadi hanya diperiksa untuk path lain.
+			if uri := string(ctx.Request.RequestURI()); !strings.Contains(tt.path, "..") && uri != tt.path {
+				t.Errorf("Expected original request URI %q to be restored, got %q", tt.path, uri)
+			}
+		})
+	}
+
+	t.Run("ByteRange", func(t *testing.T) {
+		ctx := serveStaticRequestForTest(router, "/static/css/app.css", "Range", "bytes=0-3")
+		if ctx.Response.StatusCode() != xylium.StatusPartialContent || string(ctx.Response.Body()) != "body" {
+			t.Errorf("Expected 206 with 'body', got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+}
+
+func TestRouter_ServeFilesWithConfig_CacheHeaders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.3f9a1c.js"), []byte("console.log(1)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	router := newRouterWithConfigForTest(nil)
+	router.ServeFilesWithConfig("/assets", dir, xylium.ServeFilesConfig{
+		MaxAge:          365 * 24 * time.Hour,
+		Immutable:       true,
+		NoCacheSuffixes: []string{".html"},
+		ETag:            true,
+	})
+	router.ServeFilesFSWithConfig("/embedded", staticFSForTest(), xylium.ServeFilesConfig{
+		MaxAge:              time.Hour,
+		DisableLastModified: true,
+	})
+
+	ctx := serveStaticRequestForTest(router, "/assets/app.3f9a1c.js")
+	if got := string(ctx.Response.Header.Peek("Cache-Control")); got != "public, max-age=31536000, immutable" {
+		t.Errorf("Expected long-lived immutable Cache-Control, got %q", got)
+	}
+	etag := string(ctx.Response.Header.Peek("ETag"))
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("Expected weak ETag, got %q", etag)
+	}
+	if len(ctx.Response.Header.Peek("Last-Modified")) == 0 {
+		t.Error("Expected Last-Modified header by default")
+	}
+
+	ctx = serveStaticRequestForTest(router, "/assets/app.3f9a1c.js", "If-None-Match", etag)
+	if ctx.Response.StatusCode() != xylium.StatusNotModified || len(ctx.Response.Body()) != 0 {
+		t.Errorf("Expected 304 without body for matching ETag, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if got := string(ctx.Response.Header.Peek("Cache-Control")); got != "public, max-age=31536000, immutable" {
+		t.Errorf("Expected Cache-Control on 304, got %q", got)
+	}
+
+	ctx = serveStaticRequestForTest(router, "/assets/index.html")
+	if got := string(ctx.Response.Header.Peek("Cache-Control")); got != "no-cache" {
+		t.Errorf("Expected no-cache for excluded suffix, got %q", got)
+	}
+
+	ctx = serveStaticRequestForTest(router, "/assets/missing.js")
+	if got := ctx.Response.Header.Peek("Cache-Control"); ctx.Response.StatusCode() != xylium.StatusNotFound || len(got) != 0 {
+		t.Errorf("Expected uncached 404, got %d with Cache-Control %q", ctx.Response.StatusCode(), got)
+	}
+
+	ctx = serveStaticRequestForTest(router, "/embedded/css/app.css")
+	if got := string(ctx.Response.Header.Peek("Cache-Control")); got != "public, max-age=3600" {
+		t.Errorf("Expected max-age=3600, got %q", got)
+	}
+	if got := ctx.Response.Header.Peek("Last-Modified"); len(got) != 0 {
+		t.Errorf("Expected Last-Modified to be removed, got %q", got)
+	}
+}
+
+func TestRouter_ServeFilesFS_ETagRequiresModTime(t *testing.T) {
+	// MapFS tanpa ModTime meniru embed.FS: tanpa waktu modifikasi, ETag dari ukuran saja
+	// akan tetap sama setelah file diubah, sehingga ETag tidak dikirim.
+	fsys := staticFSForTest()
+	fsys["dated.css"] = &fstest.MapFile{Data: []byte("a{}"), ModTime: time.Unix(1700000000, 0)}
+	router := newRouterWithConfigForTest(nil)
+	router.ServeFilesFSWithConfig("/embedded", fsys, xylium.ServeFilesConfig{ETag: true})
+
+	ctx := serveStaticRequestForTest(router, "/embedded/css/app.css")
+	if got := ctx.Response.Header.Peek("ETag"); ctx.Response.StatusCode() != xylium.StatusOK || len(got) != 0 {
+		t.Errorf("Expected 200 without ETag for a file without modification time, got %d %q", ctx.Response.StatusCode(), got)
+	}
+	ctx = serveStaticRequestForTest(router, "/embedded/css/app.css", "If-None-Match", "*")
+	if ctx.Response.StatusCode() == xylium.StatusNotModified {
+		t.Error("Expected no 304 from an ETag for a file without modification time")
+	}
+
+	ctx = serveStaticRequestForTest(router, "/embedded/dated.css")
+	if etag := string(ctx.Response.Header.Peek("ETag")); !strings.HasPrefix(etag, `W/"`) {
+		t.Errorf("Expected a weak ETag for a file with modification time, got %q", etag)
+	}
+}
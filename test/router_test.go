@@ -0,0 +1,932 @@
+// File: /test/router_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// newRouterWithConfigForTest membuat router untuk tes dengan ServerConfig kustom
+// yang dimodifikasi oleh fungsi `mutate` (jika tidak nil).
+func newRouterWithConfigForTest(mutate func(cfg *xylium.ServerConfig)) *xylium.Router {
+	cfg := xylium.DefaultServerConfig()
+	if mutate != nil {
+		mutate(&cfg)
+	}
+	return xylium.NewRouterForTesting(xylium.RouterTestOptions{SilenceLogs: true, Config: cfg})
+}
+
+// serveRequestForTest menjalankan satu request melalui router.Handler dan mengembalikan RequestCtx-nya.
+func serveRequestForTest(router *xylium.Router, method, uri string) *fasthttp.RequestCtx {
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(method)
+	fasthttpCtx.Request.SetRequestURI(uri)
+	router.Handler(&fasthttpCtx)
+	return &fasthttpCtx
+}
+
+func TestRouter_AutoHEAD(t *testing.T) {
+	const body = "hello from GET"
+	getHandler := func(c *xylium.Context) error {
+		c.SetHeader("X-From-Get", "yes")
+		return c.String(xylium.StatusOK, body)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.GET("/resource", getHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodHead, "/resource")
+		if ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d without AutoHEAD, got %d", xylium.StatusMethodNotAllowed, ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("Enabled_UsesGETHandlerWithoutBody", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoHEAD = true })
+		router.GET("/resource", getHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodHead, "/resource")
+		if ctx.Response.StatusCode() != xylium.StatusOK {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusOK, ctx.Response.StatusCode())
+		}
+		if got := string(ctx.Response.Header.Peek("X-From-Get")); got != "yes" {
+			t.Errorf("Expected header from GET handler to be preserved, got '%s'", got)
+		}
+		if len(ctx.Response.Body()) != 0 {
+			t.Errorf("Expected empty body for HEAD, got '%s'", ctx.Response.Body())
+		}
+		if cl := ctx.Response.Header.ContentLength(); cl != len(body) {
+			t.Errorf("Expected Content-Length %d, got %d", len(body), cl)
+		}
+	})
+
+	t.Run("Enabled_ExplicitHEADTakesPrecedence", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoHEAD = true })
+		router.GET("/resource", getHandler)
+		router.HEAD("/resource", func(c *xylium.Context) error {
+			c.SetHeader("X-From-Head", "yes")
+			return c.NoContent(xylium.StatusOK)
+		})
+
+		ctx := serveRequestForTest(router, xylium.MethodHead, "/resource")
+		if got := string(ctx.Response.Header.Peek("X-From-Head")); got != "yes" {
+			t.Errorf("Expected explicit HEAD handler to be used, header X-From-Head='%s'", got)
+		}
+	})
+
+	t.Run("Enabled_AllowHeaderIncludesHEAD", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoHEAD = true })
+		router.GET("/resource", getHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodPost, "/resource")
+		if ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusMethodNotAllowed, ctx.Response.StatusCode())
+		}
+		allow := string(ctx.Response.Header.Peek("Allow"))
+		if !strings.Contains(allow, xylium.MethodGet) || !strings.Contains(allow, xylium.MethodHead) {
+			t.Errorf("Expected Allow header to contain GET and HEAD, got '%s'", allow)
+		}
+	})
+}
+
+func TestRouter_AutoOPTIONS(t *testing.T) {
+	okHandler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") }
+
+	t.Run("Disabled", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodOptions, "/items")
+		if ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d without AutoOPTIONS, got %d", xylium.StatusMethodNotAllowed, ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("Enabled_RespondsWithAllow", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoOPTIONS = true })
+		router.GET("/items", okHandler)
+		router.POST("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodOptions, "/items")
+		if ctx.Response.StatusCode() != xylium.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusNoContent, ctx.Response.StatusCode())
+		}
+		if allow := string(ctx.Response.Header.Peek("Allow")); allow != "GET, OPTIONS, POST" {
+			t.Errorf("Expected Allow header 'GET, OPTIONS, POST', got '%s'", allow)
+		}
+	})
+
+	t.Run("Enabled_ExplicitOPTIONSTakesPrecedence", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoOPTIONS = true })
+		router.GET("/items", okHandler)
+		router.OPTIONS("/items", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "custom") })
+
+		ctx := serveRequestForTest(router, xylium.MethodOptions, "/items")
+		if body := string(ctx.Response.Body()); body != "custom" {
+			t.Errorf("Expected explicit OPTIONS handler body 'custom', got '%s'", body)
+		}
+	})
+
+	t.Run("Enabled_RunsGlobalCORSForPreflight", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoOPTIONS = true })
+		router.Use(xylium.CORSWithConfig(xylium.CORSConfig{AllowOrigins: []string{"https://app.example.com"}}))
+		router.PUT("/items/:id", okHandler)
+
+		// Preflight browser tanpa route OPTIONS dan tanpa RunMiddlewareOnNoRoute.
+		resp, err := xylium.NewTestRequest().Method(xylium.MethodOptions).Path("/items/1").
+			Header("Origin", "https://app.example.com").
+			Header("Access-Control-Request-Method", xylium.MethodPut).
+			Do(router)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusNoContent, resp.StatusCode())
+		}
+		if got := resp.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+			t.Errorf("Expected the preflight to carry CORS headers, got Access-Control-Allow-Origin '%s'", got)
+		}
+	})
+
+	t.Run("Enabled_UnknownPathStill404", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.AutoOPTIONS = true })
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodOptions, "/missing")
+		if ctx.Response.StatusCode() != xylium.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", xylium.StatusNotFound, ctx.Response.StatusCode())
+		}
+	})
+}
+
+func TestRouter_MethodOverride(t *testing.T) {
+	newApp := func() *xylium.Router {
+		router := newRouterWithConfigForTest(nil)
+		router.UsePreRouting(xylium.MethodOverride(xylium.MethodOverrideConfig{}))
+		handler := func(c *xylium.Context) error {
+			original, _ := c.Get(xylium.ContextKeyOriginalMethod)
+			return c.String(xylium.StatusOK, "%s:%v", c.Method(), original)
+		}
+		router.POST("/items/:id", handler)
+		router.PUT("/items/:id", handler)
+		router.DELETE("/items/:id", handler)
+		router.GET("/items/:id", handler)
+		return router
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		header       string
+		formBody     string
+		query        string
+		expectedBody string
+	}{
+		{"HeaderOverride", xylium.MethodPost, "PUT", "", "", "PUT:POST"},
+		{"FormFieldOverride", xylium.MethodPost, "", "_method=delete", "", "DELETE:POST"},
+		{"QueryOverride", xylium.MethodPost, "", "", "?_method=DELETE", "DELETE:POST"},
+		{"NoOverride", xylium.MethodPost, "", "", "", "POST:<nil>"},
+		{"NotInAllowList", xylium.MethodPost, "CONNECT", "", "", "POST:<nil>"},
+		{"OnlyPOSTIsUpgraded", xylium.MethodGet, "DELETE", "", "", "GET:<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newApp()
+			var fasthttpCtx fasthttp.RequestCtx
+			fasthttpCtx.Request.Header.SetMethod(tt.method)
+			fasthttpCtx.Request.SetRequestURI("/items/1" + tt.query)
+			if tt.header != "" {
+				fasthttpCtx.Request.Header.Set(xylium.DefaultMethodOverrideHeader, tt.header)
+			}
+			if tt.formBody != "" {
+				fasthttpCtx.Request.Header.SetContentType("application/x-www-form-urlencoded")
+				fasthttpCtx.Request.SetBodyString(tt.formBody)
+			}
+			router.Handler(&fasthttpCtx)
+
+			if body := string(fasthttpCtx.Response.Body()); body != tt.expectedBody {
+				t.Errorf("Expected body '%s', got '%s'", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestRouter_UsePreRouting_RewritePath(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	var hookCalls int
+	router.UsePreRouting(func(c *xylium.Context) {
+		hookCalls++
+		if p := c.Path(); strings.HasPrefix(p, "/tenant-a/") {
+			c.Set("tenant", "tenant-a")
+			c.RewritePath(strings.TrimPrefix(p, "/tenant-a"))
+		}
+	})
+	router.GET("/users/:id", func(c *xylium.Context) error {
+		tenant, _ := c.GetString("tenant")
+		return c.String(xylium.StatusOK, "%s:%s:%s", tenant, c.Param("id"), c.QueryParam("q"))
+	})
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/tenant-a/users/42?q=x")
+	if body := string(ctx.Response.Body()); body != "tenant-a:42:x" {
+		t.Errorf("Expected body 'tenant-a:42:x', got '%s'", body)
+	}
+
+	// Hook juga harus dijalankan untuk request yang berakhir 404.
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/unknown")
+	if ctx.Response.StatusCode() != xylium.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", xylium.StatusNotFound, ctx.Response.StatusCode())
+	}
+	if hookCalls != 2 {
+		t.Errorf("Expected pre-routing hook to run 2 times (including 404), ran %d times", hookCalls)
+	}
+}
+
+func TestRouteGroup_SetErrorHandler(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	failing := func(c *xylium.Context) error {
+		return xylium.NewHTTPError(xylium.StatusTeapot, "short and stout")
+	}
+	groupHandler := func(label string) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			cause, _ := c.Get(xylium.ContextKeyErrorCause)
+			return c.String(xylium.StatusTeapot, "%s: %v", label, cause)
+		}
+	}
+
+	api := router.Group("/api")
+	v1 := api.Group("/v1")
+	v2 := api.Group("/v2")
+	v2.SetErrorHandler(groupHandler("v2"))
+	api.SetErrorHandler(groupHandler("api")) // Diset setelah sub-group dibuat; tetap diwarisi.
+
+	router.GET("/plain", failing)
+	api.GET("/direct", failing)
+	v1.GET("/inherited", failing)
+	v2.GET("/own", failing)
+
+	tests := []struct {
+		path       string
+		wantPrefix string
+	}{
+		{"/api/direct", "api: "},
+		{"/api/v1/inherited", "api: "},
+		{"/api/v2/own", "v2: "},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(router, xylium.MethodGet, tt.path)
+		if body := string(ctx.Response.Body()); !strings.HasPrefix(body, tt.wantPrefix) {
+			t.Errorf("%s: expected body from group error handler with prefix %q, got %q", tt.path, tt.wantPrefix, body)
+		}
+	}
+
+	// Rute di luar grup tetap memakai GlobalErrorHandler.
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/plain")
+	if ctx.Response.StatusCode() != xylium.StatusTeapot || strings.HasPrefix(string(ctx.Response.Body()), "api: ") {
+		t.Errorf("Expected GlobalErrorHandler response for /plain, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	// Menghapus handler grup mengembalikan pewarisan.
+	v2.SetErrorHandler(nil)
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/api/v2/own")
+	if body := string(ctx.Response.Body()); !strings.HasPrefix(body, "api: ") {
+		t.Errorf("Expected v2 to inherit api handler after SetErrorHandler(nil), got %q", body)
+	}
+}
+
+func TestRouter_Fallback(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/api/status", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") })
+
+	api := router.Group("/api")
+	api.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			c.SetHeader("X-Group", "api")
+			return next(c)
+		}
+	})
+	api.SetErrorHandler(func(c *xylium.Context) error {
+		return c.JSON(xylium.StatusNotFound, xylium.M{"error": "unknown endpoint"})
+	})
+	api.Fallback(func(c *xylium.Context) error {
+		return xylium.NewHTTPError(xylium.StatusNotFound, "not found")
+	})
+	router.Fallback("/app", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "index.html") })
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"SPAClientRoute", xylium.MethodGet, "/app/users/42", xylium.StatusOK, "index.html"},
+		{"SPAPrefixItself", xylium.MethodGet, "/app", xylium.StatusOK, "index.html"},
+		{"GroupFallbackUsesGroupErrorHandler", xylium.MethodGet, "/api/nope", xylium.StatusNotFound, `{"error":"unknown endpoint"}`},
+		{"RoutesStillWin", xylium.MethodGet, "/api/status", xylium.StatusOK, "ok"},
+		{"PrefixMatchesWholeSegments", xylium.MethodGet, "/application", xylium.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := serveRequestForTest(router, tt.method, tt.path)
+			if ctx.Response.StatusCode() != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, ctx.Response.StatusCode())
+			}
+			if tt.wantBody != "" && string(ctx.Response.Body()) != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, ctx.Response.Body())
+			}
+		})
+	}
+
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/api/nope"); string(ctx.Response.Header.Peek("X-Group")) != "api" {
+		t.Error("Expected group middleware to run for the group fallback")
+	}
+	// Metode yang salah pada path yang ada tetap 405, bukan fallback.
+	if ctx := serveRequestForTest(router, xylium.MethodPost, "/api/status"); ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for wrong method, got %d", ctx.Response.StatusCode())
+	}
+
+	// Prefix terpanjang menang atas fallback root.
+	router.Fallback("/", func(c *xylium.Context) error { return c.String(xylium.StatusOK, "root") })
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/app/x"); string(ctx.Response.Body()) != "index.html" {
+		t.Errorf("Expected longest-prefix fallback, got %q", ctx.Response.Body())
+	}
+	if ctx := serveRequestForTest(router, xylium.MethodGet, "/application"); string(ctx.Response.Body()) != "root" {
+		t.Errorf("Expected root fallback, got %q", ctx.Response.Body())
+	}
+}
+
+func TestRouter_AnyAndMatch(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	echoMethod := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "%s", c.Method()) }
+	router.Any("/proxy/*rest", echoMethod)
+	router.Group("/api").Match([]string{"get", xylium.MethodPost}, "/search", echoMethod)
+
+	for _, method := range []string{xylium.MethodGet, xylium.MethodPost, xylium.MethodPut, xylium.MethodDelete, xylium.MethodPatch, xylium.MethodOptions} {
+		ctx := serveRequestForTest(router, method, "/proxy/a/b")
+		if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Body()) != method {
+			t.Errorf("Any: expected %s to be handled, got %d %q", method, ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	}
+	if ctx := serveRequestForTest(router, xylium.MethodPost, "/api/search"); string(ctx.Response.Body()) != xylium.MethodPost {
+		t.Errorf("Match: expected POST to be handled, got %d", ctx.Response.StatusCode())
+	}
+	if ctx := serveRequestForTest(router, xylium.MethodPut, "/api/search"); ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+		t.Errorf("Match: expected 405 for PUT, got %d", ctx.Response.StatusCode())
+	}
+
+	t.Run("DuplicatePanicNamesMethodsAndRegistersNone", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.GET("/x", echoMethod)
+		router.POST("/x", echoMethod)
+		defer func() {
+			msg, _ := recover().(string)
+			if !strings.Contains(msg, "GET, POST") || !strings.Contains(msg, "/x") {
+				t.Errorf("Expected panic naming GET, POST and /x, got %q", msg)
+			}
+			if ctx := serveRequestForTest(router, xylium.MethodPut, "/x"); ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+				t.Errorf("Expected PUT to remain unregistered after the panic, got %d", ctx.Response.StatusCode())
+			}
+		}()
+		router.Any("/x", echoMethod)
+	})
+}
+
+func TestRouter_RunMiddlewareOnNoRoute(t *testing.T) {
+	okHandler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") }
+	// markerMiddleware menandai response dan mencatat error yang dikembalikan handler.
+	newMarkerMiddleware := func(seenErr *error) xylium.Middleware {
+		return func(next xylium.HandlerFunc) xylium.HandlerFunc {
+			return func(c *xylium.Context) error {
+				c.SetHeader("X-Global", "yes")
+				err := next(c)
+				*seenErr = err
+				return err
+			}
+		}
+	}
+
+	t.Run("Disabled_BypassesGlobalMiddleware", func(t *testing.T) {
+		var seenErr error
+		router := newRouterWithConfigForTest(nil)
+		router.Use(newMarkerMiddleware(&seenErr))
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/missing")
+		if ctx.Response.StatusCode() != xylium.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusNotFound, ctx.Response.StatusCode())
+		}
+		if h := string(ctx.Response.Header.Peek("X-Global")); h != "" {
+			t.Errorf("Expected global middleware not to run on 404, got X-Global '%s'", h)
+		}
+	})
+
+	t.Run("Enabled_NotFound", func(t *testing.T) {
+		var seenErr error
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.RunMiddlewareOnNoRoute = true })
+		router.Use(newMarkerMiddleware(&seenErr))
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/missing")
+		if ctx.Response.StatusCode() != xylium.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusNotFound, ctx.Response.StatusCode())
+		}
+		if h := string(ctx.Response.Header.Peek("X-Global")); h != "yes" {
+			t.Errorf("Expected X-Global header 'yes' on 404, got '%s'", h)
+		}
+		var httpErr *xylium.HTTPError
+		if !errors.As(seenErr, &httpErr) || httpErr.Code != xylium.StatusNotFound {
+			t.Errorf("Expected middleware to see the 404 HTTPError, got %v", seenErr)
+		}
+	})
+
+	t.Run("Enabled_MethodNotAllowed", func(t *testing.T) {
+		var seenErr error
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.RunMiddlewareOnNoRoute = true })
+		router.Use(newMarkerMiddleware(&seenErr))
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodPost, "/items")
+		if ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed {
+			t.Fatalf("Expected status %d, got %d", xylium.StatusMethodNotAllowed, ctx.Response.StatusCode())
+		}
+		if h := string(ctx.Response.Header.Peek("X-Global")); h != "yes" {
+			t.Errorf("Expected X-Global header 'yes' on 405, got '%s'", h)
+		}
+		if allow := string(ctx.Response.Header.Peek("Allow")); allow != "GET" {
+			t.Errorf("Expected Allow header 'GET', got '%s'", allow)
+		}
+	})
+
+	t.Run("Enabled_CORSPreflightOnUnmatchedMethod", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) { cfg.RunMiddlewareOnNoRoute = true })
+		router.Use(xylium.CORSWithConfig(xylium.CORSConfig{AllowOrigins: []string{"https://app.example.com"}}))
+		router.GET("/items", okHandler)
+
+		var fasthttpCtx fasthttp.RequestCtx
+		fasthttpCtx.Request.Header.SetMethod(xylium.MethodOptions)
+		fasthttpCtx.Request.SetRequestURI("/items")
+		fasthttpCtx.Request.Header.Set("Origin", "https://app.example.com")
+		fasthttpCtx.Request.Header.Set("Access-Control-Request-Method", "GET")
+		router.Handler(&fasthttpCtx)
+
+		if fasthttpCtx.Response.StatusCode() != xylium.StatusNoContent {
+			t.Fatalf("Expected status %d for preflight, got %d", xylium.StatusNoContent, fasthttpCtx.Response.StatusCode())
+		}
+		if acao := string(fasthttpCtx.Response.Header.Peek("Access-Control-Allow-Origin")); acao != "https://app.example.com" {
+			t.Errorf("Expected ACAO 'https://app.example.com', got '%s'", acao)
+		}
+	})
+}
+
+func TestRouter_RegisterErrorMapper(t *testing.T) {
+	errNoRows := errors.New("sql: no rows in result set")
+	errInvalid := errors.New("invalid input")
+	router := newRouterWithConfigForTest(nil)
+	router.RegisterErrorMapper(
+		xylium.MapErrorIs(errNoRows, xylium.StatusNotFound, "Resource not found."),
+		func(err error) (*xylium.HTTPError, bool) {
+			if errors.Is(err, errInvalid) {
+				return xylium.NewHTTPError(xylium.StatusBadRequest, "Bad input."), true
+			}
+			return nil, false
+		},
+		// Mapper kedua untuk errNoRows tidak pernah dipakai: mapper pertama yang cocok menang.
+		xylium.MapErrorIs(errNoRows, xylium.StatusGone),
+	)
+	router.GET("/missing", func(c *xylium.Context) error {
+		return fmt.Errorf("loading user: %w", errNoRows)
+	})
+	router.GET("/invalid", func(c *xylium.Context) error { return errInvalid })
+	router.GET("/unmapped", func(c *xylium.Context) error { return errors.New("boom") })
+	router.GET("/httperror", func(c *xylium.Context) error {
+		return xylium.NewHTTPError(xylium.StatusConflict, "conflict").WithInternal(errNoRows)
+	})
+	router.GET("/committed", func(c *xylium.Context) error {
+		_ = c.String(xylium.StatusOK, "partial")
+		return errNoRows
+	})
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/missing", xylium.StatusNotFound, "Resource not found."},
+		{"/invalid", xylium.StatusBadRequest, "Bad input."},
+		{"/unmapped", xylium.StatusInternalServerError, ""},
+		{"/httperror", xylium.StatusConflict, "conflict"}, // HTTPError tidak melewati mapper.
+		{"/committed", xylium.StatusOK, "partial"},        // Respons yang sudah di-commit tidak ditimpa.
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ctx := serveRequestForTest(router, xylium.MethodGet, tt.path)
+			if ctx.Response.StatusCode() != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, ctx.Response.StatusCode())
+			}
+			if body := string(ctx.Response.Body()); !strings.Contains(body, tt.wantBody) {
+				t.Errorf("Expected body to contain '%s', got '%s'", tt.wantBody, body)
+			}
+		})
+	}
+
+	t.Run("MapErrorSetsInternal", func(t *testing.T) {
+		httpErr, ok := router.MapError(errInvalid)
+		if !ok || httpErr.Code != xylium.StatusBadRequest {
+			t.Fatalf("Expected errInvalid to map to 400, got %v (ok %t)", httpErr, ok)
+		}
+		if !errors.Is(httpErr, errInvalid) {
+			t.Errorf("Expected mapped HTTPError to wrap the original error, got internal %v", httpErr.Internal)
+		}
+		if _, ok := router.MapError(errors.New("other")); ok {
+			t.Error("Expected unknown error not to be mapped")
+		}
+	})
+}
+
+func TestRouter_RoutesIntrospection(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.RouteTimeout = 3 * time.Second
+	})
+	h := func(c *xylium.Context) error { return c.NoContent(xylium.StatusNoContent) }
+
+	router.GET("/users/:id", h).Name("users.show").Meta("scope", "read").Meta("scope", "users:read")
+	router.Match([]string{xylium.MethodPut, xylium.MethodPatch}, "/users/:id", h).Name("users.update").Timeout(10 * time.Second)
+	admin := router.Group("/admin")
+	admin.SetTimeout(time.Minute)
+	admin.DELETE("/users/:id", h).Meta("scope", "admin")
+
+	routes := router.Routes()
+	byKey := make(map[string]xylium.RouteInfo, len(routes))
+	for _, rt := range routes {
+		byKey[rt.Method+" "+rt.Path] = rt
+	}
+	if len(byKey) != 4 {
+		t.Fatalf("Expected 4 routes, got %+v", routes)
+	}
+
+	show := byKey["GET /users/:id"]
+	if show.Name != "users.show" || show.Timeout != 3*time.Second || show.Meta["scope"] != "users:read" {
+		t.Errorf("Unexpected info for GET /users/:id: %+v", show)
+	}
+	for _, method := range []string{xylium.MethodPatch, xylium.MethodPut} {
+		info := byKey[method+" /users/:id"]
+		if info.Name != "users.update" || info.Timeout != 10*time.Second || info.Meta != nil {
+			t.Errorf("Unexpected info for %s /users/:id: %+v", method, info)
+		}
+	}
+	del := byKey["DELETE /admin/users/:id"]
+	if del.Name != "" || del.Timeout != time.Minute || del.Meta["scope"] != "admin" {
+		t.Errorf("Unexpected info for DELETE /admin/users/:id: %+v", del)
+	}
+
+	// Meta yang dikembalikan adalah salinan.
+	show.Meta["scope"] = "mutated"
+	for _, rt := range router.Routes() {
+		if rt.Method == xylium.MethodGet && rt.Meta["scope"] != "users:read" {
+			t.Errorf("Expected Routes to return a copy of route metadata, got %v", rt.Meta["scope"])
+		}
+	}
+}
+
+func TestContext_RouteMeta(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.RunMiddlewareOnNoRoute = true
+	})
+
+	// Middleware global membaca metadata route untuk otorisasi berbasis data.
+	router.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			if scope, ok := c.RouteMeta("scope"); ok && c.Header("X-Scope") != scope {
+				return xylium.NewHTTPError(xylium.StatusForbidden, "insufficient scope")
+			}
+			return next(c)
+		}
+	})
+	h := func(c *xylium.Context) error {
+		_, ok := c.RouteMeta("scope")
+		return c.String(xylium.StatusOK, "scoped=%t", ok)
+	}
+	router.GET("/public", h)
+	router.Group("/admin").DELETE("/users/:id", h).Meta("scope", "admin")
+
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/public")
+	if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Body()) != "scoped=false" {
+		t.Errorf("Expected route without metadata to pass, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	ctx = serveRequestForTest(router, xylium.MethodDelete, "/admin/users/1")
+	if ctx.Response.StatusCode() != xylium.StatusForbidden {
+		t.Errorf("Expected status 403 without the required scope, got %d", ctx.Response.StatusCode())
+	}
+
+	var fasthttpCtx fasthttp.RequestCtx
+	fasthttpCtx.Request.Header.SetMethod(xylium.MethodDelete)
+	fasthttpCtx.Request.SetRequestURI("/admin/users/1")
+	fasthttpCtx.Request.Header.Set("X-Scope", "admin")
+	router.Handler(&fasthttpCtx)
+	if fasthttpCtx.Response.StatusCode() != xylium.StatusOK || string(fasthttpCtx.Response.Body()) != "scoped=true" {
+		t.Errorf("Expected request with the required scope to pass, got %d %q", fasthttpCtx.Response.StatusCode(), fasthttpCtx.Response.Body())
+	}
+
+	// Tanpa route yang cocok, tidak ada metadata.
+	router.NotFoundHandler = func(c *xylium.Context) error {
+		_, ok := c.RouteMeta("scope")
+		return c.String(xylium.StatusNotFound, "scoped=%t", ok)
+	}
+	ctx = serveRequestForTest(router, xylium.MethodGet, "/missing")
+	if string(ctx.Response.Body()) != "scoped=false" {
+		t.Errorf("Expected no route metadata for unmatched requests, got %q", ctx.Response.Body())
+	}
+}
+
+func TestContext_RoutePattern(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.RunMiddlewareOnNoRoute = true
+	})
+
+	// Middleware global melihat pola route yang cocok, bukan path konkret.
+	var seen []string
+	router.Use(func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			seen = append(seen, c.RoutePattern())
+			return next(c)
+		}
+	})
+	h := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "%s", c.RoutePattern()) }
+	router.GET("/", h)
+	router.GET("/users/:id", h)
+	router.GET("/users/:id/posts/", h) // Garis miring di akhir dinormalisasi.
+	router.GET("/files/*filepath", h)
+	router.Group("/api").Group("/v1").GET("/orders/:orderID", h)
+
+	tests := []struct{ path, pattern string }{
+		{"/", "/"},
+		{"/users/123", "/users/:id"},
+		{"/users/123/posts", "/users/:id/posts"},
+		{"/files/css/site.css", "/files/*filepath"},
+		{"/api/v1/orders/42", "/api/v1/orders/:orderID"},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(router, xylium.MethodGet, tt.path)
+		if got := string(ctx.Response.Body()); got != tt.pattern {
+			t.Errorf("%s: expected route pattern %q, got %q", tt.path, tt.pattern, got)
+		}
+	}
+
+	seen = nil
+	ctx := serveRequestForTest(router, xylium.MethodGet, "/missing/123")
+	if ctx.Response.StatusCode() != xylium.StatusNotFound || len(seen) != 1 || seen[0] != "" {
+		t.Errorf("Expected empty route pattern for unmatched request, got status %d and %q", ctx.Response.StatusCode(), seen)
+	}
+}
+
+func TestContext_ParamNamesAndValues(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	h := func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s=%s", strings.Join(c.ParamNames(), ","), strings.Join(c.ParamValues(), ","))
+	}
+	// Nama parameter sengaja tidak berurutan alfabetis agar urutan path terlihat.
+	router.GET("/zones/:zone/apps/:app/files/*path", h)
+	router.GET("/zones/:zone/apps/:app", h)
+	router.GET("/zones/:zone/status", h) // Cabang statis yang bersaing dengan :app tidak boleh mengacaukan urutan.
+	router.GET("/health", h)
+
+	tests := []struct{ path, want string }{
+		{"/zones/eu/apps/web/files/css/site.css", "zone,app,path=eu,web,css/site.css"},
+		{"/zones/eu/apps/web", "zone,app=eu,web"},
+		{"/zones/eu/status", "zone=eu"},
+		{"/health", "="},
+	}
+	for _, tt := range tests {
+		ctx := serveRequestForTest(router, xylium.MethodGet, tt.path)
+		if got := string(ctx.Response.Body()); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.path, tt.want, got)
+		}
+	}
+
+	// Konteks tanpa routing: nama diurutkan secara alfabetis.
+	c := xylium.NewContextForTest(map[string]string{"b": "2", "a": "1"}, nil)
+	if got := strings.Join(c.ParamValues(), ","); got != "1,2" {
+		t.Errorf("Expected alphabetical order without routing, got %q", got)
+	}
+}
+
+func TestRouter_PanicReportIncludesRequestDetails(t *testing.T) {
+	newPanickingRouter := func(mode string, buf *bytes.Buffer) *xylium.Router {
+		cfg := xylium.DefaultServerConfig()
+		cfg.Logger = xylium.NewDefaultLoggerWithConfig(xylium.LoggerConfig{
+			Level:     xylium.LevelDebug,
+			Formatter: xylium.JSONFormatter,
+			Output:    buf,
+		})
+		router := xylium.NewRouterForTesting(xylium.RouterTestOptions{Mode: mode, SilenceLogs: true, Config: cfg})
+		router.Use(xylium.RequestID())
+		router.POST("/orders", func(c *xylium.Context) error { panic("boom") })
+		return router
+	}
+	serve := func(router *xylium.Router) *fasthttp.RequestCtx {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(xylium.MethodPost)
+		ctx.Request.SetRequestURI("/orders?token=secret123&page=2")
+		ctx.Request.Header.Set("Authorization", "Bearer secret-token")
+		ctx.Request.Header.Set("X-Trace", "abc")
+		ctx.Request.SetBodyString(`{"card":"4111"}` + strings.Repeat("x", 3000))
+		router.Handler(&ctx)
+		return &ctx
+	}
+	// panicEntry mencari entri log dari panic yang di-recover.
+	panicEntry := func(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+		t.Helper()
+		for _, entry := range decodeLogLines(t, buf) {
+			if msg, _ := entry["message"].(string); strings.HasPrefix(msg, "PANIC RECOVERED: boom") {
+				return entry
+			}
+		}
+		t.Fatalf("Panic log entry not found in %q", buf.String())
+		return nil
+	}
+	// fieldsOf mengembalikan field terstruktur dari entri log.
+	fieldsOf := func(t *testing.T, entry map[string]interface{}) map[string]interface{} {
+		t.Helper()
+		fields, ok := entry["fields"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected structured fields in panic log entry, got %v", entry)
+		}
+		return fields
+	}
+
+	t.Run("DebugModeLogsFullDetails", func(t *testing.T) {
+		var buf bytes.Buffer
+		ctx := serve(newPanickingRouter(xylium.DebugMode, &buf))
+		if ctx.Response.StatusCode() != xylium.StatusInternalServerError {
+			t.Fatalf("Expected status 500, got %d", ctx.Response.StatusCode())
+		}
+		fields := fieldsOf(t, panicEntry(t, &buf))
+		if fields["method"] != "POST" || fields["path"] != "/orders" {
+			t.Errorf("Expected method POST and path /orders, got %v and %v", fields["method"], fields["path"])
+		}
+		if fields["query"] != "token=secret123&page=2" {
+			t.Errorf("Expected raw query in DebugMode, got %v", fields["query"])
+		}
+		requestID := string(ctx.Response.Header.Peek(xylium.DefaultRequestIDHeader))
+		if requestID == "" || fields["xylium_request_id"] != requestID {
+			t.Errorf("Expected request ID %q in panic log, got %v", requestID, fields["xylium_request_id"])
+		}
+		headers, _ := fields["headers"].(map[string]interface{})
+		if headers["Authorization"] != "Bearer secret-token" || headers["X-Trace"] != "abc" {
+			t.Errorf("Expected headers as received in DebugMode, got %v", headers)
+		}
+		body, _ := fields["body"].(string)
+		if !strings.HasPrefix(body, `{"card":"4111"}`) || !strings.Contains(body, "[truncated, 3015 bytes total]") {
+			t.Errorf("Expected truncated body snapshot, got %q", body)
+		}
+	})
+
+	t.Run("ReleaseModeRedactsSensitiveData", func(t *testing.T) {
+		var buf bytes.Buffer
+		serve(newPanickingRouter(xylium.ReleaseMode, &buf))
+		fields := fieldsOf(t, panicEntry(t, &buf))
+		if fields["path"] != "/orders" {
+			t.Errorf("Expected path /orders, got %v", fields["path"])
+		}
+		if query, _ := fields["query"].(string); strings.Contains(query, "secret123") || !strings.Contains(query, "token=[REDACTED]") {
+			t.Errorf("Expected redacted query values, got %q", query)
+		}
+		headers, _ := fields["headers"].(map[string]interface{})
+		if headers["Authorization"] != "[REDACTED]" || headers["X-Trace"] != "abc" {
+			t.Errorf("Expected Authorization redacted and X-Trace kept, got %v", headers)
+		}
+		if fields["body"] != "[REDACTED 3015 bytes]" {
+			t.Errorf("Expected only the body size, got %v", fields["body"])
+		}
+	})
+}
+
+func TestRouter_NoRouteErrorBody(t *testing.T) {
+	okHandler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "ok") }
+	// decodeBody mengurai body JSON respons menjadi map.
+	decodeBody := func(t *testing.T, ctx *fasthttp.RequestCtx) map[string]interface{} {
+		t.Helper()
+		var body map[string]interface{}
+		if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+			t.Fatalf("Failed to decode body %q: %v", ctx.Response.Body(), err)
+		}
+		return body
+	}
+
+	t.Run("DefaultEnvelope", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.GET("/items", okHandler)
+
+		body := decodeBody(t, serveRequestForTest(router, xylium.MethodGet, "/missing"))
+		if body["status"] != float64(xylium.StatusNotFound) || body["path"] != "/missing" || body["error"] == nil {
+			t.Errorf("Unexpected 404 body: %v", body)
+		}
+		body = decodeBody(t, serveRequestForTest(router, xylium.MethodDelete, "/items"))
+		if body["status"] != float64(xylium.StatusMethodNotAllowed) || body["path"] != "/items" ||
+			fmt.Sprint(body["allowed_methods"]) != "[GET]" {
+			t.Errorf("Unexpected 405 body: %v", body)
+		}
+	})
+
+	t.Run("CustomEnvelope", func(t *testing.T) {
+		router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+			cfg.NoRouteErrorBody = func(c *xylium.Context, status int, message string) interface{} {
+				return xylium.M{
+					"type":     "about:blank",
+					"title":    xylium.StatusText(status),
+					"status":   status,
+					"detail":   message,
+					"instance": c.Path(),
+					"allow":    string(c.Ctx.Response.Header.Peek("Allow")),
+				}
+			}
+		})
+		router.GET("/items", okHandler)
+
+		ctx := serveRequestForTest(router, xylium.MethodGet, "/missing")
+		body := decodeBody(t, ctx)
+		if ctx.Response.StatusCode() != xylium.StatusNotFound || body["title"] != "Not Found" || body["instance"] != "/missing" {
+			t.Errorf("Unexpected custom 404 response: %d %v", ctx.Response.StatusCode(), body)
+		}
+		ctx = serveRequestForTest(router, xylium.MethodPost, "/items")
+		body = decodeBody(t, ctx)
+		if ctx.Response.StatusCode() != xylium.StatusMethodNotAllowed || body["status"] != float64(xylium.StatusMethodNotAllowed) || body["allow"] != "GET" {
+			t.Errorf("Unexpected custom 405 response: %d %v", ctx.Response.StatusCode(), body)
+		}
+	})
+}
+
+func TestRouter_Register(t *testing.T) {
+	okHandler := func(c *xylium.Context) error { return c.String(xylium.StatusOK, "%s", c.RoutePattern()) }
+	marker := func(next xylium.HandlerFunc) xylium.HandlerFunc {
+		return func(c *xylium.Context) error {
+			c.SetHeader("X-Route-Mw", "yes")
+			return next(c)
+		}
+	}
+
+	t.Run("RegistersTable", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		err := router.Register([]xylium.RouteDef{
+			{Method: "get", Path: "/users", Handler: okHandler, Name: "users.list"},
+			{Method: xylium.MethodPost, Path: "/users", Handler: okHandler, Middleware: []xylium.Middleware{marker}},
+			{Method: xylium.MethodGet, Path: "/users/:id", Handler: okHandler},
+		})
+		if err != nil {
+			t.Fatalf("Register returned an error: %v", err)
+		}
+		routes := router.Routes()
+		if len(routes) != 3 {
+			t.Fatalf("Expected 3 routes, got %v", routes)
+		}
+		named := false
+		for _, rt := range routes {
+			if rt.Method == xylium.MethodGet && rt.Path == "/users" && rt.Name == "users.list" {
+				named = true
+			}
+		}
+		if !named {
+			t.Errorf("Expected GET /users to be named 'users.list', got %v", routes)
+		}
+		ctx := serveRequestForTest(router, xylium.MethodPost, "/users")
+		if ctx.Response.StatusCode() != xylium.StatusOK || string(ctx.Response.Header.Peek("X-Route-Mw")) != "yes" {
+			t.Errorf("Expected POST /users to run its middleware, got %d", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("RejectsInvalidTable", func(t *testing.T) {
+		router := newRouterWithConfigForTest(nil)
+		router.GET("/health", okHandler)
+		err := router.Register([]xylium.RouteDef{
+			{Method: xylium.MethodGet, Path: "/items", Handler: okHandler},
+			{Method: xylium.MethodGet, Path: "/items/", Handler: okHandler}, // Duplikat setelah normalisasi.
+			{Method: xylium.MethodGet, Path: "/health", Handler: okHandler},
+			{Method: xylium.MethodPut, Path: "items", Handler: okHandler},
+			{Method: xylium.MethodDelete, Path: "/items", Handler: nil},
+			{Path: "/nomethod", Handler: okHandler},
+		})
+		if err == nil {
+			t.Fatal("Expected an error for an invalid route table")
+		}
+		for _, want := range []string{"route 1 (GET /items/): duplicates route 0", "route 2 (GET /health): a route is already registered",
+			"route 3 (PUT items): path must begin", "route 4 (DELETE /items): handler is required", "route 5 (/nomethod): method is required"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected the error to contain %q, got:\n%v", want, err)
+			}
+		}
+		if routes := router.Routes(); len(routes) != 1 {
+			t.Errorf("Expected no route of an invalid table to be registered, got %v", routes)
+		}
+	})
+}
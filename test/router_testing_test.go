@@ -0,0 +1,164 @@
+// File: /test/router_testing_test.go
+package xylium_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+func TestRouter_TestRequest(t *testing.T) {
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.MaxRequestBodySize = 16
+	})
+	router.Use(headerMiddlewareForTest("global"))
+	router.GET("/users/:id", func(c *xylium.Context) error {
+		return c.String(xylium.StatusOK, "%s", "user "+c.Param("id")+" q="+c.QueryParam("q"))
+	})
+	router.POST("/echo", func(c *xylium.Context) error {
+		return c.String(xylium.StatusCreated, "%s", c.Body())
+	})
+	router.GET("/panic", func(c *xylium.Context) error {
+		panic("boom")
+	})
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"RouteWithParamAndQuery", xylium.MethodGet, "/users/42?q=x", "", xylium.StatusOK, "user 42 q=x"},
+		{"Body", xylium.MethodPost, "/echo", "hello", xylium.StatusCreated, "hello"},
+		{"NotFound", xylium.MethodGet, "/missing", "", xylium.StatusNotFound, ""},
+		{"PanicIsRecovered", xylium.MethodGet, "/panic", "", xylium.StatusInternalServerError, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			resp, err := router.TestRequest(tc.method, tc.path, body)
+			if err != nil {
+				t.Fatalf("TestRequest returned an error: %v", err)
+			}
+			if resp.StatusCode() != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d (body: %s)", tc.wantStatus, resp.StatusCode(), resp.Body())
+			}
+			if tc.wantBody != "" && resp.String() != tc.wantBody {
+				t.Errorf("Expected body '%s', got '%s'", tc.wantBody, resp.String())
+			}
+		})
+	}
+
+	t.Run("ServerLimitsApply", func(t *testing.T) {
+		// MaxRequestBodySize ditegakkan oleh fasthttp.Server sebelum handler berjalan.
+		resp, err := router.TestRequest(xylium.MethodPost, "/echo", strings.NewReader(strings.Repeat("x", 64)))
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if resp.StatusCode() < 400 || resp.StatusCode() == xylium.StatusCreated {
+			t.Errorf("Expected the oversized body to be rejected by the server, got status %d", resp.StatusCode())
+		}
+	})
+
+	t.Run("GlobalMiddlewareRuns", func(t *testing.T) {
+		resp, err := router.TestRequest(xylium.MethodGet, "/users/1", nil)
+		if err != nil {
+			t.Fatalf("TestRequest returned an error: %v", err)
+		}
+		if got := resp.Header().Get("X-Chain"); got != "global" {
+			t.Errorf("Expected X-Chain 'global', got '%s'", got)
+		}
+	})
+
+	t.Run("InvalidPath", func(t *testing.T) {
+		if _, err := router.TestRequest(xylium.MethodGet, "users", nil); err == nil {
+			t.Error("Expected an error for a path without a leading slash")
+		}
+	})
+}
+
+func TestTestRequestBuilder(t *testing.T) {
+	type task struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		Owner string `json:"owner"`
+	}
+
+	router := newRouterWithConfigForTest(nil)
+	router.POST("/tasks", func(c *xylium.Context) error {
+		if c.Header("X-API-Key") != "k" {
+			return xylium.NewHTTPError(xylium.StatusUnauthorized, "missing key")
+		}
+		var in task
+		if err := c.BindAndValidate(&in); err != nil {
+			return err
+		}
+		owner := c.Cookie("session")
+		return c.JSON(xylium.StatusCreated, task{ID: 1, Title: in.Title, Owner: owner})
+	})
+
+	t.Run("JSONHeaderCookie", func(t *testing.T) {
+		resp, err := xylium.NewTestRequest().
+			Method(xylium.MethodPost).
+			Path("/tasks").
+			JSON(xylium.M{"title": "Write docs"}).
+			Header("X-API-Key", "k").
+			Cookie("session", "alice").
+			Do(router)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusCreated {
+			t.Fatalf("Expected status 201, got %d (body: %s)", resp.StatusCode(), resp.Body())
+		}
+		var out task
+		if err := resp.DecodeJSON(&out); err != nil {
+			t.Fatalf("DecodeJSON returned an error: %v", err)
+		}
+		if out != (task{ID: 1, Title: "Write docs", Owner: "alice"}) {
+			t.Errorf("Unexpected response %+v", out)
+		}
+		if got := resp.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+			t.Errorf("Expected JSON Content-Type, got '%s'", got)
+		}
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		resp, err := xylium.NewTestRequest().Method(xylium.MethodPost).Path("/tasks").Do(router)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		if resp.StatusCode() != xylium.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", resp.StatusCode())
+		}
+	})
+
+	t.Run("MarshalErrorIsReturnedByDo", func(t *testing.T) {
+		_, err := xylium.NewTestRequest().Method(xylium.MethodPost).Path("/tasks").JSON(func() {}).Do(router)
+		if err == nil {
+			t.Error("Expected an error for a body that cannot be marshalled")
+		}
+	})
+
+	t.Run("DecodeJSONInvalidBody", func(t *testing.T) {
+		resp, err := xylium.NewTestRequest().Path("/missing").Do(router)
+		if err != nil {
+			t.Fatalf("Do returned an error: %v", err)
+		}
+		var out task
+		if resp.StatusCode() != xylium.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", resp.StatusCode())
+		}
+		if err := resp.DecodeJSON(&out); err != nil {
+			t.Errorf("Expected the JSON 404 body to decode, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// File: /test/router_tls_test.go
+package xylium_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+)
+
+// writeSelfSignedCertForTest membuat sertifikat self-signed dengan CommonName tertentu
+// dan menuliskannya ke direktori sementara.
+func writeSelfSignedCertForTest(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	certFile = filepath.Join(dir, commonName+".crt")
+	keyFile = filepath.Join(dir, commonName+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// servedCertCommonNameForTest melakukan handshake TLS dan mengembalikan CommonName sertifikat server.
+func servedCertCommonNameForTest(t *testing.T, addr string) string {
+	t.Helper()
+	var conn *tls.Conn
+	var err error
+	for i := 0; i < 50; i++ { // Tunggu server siap.
+		conn, err = tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("TLS dial failed: %v", err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestRouter_ReloadCertificate(t *testing.T) {
+	dir := t.TempDir()
+	oldCert, oldKey := writeSelfSignedCertForTest(t, dir, "old")
+	newCert, newKey := writeSelfSignedCertForTest(t, dir, "new")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	router := newRouterWithConfigForTest(func(cfg *xylium.ServerConfig) {
+		cfg.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	})
+	go func() { _ = router.ListenAndServeTLS(addr, oldCert, oldKey) }()
+
+	if cn := servedCertCommonNameForTest(t, addr); cn != "old" {
+		t.Fatalf("Expected initial certificate 'old', got %q", cn)
+	}
+	if err := router.ReloadCertificate(newCert, newKey); err != nil {
+		t.Fatalf("ReloadCertificate failed: %v", err)
+	}
+	if cn := servedCertCommonNameForTest(t, addr); cn != "new" {
+		t.Errorf("Expected reloaded certificate 'new', got %q", cn)
+	}
+
+	// Gagal memuat: sertifikat lama tetap dipakai.
+	if err := router.ReloadCertificate(filepath.Join(dir, "missing.crt"), newKey); err == nil {
+		t.Error("Expected an error for a missing certificate file")
+	}
+	if cn := servedCertCommonNameForTest(t, addr); cn != "new" {
+		t.Errorf("Expected certificate 'new' to stay in use, got %q", cn)
+	}
+}
@@ -0,0 +1,189 @@
+// File: /test/template_renderer_test.go
+package xylium_test
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/valyala/fasthttp"
+)
+
+// writeTemplateFilesForTest menulis file template ke direktori sementara.
+func writeTemplateFilesForTest(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write template file: %v", err)
+		}
+	}
+	return dir
+}
+
+func renderTemplateForTest(t *testing.T, renderer *xylium.TemplateRenderer, name string, data interface{}) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := renderer.Render(&buf, name, data, nil)
+	return buf.String(), err
+}
+
+func TestTemplateRenderer_PagesPartialsAndFuncs(t *testing.T) {
+	dir := writeTemplateFilesForTest(t, map[string]string{
+		"pages/home.html":       `{{template "header.html" .}}<p>{{upper .Name}}</p>`,
+		"pages/users/show.html": `{{template "header.html" .}}<p>user {{.Name}}</p>`,
+		"pages/escape.html":     `<p>{{.Name}}</p>`,
+		"partials/header.html":  `<h1>{{.Title}}</h1>`,
+	})
+
+	renderer, err := xylium.NewTemplateRenderer(filepath.Join(dir, "pages", "*"),
+		xylium.WithTemplatePartials(filepath.Join(dir, "partials", "*.html")),
+		xylium.WithTemplateFuncs(template.FuncMap{"upper": strings.ToUpper}),
+	)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+
+	out, err := renderTemplateForTest(t, renderer, "home.html", xylium.M{"Title": "Home", "Name": "xylium"})
+	if err != nil || out != "<h1>Home</h1><p>XYLIUM</p>" {
+		t.Errorf("Unexpected render of home.html: %q, %v", out, err)
+	}
+	out, err = renderTemplateForTest(t, renderer, "escape.html", xylium.M{"Name": "<b>"})
+	if err != nil || out != "<p>&lt;b&gt;</p>" {
+		t.Errorf("Expected html/template escaping, got %q, %v", out, err)
+	}
+
+	// Halaman di subdirektori diberi nama relatif terhadap prefix statis pola.
+	nested, err := xylium.NewTemplateRenderer(filepath.Join(dir, "pages", "*", "*.html"),
+		xylium.WithTemplatePartials(filepath.Join(dir, "partials", "*.html")))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+	out, err = renderTemplateForTest(t, nested, "users/show.html", xylium.M{"Title": "User", "Name": "ana"})
+	if err != nil || out != "<h1>User</h1><p>user ana</p>" {
+		t.Errorf("Unexpected render of users/show.html: %q, %v", out, err)
+	}
+
+	if _, err := renderTemplateForTest(t, renderer, "missing.html", nil); err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+}
+
+func TestTemplateRenderer_Layout(t *testing.T) {
+	dir := writeTemplateFilesForTest(t, map[string]string{
+		"views/home.html":    `{{define "title"}}Home{{end}}{{define "content"}}<p>welcome</p>{{end}}`,
+		"views/about.html":   `{{define "content"}}<p>about</p>{{end}}`,
+		"views/layout.html":  `<title>{{block "title" .}}Default{{end}}</title><main>{{template "content" .}}</main>`,
+		"views/broken.html":  `{{define "content"}}<p>{{call .Fail}}</p>{{end}}`,
+		"views/nothing.html": ``,
+	})
+
+	// Layout juga cocok dengan pola halaman, tetapi tidak dirender sebagai halaman.
+	renderer, err := xylium.NewTemplateRenderer(filepath.Join(dir, "views", "*.html"),
+		xylium.WithTemplatePartials(filepath.Join(dir, "views", "layout.html")),
+		xylium.WithTemplateLayout("layout.html"),
+	)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+
+	// Blok "content" setiap halaman tidak saling bertabrakan.
+	tests := map[string]string{
+		"home.html":  "<title>Home</title><main><p>welcome</p></main>",
+		"about.html": "<title>Default</title><main><p>about</p></main>",
+	}
+	for name, want := range tests {
+		out, err := renderTemplateForTest(t, renderer, name, nil)
+		if err != nil || out != want {
+			t.Errorf("Render(%s): expected %q, got %q, %v", name, want, out, err)
+		}
+	}
+	if _, err := renderTemplateForTest(t, renderer, "layout.html", nil); err == nil {
+		t.Error("Expected the layout not to be rendered as a page")
+	}
+
+	// Render yang gagal di tengah jalan tidak menulis apa pun.
+	fail := func() (string, error) { return "", errors.New("boom") }
+	out, err := renderTemplateForTest(t, renderer, "broken.html", xylium.M{"Fail": fail})
+	if err == nil || out != "" {
+		t.Errorf("Expected failed render to write nothing, got %q, %v", out, err)
+	}
+}
+
+func TestTemplateRenderer_ReloadAndCache(t *testing.T) {
+	dir := writeTemplateFilesForTest(t, map[string]string{"page.html": "v1"})
+	pattern := filepath.Join(dir, "*.html")
+
+	cached, err := xylium.NewTemplateRenderer(pattern, xylium.WithTemplateReload(false))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+	reloading, err := xylium.NewTemplateRenderer(pattern, xylium.WithTemplateReload(true))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := renderTemplateForTest(t, cached, "page.html", nil); out != "v1" {
+		t.Errorf("Expected cached template 'v1', got %q", out)
+	}
+	if out, _ := renderTemplateForTest(t, reloading, "page.html", nil); out != "v2" {
+		t.Errorf("Expected reloaded template 'v2', got %q", out)
+	}
+
+	// Tanpa WithTemplateReload, reload mengikuti mode router yang merender.
+	auto, err := xylium.NewTemplateRenderer(pattern)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer returned an error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte("v3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	router := xylium.NewRouterForTesting(xylium.RouterTestOptions{Mode: xylium.ReleaseMode, SilenceLogs: true})
+	router.HTMLRenderer = auto
+	var fasthttpCtx fasthttp.RequestCtx
+	ctx := xylium.NewContextForTest(nil, &fasthttpCtx)
+	ctx.SetRouterForTesting(router)
+	if err := ctx.HTML(xylium.StatusOK, "page.html", nil); err != nil {
+		t.Fatalf("HTML() returned an unexpected error: %v", err)
+	}
+	if body := string(fasthttpCtx.Response.Body()); body != "v2" {
+		t.Errorf("Expected cached template 'v2' outside DebugMode, got %q", body)
+	}
+
+	debugRouter := xylium.NewRouterForTesting(xylium.RouterTestOptions{Mode: xylium.DebugMode, SilenceLogs: true})
+	debugRouter.HTMLRenderer = auto
+	fasthttpCtx.Response.Reset()
+	ctx.SetRouterForTesting(debugRouter)
+	if err := ctx.HTML(xylium.StatusOK, "page.html", nil); err != nil {
+		t.Fatalf("HTML() returned an unexpected error: %v", err)
+	}
+	if body := string(fasthttpCtx.Response.Body()); body != "v3" {
+		t.Errorf("Expected reloaded template 'v3' in DebugMode, got %q", body)
+	}
+}
+
+func TestNewTemplateRenderer_Errors(t *testing.T) {
+	dir := writeTemplateFilesForTest(t, map[string]string{"bad.html": "{{if}}"})
+
+	if _, err := xylium.NewTemplateRenderer(filepath.Join(dir, "*.tmpl")); err == nil {
+		t.Error("Expected an error when no templates match")
+	}
+	if _, err := xylium.NewTemplateRenderer(filepath.Join(dir, "[")); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if _, err := xylium.NewTemplateRenderer(filepath.Join(dir, "*.html")); err == nil {
+		t.Error("Expected an error for a template with a syntax error")
+	}
+}
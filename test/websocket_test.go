@@ -0,0 +1,212 @@
+// File: /test/websocket_test.go
+package xylium_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/arwahdevops/xylium-core/src/xylium"
+	"github.com/arwahdevops/xylium-core/src/xylium/websocket"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+const testWebSocketKey = "dGhlIHNhbXBsZSBub25jZQ==" // Contoh kunci dari RFC 6455.
+
+// writeMaskedFrameForTest menulis satu frame klien (ter-mask) ke koneksi.
+func writeMaskedFrameForTest(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatalf("Failed to write frame: %v", err)
+	}
+}
+
+// readServerFrameForTest membaca satu frame server (tanpa mask, payload <= 125 byte).
+func readServerFrameForTest(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatalf("Failed to read frame header: %v", err)
+	}
+	payload := make([]byte, header[1]&0x7f)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("Failed to read frame payload: %v", err)
+	}
+	return header[0] & 0x0f, payload
+}
+
+// dialWebSocketEchoForTest menjalankan server echo WebSocket dan mengembalikan koneksi
+// klien yang sudah melakukan handshake.
+func dialWebSocketEchoForTest(t *testing.T) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/ws", func(c *xylium.Context) error {
+		return websocket.UpgradeWithConfig(c, websocket.Config{Subprotocols: []string{"chat"}}, func(conn *websocket.Conn) {
+			for {
+				mt, msg, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if err := conn.WriteMessage(mt, msg); err != nil {
+					return
+				}
+			}
+		})
+	})
+
+	ln := fasthttputil.NewInmemoryListener()
+	server := &fasthttp.Server{Handler: router.Handler}
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	conn, err := ln.Dial()
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake := "GET /ws HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + testWebSocketKey + "\r\nSec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Protocol: superchat, chat\r\nOrigin: http://example.com\r\n\r\n"
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		t.Fatalf("Failed to write handshake: %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Failed to read handshake response: %v", err)
+	}
+	if resp.StatusCode != xylium.StatusSwitchingProtocols {
+		t.Fatalf("Expected status 101, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Unexpected Sec-WebSocket-Accept: %q", got)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != "chat" {
+		t.Errorf("Expected subprotocol 'chat', got %q", got)
+	}
+	return conn, reader
+}
+
+func TestWebSocket_Upgrade_EchoRoundTrip(t *testing.T) {
+	conn, reader := dialWebSocketEchoForTest(t)
+
+	writeMaskedFrameForTest(t, conn, websocket.TextMessage, []byte("hello"))
+	opcode, payload := readServerFrameForTest(t, reader)
+	if opcode != websocket.TextMessage || string(payload) != "hello" {
+		t.Errorf("Expected text echo 'hello', got opcode %d payload %q", opcode, payload)
+	}
+
+	writeMaskedFrameForTest(t, conn, websocket.PingMessage, []byte("p"))
+	if opcode, payload := readServerFrameForTest(t, reader); opcode != websocket.PongMessage || string(payload) != "p" {
+		t.Errorf("Expected pong 'p', got opcode %d payload %q", opcode, payload)
+	}
+
+	closePayload := binary.BigEndian.AppendUint16(nil, websocket.CloseNormalClosure)
+	writeMaskedFrameForTest(t, conn, websocket.CloseMessage, closePayload)
+	opcode, payload = readServerFrameForTest(t, reader)
+	if opcode != websocket.CloseMessage || binary.BigEndian.Uint16(payload) != websocket.CloseNormalClosure {
+		t.Errorf("Expected close frame echo with code 1000, got opcode %d payload %v", opcode, payload)
+	}
+}
+
+func TestWebSocket_Upgrade_ClosingHandshakeAndUTF8(t *testing.T) {
+	closeFrame := func(code uint16, reason string) []byte {
+		return append(binary.BigEndian.AppendUint16(nil, code), reason...)
+	}
+	tests := []struct {
+		name        string
+		opcode      byte
+		payload     []byte
+		wantPayload []byte // Payload close frame yang diharapkan dari server.
+	}{
+		// Close tanpa status dijawab dengan close kosong; 1005 tidak boleh dikirim.
+		{"EmptyClose", websocket.CloseMessage, nil, []byte{}},
+		{"ReservedCloseCode", websocket.CloseMessage, closeFrame(1005, ""), closeFrame(websocket.CloseProtocolError, "")},
+		{"UnassignedCloseCode", websocket.CloseMessage, closeFrame(2000, ""), closeFrame(websocket.CloseProtocolError, "")},
+		{"OneByteClose", websocket.CloseMessage, []byte{3}, closeFrame(websocket.CloseProtocolError, "")},
+		{"InvalidCloseReason", websocket.CloseMessage, closeFrame(1000, "\xff"), closeFrame(websocket.CloseInvalidFramePayloadData, "")},
+		{"InvalidUTF8Text", websocket.TextMessage, []byte("a\xc3"), closeFrame(websocket.CloseInvalidFramePayloadData, "")},
+		{"ApplicationCloseCode", websocket.CloseMessage, closeFrame(4000, "bye"), closeFrame(4000, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, reader := dialWebSocketEchoForTest(t)
+			writeMaskedFrameForTest(t, conn, tt.opcode, tt.payload)
+			opcode, payload := readServerFrameForTest(t, reader)
+			if opcode != websocket.CloseMessage {
+				t.Fatalf("Expected a close frame, got opcode %d", opcode)
+			}
+			// Hanya kode status yang dibandingkan; alasan dari server bersifat informatif.
+			if len(tt.wantPayload) == 0 && len(payload) != 0 {
+				t.Errorf("Expected an empty close payload, got %v", payload)
+			}
+			if len(tt.wantPayload) >= 2 && (len(payload) < 2 || binary.BigEndian.Uint16(payload) != binary.BigEndian.Uint16(tt.wantPayload)) {
+				t.Errorf("Expected close code %d, got payload %v", binary.BigEndian.Uint16(tt.wantPayload), payload)
+			}
+		})
+	}
+}
+
+func TestWebSocket_Upgrade_RejectsInvalidHandshake(t *testing.T) {
+	router := newRouterWithConfigForTest(nil)
+	router.GET("/ws", func(c *xylium.Context) error {
+		return websocket.Upgrade(c, func(conn *websocket.Conn) {})
+	})
+
+	serve := func(mutate func(h *fasthttp.RequestHeader)) *fasthttp.RequestCtx {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(xylium.MethodGet)
+		ctx.Request.SetRequestURI("/ws")
+		ctx.Request.Header.SetHost("example.com")
+		ctx.Request.Header.Set("Upgrade", "websocket")
+		ctx.Request.Header.Set("Connection", "keep-alive, Upgrade")
+		ctx.Request.Header.Set("Sec-WebSocket-Key", testWebSocketKey)
+		ctx.Request.Header.Set("Sec-WebSocket-Version", "13")
+		mutate(&ctx.Request.Header)
+		router.Handler(&ctx)
+		return &ctx
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(h *fasthttp.RequestHeader)
+		status int
+	}{
+		{"MissingUpgradeHeader", func(h *fasthttp.RequestHeader) { h.Del("Upgrade") }, xylium.StatusBadRequest},
+		{"BadKey", func(h *fasthttp.RequestHeader) { h.Set("Sec-WebSocket-Key", "short") }, xylium.StatusBadRequest},
+		{"UnsupportedVersion", func(h *fasthttp.RequestHeader) { h.Set("Sec-WebSocket-Version", "8") }, xylium.StatusUpgradeRequired},
+		{"CrossOrigin", func(h *fasthttp.RequestHeader) { h.Set("Origin", "https://evil.example") }, xylium.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := serve(tt.mutate)
+			if ctx.Response.StatusCode() != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, ctx.Response.StatusCode())
+			}
+			if ctx.Hijacked() {
+				t.Error("Expected connection not to be hijacked for an invalid handshake")
+			}
+		})
+	}
+
+	t.Run("ValidHandshakeHijacks", func(t *testing.T) {
+		ctx := serve(func(h *fasthttp.RequestHeader) { h.Set("Origin", "http://example.com") })
+		if ctx.Response.StatusCode() != xylium.StatusSwitchingProtocols || !ctx.Hijacked() {
+			t.Errorf("Expected 101 and hijacked connection, got %d (hijacked=%t)", ctx.Response.StatusCode(), ctx.Hijacked())
+		}
+	})
+}